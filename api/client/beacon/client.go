@@ -22,14 +22,18 @@ import (
 )
 
 const (
-	getSignedBlockPath      = "/eth/v2/beacon/blocks"
-	getBlockRootPath        = "/eth/v1/beacon/blocks/{{.Id}}/root"
-	getForkForStatePath     = "/eth/v1/beacon/states/{{.Id}}/fork"
-	getWeakSubjectivityPath = "/prysm/v1/beacon/weak_subjectivity"
-	getForkSchedulePath     = "/eth/v1/config/fork_schedule"
-	getConfigSpecPath       = "/eth/v1/config/spec"
-	getStatePath            = "/eth/v2/debug/beacon/states"
-	getNodeVersionPath      = "/eth/v1/node/version"
+	getSignedBlockPath         = "/eth/v2/beacon/blocks"
+	getBlockRootPath           = "/eth/v1/beacon/blocks/{{.Id}}/root"
+	getBlockHeaderPath         = "/eth/v1/beacon/headers"
+	getBlobSidecarsPath        = "/eth/v1/beacon/blob_sidecars"
+	getForkForStatePath        = "/eth/v1/beacon/states/{{.Id}}/fork"
+	getFinalityCheckpointsPath = "/eth/v1/beacon/states/{{.Id}}/finality_checkpoints"
+	getWeakSubjectivityPath    = "/prysm/v1/beacon/weak_subjectivity"
+	getForkSchedulePath        = "/eth/v1/config/fork_schedule"
+	getConfigSpecPath          = "/eth/v1/config/spec"
+	getStatePath               = "/eth/v2/debug/beacon/states"
+	getNodeVersionPath         = "/eth/v1/node/version"
+	getForkChoiceRecordingPath = "/prysm/v1/debug/fork_choice/recording"
 )
 
 // StateOrBlockId represents the block_id / state_id parameters that several of the Eth Beacon API methods accept.
@@ -131,6 +135,38 @@ func (c *Client) GetBlockRoot(ctx context.Context, blockId StateOrBlockId) ([32]
 	return bytesutil.ToBytes32(rs), nil
 }
 
+// GetBlockHeader retrieves the header of the block for the given block id.
+// Block identifier can be one of: "head" (canonical head in node's view), "genesis", "finalized",
+// <slot>, <hex encoded blockRoot with 0x prefix>. Variables of type StateOrBlockId are exported by this package
+// for the named identifiers.
+func (c *Client) GetBlockHeader(ctx context.Context, blockId StateOrBlockId) (*structs.SignedBeaconBlockHeaderContainer, error) {
+	body, err := c.Get(ctx, path.Join(getBlockHeaderPath, string(blockId)))
+	if err != nil {
+		return nil, errors.Wrapf(err, "error requesting block header by id = %s", blockId)
+	}
+	hr := &structs.GetBlockHeaderResponse{}
+	if err := json.Unmarshal(body, hr); err != nil {
+		return nil, errors.Wrap(err, "error decoding json response in GetBlockHeader")
+	}
+	if hr.Data == nil || hr.Data.Header == nil || hr.Data.Header.Message == nil {
+		return nil, errors.Errorf("empty block header in response for block id = %s", blockId)
+	}
+	return hr.Data, nil
+}
+
+// GetBlobSidecars retrieves the BlobSidecars of the block for the given block id.
+// Block identifier can be one of: "head" (canonical head in node's view), "genesis", "finalized",
+// <slot>, <hex encoded blockRoot with 0x prefix>. Variables of type StateOrBlockId are exported by this package
+// for the named identifiers.
+// The return value contains the ssz-encoded bytes of the list of sidecars.
+func (c *Client) GetBlobSidecars(ctx context.Context, blockId StateOrBlockId) ([]byte, error) {
+	b, err := c.Get(ctx, path.Join(getBlobSidecarsPath, string(blockId)), client.WithSSZEncoding())
+	if err != nil {
+		return nil, errors.Wrapf(err, "error requesting blob sidecars by block id = %s", blockId)
+	}
+	return b, nil
+}
+
 var getForkTpl = idTemplate(getForkForStatePath)
 
 // GetFork queries the Beacon Node API for the Fork from the state identified by stateId.
@@ -152,6 +188,24 @@ func (c *Client) GetFork(ctx context.Context, stateId StateOrBlockId) (*ethpb.Fo
 	return fr.ToConsensus()
 }
 
+var getFinalityCheckpointsTpl = idTemplate(getFinalityCheckpointsPath)
+
+// GetFinalityCheckpoints queries the Beacon Node API for the finality checkpoints of the state identified by stateId.
+func (c *Client) GetFinalityCheckpoints(ctx context.Context, stateId StateOrBlockId) (*structs.FinalityCheckpoints, error) {
+	body, err := c.Get(ctx, getFinalityCheckpointsTpl(stateId))
+	if err != nil {
+		return nil, errors.Wrapf(err, "error requesting finality checkpoints by state id = %s", stateId)
+	}
+	fr := &structs.GetFinalityCheckpointsResponse{}
+	if err := json.Unmarshal(body, fr); err != nil {
+		return nil, errors.Wrap(err, "error decoding json response in GetFinalityCheckpoints")
+	}
+	if fr.Data == nil || fr.Data.Finalized == nil {
+		return nil, errors.Errorf("empty finality checkpoints in response for state id = %s", stateId)
+	}
+	return fr.Data, nil
+}
+
 // GetForkSchedule retrieve all forks, past present and future, of which this node is aware.
 func (c *Client) GetForkSchedule(ctx context.Context) (forks.OrderedSchedule, error) {
 	body, err := c.Get(ctx, getForkSchedulePath)
@@ -241,6 +295,16 @@ func (c *Client) GetState(ctx context.Context, stateId StateOrBlockId) ([]byte,
 	return b, nil
 }
 
+// GetForkChoiceRecording calls a prysm API endpoint returning the encoded inputs recorded by the
+// fork choice of the beacon node, which can be replayed offline.
+func (c *Client) GetForkChoiceRecording(ctx context.Context) ([]byte, error) {
+	b, err := c.Get(ctx, getForkChoiceRecordingPath, client.WithSSZEncoding())
+	if err != nil {
+		return nil, errors.Wrap(err, "error requesting fork choice recording")
+	}
+	return b, nil
+}
+
 // GetWeakSubjectivity calls a proposed API endpoint that is unique to prysm
 // This api method does the following:
 // - computes weak subjectivity epoch
@@ -1,10 +1,16 @@
 package beacon
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
 	"net/url"
 	"testing"
 
 	"github.com/prysmaticlabs/prysm/v5/api/client"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
 	"github.com/prysmaticlabs/prysm/v5/testing/require"
 )
 
@@ -137,3 +143,42 @@ func TestValidHostname(t *testing.T) {
 		})
 	}
 }
+
+func TestGetFinalityCheckpointsAndBlockHeader(t *testing.T) {
+	trans := &testRT{rt: func(req *http.Request) (*http.Response, error) {
+		res := &http.Response{Request: req, StatusCode: http.StatusOK}
+		var v interface{}
+		switch req.URL.Path {
+		case "/eth/v1/beacon/states/head/finality_checkpoints":
+			v = &structs.GetFinalityCheckpointsResponse{Data: &structs.FinalityCheckpoints{
+				Finalized: &structs.Checkpoint{Epoch: "10", Root: "0x01"},
+			}}
+		case "/eth/v1/beacon/headers/head":
+			v = &structs.GetBlockHeaderResponse{Data: &structs.SignedBeaconBlockHeaderContainer{
+				Root:   "0x02",
+				Header: &structs.SignedBeaconBlockHeader{Message: &structs.BeaconBlockHeader{Slot: "352"}},
+			}}
+		default:
+			res.StatusCode = http.StatusNotFound
+			res.Body = io.NopCloser(bytes.NewBuffer(nil))
+			return res, nil
+		}
+		encoded, err := json.Marshal(v)
+		require.NoError(t, err)
+		res.Body = io.NopCloser(bytes.NewBuffer(encoded))
+		return res, nil
+	}}
+	c, err := NewClient("http://localhost:3500", client.WithRoundTripper(trans))
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	cp, err := c.GetFinalityCheckpoints(ctx, IdHead)
+	require.NoError(t, err)
+	require.Equal(t, "10", cp.Finalized.Epoch)
+	h, err := c.GetBlockHeader(ctx, IdHead)
+	require.NoError(t, err)
+	require.Equal(t, "352", h.Header.Message.Slot)
+
+	_, err = c.GetBlockHeader(ctx, IdFromSlot(1))
+	require.ErrorIs(t, err, client.ErrNotFound)
+}
@@ -1,6 +1,10 @@
 package client
 
 import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"testing"
 
@@ -46,3 +50,29 @@ func TestBaseURL(t *testing.T) {
 	require.Equal(t, "www.offchainlabs.com", cl.BaseURL().Hostname())
 	require.Equal(t, "3500", cl.BaseURL().Port())
 }
+
+func TestPost(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		require.Equal(t, "/eth/v1/beacon/pool/proposer_slashings", r.URL.Path)
+		require.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		require.Equal(t, "v", r.Header.Get("X-Test"))
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		if string(body) != "{}" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		_, err = w.Write([]byte("ok"))
+		require.NoError(t, err)
+	}))
+	defer srv.Close()
+
+	cl, err := NewClient(srv.URL)
+	require.NoError(t, err)
+	b, err := cl.Post(context.Background(), "/eth/v1/beacon/pool/proposer_slashings", []byte("{}"), WithHeader("X-Test", "v"))
+	require.NoError(t, err)
+	require.Equal(t, "ok", string(b))
+	_, err = cl.Post(context.Background(), "/eth/v1/beacon/pool/proposer_slashings", []byte("[]"), WithHeader("X-Test", "v"))
+	require.ErrorIs(t, err, ErrNotOK)
+}
@@ -14,18 +14,24 @@ import (
 )
 
 const (
-	EventHead                = "head"
-	EventBlock               = "block"
-	EventAttestation         = "attestation"
-	EventVoluntaryExit       = "voluntary_exit"
-	EventProposerSlashing    = "proposer_slashing"
-	EventAttesterSlashing    = "attester_slashing"
-	EventFinalizedCheckpoint = "finalized_checkpoint"
-	EventChainReorg          = "chain_reorg"
-	EventPayloadAttributes   = "payload_attributes"
-	EventBlobSidecar         = "blob_sidecar"
-	EventError               = "error"
-	EventConnectionError     = "connection_error"
+	EventHead                    = "head"
+	EventBlock                   = "block"
+	EventAttestation             = "attestation"
+	EventVoluntaryExit           = "voluntary_exit"
+	EventProposerSlashing        = "proposer_slashing"
+	EventAttesterSlashing        = "attester_slashing"
+	EventFinalizedCheckpoint     = "finalized_checkpoint"
+	EventChainReorg              = "chain_reorg"
+	EventPayloadAttributes       = "payload_attributes"
+	EventBlobSidecar             = "blob_sidecar"
+	EventPendingDepositEnqueued  = "pending_deposit_enqueued"
+	EventPendingDepositProcessed = "pending_deposit_processed"
+	EventValidatorActivated      = "validator_activated"
+	EventValidatorExitInitiated  = "validator_exit_initiated"
+	EventWithdrawalProcessed     = "withdrawal_processed"
+	EventLateBlockReorgDecision  = "late_block_reorg_decision"
+	EventError                   = "error"
+	EventConnectionError         = "connection_error"
 )
 
 var (
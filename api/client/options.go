@@ -23,6 +23,13 @@ func WithAuthorizationToken(token string) ReqOption {
 	}
 }
 
+// WithHeader is a request functional option that sets a header.
+func WithHeader(name, value string) ReqOption {
+	return func(req *http.Request) {
+		req.Header.Set(name, value)
+	}
+}
+
 // ClientOpt is a functional option for the Client type (http.Client wrapper)
 type ClientOpt func(*Client)
 
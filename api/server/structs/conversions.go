@@ -3,12 +3,15 @@ package structs
 import (
 	"fmt"
 	"strconv"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v5/api/server"
+	forkchoicetypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/forkchoice/types"
 	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/forkchoice"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/validator"
 	"github.com/prysmaticlabs/prysm/v5/container/slice"
@@ -17,6 +20,7 @@ import (
 	enginev1 "github.com/prysmaticlabs/prysm/v5/proto/engine/v1"
 	ethv1 "github.com/prysmaticlabs/prysm/v5/proto/eth/v1"
 	eth "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/runtime"
 )
 
 var errNilValue = errors.New("nil value")
@@ -334,6 +338,58 @@ func CheckpointFromConsensus(c *eth.Checkpoint) *Checkpoint {
 	}
 }
 
+func ForkChoiceDumpFromConsensus(dump *forkchoice.Dump) *GetForkChoiceDumpResponse {
+	nodes := make([]*ForkChoiceNode, len(dump.ForkChoiceNodes))
+	for i, n := range dump.ForkChoiceNodes {
+		nodes[i] = &ForkChoiceNode{
+			Slot:               fmt.Sprintf("%d", n.Slot),
+			BlockRoot:          hexutil.Encode(n.BlockRoot),
+			ParentRoot:         hexutil.Encode(n.ParentRoot),
+			JustifiedEpoch:     fmt.Sprintf("%d", n.JustifiedEpoch),
+			FinalizedEpoch:     fmt.Sprintf("%d", n.FinalizedEpoch),
+			Weight:             fmt.Sprintf("%d", n.Weight),
+			ExecutionBlockHash: hexutil.Encode(n.ExecutionBlockHash),
+			Validity:           n.Validity.String(),
+			ExtraData: &ForkChoiceNodeExtraData{
+				UnrealizedJustifiedEpoch: fmt.Sprintf("%d", n.UnrealizedJustifiedEpoch),
+				UnrealizedFinalizedEpoch: fmt.Sprintf("%d", n.UnrealizedFinalizedEpoch),
+				Balance:                  fmt.Sprintf("%d", n.Balance),
+				ExecutionOptimistic:      n.ExecutionOptimistic,
+				TimeStamp:                fmt.Sprintf("%d", n.Timestamp),
+			},
+		}
+	}
+	return &GetForkChoiceDumpResponse{
+		JustifiedCheckpoint: CheckpointFromConsensus(dump.JustifiedCheckpoint),
+		FinalizedCheckpoint: CheckpointFromConsensus(dump.FinalizedCheckpoint),
+		ForkChoiceNodes:     nodes,
+		ExtraData: &ForkChoiceDumpExtraData{
+			UnrealizedJustifiedCheckpoint: CheckpointFromConsensus(dump.UnrealizedJustifiedCheckpoint),
+			UnrealizedFinalizedCheckpoint: CheckpointFromConsensus(dump.UnrealizedFinalizedCheckpoint),
+			ProposerBoostRoot:             hexutil.Encode(dump.ProposerBoostRoot),
+			PreviousProposerBoostRoot:     hexutil.Encode(dump.PreviousProposerBoostRoot),
+			HeadRoot:                      hexutil.Encode(dump.HeadRoot),
+		},
+	}
+}
+
+func ForkChoiceReplayFromConsensus(result *forkchoicetypes.ReplayResult) *GetForkChoiceReplayResponse {
+	boosts := make([]*ProposerBoostDecision, len(result.ProposerBoosts))
+	for i, b := range result.ProposerBoosts {
+		boosts[i] = &ProposerBoostDecision{
+			BlockRoot:       hexutil.Encode(b.Root[:]),
+			Slot:            fmt.Sprintf("%d", b.Slot),
+			SecondsIntoSlot: fmt.Sprintf("%d", b.SecondsIntoSlot),
+			Boosted:         b.Boosted,
+		}
+	}
+	return &GetForkChoiceReplayResponse{
+		Slot:           fmt.Sprintf("%d", result.Slot),
+		ForkChoice:     ForkChoiceDumpFromConsensus(result.Dump),
+		ProposerBoosts: boosts,
+	}
+}
+
 func (b *BeaconCommitteeSubscription) ToConsensus() (*validator.BeaconCommitteeSubscription, error) {
 	valIndex, err := strconv.ParseUint(b.ValidatorIndex, 10, 64)
 	if err != nil {
@@ -1246,3 +1302,27 @@ func EventChainReorgFromV1(event *ethv1.EventChainReorg) *ChainReorgEvent {
 		ExecutionOptimistic: event.ExecutionOptimistic,
 	}
 }
+
+func ShutdownResponseFromStatus(s *runtime.ShutdownStatus) *ShutdownResponse {
+	resp := &ShutdownResponse{
+		Phase:    string(s.Phase),
+		Drain:    s.Drain,
+		Services: make([]*ServiceShutdownStatus, len(s.Services)),
+	}
+	if s.DrainError != nil {
+		resp.DrainError = s.DrainError.Error()
+	}
+	if !s.StartedAt.IsZero() {
+		resp.StartedAt = s.StartedAt.UTC().Format(time.RFC3339)
+	}
+	for i, svc := range s.Services {
+		resp.Services[i] = &ServiceShutdownStatus{
+			Service: svc.Service,
+			State:   string(svc.State),
+		}
+		if svc.Err != nil {
+			resp.Services[i].Error = svc.Err.Error()
+		}
+	}
+	return resp
+}
@@ -55,3 +55,16 @@ type ForkChoiceNodeExtraData struct {
 	ExecutionOptimistic      bool   `json:"execution_optimistic"`
 	TimeStamp                string `json:"timestamp"`
 }
+
+type GetForkChoiceReplayResponse struct {
+	Slot           string                     `json:"slot"`
+	ForkChoice     *GetForkChoiceDumpResponse `json:"fork_choice"`
+	ProposerBoosts []*ProposerBoostDecision   `json:"proposer_boosts"`
+}
+
+type ProposerBoostDecision struct {
+	BlockRoot       string `json:"block_root"`
+	Slot            string `json:"slot"`
+	SecondsIntoSlot string `json:"seconds_into_slot"`
+	Boosted         bool   `json:"boosted"`
+}
@@ -20,6 +20,65 @@ type BlockEvent struct {
 	ExecutionOptimistic bool   `json:"execution_optimistic"`
 }
 
+type PendingDepositEnqueuedEvent struct {
+	Slot                  string `json:"slot"`
+	Block                 string `json:"block"`
+	Pubkey                string `json:"pubkey"`
+	WithdrawalCredentials string `json:"withdrawal_credentials"`
+	Amount                string `json:"amount"`
+	DepositSlot           string `json:"deposit_slot"`
+}
+
+type PendingDepositProcessedEvent struct {
+	Slot                  string `json:"slot"`
+	Block                 string `json:"block"`
+	Pubkey                string `json:"pubkey"`
+	WithdrawalCredentials string `json:"withdrawal_credentials"`
+	Amount                string `json:"amount"`
+	DepositSlot           string `json:"deposit_slot"`
+	Credited              bool   `json:"credited"`
+	ValidatorIndex        string `json:"validator_index,omitempty"`
+}
+
+type ValidatorActivatedEvent struct {
+	Slot            string `json:"slot"`
+	Block           string `json:"block"`
+	ValidatorIndex  string `json:"validator_index"`
+	Pubkey          string `json:"pubkey"`
+	ActivationEpoch string `json:"activation_epoch"`
+}
+
+type ValidatorExitInitiatedEvent struct {
+	Slot              string `json:"slot"`
+	Block             string `json:"block"`
+	ValidatorIndex    string `json:"validator_index"`
+	Pubkey            string `json:"pubkey"`
+	ExitEpoch         string `json:"exit_epoch"`
+	WithdrawableEpoch string `json:"withdrawable_epoch"`
+	Reason            string `json:"reason"`
+	Bailout           bool   `json:"bailout"`
+}
+
+type WithdrawalProcessedEvent struct {
+	Slot           string `json:"slot"`
+	Block          string `json:"block"`
+	Index          string `json:"index"`
+	ValidatorIndex string `json:"validator_index"`
+	Address        string `json:"address"`
+	Amount         string `json:"amount"`
+}
+
+type LateBlockReorgDecisionEvent struct {
+	Check        string `json:"check"`
+	Slot         string `json:"slot"`
+	HeadBlock    string `json:"head_block"`
+	ParentBlock  string `json:"parent_block"`
+	HeadWeight   string `json:"head_weight"`
+	ParentWeight string `json:"parent_weight"`
+	Reorg        bool   `json:"reorg"`
+	Reason       string `json:"reason"`
+}
+
 type AggregatedAttEventSource struct {
 	Aggregate *Attestation `json:"aggregate"`
 }
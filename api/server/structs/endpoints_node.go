@@ -71,3 +71,92 @@ type AddrRequest struct {
 type PeersResponse struct {
 	Peers []*Peer `json:"peers"`
 }
+
+type GetPeerDetailsResponse struct {
+	Data *PeerDetails `json:"data"`
+}
+
+type PeerDetails struct {
+	Peer         *Peer             `json:"peer"`
+	Banned       bool              `json:"banned"`
+	Trusted      bool              `json:"trusted"`
+	BadResponses string            `json:"bad_responses"`
+	Scores       *PeerScores       `json:"scores"`
+	Gossip       *PeerGossipScores `json:"gossip"`
+	RateLimits   []*RateLimitUsage `json:"rate_limits"`
+	LastGoodbye  *Goodbye          `json:"last_goodbye"`
+}
+
+type PeerScores struct {
+	Total         string `json:"total"`
+	BadResponses  string `json:"bad_responses"`
+	BlockProvider string `json:"block_provider"`
+	PeerStatus    string `json:"peer_status"`
+	Gossip        string `json:"gossip"`
+}
+
+type PeerGossipScores struct {
+	Score            string        `json:"score"`
+	BehaviourPenalty string        `json:"behaviour_penalty"`
+	Topics           []*TopicScore `json:"topics"`
+}
+
+type TopicScore struct {
+	Topic                    string `json:"topic"`
+	TimeInMesh               string `json:"time_in_mesh"`
+	FirstMessageDeliveries   string `json:"first_message_deliveries"`
+	MeshMessageDeliveries    string `json:"mesh_message_deliveries"`
+	InvalidMessageDeliveries string `json:"invalid_message_deliveries"`
+}
+
+type RateLimitUsage struct {
+	Topic     string `json:"topic"`
+	Count     string `json:"count"`
+	Remaining string `json:"remaining"`
+	Capacity  string `json:"capacity"`
+}
+
+type Goodbye struct {
+	Code   string `json:"code"`
+	Reason string `json:"reason"`
+	Sent   bool   `json:"sent"`
+	Time   string `json:"time"`
+}
+
+type BanPeerRequest struct {
+	PeerId string `json:"peer_id"`
+}
+
+type BannedPeersResponse struct {
+	PeerIds []string `json:"peer_ids"`
+}
+
+type BanIPRequest struct {
+	IP string `json:"ip"`
+}
+
+type BannedIPsResponse struct {
+	IPs []string `json:"ips"`
+}
+
+type GetBackfillRepairResponse struct {
+	Data *BackfillRepairStatus `json:"data"`
+}
+
+type BackfillRepairStatus struct {
+	State    string               `json:"state"`
+	Pass     string               `json:"pass"`
+	LowSlot  string               `json:"low_slot"`
+	HighSlot string               `json:"high_slot"`
+	Gaps     []*BackfillRepairGap `json:"gaps"`
+	Error    string               `json:"error,omitempty"`
+}
+
+type BackfillRepairGap struct {
+	Kind             string `json:"kind"`
+	StartSlot        string `json:"start_slot"`
+	EndSlot          string `json:"end_slot"`
+	Repaired         bool   `json:"repaired"`
+	BatchesImported  string `json:"batches_imported"`
+	BatchesRemaining string `json:"batches_remaining"`
+}
@@ -44,6 +44,14 @@ type DepositEstimationContainer struct {
 	ExpectedActivationEpoch uint64 `json:"expected_activation_epoch,omitempty"`
 }
 
+type GetDepositEstimationsResponse struct {
+	Data                []*DepositEstimationContainer `json:"data"`
+	NextPageToken       string                        `json:"next_page_token"`
+	TotalSize           int                           `json:"total_size"`
+	ExecutionOptimistic bool                          `json:"execution_optimistic"`
+	Finalized           bool                          `json:"finalized"`
+}
+
 type PendingDepositEstimationContainer struct {
 	Type string                    `json:"type"` // "initial" or "top-up"
 	Data *PendingDepositEstimation `json:"data"`
@@ -69,6 +77,14 @@ type WithdrawalEstimationContainer struct {
 	PendingPartialWithdrawals []*PendingPartialWithdrawalContainer `json:"pending_partial_withdrawals"`
 }
 
+type GetWithdrawalEstimationsResponse struct {
+	Data                []*WithdrawalEstimationContainer `json:"data"`
+	NextPageToken       string                           `json:"next_page_token"`
+	TotalSize           int                              `json:"total_size"`
+	ExecutionOptimistic bool                             `json:"execution_optimistic"`
+	Finalized           bool                             `json:"finalized"`
+}
+
 type PendingPartialWithdrawalContainer struct {
 	Amount        uint64 `json:"amount"`
 	ExpectedEpoch uint64 `json:"expected_epoch"`
@@ -83,3 +99,176 @@ type GetExitQueueEpochResponse struct {
 type ExitQueueEpochContainer struct {
 	ExitQueueEpoch uint64 `json:"exit_queue_epoch"`
 }
+
+type GetTokenomicsProjectionResponse struct {
+	Data                *TokenomicsProjection `json:"data"`
+	ExecutionOptimistic bool                  `json:"execution_optimistic"`
+	Finalized           bool                  `json:"finalized"`
+}
+
+type TokenomicsProjection struct {
+	StartEpoch    string `json:"start_epoch"`
+	EndEpoch      string `json:"end_epoch"`
+	DepositModel  string `json:"deposit_model"`
+	DepositGrowth string `json:"deposit_growth"`
+
+	TotalIssuance               string `json:"total_issuance"`
+	TotalReserveUsage           string `json:"total_reserve_usage"`
+	FinalReserves               string `json:"final_reserves"`
+	FinalRewardAdjustmentFactor string `json:"final_reward_adjustment_factor"`
+	// if the reserves are depleted within the horizon, include the epoch they run out.
+	ReservesDepletionEpoch string `json:"reserves_depletion_epoch,omitempty"`
+
+	Points []*TokenomicsProjectionPoint `json:"points"`
+}
+
+type TokenomicsProjectionPoint struct {
+	Epoch                  string `json:"epoch"`
+	Year                   string `json:"year"`
+	RewardAdjustmentFactor string `json:"reward_adjustment_factor"`
+	Reserves               string `json:"reserves"`
+	Deposit                string `json:"deposit"`
+	TargetDeposit          string `json:"target_deposit"`
+	EpochIssuance          string `json:"epoch_issuance"`
+	FeedbackBoost          string `json:"feedback_boost"`
+	TotalIssuance          string `json:"total_issuance"`
+	TotalReserveUsage      string `json:"total_reserve_usage"`
+}
+
+type GetTokenomicsHistoryResponse struct {
+	Data             []*TokenomicsSnapshot `json:"data"`
+	LastIndexedEpoch string                `json:"last_indexed_epoch"`
+}
+
+type TokenomicsSnapshot struct {
+	Epoch                  string `json:"epoch"`
+	BlockRoot              string `json:"block_root"`
+	Reserves               string `json:"reserves"`
+	RewardAdjustmentFactor string `json:"reward_adjustment_factor"`
+	FeedbackBoost          string `json:"feedback_boost"`
+	EpochIssuance          string `json:"epoch_issuance"`
+	TotalActiveBalance     string `json:"total_active_balance"`
+}
+
+type GetBailoutRiskResponse struct {
+	Data                []*BailoutRisk `json:"data"`
+	InactivityLeak      bool           `json:"inactivity_leak"`
+	ExecutionOptimistic bool           `json:"execution_optimistic"`
+	Finalized           bool           `json:"finalized"`
+}
+
+type BailoutRisk struct {
+	Index              string `json:"index"`
+	Pubkey             string `json:"pubkey"`
+	PrincipalBalance   string `json:"principal_balance"`
+	Balance            string `json:"balance"`
+	BailoutBuffer      string `json:"bailout_buffer"`
+	BufferRemaining    string `json:"buffer_remaining"`
+	InactivityScore    string `json:"inactivity_score"`
+	EligibleForBailout bool   `json:"eligible_for_bailout"`
+	// if the validator can be bailed out in the projection, include the number of epochs until it is eligible
+	// and the first epoch starting with its exit initiated.
+	ProjectedEpochsToBailout string `json:"projected_epochs_to_bailout,omitempty"`
+	ProjectedBailoutEpoch    string `json:"projected_bailout_epoch,omitempty"`
+}
+
+type GetEpochRewardsBreakdownResponse struct {
+	Data                []*ValidatorEpochRewards `json:"data"`
+	ExecutionOptimistic bool                     `json:"execution_optimistic"`
+	Finalized           bool                     `json:"finalized"`
+}
+
+type ValidatorEpochRewards struct {
+	ValidatorIndex string `json:"validator_index"`
+	Source         string `json:"source"`
+	Target         string `json:"target"`
+	Head           string `json:"head"`
+	LightLayer     string `json:"light_layer"`
+	Proposer       string `json:"proposer"`
+	// rewards are split into the share funded by the reserves (feedback boost) and the share funded by issuance.
+	ReserveShare      string `json:"reserve_share"`
+	IssuanceShare     string `json:"issuance_share"`
+	InactivityPenalty string `json:"inactivity_penalty"`
+	InactivityScore   string `json:"inactivity_score"`
+	Total             string `json:"total"` // can be negative
+}
+
+type GetPrincipalHistoryResponse struct {
+	Data                *PrincipalHistory `json:"data"`
+	ExecutionOptimistic bool              `json:"execution_optimistic"`
+	Finalized           bool              `json:"finalized"`
+}
+
+type PrincipalHistory struct {
+	Index      string                   `json:"index"`
+	Pubkey     string                   `json:"pubkey"`
+	StartEpoch string                   `json:"start_epoch"`
+	EndEpoch   string                   `json:"end_epoch"`
+	Points     []*PrincipalHistoryPoint `json:"points"`
+	Events     []*PrincipalChangeEvent  `json:"events"`
+	Earnings   string                   `json:"earnings"` // can be negative
+	// if the validator had a principal balance within the range, include the realized APR as a fraction.
+	RealizedApr string `json:"realized_apr,omitempty"`
+}
+
+type PrincipalHistoryPoint struct {
+	Epoch            string `json:"epoch"`
+	PrincipalBalance string `json:"principal_balance"`
+	Balance          string `json:"balance"`
+}
+
+// PrincipalChangeEvent is a deposit or a withdrawal of a validator, reported at the slot of the block including it.
+type PrincipalChangeEvent struct {
+	Epoch  string `json:"epoch"`
+	Slot   string `json:"slot"`
+	Type   string `json:"type"` // "deposit" or "withdrawal"
+	Amount string `json:"amount"`
+}
+
+type ShutdownResponse struct {
+	Phase      string                   `json:"phase"`
+	Drain      bool                     `json:"drain"`
+	DrainError string                   `json:"drain_error,omitempty"`
+	StartedAt  string                   `json:"started_at,omitempty"`
+	Services   []*ServiceShutdownStatus `json:"services"`
+}
+
+type ServiceShutdownStatus struct {
+	Service string `json:"service"`
+	State   string `json:"state"`
+	Error   string `json:"error,omitempty"`
+}
+
+type GetSlasherDatabaseResponse struct {
+	Data *SlasherDatabase `json:"data"`
+}
+
+type SlasherDatabase struct {
+	FileSize string           `json:"file_size"`
+	Buckets  []*SlasherBucket `json:"buckets"`
+	// epoch and slot ranges are only included when the database holds attestations, proposals or spans.
+	LowestTargetEpoch   string                  `json:"lowest_target_epoch,omitempty"`
+	HighestTargetEpoch  string                  `json:"highest_target_epoch,omitempty"`
+	LowestProposalSlot  string                  `json:"lowest_proposal_slot,omitempty"`
+	HighestProposalSlot string                  `json:"highest_proposal_slot,omitempty"`
+	AttestedValidators  string                  `json:"attested_validators"`
+	LowestSpanEpoch     string                  `json:"lowest_span_epoch,omitempty"`
+	HighestSpanEpoch    string                  `json:"highest_span_epoch,omitempty"`
+	ChunkCoverage       []*SlasherChunkCoverage `json:"chunk_coverage"`
+}
+
+type SlasherBucket struct {
+	Name           string `json:"name"`
+	Entries        string `json:"entries"`
+	InUseBytes     string `json:"in_use_bytes"`
+	AllocatedBytes string `json:"allocated_bytes"`
+}
+
+type SlasherChunkCoverage struct {
+	Kind                    string   `json:"kind"` // "minspan" or "maxspan"
+	Chunks                  string   `json:"chunks"`
+	ValidatorChunks         string   `json:"validator_chunks"`
+	CompleteValidatorChunks string   `json:"complete_validator_chunks"`
+	MissingValidatorChunks  []string `json:"missing_validator_chunks"`
+	HighestValidatorIndex   string   `json:"highest_validator_index"`
+}
@@ -58,6 +58,13 @@ func (s *Service) ForkChoiceDump(ctx context.Context) (*forkchoice.Dump, error)
 	return s.cfg.ForkChoiceStore.ForkChoiceDump(ctx)
 }
 
+// ForkChoiceRecording returns the corresponding value from forkchoice
+func (s *Service) ForkChoiceRecording() ([]byte, error) {
+	s.cfg.ForkChoiceStore.RLock()
+	defer s.cfg.ForkChoiceStore.RUnlock()
+	return s.cfg.ForkChoiceStore.Recording()
+}
+
 // NewSlot returns the corresponding value from forkchoice
 func (s *Service) NewSlot(ctx context.Context, slot primitives.Slot) error {
 	s.cfg.ForkChoiceStore.Lock()
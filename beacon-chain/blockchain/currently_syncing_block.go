@@ -25,3 +25,9 @@ func (b *currentlySyncingBlock) isSyncing(root [32]byte) bool {
 	_, ok := b.roots[root]
 	return ok
 }
+
+func (b *currentlySyncingBlock) isEmpty() bool {
+	b.Lock()
+	defer b.Unlock()
+	return len(b.roots) == 0
+}
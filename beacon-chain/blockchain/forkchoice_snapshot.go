@@ -0,0 +1,125 @@
+package blockchain
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	forkchoicetypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/forkchoice/types"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+	"github.com/sirupsen/logrus"
+)
+
+// restoreForkchoiceSnapshot loads the fork choice store saved by the previous run of the node, so
+// that the node resumes from its previous head rather than from the finalized checkpoint. The
+// snapshot is only used if it is consistent with the database: it must have the finalized
+// checkpoint of the database, and all its blocks and the state of its head must be available.
+// It returns whether the snapshot was restored. The caller must hold the fork choice lock.
+func (s *Service) restoreForkchoiceSnapshot(ctx context.Context, finalized *ethpb.Checkpoint) bool {
+	enc, err := s.cfg.BeaconDB.ForkchoiceSnapshot(ctx)
+	if err != nil {
+		log.WithError(err).Warn("Could not read fork choice snapshot")
+		return false
+	}
+	if len(enc) == 0 {
+		return false
+	}
+
+	var headRoot [32]byte
+	var headBlock interfaces.ReadOnlySignedBeaconBlock
+	var headState state.BeaconState
+	verify := func(info *forkchoicetypes.SnapshotInfo) error {
+		if info.FinalizedCheckpoint.Epoch != finalized.Epoch || info.FinalizedCheckpoint.Root != bytesutil.ToBytes32(finalized.Root) {
+			return errors.Errorf("snapshot finalized checkpoint %d %#x does not match the database", info.FinalizedCheckpoint.Epoch, info.FinalizedCheckpoint.Root)
+		}
+		if info.GenesisTime != uint64(s.genesisTime.Unix()) {
+			return errors.Errorf("snapshot genesis time %d does not match the chain", info.GenesisTime)
+		}
+		for _, root := range info.Roots {
+			if !s.cfg.BeaconDB.HasBlock(ctx, root) {
+				return errors.Errorf("block %#x of the snapshot is not in the database", root)
+			}
+		}
+		headRoot = info.HeadRoot
+		if headBlock, err = s.cfg.BeaconDB.Block(ctx, headRoot); err != nil {
+			return errors.Wrap(err, "could not get head block")
+		}
+		if headState, err = s.cfg.StateGen.StateByRoot(ctx, headRoot); err != nil {
+			return errors.Wrap(err, "could not get head state")
+		}
+		return nil
+	}
+	if err := s.cfg.ForkChoiceStore.LoadSnapshot(enc, verify); err != nil {
+		log.WithError(err).Warn("Could not restore fork choice snapshot, starting from the finalized checkpoint")
+		return false
+	}
+
+	optimistic, err := s.cfg.ForkChoiceStore.IsOptimistic(headRoot)
+	if err != nil {
+		log.WithError(err).Warn("Could not get optimistic status of the fork choice snapshot head")
+	}
+	if err := s.setHead(&head{headRoot, headBlock, headState, headBlock.Block().Slot(), optimistic}); err != nil {
+		log.WithError(err).Warn("Could not set head from the fork choice snapshot")
+		return true
+	}
+	log.WithFields(logrus.Fields{
+		"headRoot": fmt.Sprintf("%#x", headRoot),
+		"headSlot": headBlock.Block().Slot(),
+		"nodes":    s.cfg.ForkChoiceStore.NodeCount(),
+	}).Info("Restored fork choice snapshot")
+	return true
+}
+
+// saveForkchoiceSnapshot saves the fork choice store to the database, unless it is empty because
+// the chain has not started yet.
+func (s *Service) saveForkchoiceSnapshot(ctx context.Context) error {
+	if s.cfg.ForkChoiceStore == nil {
+		return nil
+	}
+	s.cfg.ForkChoiceStore.RLock()
+	if s.cfg.ForkChoiceStore.NodeCount() == 0 {
+		s.cfg.ForkChoiceStore.RUnlock()
+		return nil
+	}
+	enc, err := s.cfg.ForkChoiceStore.Snapshot()
+	s.cfg.ForkChoiceStore.RUnlock()
+	if err != nil {
+		return errors.Wrap(err, "could not encode fork choice snapshot")
+	}
+	return s.cfg.BeaconDB.SaveForkchoiceSnapshot(ctx, enc)
+}
+
+// spawnForkchoiceSnapshotRoutine saves the fork choice store at the start of every epoch, so
+// that a node which is not shut down gracefully can still restore a recent snapshot.
+func (s *Service) spawnForkchoiceSnapshotRoutine() {
+	go func() {
+		if _, err := s.clockWaiter.WaitForClock(s.ctx); err != nil {
+			log.WithError(err).Error("spawnForkchoiceSnapshotRoutine failed to receive genesis data")
+			return
+		}
+		ticker := slots.NewSlotTicker(s.genesisTime, params.BeaconConfig().SecondsPerSlot)
+		defer ticker.Done()
+		for {
+			select {
+			case <-s.ctx.Done():
+				return
+			case slot := <-ticker.C():
+				if !slots.IsEpochStart(slot) {
+					continue
+				}
+				start := time.Now()
+				if err := s.saveForkchoiceSnapshot(s.ctx); err != nil {
+					log.WithError(err).Error("Could not save fork choice snapshot")
+					continue
+				}
+				log.WithField("duration", time.Since(start)).Debug("Saved fork choice snapshot")
+			}
+		}
+	}()
+}
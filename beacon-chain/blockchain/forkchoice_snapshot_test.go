@@ -0,0 +1,98 @@
+package blockchain
+
+import (
+	"testing"
+	"time"
+
+	doublylinkedtree "github.com/prysmaticlabs/prysm/v5/beacon-chain/forkchoice/doubly-linked-tree"
+	forkchoicetypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/forkchoice/types"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state/stategen"
+	consensusblocks "github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+func TestService_ForkchoiceSnapshot(t *testing.T) {
+	s, tr := minimalTestService(t)
+	ctx, beaconDB, fcs := tr.ctx, tr.db, tr.fcs
+	s.genesisTime = time.Unix(1000, 0)
+	fcs.SetGenesisTime(1000)
+
+	// Nothing is saved before the chain starts.
+	require.NoError(t, s.saveForkchoiceSnapshot(ctx))
+	enc, err := beaconDB.ForkchoiceSnapshot(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(enc))
+
+	var roots [][32]byte
+	parent := [32]byte{}
+	for slot := 0; slot < 3; slot++ {
+		blk := util.NewBeaconBlock()
+		blk.Block.Slot = primitives.Slot(slot)
+		blk.Block.ParentRoot = parent[:]
+		root, err := blk.Block.HashTreeRoot()
+		require.NoError(t, err)
+		util.SaveBlock(t, ctx, beaconDB, blk)
+		st, err := util.NewBeaconState()
+		require.NoError(t, err)
+		require.NoError(t, st.SetSlot(blk.Block.Slot))
+		require.NoError(t, beaconDB.SaveState(ctx, st, root))
+		if slot == 0 {
+			cp := &forkchoicetypes.Checkpoint{Root: root}
+			require.NoError(t, fcs.UpdateJustifiedCheckpoint(ctx, cp))
+			require.NoError(t, fcs.UpdateFinalizedCheckpoint(cp))
+		}
+		wsb, err := consensusblocks.NewSignedBeaconBlock(blk)
+		require.NoError(t, err)
+		roblock, err := consensusblocks.NewROBlockWithRoot(wsb, root)
+		require.NoError(t, err)
+		require.NoError(t, fcs.InsertNode(ctx, st, roblock))
+		roots = append(roots, root)
+		parent = root
+	}
+	fcs.Lock()
+	_, err = fcs.Head(ctx)
+	fcs.Unlock()
+	require.NoError(t, err)
+	require.NoError(t, s.saveForkchoiceSnapshot(ctx))
+	finalized := &ethpb.Checkpoint{Root: roots[0][:]}
+
+	// The service is restarted with an empty fork choice store.
+	restarted := func() *Service {
+		fcs := doublylinkedtree.New()
+		fcs.SetBalancesByRooter(tr.sg.ActiveNonSlashedBalancesByRoot)
+		s.cfg.ForkChoiceStore = fcs
+		s.cfg.StateGen = stategen.New(beaconDB, fcs)
+		s.head = nil
+		return s
+	}
+
+	r := restarted()
+	r.cfg.ForkChoiceStore.Lock()
+	require.Equal(t, true, r.restoreForkchoiceSnapshot(ctx, finalized))
+	r.cfg.ForkChoiceStore.Unlock()
+	assert.Equal(t, 3, r.cfg.ForkChoiceStore.NodeCount())
+	headRoot, err := r.HeadRoot(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, roots[2], bytesutil.ToBytes32(headRoot))
+	assert.Equal(t, roots[2], r.cfg.ForkChoiceStore.CachedHeadRoot())
+
+	// A snapshot which does not match the finalized checkpoint of the database is not used.
+	r = restarted()
+	r.cfg.ForkChoiceStore.Lock()
+	require.Equal(t, false, r.restoreForkchoiceSnapshot(ctx, &ethpb.Checkpoint{Epoch: 1, Root: roots[0][:]}))
+	r.cfg.ForkChoiceStore.Unlock()
+	assert.Equal(t, 0, r.cfg.ForkChoiceStore.NodeCount())
+
+	// Nor is a snapshot with blocks missing from the database.
+	require.NoError(t, beaconDB.DeleteBlock(ctx, roots[2]))
+	r = restarted()
+	r.cfg.ForkChoiceStore.Lock()
+	require.Equal(t, false, r.restoreForkchoiceSnapshot(ctx, finalized))
+	r.cfg.ForkChoiceStore.Unlock()
+	assert.Equal(t, 0, r.cfg.ForkChoiceStore.NodeCount())
+}
@@ -84,6 +84,14 @@ func WithTrackedValidatorsCache(c *cache.TrackedValidatorsCache) Option {
 	}
 }
 
+// WithEventSubscribersCache for the event streams subscribed to the state feed events.
+func WithEventSubscribersCache(c *cache.EventSubscribersCache) Option {
+	return func(s *Service) error {
+		s.cfg.EventSubscribers = c
+		return nil
+	}
+}
+
 // WithAttestationPool for attestation lifecycle after chain inclusion.
 func WithAttestationPool(p attestations.Pool) Option {
 	return func(s *Service) error {
@@ -190,6 +198,15 @@ func WithBlobStorage(b *filesystem.BlobStorage) Option {
 	}
 }
 
+// WithReorgPolicyFile sets the YAML file of the reorg policy followed by fork choice, which is
+// reloaded whenever the file changes.
+func WithReorgPolicyFile(path string) Option {
+	return func(s *Service) error {
+		s.cfg.ReorgPolicyFile = path
+		return nil
+	}
+}
+
 func WithSyncChecker(checker Checker) Option {
 	return func(s *Service) error {
 		s.cfg.SyncChecker = checker
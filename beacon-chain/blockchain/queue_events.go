@@ -0,0 +1,430 @@
+package blockchain
+
+import (
+	"context"
+	"encoding/binary"
+	"sort"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/electra"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/feed"
+	statefeed "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/feed/state"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/transition"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	consensusblocks "github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/container/slice"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+)
+
+// queueEventBlocksBufferSize is the number of imports whose blocks can wait for their validator queue events.
+const queueEventBlocksBufferSize = 64
+
+// queueEventTopics are the validator queue events which an event stream is subscribed to.
+type queueEventTopics struct {
+	enqueued    bool
+	processed   bool
+	activated   bool
+	exits       bool
+	withdrawals bool
+}
+
+func (t queueEventTopics) any() bool {
+	return t.enqueued || t.processed || t.activated || t.exits || t.withdrawals
+}
+
+func (s *Service) subscribedQueueEvents() queueEventTopics {
+	c := s.cfg.EventSubscribers
+	return queueEventTopics{
+		enqueued:    c.Subscribed(statefeed.PendingDepositsEnqueued),
+		processed:   c.Subscribed(statefeed.PendingDepositsProcessed),
+		activated:   c.Subscribed(statefeed.ValidatorsActivated),
+		exits:       c.Subscribed(statefeed.ValidatorExitsInitiated),
+		withdrawals: c.Subscribed(statefeed.WithdrawalsProcessed),
+	}
+}
+
+// queueEventsForBlocks hands a chain of imported blocks to the routine which sends their validator queue events,
+// if an event stream is subscribed to any of them. The events are derived from the states saved by the import,
+// so the import does not wait for them, and the blocks are dropped if the routine falls too far behind.
+func (s *Service) queueEventsForBlocks(blks []consensusblocks.ROBlock) {
+	if len(blks) == 0 || !s.subscribedQueueEvents().any() {
+		return
+	}
+	select {
+	case s.queueEventBlocks <- blks:
+	default:
+		log.WithField("slot", blks[len(blks)-1].Block().Slot()).Warn("Dropping validator queue events of imported blocks, the event streams are falling behind")
+	}
+}
+
+// spawnQueueEventsRoutine sends the validator queue events of the imported blocks, in the order of their import.
+func (s *Service) spawnQueueEventsRoutine() {
+	go func() {
+		for {
+			select {
+			case <-s.ctx.Done():
+				return
+			case blks := <-s.queueEventBlocks:
+				if err := s.sendQueueEventsForBlocks(s.ctx, blks); err != nil {
+					log.WithError(err).Error("Could not send validator queue events")
+				}
+			}
+		}
+	}()
+}
+
+// sendQueueEventsForBlocks sends the validator queue events of a chain of imported blocks. The pre-state of the
+// first block and the post-state of the last one are read from the state cache, and the post-states in between
+// are replayed, as a batch import only saves the post-state of its last block.
+func (s *Service) sendQueueEventsForBlocks(ctx context.Context, blks []consensusblocks.ROBlock) error {
+	topics := s.subscribedQueueEvents()
+	if !topics.any() {
+		return nil
+	}
+	preState, err := s.cfg.StateGen.StateByRoot(ctx, blks[0].Block().ParentRoot())
+	if err != nil {
+		return errors.Wrap(err, "could not get pre state")
+	}
+	for i, b := range blks {
+		// The snapshot has to be taken before the state transition, which mutates the pre-state.
+		qs, err := newQueueSnapshot(preState, b.Block(), topics)
+		if err != nil {
+			return errors.Wrap(err, "could not take snapshot of pre state")
+		}
+		var postState state.BeaconState
+		if i == len(blks)-1 {
+			postState, err = s.cfg.StateGen.StateByRoot(ctx, b.Root())
+		} else {
+			_, postState, err = transition.ExecuteStateTransitionNoVerifyAnySig(ctx, preState, b)
+		}
+		if err != nil {
+			return errors.Wrapf(err, "could not get post state of slot %d", b.Block().Slot())
+		}
+		if err := s.sendQueueEvents(qs, b, postState); err != nil {
+			return err
+		}
+		preState = postState
+	}
+	return nil
+}
+
+// queueSnapshot holds what is needed from a block's pre-state to derive the validator queue events of the block.
+// Only what is needed for the subscribed events is read from the pre-state.
+type queueSnapshot struct {
+	topics queueEventTopics
+	epoch  primitives.Epoch
+	// enqueued are the pending deposits appended to the queue by the block.
+	enqueued []*ethpb.PendingDeposit
+	// exitCandidates are the validators without an initiated exit which are exited by the operations of the block,
+	// mapped to the reason of the exit.
+	exitCandidates map[primitives.ValidatorIndex]string
+
+	// The following are only set when the block crosses an epoch boundary.
+	crossesEpoch    bool
+	pendingDeposits []*ethpb.PendingDeposit
+	notExited       []primitives.ValidatorIndex
+}
+
+// newQueueSnapshot takes the snapshot of the pre-state of the block for the given events.
+func newQueueSnapshot(preState state.ReadOnlyBeaconState, blk interfaces.ReadOnlyBeaconBlock, topics queueEventTopics) (*queueSnapshot, error) {
+	farFuture := params.BeaconConfig().FarFutureEpoch
+	qs := &queueSnapshot{
+		topics:         topics,
+		epoch:          slots.ToEpoch(preState.Slot()),
+		exitCandidates: make(map[primitives.ValidatorIndex]string),
+	}
+	addExitCandidate := func(idx primitives.ValidatorIndex, reason string) error {
+		if !topics.exits {
+			return nil
+		}
+		if _, ok := qs.exitCandidates[idx]; ok || uint64(idx) >= uint64(preState.NumValidators()) {
+			return nil
+		}
+		val, err := preState.ValidatorAtIndexReadOnly(idx)
+		if err != nil {
+			return err
+		}
+		if val.ExitEpoch() == farFuture {
+			qs.exitCandidates[idx] = reason
+		}
+		return nil
+	}
+
+	// The operations are visited in the order of the block processing, so an exit gets the reason of the first one.
+	body := blk.Body()
+	for _, s := range body.ProposerSlashings() {
+		if err := addExitCandidate(s.Header_1.Header.ProposerIndex, statefeed.ExitReasonSlashing); err != nil {
+			return nil, err
+		}
+	}
+	for _, s := range body.AttesterSlashings() {
+		for _, idx := range slice.IntersectionUint64(s.FirstAttestation().GetAttestingIndices(), s.SecondAttestation().GetAttestingIndices()) {
+			if err := addExitCandidate(primitives.ValidatorIndex(idx), statefeed.ExitReasonSlashing); err != nil {
+				return nil, err
+			}
+		}
+	}
+	for _, e := range body.VoluntaryExits() {
+		if err := addExitCandidate(e.Exit.ValidatorIndex, statefeed.ExitReasonVoluntaryExit); err != nil {
+			return nil, err
+		}
+	}
+
+	if blk.Version() >= version.Alpaca && topics.enqueued {
+		// Deposits of new validators with an invalid signature are dropped instead of being queued.
+		registered := make(map[[fieldparams.BLSPubkeyLength]byte]bool)
+		for _, d := range body.Deposits() {
+			pubkey := bytesutil.ToBytes48(d.Data.PublicKey)
+			if _, ok := preState.ValidatorIndexByPubkey(pubkey); !ok && !registered[pubkey] {
+				valid, err := electra.IsValidDepositSignature(d.Data)
+				if err != nil {
+					return nil, err
+				}
+				if !valid {
+					continue
+				}
+				registered[pubkey] = true
+			}
+			qs.enqueued = append(qs.enqueued, &ethpb.PendingDeposit{
+				PublicKey:             d.Data.PublicKey,
+				WithdrawalCredentials: d.Data.WithdrawalCredentials,
+				Amount:                d.Data.Amount,
+				Signature:             d.Data.Signature,
+				Slot:                  params.BeaconConfig().GenesisSlot,
+			})
+		}
+	}
+	if blk.Version() >= version.Alpaca {
+		requests, err := body.ExecutionRequests()
+		if err != nil {
+			return nil, errors.Wrap(err, "could not get execution requests")
+		}
+		if requests != nil && topics.enqueued {
+			for _, r := range requests.Deposits {
+				qs.enqueued = append(qs.enqueued, &ethpb.PendingDeposit{
+					PublicKey:             r.Pubkey,
+					WithdrawalCredentials: r.WithdrawalCredentials,
+					Amount:                r.Amount,
+					Signature:             r.Signature,
+					Slot:                  blk.Slot(),
+				})
+			}
+		}
+		if requests != nil && topics.exits {
+			for _, r := range requests.Withdrawals {
+				if r.Amount != params.BeaconConfig().FullExitRequestAmount {
+					continue
+				}
+				idx, ok := preState.ValidatorIndexByPubkey(bytesutil.ToBytes48(r.ValidatorPubkey))
+				if !ok {
+					continue
+				}
+				if err := addExitCandidate(idx, statefeed.ExitReasonWithdrawalRequest); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	if slots.ToEpoch(blk.Slot()) <= qs.epoch {
+		return qs, nil
+	}
+	qs.crossesEpoch = true
+	if topics.processed && preState.Version() >= version.Alpaca {
+		pds, err := preState.PendingDeposits()
+		if err != nil {
+			return nil, errors.Wrap(err, "could not get pending deposits")
+		}
+		qs.pendingDeposits = pds
+	}
+	if !topics.exits {
+		return qs, nil
+	}
+	if err := preState.ReadFromEveryValidator(func(idx int, val state.ReadOnlyValidator) error {
+		if val.ExitEpoch() == farFuture {
+			qs.notExited = append(qs.notExited, primitives.ValidatorIndex(idx))
+		}
+		return nil
+	}); err != nil {
+		return nil, errors.Wrap(err, "could not read validators")
+	}
+	return qs, nil
+}
+
+// sendQueueEvents sends the events of the changes made by the block's state transition to the pending deposit queue,
+// the activations, the exits and the withdrawals to the state feed, for the events of the snapshot.
+//
+// The exits initiated by the epoch processing are the bailouts. Only the validators which were registered in the
+// pre-state are checked for them, as new validators can not be active before they go through the activation queue.
+func (s *Service) sendQueueEvents(qs *queueSnapshot, roblock consensusblocks.ROBlock, postState state.ReadOnlyBeaconState) error {
+	slot := roblock.Block().Slot()
+	root := roblock.Root()
+	farFuture := params.BeaconConfig().FarFutureEpoch
+
+	if len(qs.enqueued) > 0 {
+		s.cfg.StateNotifier.StateFeed().Send(&feed.Event{
+			Type: statefeed.PendingDepositsEnqueued,
+			Data: &statefeed.PendingDepositsEnqueuedData{
+				Slot:            slot,
+				BlockRoot:       root,
+				PendingDeposits: qs.enqueued,
+			},
+		})
+	}
+
+	if qs.topics.processed && qs.crossesEpoch && postState.Version() >= version.Alpaca {
+		processed, err := processedDeposits(qs.pendingDeposits, postState)
+		if err != nil {
+			return err
+		}
+		if len(processed) > 0 {
+			s.cfg.StateNotifier.StateFeed().Send(&feed.Event{
+				Type: statefeed.PendingDepositsProcessed,
+				Data: &statefeed.PendingDepositsProcessedData{
+					Slot:      slot,
+					BlockRoot: root,
+					Deposits:  processed,
+				},
+			})
+		}
+	}
+
+	if qs.topics.activated && qs.crossesEpoch {
+		postEpoch := slots.ToEpoch(postState.Slot())
+		activations := make([]*statefeed.ValidatorActivation, 0)
+		if err := postState.ReadFromEveryValidator(func(idx int, val state.ReadOnlyValidator) error {
+			if val.ActivationEpoch() > qs.epoch && val.ActivationEpoch() <= postEpoch {
+				activations = append(activations, &statefeed.ValidatorActivation{
+					ValidatorIndex:  primitives.ValidatorIndex(idx),
+					Pubkey:          val.PublicKey(),
+					ActivationEpoch: val.ActivationEpoch(),
+				})
+			}
+			return nil
+		}); err != nil {
+			return errors.Wrap(err, "could not read validators")
+		}
+		if len(activations) > 0 {
+			s.cfg.StateNotifier.StateFeed().Send(&feed.Event{
+				Type: statefeed.ValidatorsActivated,
+				Data: &statefeed.ValidatorsActivatedData{
+					Slot:        slot,
+					BlockRoot:   root,
+					Activations: activations,
+				},
+			})
+		}
+	}
+
+	reasons := qs.exitCandidates
+	if qs.crossesEpoch {
+		reasons = make(map[primitives.ValidatorIndex]string, len(qs.exitCandidates))
+		for _, idx := range qs.notExited {
+			reasons[idx] = statefeed.ExitReasonBailout
+		}
+		for idx, reason := range qs.exitCandidates {
+			reasons[idx] = reason
+		}
+	}
+	exits := make([]*statefeed.ValidatorExit, 0)
+	for idx, reason := range reasons {
+		val, err := postState.ValidatorAtIndexReadOnly(idx)
+		if err != nil {
+			return errors.Wrap(err, "could not get validator")
+		}
+		if val.ExitEpoch() == farFuture {
+			continue
+		}
+		exits = append(exits, &statefeed.ValidatorExit{
+			ValidatorIndex:    idx,
+			Pubkey:            val.PublicKey(),
+			ExitEpoch:         val.ExitEpoch(),
+			WithdrawableEpoch: helpers.GetWithdrawableEpoch(val.ExitEpoch(), val.Slashed()),
+			Reason:            reason,
+		})
+	}
+	if len(exits) > 0 {
+		sort.Slice(exits, func(i, j int) bool { return exits[i].ValidatorIndex < exits[j].ValidatorIndex })
+		s.cfg.StateNotifier.StateFeed().Send(&feed.Event{
+			Type: statefeed.ValidatorExitsInitiated,
+			Data: &statefeed.ValidatorExitsInitiatedData{
+				Slot:      slot,
+				BlockRoot: root,
+				Exits:     exits,
+			},
+		})
+	}
+
+	if qs.topics.withdrawals && roblock.Version() >= version.Capella {
+		payload, err := roblock.Block().Body().Execution()
+		if err != nil {
+			return errors.Wrap(err, "could not get execution payload")
+		}
+		withdrawals, err := payload.Withdrawals()
+		if err != nil {
+			return errors.Wrap(err, "could not get withdrawals")
+		}
+		if len(withdrawals) > 0 {
+			s.cfg.StateNotifier.StateFeed().Send(&feed.Event{
+				Type: statefeed.WithdrawalsProcessed,
+				Data: &statefeed.WithdrawalsProcessedData{
+					Slot:        slot,
+					BlockRoot:   root,
+					Withdrawals: withdrawals,
+				},
+			})
+		}
+	}
+	return nil
+}
+
+// processedDeposits returns the pending deposits of the pre-state which are not in the queue of the post-state.
+// The deposits postponed by the epoch processing stay in the queue, and the ones appended by the block
+// are not in the pre-state, so neither of them are included.
+func processedDeposits(pre []*ethpb.PendingDeposit, postState state.ReadOnlyBeaconState) ([]*statefeed.ProcessedDeposit, error) {
+	if len(pre) == 0 {
+		return nil, nil
+	}
+	post, err := postState.PendingDeposits()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get pending deposits")
+	}
+	remaining := make(map[string]int, len(post))
+	for _, pd := range post {
+		remaining[pendingDepositKey(pd)]++
+	}
+	processed := make([]*statefeed.ProcessedDeposit, 0)
+	for _, pd := range pre {
+		key := pendingDepositKey(pd)
+		if remaining[key] > 0 {
+			remaining[key]--
+			continue
+		}
+		idx, ok := postState.ValidatorIndexByPubkey(bytesutil.ToBytes48(pd.PublicKey))
+		processed = append(processed, &statefeed.ProcessedDeposit{
+			PendingDeposit: pd,
+			Credited:       ok,
+			ValidatorIndex: idx,
+		})
+	}
+	return processed, nil
+}
+
+// pendingDepositKey identifies a pending deposit by all of its fields.
+func pendingDepositKey(pd *ethpb.PendingDeposit) string {
+	key := make([]byte, 0, len(pd.PublicKey)+len(pd.WithdrawalCredentials)+len(pd.Signature)+16)
+	key = append(key, pd.PublicKey...)
+	key = append(key, pd.WithdrawalCredentials...)
+	key = append(key, pd.Signature...)
+	key = binary.LittleEndian.AppendUint64(key, pd.Amount)
+	key = binary.LittleEndian.AppendUint64(key, uint64(pd.Slot))
+	return string(key)
+}
@@ -0,0 +1,241 @@
+package blockchain
+
+import (
+	"context"
+	"testing"
+
+	mock "github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/cache"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/feed"
+	statefeed "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/feed/state"
+	testDB "github.com/prysmaticlabs/prysm/v5/beacon-chain/db/testing"
+	doublylinkedtree "github.com/prysmaticlabs/prysm/v5/beacon-chain/forkchoice/doubly-linked-tree"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state/stategen"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	enginev1 "github.com/prysmaticlabs/prysm/v5/proto/engine/v1"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+var allQueueEvents = queueEventTopics{enqueued: true, processed: true, activated: true, exits: true, withdrawals: true}
+
+func TestSendQueueEvents(t *testing.T) {
+	farFuture := params.BeaconConfig().FarFutureEpoch
+	newPubkey := bytesutil.PadTo([]byte{0xaa}, fieldparams.BLSPubkeyLength)
+
+	preState, _ := util.DeterministicGenesisStateElectra(t, 8)
+	pubkey0 := preState.PubkeyAtIndex(0)
+	topUp := &ethpb.PendingDeposit{
+		PublicKey:             pubkey0[:],
+		WithdrawalCredentials: make([]byte, 32),
+		Amount:                params.BeaconConfig().MinActivationBalance,
+		Signature:             make([]byte, 96),
+		Slot:                  1,
+	}
+	initial := &ethpb.PendingDeposit{
+		PublicKey:             newPubkey,
+		WithdrawalCredentials: make([]byte, 32),
+		Amount:                params.BeaconConfig().MinActivationBalance,
+		Signature:             make([]byte, 96),
+		Slot:                  2,
+	}
+	require.NoError(t, preState.AppendPendingDeposit(topUp))
+	require.NoError(t, preState.AppendPendingDeposit(initial))
+	val2, err := preState.ValidatorAtIndex(2)
+	require.NoError(t, err)
+	val2.ActivationEpoch = farFuture
+	require.NoError(t, preState.UpdateValidatorAtIndex(2, val2))
+
+	// The block exits validator 1, queues a deposit request and withdraws from validator 4.
+	depositRequest := &enginev1.DepositRequest{
+		Pubkey:                newPubkey,
+		WithdrawalCredentials: make([]byte, 32),
+		Amount:                params.BeaconConfig().MinActivationBalance,
+		Signature:             make([]byte, 96),
+	}
+	withdrawal := &enginev1.Withdrawal{Index: 7, ValidatorIndex: 4, Address: make([]byte, 20), Amount: 100}
+	newBlock := func(slot primitives.Slot) blocks.ROBlock {
+		b := util.NewBeaconBlockElectra()
+		b.Block.Slot = slot
+		b.Block.Body.VoluntaryExits = []*ethpb.SignedVoluntaryExit{{Exit: &ethpb.VoluntaryExit{ValidatorIndex: 1}, Signature: make([]byte, 96)}}
+		b.Block.Body.ExecutionRequests.Deposits = []*enginev1.DepositRequest{depositRequest}
+		b.Block.Body.ExecutionPayload.Withdrawals = []*enginev1.Withdrawal{withdrawal}
+		sb, err := blocks.NewSignedBeaconBlock(b)
+		require.NoError(t, err)
+		roblock, err := blocks.NewROBlockWithRoot(sb, [32]byte{'a'})
+		require.NoError(t, err)
+		return roblock
+	}
+	// newPostState applies the changes of the block's state transition to a copy of the pre-state.
+	newPostState := func(slot primitives.Slot, pendingDeposits []*ethpb.PendingDeposit, exitEpochs map[primitives.ValidatorIndex]primitives.Epoch) state.BeaconState {
+		st := preState.Copy()
+		require.NoError(t, st.SetSlot(slot))
+		require.NoError(t, st.SetPendingDeposits(pendingDeposits))
+		for idx, epoch := range exitEpochs {
+			val, err := st.ValidatorAtIndex(idx)
+			require.NoError(t, err)
+			val.ExitEpoch = epoch
+			require.NoError(t, st.UpdateValidatorAtIndex(idx, val))
+		}
+		return st
+	}
+	enqueued := &ethpb.PendingDeposit{
+		PublicKey:             depositRequest.Pubkey,
+		WithdrawalCredentials: depositRequest.WithdrawalCredentials,
+		Amount:                depositRequest.Amount,
+		Signature:             depositRequest.Signature,
+	}
+
+	t.Run("within an epoch", func(t *testing.T) {
+		require.NoError(t, preState.SetSlot(4))
+		roblock := newBlock(5)
+		qs, err := newQueueSnapshot(preState, roblock.Block(), allQueueEvents)
+		require.NoError(t, err)
+		assert.Equal(t, false, qs.crossesEpoch)
+
+		enqueued.Slot = 5
+		// Validator 3 can not be bailed out within an epoch, so it is not reported.
+		postState := newPostState(5, []*ethpb.PendingDeposit{topUp, initial, enqueued}, map[primitives.ValidatorIndex]primitives.Epoch{1: 10, 3: 11})
+		notifier := &mock.MockStateNotifier{RecordEvents: true}
+		s := &Service{cfg: &config{StateNotifier: notifier}}
+		require.NoError(t, s.sendQueueEvents(qs, roblock, postState))
+
+		events := notifier.ReceivedEvents()
+		require.Equal(t, 3, len(events))
+		enqueuedData, ok := events[0].Data.(*statefeed.PendingDepositsEnqueuedData)
+		require.Equal(t, true, ok)
+		assert.Equal(t, primitives.Slot(5), enqueuedData.Slot)
+		require.DeepEqual(t, []*ethpb.PendingDeposit{enqueued}, enqueuedData.PendingDeposits)
+		exitsData, ok := events[1].Data.(*statefeed.ValidatorExitsInitiatedData)
+		require.Equal(t, true, ok)
+		require.Equal(t, 1, len(exitsData.Exits))
+		assert.Equal(t, primitives.ValidatorIndex(1), exitsData.Exits[0].ValidatorIndex)
+		assert.Equal(t, statefeed.ExitReasonVoluntaryExit, exitsData.Exits[0].Reason)
+		withdrawalsData, ok := events[2].Data.(*statefeed.WithdrawalsProcessedData)
+		require.Equal(t, true, ok)
+		require.DeepEqual(t, []*enginev1.Withdrawal{withdrawal}, withdrawalsData.Withdrawals)
+	})
+	t.Run("crossing an epoch boundary", func(t *testing.T) {
+		require.NoError(t, preState.SetSlot(params.BeaconConfig().SlotsPerEpoch-1))
+		slot := params.BeaconConfig().SlotsPerEpoch
+		roblock := newBlock(slot)
+		qs, err := newQueueSnapshot(preState, roblock.Block(), allQueueEvents)
+		require.NoError(t, err)
+		assert.Equal(t, true, qs.crossesEpoch)
+
+		// The top-up is processed, validator 2 is activated and validator 3 is bailed out.
+		enqueued.Slot = slot
+		postState := newPostState(slot, []*ethpb.PendingDeposit{initial, enqueued}, map[primitives.ValidatorIndex]primitives.Epoch{1: 10, 3: 11})
+		val2, err := postState.ValidatorAtIndex(2)
+		require.NoError(t, err)
+		val2.ActivationEpoch = 1
+		require.NoError(t, postState.UpdateValidatorAtIndex(2, val2))
+		notifier := &mock.MockStateNotifier{RecordEvents: true}
+		s := &Service{cfg: &config{StateNotifier: notifier}}
+		require.NoError(t, s.sendQueueEvents(qs, roblock, postState))
+
+		events := notifier.ReceivedEvents()
+		require.Equal(t, 5, len(events))
+		processedData, ok := events[1].Data.(*statefeed.PendingDepositsProcessedData)
+		require.Equal(t, true, ok)
+		require.Equal(t, 1, len(processedData.Deposits))
+		require.DeepEqual(t, topUp, processedData.Deposits[0].PendingDeposit)
+		assert.Equal(t, true, processedData.Deposits[0].Credited)
+		assert.Equal(t, primitives.ValidatorIndex(0), processedData.Deposits[0].ValidatorIndex)
+
+		activatedData, ok := events[2].Data.(*statefeed.ValidatorsActivatedData)
+		require.Equal(t, true, ok)
+		require.Equal(t, 1, len(activatedData.Activations))
+		assert.Equal(t, primitives.ValidatorIndex(2), activatedData.Activations[0].ValidatorIndex)
+		assert.Equal(t, primitives.Epoch(1), activatedData.Activations[0].ActivationEpoch)
+
+		exitsData, ok := events[3].Data.(*statefeed.ValidatorExitsInitiatedData)
+		require.Equal(t, true, ok)
+		require.Equal(t, 2, len(exitsData.Exits))
+		assert.Equal(t, statefeed.ExitReasonVoluntaryExit, exitsData.Exits[0].Reason)
+		assert.Equal(t, primitives.ValidatorIndex(3), exitsData.Exits[1].ValidatorIndex)
+		assert.Equal(t, statefeed.ExitReasonBailout, exitsData.Exits[1].Reason)
+		assert.Equal(t, primitives.Epoch(11), exitsData.Exits[1].ExitEpoch)
+	})
+	t.Run("only subscribed events", func(t *testing.T) {
+		require.NoError(t, preState.SetSlot(params.BeaconConfig().SlotsPerEpoch-1))
+		slot := params.BeaconConfig().SlotsPerEpoch
+		roblock := newBlock(slot)
+		qs, err := newQueueSnapshot(preState, roblock.Block(), queueEventTopics{withdrawals: true})
+		require.NoError(t, err)
+		// Neither the deposits nor the validators of the pre-state are read.
+		assert.Equal(t, 0, len(qs.enqueued))
+		assert.Equal(t, 0, len(qs.exitCandidates))
+		assert.Equal(t, 0, len(qs.pendingDeposits))
+		assert.Equal(t, 0, len(qs.notExited))
+
+		postState := newPostState(slot, []*ethpb.PendingDeposit{initial}, map[primitives.ValidatorIndex]primitives.Epoch{1: 10, 3: 11})
+		notifier := &mock.MockStateNotifier{RecordEvents: true}
+		s := &Service{cfg: &config{StateNotifier: notifier}}
+		require.NoError(t, s.sendQueueEvents(qs, roblock, postState))
+
+		events := notifier.ReceivedEvents()
+		require.Equal(t, 1, len(events))
+		_, ok := events[0].Data.(*statefeed.WithdrawalsProcessedData)
+		require.Equal(t, true, ok)
+	})
+}
+
+func TestService_QueueEventsForBlocks(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := testDB.SetupDB(t)
+	notifier := &mock.MockStateNotifier{RecordEvents: true}
+	subscribers := cache.NewEventSubscribersCache()
+	s := &Service{
+		ctx:              ctx,
+		cfg:              &config{StateNotifier: notifier, StateGen: stategen.New(beaconDB, doublylinkedtree.New()), EventSubscribers: subscribers},
+		queueEventBlocks: make(chan []blocks.ROBlock, 1),
+	}
+
+	preState, _ := util.DeterministicGenesisStateElectra(t, 8)
+	require.NoError(t, preState.SetSlot(4))
+	parentRoot := [32]byte{'p'}
+	require.NoError(t, s.cfg.StateGen.SaveState(ctx, parentRoot, preState))
+	b := util.NewBeaconBlockElectra()
+	b.Block.Slot = 5
+	b.Block.ParentRoot = parentRoot[:]
+	b.Block.Body.VoluntaryExits = []*ethpb.SignedVoluntaryExit{{Exit: &ethpb.VoluntaryExit{ValidatorIndex: 1}, Signature: make([]byte, 96)}}
+	sb, err := blocks.NewSignedBeaconBlock(b)
+	require.NoError(t, err)
+	roblock, err := blocks.NewROBlockWithRoot(sb, [32]byte{'a'})
+	require.NoError(t, err)
+	postState := preState.Copy()
+	require.NoError(t, postState.SetSlot(5))
+	val, err := postState.ValidatorAtIndex(1)
+	require.NoError(t, err)
+	val.ExitEpoch = 10
+	require.NoError(t, postState.UpdateValidatorAtIndex(1, val))
+	require.NoError(t, s.cfg.StateGen.SaveState(ctx, roblock.Root(), postState))
+
+	// The blocks are not queued without an event stream subscribed to the queue events.
+	s.queueEventsForBlocks([]blocks.ROBlock{roblock})
+	require.Equal(t, 0, len(s.queueEventBlocks))
+
+	unsubscribe := subscribers.Subscribe([]feed.EventType{statefeed.ValidatorExitsInitiated})
+	defer unsubscribe()
+	s.queueEventsForBlocks([]blocks.ROBlock{roblock})
+	require.Equal(t, 1, len(s.queueEventBlocks))
+	// The blocks are dropped instead of blocking the import when the routine falls behind.
+	s.queueEventsForBlocks([]blocks.ROBlock{roblock})
+	require.Equal(t, 1, len(s.queueEventBlocks))
+
+	require.NoError(t, s.sendQueueEventsForBlocks(ctx, <-s.queueEventBlocks))
+	events := notifier.ReceivedEvents()
+	require.Equal(t, 1, len(events))
+	exitsData, ok := events[0].Data.(*statefeed.ValidatorExitsInitiatedData)
+	require.Equal(t, true, ok)
+	require.Equal(t, 1, len(exitsData.Exits))
+	assert.Equal(t, primitives.ValidatorIndex(1), exitsData.Exits[0].ValidatorIndex)
+}
@@ -0,0 +1,175 @@
+package blockchain
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/feed"
+	statefeed "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/feed/state"
+	forkchoicetypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/forkchoice/types"
+	"github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v2"
+)
+
+// loadReorgPolicy reads a reorg policy from a YAML file. The parameters missing from the file
+// keep the values of the beacon chain config.
+func loadReorgPolicy(path string) (*forkchoicetypes.ReorgPolicy, error) {
+	enc, err := os.ReadFile(path) // #nosec G304 -- The path is given by the node operator.
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read reorg policy file")
+	}
+	policy := forkchoicetypes.DefaultReorgPolicy()
+	if err := yaml.UnmarshalStrict(enc, policy); err != nil {
+		return nil, errors.Wrap(err, "could not parse reorg policy file")
+	}
+	if err := policy.Validate(); err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+// reorgDecisionsBufferSize is the number of late block reorg decisions which can wait to be sent to the state feed.
+const reorgDecisionsBufferSize = 16
+
+// setupReorgPolicy applies the reorg policy file, if any, to fork choice, and forwards the late
+// block reorg decisions of fork choice to the state feed.
+func (s *Service) setupReorgPolicy() error {
+	if s.cfg.ForkChoiceStore == nil {
+		return nil
+	}
+	s.cfg.ForkChoiceStore.Lock()
+	defer s.cfg.ForkChoiceStore.Unlock()
+	s.cfg.ForkChoiceStore.SetReorgDecisionHandler(s.queueReorgDecision)
+	if s.cfg.ReorgPolicyFile == "" {
+		return nil
+	}
+	policy, err := loadReorgPolicy(s.cfg.ReorgPolicyFile)
+	if err != nil {
+		return err
+	}
+	if err := s.cfg.ForkChoiceStore.SetReorgPolicy(policy); err != nil {
+		return err
+	}
+	logReorgPolicy(policy).Info("Loaded reorg policy")
+	return nil
+}
+
+// reloadReorgPolicy applies the reorg policy file to fork choice. The current policy is kept if
+// the file is invalid.
+func (s *Service) reloadReorgPolicy() {
+	policy, err := loadReorgPolicy(s.cfg.ReorgPolicyFile)
+	if err != nil {
+		log.WithError(err).Error("Could not reload reorg policy, keeping the current one")
+		return
+	}
+	s.cfg.ForkChoiceStore.Lock()
+	err = s.cfg.ForkChoiceStore.SetReorgPolicy(policy)
+	s.cfg.ForkChoiceStore.Unlock()
+	if err != nil {
+		log.WithError(err).Error("Could not reload reorg policy, keeping the current one")
+		return
+	}
+	logReorgPolicy(policy).Info("Reloaded reorg policy")
+}
+
+// spawnReorgPolicyWatcher reloads the reorg policy whenever its file changes. The directory of
+// the file is watched rather than the file, so that files replaced by editors are also reloaded.
+func (s *Service) spawnReorgPolicyWatcher() {
+	if s.cfg.ReorgPolicyFile == "" || s.cfg.ForkChoiceStore == nil {
+		return
+	}
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		log.WithError(err).Error("Could not initialize reorg policy file watcher")
+		return
+	}
+	path := filepath.Clean(s.cfg.ReorgPolicyFile)
+	if err := watcher.Add(filepath.Dir(path)); err != nil {
+		log.WithError(err).Errorf("Could not watch reorg policy file %s", path)
+		if err := watcher.Close(); err != nil {
+			log.WithError(err).Error("Could not close file watcher")
+		}
+		return
+	}
+	go func() {
+		defer func() {
+			if err := watcher.Close(); err != nil {
+				log.WithError(err).Error("Could not close file watcher")
+			}
+		}()
+		for {
+			select {
+			case event := <-watcher.Events:
+				if filepath.Clean(event.Name) != path || !(event.Has(fsnotify.Write) || event.Has(fsnotify.Create)) {
+					continue
+				}
+				s.reloadReorgPolicy()
+			case err := <-watcher.Errors:
+				log.WithError(err).Errorf("Could not watch reorg policy file %s", path)
+			case <-s.ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// queueReorgDecision hands a late block reorg decision of fork choice to the routine which sends
+// it to the state feed. It is called while the fork choice lock is held, so it never waits for the
+// subscribers of the feed, and drops the decision if the routine falls too far behind.
+func (s *Service) queueReorgDecision(d *forkchoicetypes.ReorgDecision) {
+	if s.cfg.StateNotifier == nil {
+		return
+	}
+	select {
+	case s.reorgDecisions <- d:
+	default:
+		log.WithField("slot", d.Slot).Warn("Dropping late block reorg decision, the state feed subscribers are falling behind")
+	}
+}
+
+// spawnReorgDecisionsRoutine sends the queued late block reorg decisions to the state feed, in the
+// order in which fork choice made them.
+func (s *Service) spawnReorgDecisionsRoutine() {
+	go func() {
+		for {
+			select {
+			case <-s.ctx.Done():
+				return
+			case d := <-s.reorgDecisions:
+				s.notifyReorgDecision(d)
+			}
+		}
+	}()
+}
+
+// notifyReorgDecision sends a late block reorg decision of fork choice to the state feed.
+func (s *Service) notifyReorgDecision(d *forkchoicetypes.ReorgDecision) {
+	if s.cfg.StateNotifier == nil {
+		return
+	}
+	s.cfg.StateNotifier.StateFeed().Send(&feed.Event{
+		Type: statefeed.LateBlockReorgDecision,
+		Data: &statefeed.LateBlockReorgDecisionData{
+			Check:        d.Check,
+			Slot:         d.Slot,
+			HeadRoot:     d.HeadRoot,
+			ParentRoot:   d.ParentRoot,
+			HeadWeight:   d.HeadWeight,
+			ParentWeight: d.ParentWeight,
+			Reorg:        d.Reorg,
+			Reason:       d.Reason,
+		},
+	})
+}
+
+func logReorgPolicy(policy *forkchoicetypes.ReorgPolicy) *logrus.Entry {
+	return log.WithFields(logrus.Fields{
+		"disableLateBlockReorgs":     policy.DisableLateBlockReorgs,
+		"proposerScoreBoost":         policy.ProposerScoreBoost,
+		"headWeightThreshold":        policy.HeadWeightThreshold,
+		"parentWeightThreshold":      policy.ParentWeightThreshold,
+		"maxEpochsSinceFinalization": policy.MaxEpochsSinceFinalization,
+	})
+}
@@ -0,0 +1,120 @@
+package blockchain
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	mock "github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain/testing"
+	statefeed "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/feed/state"
+	doublylinkedtree "github.com/prysmaticlabs/prysm/v5/beacon-chain/forkchoice/doubly-linked-tree"
+	forkchoicetypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/forkchoice/types"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestLoadReorgPolicy(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "reorg_policy.yaml")
+
+	require.NoError(t, os.WriteFile(path, []byte("PROPOSER_SCORE_BOOST: 10\nREORG_HEAD_WEIGHT_THRESHOLD: 30\n"), 0600))
+	policy, err := loadReorgPolicy(path)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(10), policy.ProposerScoreBoost)
+	assert.Equal(t, uint64(30), policy.HeadWeightThreshold)
+	assert.Equal(t, params.BeaconConfig().ReorgParentWeightThreshold, policy.ParentWeightThreshold)
+	assert.Equal(t, params.BeaconConfig().ReorgMaxEpochsSinceFinalization, policy.MaxEpochsSinceFinalization)
+	assert.Equal(t, false, policy.DisableLateBlockReorgs)
+
+	require.NoError(t, os.WriteFile(path, []byte("REORG_WEIGHT_THRESHOLD: 30\n"), 0600))
+	_, err = loadReorgPolicy(path)
+	require.ErrorContains(t, "could not parse", err)
+
+	require.NoError(t, os.WriteFile(path, []byte("PROPOSER_SCORE_BOOST: 140\n"), 0600))
+	_, err = loadReorgPolicy(path)
+	require.ErrorContains(t, "proposer score boost", err)
+
+	_, err = loadReorgPolicy(filepath.Join(dir, "missing.yaml"))
+	require.ErrorContains(t, "could not read", err)
+}
+
+func TestService_ReorgPolicy(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	path := filepath.Join(t.TempDir(), "reorg_policy.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("DISABLE_LATE_BLOCK_REORGS: true\n"), 0600))
+	fcs := doublylinkedtree.New()
+	notifier := &mock.MockStateNotifier{RecordEvents: true}
+	s := &Service{ctx: ctx, cfg: &config{ForkChoiceStore: fcs, StateNotifier: notifier, ReorgPolicyFile: path}}
+	require.NoError(t, s.setupReorgPolicy())
+	assert.Equal(t, true, fcs.ReorgPolicy().DisableLateBlockReorgs)
+
+	// An invalid file keeps the current policy.
+	require.NoError(t, os.WriteFile(path, []byte("REORG_HEAD_WEIGHT_THRESHOLD: 200\n"), 0600))
+	s.reloadReorgPolicy()
+	assert.Equal(t, true, fcs.ReorgPolicy().DisableLateBlockReorgs)
+	assert.Equal(t, params.BeaconConfig().ReorgWeightThreshold, fcs.ReorgPolicy().HeadWeightThreshold)
+
+	s.spawnReorgPolicyWatcher()
+	require.NoError(t, os.WriteFile(path, []byte("REORG_HEAD_WEIGHT_THRESHOLD: 25\n"), 0600))
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		fcs.RLock()
+		policy := fcs.ReorgPolicy()
+		fcs.RUnlock()
+		if policy.HeadWeightThreshold == 25 {
+			assert.Equal(t, false, policy.DisableLateBlockReorgs)
+			break
+		}
+		require.Equal(t, true, time.Now().Before(deadline), "reorg policy was not reloaded")
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	s.notifyReorgDecision(&forkchoicetypes.ReorgDecision{
+		Check:  forkchoicetypes.ReorgCheckProposerHead,
+		Slot:   3,
+		Reason: forkchoicetypes.ReorgReasonHeadOnTime,
+	})
+	events := notifier.ReceivedEvents()
+	require.Equal(t, 1, len(events))
+	require.Equal(t, statefeed.LateBlockReorgDecision, int(events[0].Type))
+	data, ok := events[0].Data.(*statefeed.LateBlockReorgDecisionData)
+	require.Equal(t, true, ok)
+	assert.Equal(t, forkchoicetypes.ReorgCheckProposerHead, data.Check)
+	assert.Equal(t, forkchoicetypes.ReorgReasonHeadOnTime, data.Reason)
+	assert.Equal(t, false, data.Reorg)
+}
+
+func TestService_QueueReorgDecision(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	notifier := &mock.MockStateNotifier{RecordEvents: true}
+	s := &Service{
+		ctx:            ctx,
+		cfg:            &config{StateNotifier: notifier},
+		reorgDecisions: make(chan *forkchoicetypes.ReorgDecision, 1),
+	}
+
+	// The decisions are queued without waiting for the state feed, and dropped once the queue is full.
+	s.queueReorgDecision(&forkchoicetypes.ReorgDecision{Slot: 3, Reason: forkchoicetypes.ReorgReasonHeadOnTime})
+	s.queueReorgDecision(&forkchoicetypes.ReorgDecision{Slot: 4, Reason: forkchoicetypes.ReorgReasonWeakHead, Reorg: true})
+	require.Equal(t, 1, len(s.reorgDecisions))
+	require.Equal(t, 0, len(notifier.ReceivedEvents()))
+
+	s.spawnReorgDecisionsRoutine()
+	deadline := time.Now().Add(5 * time.Second)
+	for len(notifier.ReceivedEvents()) == 0 {
+		require.Equal(t, true, time.Now().Before(deadline), "reorg decision was not sent")
+		time.Sleep(10 * time.Millisecond)
+	}
+	events := notifier.ReceivedEvents()
+	require.Equal(t, 1, len(events))
+	data, ok := events[0].Data.(*statefeed.LateBlockReorgDecisionData)
+	require.Equal(t, true, ok)
+	assert.Equal(t, primitives.Slot(3), data.Slot)
+	assert.Equal(t, forkchoicetypes.ReorgReasonHeadOnTime, data.Reason)
+}
@@ -64,6 +64,8 @@ type Service struct {
 	blobNotifiers        *blobNotifierMap
 	blockBeingSynced     *currentlySyncingBlock
 	blobStorage          *filesystem.BlobStorage
+	queueEventBlocks     chan []blocks.ROBlock
+	reorgDecisions       chan *forkchoicetypes.ReorgDecision
 }
 
 // config options for the service.
@@ -74,6 +76,7 @@ type config struct {
 	DepositCache            cache.DepositCache
 	PayloadIDCache          *cache.PayloadIDCache
 	TrackedValidatorsCache  *cache.TrackedValidatorsCache
+	EventSubscribers        *cache.EventSubscribersCache
 	AttPool                 attestations.Pool
 	ExitPool                voluntaryexits.PoolManager
 	SlashingPool            slashings.PoolManager
@@ -89,6 +92,7 @@ type config struct {
 	FinalizedStateAtStartUp state.BeaconState
 	ExecutionEngineCaller   execution.EngineCaller
 	SyncChecker             Checker
+	ReorgPolicyFile         string
 }
 
 // Checker is an interface used to determine if a node is in initial sync
@@ -99,6 +103,9 @@ type Checker interface {
 
 var ErrMissingClockSetter = errors.New("blockchain Service initialized without a startup.ClockSetter")
 
+// blockImportsPollInterval is how often WaitForBlockImports checks for blocks being imported.
+var blockImportsPollInterval = 50 * time.Millisecond
+
 type blobNotifierMap struct {
 	sync.RWMutex
 	notifiers map[[32]byte]chan uint64
@@ -175,6 +182,8 @@ func NewService(ctx context.Context, opts ...Option) (*Service, error) {
 		blobNotifiers:        bn,
 		cfg:                  &config{},
 		blockBeingSynced:     &currentlySyncingBlock{roots: make(map[[32]byte]struct{})},
+		queueEventBlocks:     make(chan []blocks.ROBlock, queueEventBlocksBufferSize),
+		reorgDecisions:       make(chan *forkchoicetypes.ReorgDecision, reorgDecisionsBufferSize),
 	}
 	for _, opt := range opts {
 		if err := opt(srv); err != nil {
@@ -188,6 +197,9 @@ func NewService(ctx context.Context, opts ...Option) (*Service, error) {
 	if err != nil {
 		return nil, err
 	}
+	if err := srv.setupReorgPolicy(); err != nil {
+		return nil, errors.Wrap(err, "could not set up reorg policy")
+	}
 	return srv, nil
 }
 
@@ -206,27 +218,61 @@ func (s *Service) Start() {
 		}
 	}
 	s.spawnProcessAttestationsRoutine()
+	s.spawnForkchoiceSnapshotRoutine()
+	s.spawnReorgPolicyWatcher()
+	s.spawnQueueEventsRoutine()
+	s.spawnReorgDecisionsRoutine()
 	go s.runLateBlockTasks()
 }
 
 // Stop the blockchain service's main event loop and associated goroutines.
 func (s *Service) Stop() error {
 	defer s.cancel()
+	if err := s.Flush(s.ctx); err != nil {
+		return err
+	}
+	s.clearInitSyncBlocks()
+	return nil
+}
 
+// Flush saves the state which the service only holds in memory to the database: the last finalized
+// state, the blocks cached by initial sync and the fork choice store. It is called when the service
+// stops, and by a graceful shutdown before the services are stopped. The blocks cached by initial sync
+// are kept in the cache, as a batch being imported may still be caching blocks while they are saved.
+func (s *Service) Flush(ctx context.Context) error {
 	// lock before accessing s.head, s.head.state, s.head.state.FinalizedCheckpoint().Root
 	s.headLock.RLock()
 	if s.cfg.StateGen != nil && s.head != nil && s.head.state != nil {
 		r := s.head.state.FinalizedCheckpoint().Root
 		s.headLock.RUnlock()
 		// Save the last finalized state so that starting up in the following run will be much faster.
-		if err := s.cfg.StateGen.ForceCheckpoint(s.ctx, r); err != nil {
-			return err
+		if err := s.cfg.StateGen.ForceCheckpoint(ctx, r); err != nil {
+			return errors.Wrap(err, "could not save finalized state")
 		}
 	} else {
 		s.headLock.RUnlock()
 	}
-	// Save initial sync cached blocks to the DB before stop.
-	return s.cfg.BeaconDB.SaveBlocks(s.ctx, s.getInitSyncBlocks())
+	// Save initial sync cached blocks to the DB.
+	if err := s.cfg.BeaconDB.SaveBlocks(ctx, s.getInitSyncBlocks()); err != nil {
+		return errors.Wrap(err, "could not save initial sync blocks")
+	}
+	// Save the fork choice store so that the following run resumes from the current head.
+	return s.saveForkchoiceSnapshot(ctx)
+}
+
+// WaitForBlockImports waits until none of the blocks being imported is left unsaved, so a
+// graceful shutdown does not interrupt the import of a block.
+func (s *Service) WaitForBlockImports(ctx context.Context) error {
+	ticker := time.NewTicker(blockImportsPollInterval)
+	defer ticker.Stop()
+	for !s.blockBeingSynced.isEmpty() {
+		select {
+		case <-ctx.Done():
+			return errors.Wrap(ctx.Err(), "blocks are still being imported")
+		case <-ticker.C:
+		}
+	}
+	return nil
 }
 
 // Status always returns nil unless there is an error condition that causes
@@ -282,9 +328,32 @@ func (s *Service) StartFromSavedState(saved state.BeaconState) error {
 		return errNilFinalizedCheckpoint
 	}
 
-	fRoot := s.ensureRootNotZeros(bytesutil.ToBytes32(finalized.Root))
 	s.cfg.ForkChoiceStore.Lock()
 	defer s.cfg.ForkChoiceStore.Unlock()
+	if !s.restoreForkchoiceSnapshot(s.ctx, finalized) {
+		if err := s.initializeForkchoiceFromFinalized(justified, finalized); err != nil {
+			return err
+		}
+	}
+	// not attempting to save initial sync blocks here, because there shouldn't be any until
+	// after the statefeed.Initialized event is fired (below)
+	if err := s.wsVerifier.VerifyWeakSubjectivity(s.ctx, finalized.Epoch); err != nil {
+		// Exit run time if the node failed to verify weak subjectivity checkpoint.
+		return errors.Wrap(err, "could not verify initial checkpoint provided for chain sync")
+	}
+
+	vr := bytesutil.ToBytes32(saved.GenesisValidatorsRoot())
+	if err := s.clockSetter.SetClock(startup.NewClock(s.genesisTime, vr)); err != nil {
+		return errors.Wrap(err, "failed to initialize blockchain service")
+	}
+
+	return nil
+}
+
+// initializeForkchoiceFromFinalized initializes the fork choice store with the finalized block
+// and the checkpoints of the database. The caller must hold the fork choice lock.
+func (s *Service) initializeForkchoiceFromFinalized(justified, finalized *ethpb.Checkpoint) error {
+	fRoot := s.ensureRootNotZeros(bytesutil.ToBytes32(finalized.Root))
 	if err := s.cfg.ForkChoiceStore.UpdateJustifiedCheckpoint(s.ctx, &forkchoicetypes.Checkpoint{Epoch: justified.Epoch,
 		Root: bytesutil.ToBytes32(justified.Root)}); err != nil {
 		return errors.Wrap(err, "could not update forkchoice's justified checkpoint")
@@ -321,18 +390,6 @@ func (s *Service) StartFromSavedState(saved state.BeaconState) error {
 			}
 		}
 	}
-	// not attempting to save initial sync blocks here, because there shouldn't be any until
-	// after the statefeed.Initialized event is fired (below)
-	if err := s.wsVerifier.VerifyWeakSubjectivity(s.ctx, finalized.Epoch); err != nil {
-		// Exit run time if the node failed to verify weak subjectivity checkpoint.
-		return errors.Wrap(err, "could not verify initial checkpoint provided for chain sync")
-	}
-
-	vr := bytesutil.ToBytes32(saved.GenesisValidatorsRoot())
-	if err := s.clockSetter.SetClock(startup.NewClock(s.genesisTime, vr)); err != nil {
-		return errors.Wrap(err, "failed to initialize blockchain service")
-	}
-
 	return nil
 }
 
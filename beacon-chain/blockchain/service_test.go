@@ -407,6 +407,35 @@ func TestServiceStop_SaveCachedBlocks(t *testing.T) {
 	require.NoError(t, s.saveInitSyncBlock(ctx, r, wsb))
 	require.NoError(t, s.Stop())
 	require.Equal(t, true, s.cfg.BeaconDB.HasBlock(ctx, r))
+	require.Equal(t, 0, len(s.getInitSyncBlocks()))
+}
+
+func TestService_Flush(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := testDB.SetupDB(t)
+	fcs := doublylinkedtree.New()
+	s := &Service{
+		cfg:            &config{BeaconDB: beaconDB, StateGen: stategen.New(beaconDB, fcs), ForkChoiceStore: fcs},
+		ctx:            ctx,
+		initSyncBlocks: make(map[[32]byte]interfaces.ReadOnlySignedBeaconBlock),
+	}
+	st, root, err := prepareForkchoiceState(ctx, 0, [32]byte{'a'}, [32]byte{}, [32]byte{'b'}, &ethpb.Checkpoint{}, &ethpb.Checkpoint{})
+	require.NoError(t, err)
+	require.NoError(t, fcs.InsertNode(ctx, st, root))
+	bb := util.NewBeaconBlock()
+	r, err := bb.Block.HashTreeRoot()
+	require.NoError(t, err)
+	wsb, err := consensusblocks.NewSignedBeaconBlock(bb)
+	require.NoError(t, err)
+	require.NoError(t, s.saveInitSyncBlock(ctx, r, wsb))
+
+	require.NoError(t, s.Flush(ctx))
+	require.Equal(t, true, beaconDB.HasBlock(ctx, r))
+	// The node keeps running after a flush, so the cached blocks are kept for the imports which follow.
+	require.Equal(t, 1, len(s.getInitSyncBlocks()))
+	enc, err := beaconDB.ForkchoiceSnapshot(ctx)
+	require.NoError(t, err)
+	require.NotEqual(t, 0, len(enc))
 }
 
 func TestProcessChainStartTime_ReceivedFeed(t *testing.T) {
@@ -628,3 +657,20 @@ func TestNotifyIndex(t *testing.T) {
 		t.Errorf("Notifier channel did not receive the index")
 	}
 }
+
+func TestService_WaitForBlockImports(t *testing.T) {
+	s := &Service{blockBeingSynced: &currentlySyncingBlock{roots: make(map[[32]byte]struct{})}}
+	require.NoError(t, s.WaitForBlockImports(context.Background()))
+
+	root := [32]byte{'a'}
+	s.blockBeingSynced.set(root)
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	require.ErrorContains(t, "blocks are still being imported", s.WaitForBlockImports(ctx))
+
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		s.blockBeingSynced.unset(root)
+	}()
+	require.NoError(t, s.WaitForBlockImports(context.Background()))
+}
@@ -635,6 +635,14 @@ func (s *ChainService) ForkChoiceDump(ctx context.Context) (*forkchoice2.Dump, e
 	return nil, nil
 }
 
+// ForkChoiceRecording mocks the same method in the chain service
+func (s *ChainService) ForkChoiceRecording() ([]byte, error) {
+	if s.ForkChoiceStore != nil {
+		return s.ForkChoiceStore.Recording()
+	}
+	return nil, nil
+}
+
 // NewSlot mocks the same method in the chain service
 func (s *ChainService) NewSlot(ctx context.Context, slot primitives.Slot) error {
 	if s.ForkChoiceStore != nil {
@@ -0,0 +1,56 @@
+package cache
+
+import (
+	"sync"
+
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/feed"
+)
+
+// EventSubscribersCache counts the event streams subscribed to each type of feed event, so that
+// the events which are costly to derive are only derived while a stream is listening to them.
+type EventSubscribersCache struct {
+	sync.Mutex
+	subscribers map[feed.EventType]int
+}
+
+func NewEventSubscribersCache() *EventSubscribersCache {
+	return &EventSubscribersCache{
+		subscribers: make(map[feed.EventType]int),
+	}
+}
+
+// Subscribe counts a stream subscribed to the event types, and returns the function which
+// removes the subscription once the stream is closed.
+func (c *EventSubscribersCache) Subscribe(types []feed.EventType) func() {
+	if c == nil || len(types) == 0 {
+		return func() {}
+	}
+	c.Lock()
+	defer c.Unlock()
+	for _, t := range types {
+		c.subscribers[t]++
+	}
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			c.Lock()
+			defer c.Unlock()
+			for _, t := range types {
+				c.subscribers[t]--
+				if c.subscribers[t] <= 0 {
+					delete(c.subscribers, t)
+				}
+			}
+		})
+	}
+}
+
+// Subscribed returns true if a stream is subscribed to the event type. A nil cache has no subscribers.
+func (c *EventSubscribersCache) Subscribed(t feed.EventType) bool {
+	if c == nil {
+		return false
+	}
+	c.Lock()
+	defer c.Unlock()
+	return c.subscribers[t] > 0
+}
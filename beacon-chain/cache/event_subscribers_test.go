@@ -0,0 +1,31 @@
+package cache
+
+import (
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/feed"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestEventSubscribersCache(t *testing.T) {
+	c := NewEventSubscribersCache()
+	require.Equal(t, false, c.Subscribed(feed.EventType(1)))
+
+	unsubscribe1 := c.Subscribe([]feed.EventType{1, 2})
+	unsubscribe2 := c.Subscribe([]feed.EventType{2})
+	require.Equal(t, true, c.Subscribed(1))
+	require.Equal(t, true, c.Subscribed(2))
+	require.Equal(t, false, c.Subscribed(3))
+
+	unsubscribe1()
+	// Unsubscribing twice only removes the subscription once.
+	unsubscribe1()
+	require.Equal(t, false, c.Subscribed(1))
+	require.Equal(t, true, c.Subscribed(2))
+	unsubscribe2()
+	require.Equal(t, false, c.Subscribed(2))
+
+	var nilCache *EventSubscribersCache
+	nilCache.Subscribe([]feed.EventType{1})()
+	require.Equal(t, false, nilCache.Subscribed(1))
+}
@@ -374,3 +374,43 @@ func attestationDelta(
 
 	return attDelta, nil
 }
+
+// EpochAttestationsDelta computes the attestation rewards and penalties which the next epoch processing
+// applies to the given state, along with the reserve usage of the rewards of each validator.
+// It runs the same precompute steps as ProcessEpoch before the rewards and penalties, i.e. the justification
+// and finalization and the inactivity updates, on a copy of the state, so the given state is not modified.
+func EpochAttestationsDelta(ctx context.Context, beaconState state.BeaconState) ([]*precompute.Validator, []*AttDelta, []uint64, error) {
+	ctx, span := trace.StartSpan(ctx, "altair.EpochAttestationsDelta")
+	defer span.End()
+
+	st := beaconState.Copy()
+	vals, bal, err := InitializePrecomputeValidators(ctx, st)
+	if err != nil {
+		return nil, nil, nil, errors.Wrap(err, "could not initialize precompute validators")
+	}
+	vals, bal, err = ProcessEpochParticipation(ctx, st, bal, vals)
+	if err != nil {
+		return nil, nil, nil, errors.Wrap(err, "could not process epoch participation")
+	}
+	st, err = precompute.ProcessJustificationAndFinalizationPreCompute(st, bal)
+	if err != nil {
+		return nil, nil, nil, errors.Wrap(err, "could not process justification")
+	}
+	st, vals, err = ProcessInactivityScores(ctx, st, vals)
+	if err != nil {
+		return nil, nil, nil, errors.Wrap(err, "could not process inactivity updates")
+	}
+	// Rewards and penalties are not processed in the genesis epoch.
+	if time.CurrentEpoch(st) == params.BeaconConfig().GenesisEpoch {
+		attDeltas := make([]*AttDelta, len(vals))
+		for i := range attDeltas {
+			attDeltas[i] = &AttDelta{}
+		}
+		return vals, attDeltas, make([]uint64, len(vals)), nil
+	}
+	attDeltas, attReserves, err := AttestationsDelta(st, bal, vals)
+	if err != nil {
+		return nil, nil, nil, errors.Wrap(err, "could not get attestation delta")
+	}
+	return vals, attDeltas, attReserves, nil
+}
@@ -409,6 +409,41 @@ func TestProcessRewardsAndPenaltiesPrecompute_Ok(t *testing.T) {
 	require.Equal(t, uint64(0), totalReserve)
 }
 
+func TestEpochAttestationsDelta(t *testing.T) {
+	s, err := testState()
+	require.NoError(t, err)
+	maxBalance := params.BeaconConfig().MaxEffectiveBalance
+	require.NoError(t, s.SetBalances([]uint64{maxBalance, maxBalance, maxBalance, maxBalance}))
+	require.NoError(t, s.SetInactivityScores([]uint64{params.BeaconConfig().InactivityScorePenaltyThreshold + 100, 0, 0, 0}))
+
+	_, deltas, _, err := EpochAttestationsDelta(context.Background(), s)
+	require.NoError(t, err)
+	// The given state is not modified.
+	scores, err := s.InactivityScores()
+	require.NoError(t, err)
+	require.DeepEqual(t, []uint64{params.BeaconConfig().InactivityScorePenaltyThreshold + 100, 0, 0, 0}, scores)
+	require.DeepEqual(t, []uint64{maxBalance, maxBalance, maxBalance, maxBalance}, s.Balances())
+
+	// The deltas match the balance changes of the epoch processing.
+	validators, balance, err := InitializePrecomputeValidators(context.Background(), s)
+	require.NoError(t, err)
+	validators, balance, err = ProcessEpochParticipation(context.Background(), s, balance, validators)
+	require.NoError(t, err)
+	s, err = precompute.ProcessJustificationAndFinalizationPreCompute(s, balance)
+	require.NoError(t, err)
+	s, validators, err = ProcessInactivityScores(context.Background(), s, validators)
+	require.NoError(t, err)
+	s, err = ProcessRewardsAndPenaltiesPrecompute(s, balance, validators)
+	require.NoError(t, err)
+
+	require.Equal(t, true, deltas[0].SourcePenalty > 0)
+	require.Equal(t, true, deltas[0].TargetPenalty > 0)
+	for i, b := range s.Balances() {
+		d := deltas[i]
+		require.Equal(t, maxBalance+d.HeadReward+d.SourceReward+d.TargetReward-d.SourcePenalty-d.TargetPenalty, b)
+	}
+}
+
 func TestProcessInactivityScores_CanProcessInactivityLeak(t *testing.T) {
 	s, err := testState()
 	require.NoError(t, err)
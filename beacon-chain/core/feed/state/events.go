@@ -6,8 +6,11 @@ package state
 import (
 	"time"
 
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	enginev1 "github.com/prysmaticlabs/prysm/v5/proto/engine/v1"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
 )
 
 const (
@@ -29,6 +32,26 @@ const (
 	MissedSlot
 	// PayloadAttributes events are fired upon a missed slot or new head.
 	PayloadAttributes
+	// PendingDepositsEnqueued is sent when the deposits of a processed block are appended to the pending deposit queue.
+	PendingDepositsEnqueued
+	// PendingDepositsProcessed is sent when pending deposits are processed by the epoch processing of a block's state transition.
+	PendingDepositsProcessed
+	// ValidatorsActivated is sent when validators become active within a block's state transition.
+	ValidatorsActivated
+	// ValidatorExitsInitiated is sent when exits of validators are initiated within a block's state transition.
+	ValidatorExitsInitiated
+	// WithdrawalsProcessed is sent when the withdrawals of a processed block's execution payload are applied.
+	WithdrawalsProcessed
+	// LateBlockReorgDecision is sent when fork choice decides whether a late head block is orphaned by the next proposer.
+	LateBlockReorgDecision
+)
+
+// Reasons of the exits sent with ValidatorExitsInitiated events.
+const (
+	ExitReasonVoluntaryExit     = "voluntary_exit"
+	ExitReasonWithdrawalRequest = "withdrawal_request"
+	ExitReasonSlashing          = "slashing"
+	ExitReasonBailout           = "bailout"
 )
 
 // BlockProcessedData is the data sent with BlockProcessed events.
@@ -64,3 +87,98 @@ type InitializedData struct {
 	// GenesisValidatorsRoot represents state.validators.HashTreeRoot().
 	GenesisValidatorsRoot []byte
 }
+
+// PendingDepositsEnqueuedData is the data sent with PendingDepositsEnqueued events.
+type PendingDepositsEnqueuedData struct {
+	// Slot is the slot of the processed block.
+	Slot primitives.Slot
+	// BlockRoot of the processed block.
+	BlockRoot [32]byte
+	// PendingDeposits are the deposits appended to the queue, in the queue order.
+	PendingDeposits []*ethpb.PendingDeposit
+}
+
+// ProcessedDeposit is a pending deposit which has been removed from the queue by the epoch processing.
+type ProcessedDeposit struct {
+	PendingDeposit *ethpb.PendingDeposit
+	// Credited is false if there is no validator with the pubkey of the deposit after the processing,
+	// i.e. the deposit was for a new validator and had an invalid signature.
+	Credited bool
+	// ValidatorIndex is the index of the validator credited by the deposit, if any.
+	ValidatorIndex primitives.ValidatorIndex
+}
+
+// PendingDepositsProcessedData is the data sent with PendingDepositsProcessed events.
+type PendingDepositsProcessedData struct {
+	// Slot is the slot of the processed block.
+	Slot primitives.Slot
+	// BlockRoot of the processed block.
+	BlockRoot [32]byte
+	// Deposits are the deposits removed from the queue, in the queue order.
+	Deposits []*ProcessedDeposit
+}
+
+// ValidatorActivation is a validator which became active.
+type ValidatorActivation struct {
+	ValidatorIndex  primitives.ValidatorIndex
+	Pubkey          [fieldparams.BLSPubkeyLength]byte
+	ActivationEpoch primitives.Epoch
+}
+
+// ValidatorsActivatedData is the data sent with ValidatorsActivated events.
+type ValidatorsActivatedData struct {
+	// Slot is the slot of the processed block.
+	Slot primitives.Slot
+	// BlockRoot of the processed block.
+	BlockRoot [32]byte
+	// Activations are the validators which became active, in the order of their indices.
+	Activations []*ValidatorActivation
+}
+
+// ValidatorExit is an exit initiated for a validator.
+type ValidatorExit struct {
+	ValidatorIndex    primitives.ValidatorIndex
+	Pubkey            [fieldparams.BLSPubkeyLength]byte
+	ExitEpoch         primitives.Epoch
+	WithdrawableEpoch primitives.Epoch
+	// Reason is one of the ExitReason constants.
+	Reason string
+}
+
+// ValidatorExitsInitiatedData is the data sent with ValidatorExitsInitiated events.
+type ValidatorExitsInitiatedData struct {
+	// Slot is the slot of the processed block.
+	Slot primitives.Slot
+	// BlockRoot of the processed block.
+	BlockRoot [32]byte
+	// Exits are the exits initiated, in the order of the validator indices.
+	Exits []*ValidatorExit
+}
+
+// WithdrawalsProcessedData is the data sent with WithdrawalsProcessed events.
+type WithdrawalsProcessedData struct {
+	// Slot is the slot of the processed block.
+	Slot primitives.Slot
+	// BlockRoot of the processed block.
+	BlockRoot [32]byte
+	// Withdrawals are the withdrawals of the block's execution payload.
+	Withdrawals []*enginev1.Withdrawal
+}
+
+// LateBlockReorgDecisionData is the data sent with LateBlockReorgDecision events.
+type LateBlockReorgDecisionData struct {
+	// Check is the fork choice check which took the decision, before the proposal slot or when proposing.
+	Check string
+	// Slot is the slot of the head block.
+	Slot primitives.Slot
+	// HeadRoot and ParentRoot are the roots of the head block and of its parent.
+	HeadRoot   [32]byte
+	ParentRoot [32]byte
+	// HeadWeight and ParentWeight are the fork choice weights of the blocks, in Gwei.
+	HeadWeight   uint64
+	ParentWeight uint64
+	// Reorg is true if the head block is orphaned.
+	Reorg bool
+	// Reason is the condition which decided the outcome.
+	Reason string
+}
@@ -361,11 +361,16 @@ func TotalRewardWithReserveUsage(s state.ReadOnlyBeaconState) (uint64, uint64) {
 
 // EpochFeedbackBoost returns the boost reward from feedback model in tokenomics.
 func EpochFeedbackBoost(s state.ReadOnlyBeaconState) uint64 {
+	return FeedbackBoost(s.RewardAdjustmentFactor(), s.Reserves())
+}
+
+// FeedbackBoost returns the boost reward for the given reward adjustment factor.
+// The boost is funded by the reserves, so it never exceeds the given reserves.
+func FeedbackBoost(rewardAdjustmentFactor, reserves uint64) uint64 {
 	cfg := params.BeaconConfig()
-	rewardAdjustmentFactor := s.RewardAdjustmentFactor()
 	feedbackBoost := cfg.MaxTokenSupply / cfg.RewardAdjustmentFactorPrecision * rewardAdjustmentFactor / cfg.EpochsPerYear
 
-	if reserves := s.Reserves(); feedbackBoost > reserves {
+	if feedbackBoost > reserves {
 		return reserves
 	}
 	return feedbackBoost
@@ -391,14 +396,10 @@ func ProcessRewardAdjustmentFactor(state state.BeaconState) (state.BeaconState,
 	}
 	targetDeposit := TargetDepositPlan(time.NextEpoch(state))
 
-	if futureDeposit > targetDeposit {
-		err = DecreaseRewardAdjustmentFactor(state)
-		if err != nil {
-			return nil, err
-		}
-	} else if futureDeposit < targetDeposit {
-		err = IncreaseRewardAdjustmentFactor(state)
-		if err != nil {
+	factor := state.RewardAdjustmentFactor()
+	newFactor := NextRewardAdjustmentFactor(factor, futureDeposit, targetDeposit, time.CurrentEpoch(state))
+	if newFactor != factor {
+		if err := state.SetRewardAdjustmentFactor(newFactor); err != nil {
 			return nil, err
 		}
 	}
@@ -406,24 +407,31 @@ func ProcessRewardAdjustmentFactor(state state.BeaconState) (state.BeaconState,
 	return state, nil
 }
 
+// NextRewardAdjustmentFactor returns the reward adjustment factor for the next epoch,
+// given the current factor, the future total deposit and the target deposit.
+// The epoch is used to look up the maximum reward adjustment factor of the year.
+func NextRewardAdjustmentFactor(factor, futureDeposit, targetDeposit uint64, epoch primitives.Epoch) uint64 {
+	if futureDeposit > targetDeposit {
+		return DecreasedRewardAdjustmentFactor(factor)
+	} else if futureDeposit < targetDeposit {
+		return IncreasedRewardAdjustmentFactor(factor, epoch)
+	}
+	return factor
+}
+
 // DecreaseRewardAdjustmentFactor reduces the RewardAdjustmentFactor with fixed amount.
 // If the RewardAdjustmentFactor is less than the given amount, it sets the RewardAdjustmentFactor to 0.
 func DecreaseRewardAdjustmentFactor(state state.BeaconState) error {
+	return state.SetRewardAdjustmentFactor(DecreasedRewardAdjustmentFactor(state.RewardAdjustmentFactor()))
+}
+
+// DecreasedRewardAdjustmentFactor returns the given factor reduced with fixed amount, floored at 0.
+func DecreasedRewardAdjustmentFactor(factor uint64) uint64 {
 	delta := params.BeaconConfig().RewardAdjustmentFactorDelta
-	factor := state.RewardAdjustmentFactor()
 	if factor < delta {
-		err := state.SetRewardAdjustmentFactor(0)
-		if err != nil {
-			return err
-		}
-	} else {
-		err := state.SetRewardAdjustmentFactor(factor - delta)
-		if err != nil {
-			return err
-		}
+		return 0
 	}
-
-	return nil
+	return factor - delta
 }
 
 // IncreaseRewardAdjustmentFactor increases the RewardAdjustmentFactor with fixed amount.
@@ -431,22 +439,17 @@ func DecreaseRewardAdjustmentFactor(state state.BeaconState) error {
 // it sets the RewardAdjustmentFactor to MaxRewardAdjustmentFactors[year].
 func IncreaseRewardAdjustmentFactor(state state.BeaconState) error {
 	epoch := slots.ToEpoch(state.Slot())
-	newFactor := state.RewardAdjustmentFactor() + params.BeaconConfig().RewardAdjustmentFactorDelta
+	return state.SetRewardAdjustmentFactor(IncreasedRewardAdjustmentFactor(state.RewardAdjustmentFactor(), epoch))
+}
 
-	maxBoostYield := MaxRewardAdjustmentFactor(epoch)
-	if maxBoostYield < newFactor {
-		err := state.SetRewardAdjustmentFactor(maxBoostYield)
-		if err != nil {
-			return err
-		}
-	} else {
-		err := state.SetRewardAdjustmentFactor(newFactor)
-		if err != nil {
-			return err
-		}
+// IncreasedRewardAdjustmentFactor returns the given factor increased with fixed amount,
+// capped at the maximum reward adjustment factor of the year for the given epoch.
+func IncreasedRewardAdjustmentFactor(factor uint64, epoch primitives.Epoch) uint64 {
+	newFactor := factor + params.BeaconConfig().RewardAdjustmentFactorDelta
+	if maxBoostYield := MaxRewardAdjustmentFactor(epoch); maxBoostYield < newFactor {
+		return maxBoostYield
 	}
-
-	return nil
+	return newFactor
 }
 
 // MaxRewardAdjustmentFactor gets the maximum reward adjustment factor of corresponding year for the given epoch.
@@ -648,6 +648,38 @@ func TestIncreaseRewardAdjustmentFactor_OK(t *testing.T) {
 	}
 }
 
+func TestNextRewardAdjustmentFactor(t *testing.T) {
+	epoch := primitives.Epoch(params.BeaconConfig().EpochsPerYear / 2)
+	maxFactor := helpers.MaxRewardAdjustmentFactor(epoch)
+	tests := []struct {
+		name          string
+		factor        uint64
+		futureDeposit uint64
+		targetDeposit uint64
+		want          uint64
+	}{
+		{name: "Deposit larger than target", factor: 200, futureDeposit: 2, targetDeposit: 1, want: 50},
+		{name: "Deposit larger than target, floored", factor: 100, futureDeposit: 2, targetDeposit: 1, want: 0},
+		{name: "Deposit smaller than target", factor: 200, futureDeposit: 1, targetDeposit: 2, want: 350},
+		{name: "Deposit smaller than target, capped", factor: maxFactor - 1, futureDeposit: 1, targetDeposit: 2, want: maxFactor},
+		{name: "Deposit same as target", factor: 200, futureDeposit: 1, targetDeposit: 1, want: 200},
+	}
+	for _, test := range tests {
+		got := helpers.NextRewardAdjustmentFactor(test.factor, test.futureDeposit, test.targetDeposit, epoch)
+		assert.Equal(t, test.want, got, test.name)
+	}
+}
+
+func TestFeedbackBoost(t *testing.T) {
+	cfg := params.BeaconConfig()
+	factor := cfg.RewardAdjustmentFactorPrecision / 100
+	boost := cfg.MaxTokenSupply / cfg.RewardAdjustmentFactorPrecision * factor / cfg.EpochsPerYear
+
+	assert.Equal(t, boost, helpers.FeedbackBoost(factor, boost+1))
+	assert.Equal(t, boost-1, helpers.FeedbackBoost(factor, boost-1))
+	assert.Equal(t, uint64(0), helpers.FeedbackBoost(0, boost))
+}
+
 func TestDecreaseReserves_OK(t *testing.T) {
 	tests := []struct {
 		r    uint64
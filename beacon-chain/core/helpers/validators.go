@@ -531,8 +531,58 @@ func IsBailOut(state state.ReadOnlyBeaconState, validator *ethpb.Validator, idx
 }
 
 func isBelowThresholdForBailOut(actualBalance, principalBalance uint64) bool {
-	bailoutBuffer := principalBalance * params.BeaconConfig().InactivityPenaltyRate / params.BeaconConfig().InactivityPenaltyRatePrecision
-	return actualBalance+bailoutBuffer < principalBalance
+	return actualBalance+BailOutBuffer(principalBalance) < principalBalance
+}
+
+// BailOutBuffer returns the amount of the principal balance a validator can lose before it is bailed out.
+func BailOutBuffer(principalBalance uint64) uint64 {
+	return principalBalance * params.BeaconConfig().InactivityPenaltyRate / params.BeaconConfig().InactivityPenaltyRatePrecision
+}
+
+// BailOutBufferRemaining returns the amount the validator can still lose before it is below the bailout threshold.
+func BailOutBufferRemaining(actualBalance, principalBalance uint64) uint64 {
+	buffer := BailOutBuffer(principalBalance)
+	if actualBalance+buffer < principalBalance {
+		return 0
+	}
+	return actualBalance + buffer - principalBalance
+}
+
+// EpochsToBailOut projects the number of epochs until the validator becomes eligible for bailout,
+// assuming it keeps missing the source and the target votes and the inactivity leak status does not change.
+// It follows the inactivity score updates and the inactivity penalties of the epoch processing:
+//   - during an inactivity leak, the penalties stop before reaching the bailout buffer,
+//     so the validator is bailed out once its inactivity score passes INACTIVITY_LEAK_BAILOUT_SCORE_THRESHOLD.
+//   - otherwise, the validator is penalized once its inactivity score passes INACTIVITY_SCORE_PENALTY_THRESHOLD,
+//     and bailed out once the penalties use up the bailout buffer.
+//
+// Zero is returned if the validator is already eligible, and false if it can never be bailed out in the projection.
+func EpochsToBailOut(actualBalance, principalBalance, inactivityScore uint64, leak bool) (primitives.Epoch, bool) {
+	cfg := params.BeaconConfig()
+	if isBelowThresholdForBailOut(actualBalance, principalBalance) {
+		return 0, true
+	}
+	if leak {
+		if inactivityScore > cfg.InactivityLeakBailoutScoreThreshold {
+			return 0, true
+		}
+		return primitives.Epoch((cfg.InactivityLeakBailoutScoreThreshold-inactivityScore)/cfg.InactivityScoreBias + 1), true
+	}
+
+	// The inactivity penalty of missing both the source and the target votes.
+	penaltyNumerator := BailOutBuffer(principalBalance)
+	denominator := (cfg.TimelySourceWeight + cfg.TimelyTargetWeight) * cfg.InactivityPenaltyDuration
+	penalty := penaltyNumerator*cfg.TimelySourceWeight/denominator + penaltyNumerator*cfg.TimelyTargetWeight/denominator
+	if penalty == 0 {
+		return 0, false
+	}
+	// The inactivity score is updated before the penalties are applied in the same epoch.
+	epochsBeforePenalty := uint64(0)
+	if inactivityScore <= cfg.InactivityScorePenaltyThreshold {
+		epochsBeforePenalty = (cfg.InactivityScorePenaltyThreshold - inactivityScore) / cfg.InactivityScoreBias
+	}
+	penalizedEpochs := BailOutBufferRemaining(actualBalance, principalBalance)/penalty + 1
+	return primitives.Epoch(epochsBeforePenalty + penalizedEpochs), true
 }
 
 func inactivityScoreAtIndex(state state.ReadOnlyBeaconState, idx int) (uint64, error) {
@@ -945,6 +945,61 @@ func TestIsEligibleForBailOut(t *testing.T) {
 	}
 }
 
+func TestBailOutBufferRemaining(t *testing.T) {
+	principal := params.BeaconConfig().MaxEffectiveBalance
+	buffer := helpers.BailOutBuffer(principal)
+	assert.Equal(t, buffer+10, helpers.BailOutBufferRemaining(principal+10, principal))
+	assert.Equal(t, uint64(1), helpers.BailOutBufferRemaining(principal-buffer+1, principal))
+	assert.Equal(t, uint64(0), helpers.BailOutBufferRemaining(principal-buffer, principal))
+	assert.Equal(t, uint64(0), helpers.BailOutBufferRemaining(principal-buffer-1, principal))
+}
+
+func TestEpochsToBailOut(t *testing.T) {
+	cfg := params.BeaconConfig()
+	principal := cfg.MaxEffectiveBalance
+	buffer := helpers.BailOutBuffer(principal)
+	denominator := (cfg.TimelySourceWeight + cfg.TimelyTargetWeight) * cfg.InactivityPenaltyDuration
+	penalty := buffer*cfg.TimelySourceWeight/denominator + buffer*cfg.TimelyTargetWeight/denominator
+
+	// simulate follows the inactivity score updates and penalties of an offline validator outside of a leak.
+	simulate := func(balance, score uint64) primitives.Epoch {
+		epochs := primitives.Epoch(0)
+		for balance+buffer >= principal {
+			epochs++
+			score += cfg.InactivityScoreBias
+			if score > cfg.InactivityScorePenaltyThreshold {
+				balance -= penalty
+			}
+		}
+		return epochs
+	}
+
+	tests := []struct {
+		name      string
+		balance   uint64
+		principal uint64
+		score     uint64
+		leak      bool
+		want      primitives.Epoch
+		wantOk    bool
+	}{
+		{name: "already below buffer", balance: principal - buffer - 1, principal: principal, want: 0, wantOk: true},
+		{name: "leak, already above score threshold", balance: principal, principal: principal, score: cfg.InactivityLeakBailoutScoreThreshold + 1, leak: true, want: 0, wantOk: true},
+		{name: "leak, at score threshold", balance: principal, principal: principal, score: cfg.InactivityLeakBailoutScoreThreshold, leak: true, want: 1, wantOk: true},
+		{name: "leak, from zero score", balance: principal, principal: principal, leak: true, want: primitives.Epoch(cfg.InactivityLeakBailoutScoreThreshold/cfg.InactivityScoreBias + 1), wantOk: true},
+		{name: "no leak, from zero score", balance: principal, principal: principal, want: simulate(principal, 0), wantOk: true},
+		{name: "no leak, above penalty threshold", balance: principal + 1, principal: principal, score: cfg.InactivityScorePenaltyThreshold + 3, want: simulate(principal+1, cfg.InactivityScorePenaltyThreshold+3), wantOk: true},
+		{name: "no leak, zero principal", balance: 0, principal: 0, want: 0, wantOk: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := helpers.EpochsToBailOut(tt.balance, tt.principal, tt.score, tt.leak)
+			assert.Equal(t, tt.wantOk, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
 func computeProposerIndexWithValidators(validators []*ethpb.Validator, activeIndices []primitives.ValidatorIndex, seed [32]byte) (primitives.ValidatorIndex, error) {
 	length := uint64(len(activeIndices))
 	if length == 0 {
@@ -0,0 +1,174 @@
+// Package tokenomics implements an audit of the Over tokenomics invariants of the state transition.
+//
+// The audit is opt-in, as it replays part of the epoch processing to record the reserve usage.
+// It checks every epoch transition for:
+//   - the tokens held by the consensus layer never exceeding MaxTokenSupply,
+//   - the tokens held by the consensus layer never growing faster than the issuance schedule,
+//   - the reserves only decreasing by the usage recorded by the rewards of the epoch,
+//   - the reward adjustment factor staying within the maximum of the year and changing by at most its delta.
+package tokenomics
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/altair"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/time"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v5/config/features"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	mathutil "github.com/prysmaticlabs/prysm/v5/math"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+)
+
+// Names of the audited invariants.
+const (
+	InvariantMaxTokenSupply         = "max_token_supply"
+	InvariantEpochIssuance          = "epoch_issuance"
+	InvariantReserveUsage           = "reserve_usage"
+	InvariantRewardAdjustmentFactor = "reward_adjustment_factor"
+)
+
+// Violation is a tokenomics invariant which does not hold for an epoch transition.
+type Violation struct {
+	Invariant string
+	// Epoch is the epoch whose processing violated the invariant.
+	Epoch primitives.Epoch
+	// StateRoot is the root of the state at the last slot of the epoch, before the epoch processing.
+	StateRoot [32]byte
+	Message   string
+}
+
+// Error implements the error interface.
+func (v *Violation) Error() string {
+	return fmt.Sprintf("tokenomics invariant %s violated at epoch %d, state root %#x: %s", v.Invariant, v.Epoch, v.StateRoot, v.Message)
+}
+
+// Enabled returns true if epoch transitions should be audited, either because the audit feature flag
+// is set or because the context collects violations.
+func Enabled(ctx context.Context) bool {
+	return features.Get().EnableTokenomicsAudit || collectorFromContext(ctx) != nil
+}
+
+// EpochAudit holds the tokenomics values of a state before its epoch processing,
+// which the state after the epoch processing is checked against.
+type EpochAudit struct {
+	epoch     primitives.Epoch
+	stateRoot [32]byte
+	supply    uint64
+	reserves  uint64
+	factor    uint64
+	// reserveUsage is the reserve usage of the attestation rewards of the epoch processing,
+	// which is only recorded from Altair on.
+	reserveUsage         uint64
+	reserveUsageRecorded bool
+}
+
+// NewEpochAudit records the tokenomics values of the given state, which is about to be processed for an epoch.
+// The state is not modified.
+func NewEpochAudit(ctx context.Context, st state.BeaconState) (*EpochAudit, error) {
+	supply, err := Supply(st)
+	if err != nil {
+		return nil, err
+	}
+	root, err := st.StateRootAtIndex(uint64(st.Slot() % params.BeaconConfig().SlotsPerHistoricalRoot))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get state root")
+	}
+	a := &EpochAudit{
+		epoch:     time.CurrentEpoch(st),
+		stateRoot: bytesutil.ToBytes32(root),
+		supply:    supply,
+		reserves:  st.Reserves(),
+		factor:    st.RewardAdjustmentFactor(),
+	}
+	if st.Version() >= version.Altair {
+		_, _, reserveUsages, err := altair.EpochAttestationsDelta(ctx, st)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not record reserve usage")
+		}
+		for _, u := range reserveUsages {
+			a.reserveUsage += u
+		}
+		a.reserveUsageRecorded = true
+	}
+	return a, nil
+}
+
+// Verify checks the state after the epoch processing against the recorded values,
+// and returns the violated invariants.
+func (a *EpochAudit) Verify(post state.ReadOnlyBeaconState) ([]*Violation, error) {
+	cfg := params.BeaconConfig()
+	var violations []*Violation
+	violate := func(invariant, format string, args ...any) {
+		violations = append(violations, &Violation{
+			Invariant: invariant,
+			Epoch:     a.epoch,
+			StateRoot: a.stateRoot,
+			Message:   fmt.Sprintf(format, args...),
+		})
+	}
+
+	supply, err := Supply(post)
+	if err != nil {
+		return nil, err
+	}
+	if supply > cfg.MaxTokenSupply {
+		violate(InvariantMaxTokenSupply, "supply %d exceeds max token supply %d", supply, cfg.MaxTokenSupply)
+	}
+	// The feedback boost moves tokens from the reserves to the balances,
+	// so only the issuance can increase the supply.
+	issuance := helpers.EpochIssuance(a.epoch)
+	if supply > a.supply && supply-a.supply > issuance {
+		violate(InvariantEpochIssuance, "supply increased by %d, more than the epoch issuance %d", supply-a.supply, issuance)
+	}
+
+	reserves := post.Reserves()
+	switch {
+	case reserves > a.reserves:
+		violate(InvariantReserveUsage, "reserves increased from %d to %d", a.reserves, reserves)
+	case a.reserveUsageRecorded && a.reserves-reserves != mathutil.Min(a.reserves, a.reserveUsage):
+		violate(InvariantReserveUsage, "reserves decreased by %d, but the recorded usage is %d", a.reserves-reserves, mathutil.Min(a.reserves, a.reserveUsage))
+	}
+
+	factor := post.RewardAdjustmentFactor()
+	// A factor above the maximum may only be the result of the maximum decreasing at the start of a year,
+	// in which case it must be decreasing towards it.
+	if maxFactor := helpers.MaxRewardAdjustmentFactor(a.epoch); factor > maxFactor && factor >= a.factor {
+		violate(InvariantRewardAdjustmentFactor, "factor %d exceeds the yearly max %d", factor, maxFactor)
+	}
+	if diff := mathutil.Max(factor, a.factor) - mathutil.Min(factor, a.factor); diff > cfg.RewardAdjustmentFactorDelta {
+		violate(InvariantRewardAdjustmentFactor, "factor changed from %d to %d, more than the delta %d", a.factor, factor, cfg.RewardAdjustmentFactorDelta)
+	}
+	return violations, nil
+}
+
+// Supply returns the tokens held by the consensus layer, i.e. the balances of the validators,
+// the reserves and the pending deposits which are not credited to a balance yet.
+func Supply(st state.ReadOnlyBeaconState) (uint64, error) {
+	supply := st.Reserves()
+	var err error
+	for _, b := range st.Balances() {
+		supply, err = mathutil.Add64(supply, b)
+		if err != nil {
+			return 0, errors.Wrap(err, "supply overflows")
+		}
+	}
+	if st.Version() >= version.Alpaca {
+		deposits, err := st.PendingDeposits()
+		if err != nil {
+			return 0, errors.Wrap(err, "could not get pending deposits")
+		}
+		for _, d := range deposits {
+			supply, err = mathutil.Add64(supply, d.Amount)
+			if err != nil {
+				return 0, errors.Wrap(err, "supply overflows")
+			}
+		}
+	}
+	return supply, nil
+}
@@ -0,0 +1,109 @@
+package tokenomics_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/electra"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/tokenomics"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+func TestEpochAudit(t *testing.T) {
+	ctx := context.Background()
+	cfg := params.BeaconConfig()
+
+	// newState returns a state at the last slot of epoch 2, where every validator attested in the previous epoch.
+	newState := func(t *testing.T) state.BeaconState {
+		st, _ := util.DeterministicGenesisStateElectra(t, 64)
+		require.NoError(t, st.SetSlot(3*cfg.SlotsPerEpoch-1))
+		participation := make([]byte, st.NumValidators())
+		for i := range participation {
+			participation[i] = 0b111
+		}
+		require.NoError(t, st.SetPreviousParticipationBits(participation))
+		require.NoError(t, st.SetReserves(1000*cfg.EffectiveBalanceIncrement))
+		require.NoError(t, st.SetRewardAdjustmentFactor(cfg.RewardAdjustmentFactorDelta*10))
+		return st
+	}
+
+	t.Run("epoch processing", func(t *testing.T) {
+		st := newState(t)
+		reserves := st.Reserves()
+		audit, err := tokenomics.NewEpochAudit(ctx, st)
+		require.NoError(t, err)
+		require.NoError(t, electra.ProcessEpoch(ctx, st))
+		assert.Equal(t, true, st.Reserves() < reserves)
+
+		violations, err := audit.Verify(st)
+		require.NoError(t, err)
+		assert.Equal(t, 0, len(violations))
+	})
+	t.Run("violations", func(t *testing.T) {
+		st := newState(t)
+		reserves := st.Reserves()
+		audit, err := tokenomics.NewEpochAudit(ctx, st)
+		require.NoError(t, err)
+
+		post := st.Copy()
+		bal, err := post.BalanceAtIndex(0)
+		require.NoError(t, err)
+		require.NoError(t, post.UpdateBalancesAtIndex(0, bal+helpers.EpochIssuance(2)+1))
+		require.NoError(t, post.SetReserves(reserves+1))
+		require.NoError(t, post.SetRewardAdjustmentFactor(cfg.MaxRewardAdjustmentFactors[0]+1))
+
+		violations, err := audit.Verify(post)
+		require.NoError(t, err)
+		require.Equal(t, 4, len(violations))
+		assert.Equal(t, tokenomics.InvariantEpochIssuance, violations[0].Invariant)
+		assert.Equal(t, tokenomics.InvariantReserveUsage, violations[1].Invariant)
+		assert.Equal(t, tokenomics.InvariantRewardAdjustmentFactor, violations[2].Invariant)
+		assert.Equal(t, tokenomics.InvariantRewardAdjustmentFactor, violations[3].Invariant)
+		for _, v := range violations {
+			assert.Equal(t, primitives.Epoch(2), v.Epoch)
+		}
+
+		// The reserves may not decrease more than recorded either.
+		post = st.Copy()
+		require.NoError(t, post.SetReserves(0))
+		violations, err = audit.Verify(post)
+		require.NoError(t, err)
+		require.Equal(t, 1, len(violations))
+		assert.Equal(t, tokenomics.InvariantReserveUsage, violations[0].Invariant)
+	})
+	t.Run("max token supply", func(t *testing.T) {
+		st := newState(t)
+		// The rewards of the epoch push the supply above the max.
+		supply, err := tokenomics.Supply(st)
+		require.NoError(t, err)
+		bal, err := st.BalanceAtIndex(0)
+		require.NoError(t, err)
+		require.NoError(t, st.UpdateBalancesAtIndex(0, bal+cfg.MaxTokenSupply-supply))
+		audit, err := tokenomics.NewEpochAudit(ctx, st)
+		require.NoError(t, err)
+		require.NoError(t, electra.ProcessEpoch(ctx, st))
+
+		violations, err := audit.Verify(st)
+		require.NoError(t, err)
+		require.Equal(t, 1, len(violations))
+		assert.Equal(t, tokenomics.InvariantMaxTokenSupply, violations[0].Invariant)
+	})
+}
+
+func TestReport(t *testing.T) {
+	c := &tokenomics.Collector{}
+	ctx := tokenomics.WithCollector(context.Background(), c)
+	assert.Equal(t, true, tokenomics.Enabled(ctx))
+	assert.Equal(t, false, tokenomics.Enabled(context.Background()))
+
+	v := &tokenomics.Violation{Invariant: tokenomics.InvariantReserveUsage, Epoch: 3}
+	tokenomics.Report(ctx, []*tokenomics.Violation{v})
+	tokenomics.Report(context.Background(), []*tokenomics.Violation{{Invariant: tokenomics.InvariantEpochIssuance}})
+	require.DeepEqual(t, []*tokenomics.Violation{v}, c.Violations())
+}
@@ -0,0 +1,68 @@
+package tokenomics
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	log = logrus.WithField("prefix", "tokenomics")
+
+	violationsCount = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "tokenomics_invariant_violations_total",
+		Help: "The number of tokenomics invariant violations found by the audit of the epoch transitions.",
+	}, []string{"invariant"})
+)
+
+type collectorKey struct{}
+
+// Collector collects the violations reported within a context, so the caller of a state transition
+// can inspect them. A context carrying a collector enables the audit regardless of the feature flag.
+type Collector struct {
+	lock       sync.Mutex
+	violations []*Violation
+}
+
+// WithCollector returns a context which collects the reported violations into the given collector.
+func WithCollector(ctx context.Context, c *Collector) context.Context {
+	return context.WithValue(ctx, collectorKey{}, c)
+}
+
+func collectorFromContext(ctx context.Context) *Collector {
+	c, ok := ctx.Value(collectorKey{}).(*Collector)
+	if !ok {
+		return nil
+	}
+	return c
+}
+
+// Violations returns the collected violations in the order they were reported.
+func (c *Collector) Violations() []*Violation {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return append([]*Violation(nil), c.violations...)
+}
+
+// Report logs the given violations and counts them in metrics. When the context carries a collector,
+// the violations are also collected.
+func Report(ctx context.Context, violations []*Violation) {
+	for _, v := range violations {
+		log.WithFields(logrus.Fields{
+			"invariant": v.Invariant,
+			"epoch":     v.Epoch,
+			"stateRoot": fmt.Sprintf("%#x", v.StateRoot),
+			"detail":    v.Message,
+		}).Error("Tokenomics invariant violated")
+		violationsCount.WithLabelValues(v.Invariant).Inc()
+	}
+	if c := collectorFromContext(ctx); c != nil && len(violations) > 0 {
+		c.lock.Lock()
+		c.violations = append(c.violations, violations...)
+		c.lock.Unlock()
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/execution"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/time"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/tokenomics"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
 	"github.com/prysmaticlabs/prysm/v5/config/features"
 	"github.com/prysmaticlabs/prysm/v5/config/params"
@@ -296,8 +297,32 @@ func ProcessSlotsCore(ctx context.Context, span trace.Span, state state.BeaconSt
 	return state, nil
 }
 
-// ProcessEpoch is a wrapper on fork specific epoch processing
+// ProcessEpoch is a wrapper on fork specific epoch processing.
+// In the tokenomics audit mode, the epoch transition is checked for violations of the tokenomics invariants,
+// which are reported without failing the transition.
 func ProcessEpoch(ctx context.Context, state state.BeaconState) (state.BeaconState, error) {
+	if !time.CanProcessEpoch(state) || !tokenomics.Enabled(ctx) {
+		return processEpoch(ctx, state)
+	}
+	audit, err := tokenomics.NewEpochAudit(ctx, state)
+	if err != nil {
+		log.WithError(err).Error("Could not audit epoch transition")
+		return processEpoch(ctx, state)
+	}
+	state, err = processEpoch(ctx, state)
+	if err != nil {
+		return nil, err
+	}
+	violations, err := audit.Verify(state)
+	if err != nil {
+		log.WithError(err).Error("Could not audit epoch transition")
+		return state, nil
+	}
+	tokenomics.Report(ctx, violations)
+	return state, nil
+}
+
+func processEpoch(ctx context.Context, state state.BeaconState) (state.BeaconState, error) {
 	var err error
 	if time.CanProcessEpoch(state) {
 		if state.Version() >= version.Alpaca {
@@ -10,6 +10,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/signing"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/time"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/tokenomics"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/transition"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
 	state_native "github.com/prysmaticlabs/prysm/v5/beacon-chain/state/state-native"
@@ -667,6 +668,37 @@ func TestProcessSlots_ThroughBadgerEpoch(t *testing.T) {
 	require.Equal(t, params.BeaconConfig().SlotsPerEpoch*10, st.Slot())
 }
 
+func TestProcessSlots_TokenomicsAudit(t *testing.T) {
+	cfg := params.BeaconConfig()
+	st, _ := util.DeterministicGenesisStateElectra(t, 64)
+	require.NoError(t, st.SetSlot(3*cfg.SlotsPerEpoch-1))
+	participation := make([]byte, st.NumValidators())
+	for i := range participation {
+		participation[i] = 0b111
+	}
+	require.NoError(t, st.SetPreviousParticipationBits(participation))
+	require.NoError(t, st.SetReserves(1000*cfg.EffectiveBalanceIncrement))
+	// The rewards of the epoch push the supply above the max.
+	supply, err := tokenomics.Supply(st)
+	require.NoError(t, err)
+	bal, err := st.BalanceAtIndex(0)
+	require.NoError(t, err)
+	require.NoError(t, st.UpdateBalancesAtIndex(0, bal+cfg.MaxTokenSupply-supply))
+	root, err := st.HashTreeRoot(context.Background())
+	require.NoError(t, err)
+
+	c := &tokenomics.Collector{}
+	ctx := tokenomics.WithCollector(context.Background(), c)
+	_, err = transition.ProcessSlots(ctx, st, st.Slot()+1)
+	require.NoError(t, err)
+
+	violations := c.Violations()
+	require.Equal(t, 1, len(violations))
+	assert.Equal(t, tokenomics.InvariantMaxTokenSupply, violations[0].Invariant)
+	assert.Equal(t, primitives.Epoch(2), violations[0].Epoch)
+	assert.Equal(t, root, violations[0].StateRoot)
+}
+
 func TestProcessSlotsUsingNextSlotCache(t *testing.T) {
 	s, _ := util.DeterministicGenesisState(t, 1)
 	r := []byte{'a'}
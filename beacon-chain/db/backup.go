@@ -0,0 +1,125 @@
+package db
+
+import (
+	"os"
+	"path"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/kv"
+	"github.com/prysmaticlabs/prysm/v5/cmd"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/io/file"
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+)
+
+// Backup writes a backup archive of an offline beacon chain database to a file, or streams it to the standard output.
+func Backup(cliCtx *cli.Context) error {
+	dbDir := path.Join(cliCtx.String(cmd.DataDirFlag.Name), kv.BeaconNodeDbDirName)
+	dbExists, err := file.Exists(kv.StoreDatafilePath(dbDir), file.Regular)
+	if err != nil {
+		return errors.Wrapf(err, "could not check if database exists in %s", dbDir)
+	}
+	if !dbExists {
+		return errors.Errorf("no database found in %s", dbDir)
+	}
+	d, err := kv.NewKVStore(cliCtx.Context, dbDir)
+	if err != nil {
+		return errors.Wrap(err, "could not open database")
+	}
+	defer func() {
+		if err := d.Close(); err != nil {
+			log.WithError(err).Error("Could not close database")
+		}
+	}()
+
+	output := cliCtx.String(cmd.BackupOutputFlag.Name)
+	incremental := cliCtx.Bool(cmd.BackupIncrementalFlag.Name)
+	if output == "-" {
+		// Logs must not be mixed into the archive.
+		logrus.SetOutput(os.Stderr)
+		m, err := d.StreamBackup(cliCtx.Context, os.Stdout, incremental)
+		if err != nil {
+			return err
+		}
+		logBackup(m, "stdout")
+		return nil
+	}
+
+	// The archive is written to a temporary file first, so that a failed backup does not leave a partial archive.
+	partial := output + ".partial"
+	f, err := os.OpenFile(partial, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, params.BeaconIoConfig().ReadWritePermissions)
+	if err != nil {
+		return errors.Wrap(err, "could not create backup file")
+	}
+	m, err := d.StreamBackup(cliCtx.Context, f, incremental)
+	if err == nil {
+		err = f.Sync()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		if rmErr := os.Remove(partial); rmErr != nil {
+			log.WithError(rmErr).Error("Could not remove partial backup file")
+		}
+		return err
+	}
+	if err := os.Rename(partial, output); err != nil {
+		return errors.Wrap(err, "could not rename backup file")
+	}
+	logBackup(m, output)
+	return nil
+}
+
+func logBackup(m *kv.BackupManifest, output string) {
+	log.WithFields(logrus.Fields{
+		"output":      output,
+		"id":          m.ID,
+		"base":        m.Base,
+		"fromSlot":    m.FromSlot,
+		"toSlot":      m.ToSlot,
+		"incremental": m.Incremental(),
+	}).Info("Backup completed successfully")
+}
+
+// restoreArchives restores the backup archives in order into a new database in the given directory,
+// and verifies it against the manifest of the last archive.
+func restoreArchives(cliCtx *cli.Context, dir string, archives []string) error {
+	d, err := kv.NewKVStore(cliCtx.Context, dir, kv.WithoutBlockStorageSetup())
+	if err != nil {
+		return errors.Wrap(err, "could not create database")
+	}
+	defer func() {
+		if err := d.Close(); err != nil {
+			log.WithError(err).Error("Could not close database")
+		}
+	}()
+	var m *kv.BackupManifest
+	for _, a := range archives {
+		m, err = restoreArchive(cliCtx, d, a, m)
+		if err != nil {
+			return errors.Wrapf(err, "could not restore %s", a)
+		}
+		log.WithFields(logrus.Fields{
+			"archive":  a,
+			"id":       m.ID,
+			"fromSlot": m.FromSlot,
+			"toSlot":   m.ToSlot,
+		}).Info("Restored backup archive")
+	}
+	return d.VerifyRestoredBackup(cliCtx.Context, m)
+}
+
+func restoreArchive(cliCtx *cli.Context, d *kv.Store, archive string, base *kv.BackupManifest) (*kv.BackupManifest, error) {
+	f, err := os.Open(archive) // #nosec G304
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := f.Close(); err != nil {
+			log.WithError(err).Error("Could not close backup archive")
+		}
+	}()
+	return d.RestoreBackup(cliCtx.Context, f, base)
+}
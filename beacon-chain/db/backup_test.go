@@ -0,0 +1,124 @@
+package db
+
+import (
+	"context"
+	"flag"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/kv"
+	"github.com/prysmaticlabs/prysm/v5/cmd"
+	"github.com/prysmaticlabs/prysm/v5/config/features"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+	"github.com/urfave/cli/v2"
+)
+
+func saveHeadBlock(t *testing.T, d *kv.Store, slot primitives.Slot) {
+	ctx := context.Background()
+	head := util.NewBeaconBlock()
+	head.Block.Slot = slot
+	wsb, err := blocks.NewSignedBeaconBlock(head)
+	require.NoError(t, err)
+	require.NoError(t, d.SaveBlock(ctx, wsb))
+	root, err := head.Block.HashTreeRoot()
+	require.NoError(t, err)
+	st, err := util.NewBeaconState()
+	require.NoError(t, err)
+	require.NoError(t, d.SaveState(ctx, st, root))
+	require.NoError(t, d.SaveHeadBlockRoot(ctx, root))
+}
+
+func TestBackupAndRestoreArchives(t *testing.T) {
+	ctx := context.Background()
+	dataDir := t.TempDir()
+	d, err := kv.NewKVStore(ctx, path.Join(dataDir, kv.BeaconNodeDbDirName))
+	require.NoError(t, err)
+	saveHeadBlock(t, d, 100)
+	require.NoError(t, d.Close())
+
+	backup := func(output string, incremental bool) {
+		set := flag.NewFlagSet("test", 0)
+		set.String(cmd.DataDirFlag.Name, dataDir, "")
+		set.String(cmd.BackupOutputFlag.Name, output, "")
+		set.Bool(cmd.BackupIncrementalFlag.Name, incremental, "")
+		require.NoError(t, Backup(cli.NewContext(&cli.App{}, set, nil)))
+	}
+	backupDir := t.TempDir()
+	full := path.Join(backupDir, "full.tar")
+	incremental := path.Join(backupDir, "incremental.tar")
+	backup(full, false)
+
+	d, err = kv.NewKVStore(ctx, path.Join(dataDir, kv.BeaconNodeDbDirName))
+	require.NoError(t, err)
+	saveHeadBlock(t, d, 200)
+	require.NoError(t, d.Close())
+	backup(incremental, true)
+	_, err = os.Stat(incremental + ".partial")
+	assert.Equal(t, true, os.IsNotExist(err))
+
+	restore := func(targetDir, source string, incrementals ...string) error {
+		set := flag.NewFlagSet("test", 0)
+		set.String(cmd.RestoreSourceFileFlag.Name, source, "")
+		set.String(cmd.RestoreTargetDirFlag.Name, targetDir, "")
+		incrementalFiles := cli.NewStringSlice(incrementals...)
+		set.Var(incrementalFiles, cmd.RestoreIncrementalFilesFlag.Name, "")
+		return Restore(cli.NewContext(&cli.App{}, set, nil))
+	}
+
+	targetDir := t.TempDir()
+	require.NoError(t, restore(targetDir, full, incremental))
+	files, err := os.ReadDir(targetDir)
+	require.NoError(t, err)
+	assert.Equal(t, 1, len(files), "staging directory was not removed")
+	restored, err := kv.NewKVStore(ctx, path.Join(targetDir, kv.BeaconNodeDbDirName))
+	require.NoError(t, err)
+	head, err := restored.HeadBlock(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, primitives.Slot(200), head.Block().Slot())
+	require.NoError(t, restored.Close())
+
+	targetDir = t.TempDir()
+	require.ErrorContains(t, "needs its base backup", restore(targetDir, incremental))
+	_, err = os.Stat(path.Join(targetDir, kv.BeaconNodeDbDirName, kv.DatabaseFileName))
+	assert.Equal(t, true, os.IsNotExist(err))
+	require.ErrorContains(t, "on top of a backup archive", restore(targetDir, kv.StoreDatafilePath(path.Join(dataDir, kv.BeaconNodeDbDirName)), incremental))
+}
+
+func TestBackupAndRestoreArchives_FullExecutionPayloads(t *testing.T) {
+	ctx := context.Background()
+	dataDir := t.TempDir()
+	dbDir := path.Join(dataDir, kv.BeaconNodeDbDirName)
+	resetCfg := features.InitWithReset(&features.Flags{SaveFullExecutionPayloads: true})
+	d, err := kv.NewKVStore(ctx, dbDir)
+	require.NoError(t, err)
+	saveHeadBlock(t, d, 100)
+	require.NoError(t, d.Close())
+	resetCfg()
+
+	set := flag.NewFlagSet("test", 0)
+	set.String(cmd.DataDirFlag.Name, dataDir, "")
+	full := path.Join(t.TempDir(), "full.tar")
+	set.String(cmd.BackupOutputFlag.Name, full, "")
+	require.NoError(t, Backup(cli.NewContext(&cli.App{}, set, nil)))
+
+	targetDir := t.TempDir()
+	set = flag.NewFlagSet("test", 0)
+	set.String(cmd.RestoreSourceFileFlag.Name, full, "")
+	set.String(cmd.RestoreTargetDirFlag.Name, targetDir, "")
+	require.NoError(t, Restore(cli.NewContext(&cli.App{}, set, nil)))
+
+	// The restored database still stores full blocks, so the node can start with the flag again.
+	resetCfg = features.InitWithReset(&features.Flags{SaveFullExecutionPayloads: true})
+	defer resetCfg()
+	restored, err := kv.NewKVStore(ctx, path.Join(targetDir, kv.BeaconNodeDbDirName))
+	require.NoError(t, err)
+	head, err := restored.HeadBlock(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, primitives.Slot(100), head.Block().Slot())
+	require.NoError(t, restored.Close())
+}
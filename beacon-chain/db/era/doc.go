@@ -0,0 +1,20 @@
+/*
+Package era reads and writes era files, portable archives of the finalized chain that can be used to seed a
+beacon node without syncing from the network.
+
+An era file is an e2store file, a flat sequence of entries that each start with an 8 byte header made of a 2 byte
+type, a 4 byte little endian data length and 2 reserved zero bytes. Blocks, blob sidecars and states are stored as
+snappy framed SSZ. Era N covers the slots [(N-1)*SLOTS_PER_HISTORICAL_ROOT, N*SLOTS_PER_HISTORICAL_ROOT) and holds
+the state at slot N*SLOTS_PER_HISTORICAL_ROOT, before the block at that slot is applied, whose block roots vector
+covers exactly the blocks of the era. Era 0 only holds the genesis state. Blocks that are stored without their
+execution payload in the database are exported as blinded blocks, with their own entry type, and imported as such;
+the payloads are then served by the execution client like for any other blinded block in the database.
+
+	era := Version | (block | blob-sidecar*)* | state | historical-summary | block-index | state-index
+
+The historical summary is the entry of the era in the historical accumulator of the chain, it is checked against
+the block and state roots of the era state and against the accumulator of the era state and of any later state.
+The slot indices map a starting slot and a number of slots to the offsets of the entries of every slot, relative to
+the start of the index entry, with zero marking an empty slot. Era 0 has no historical summary and no block index.
+*/
+package era
@@ -0,0 +1,168 @@
+package era
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/golang/snappy"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+)
+
+// headerSize is the size of the header of an e2store entry.
+const headerSize = 8
+
+type entryType [2]byte
+
+var (
+	typeVersion           = entryType{0x65, 0x32}
+	typeBlock             = entryType{0x01, 0x00}
+	typeState             = entryType{0x02, 0x00}
+	typeBlobSidecar       = entryType{0x03, 0x00}
+	typeHistoricalSummary = entryType{0x04, 0x00}
+	typeBlindedBlock      = entryType{0x05, 0x00}
+	typeSlotIndex         = entryType{0x69, 0x32}
+)
+
+func (t entryType) String() string {
+	switch t {
+	case typeVersion:
+		return "version"
+	case typeBlock:
+		return "block"
+	case typeState:
+		return "state"
+	case typeBlobSidecar:
+		return "blob sidecar"
+	case typeHistoricalSummary:
+		return "historical summary"
+	case typeBlindedBlock:
+		return "blinded block"
+	case typeSlotIndex:
+		return "slot index"
+	default:
+		return fmt.Sprintf("unknown %#x", t[:])
+	}
+}
+
+var errCorruptFile = errors.New("corrupt era file")
+
+// entry is an e2store entry, along with its offset in the file.
+type entry struct {
+	typ    entryType
+	data   []byte
+	offset int64
+}
+
+// writer writes e2store entries and keeps track of their offsets.
+type writer struct {
+	w      io.Writer
+	offset int64
+}
+
+// write writes an entry and returns its offset.
+func (w *writer) write(typ entryType, data []byte) (int64, error) {
+	if uint64(len(data)) > math.MaxUint32 {
+		return 0, fmt.Errorf("%s entry of %d bytes is too large", typ, len(data))
+	}
+	var header [headerSize]byte
+	copy(header[:2], typ[:])
+	binary.LittleEndian.PutUint32(header[2:6], uint32(len(data)))
+	offset := w.offset
+	if _, err := w.w.Write(header[:]); err != nil {
+		return 0, err
+	}
+	if _, err := w.w.Write(data); err != nil {
+		return 0, err
+	}
+	w.offset += headerSize + int64(len(data))
+	return offset, nil
+}
+
+// writeCompressed writes an entry with snappy framed data.
+func (w *writer) writeCompressed(typ entryType, data []byte) (int64, error) {
+	buf := bytes.NewBuffer(make([]byte, 0, len(data)/2))
+	sw := snappy.NewBufferedWriter(buf)
+	if _, err := sw.Write(data); err != nil {
+		return 0, err
+	}
+	if err := sw.Close(); err != nil {
+		return 0, err
+	}
+	return w.write(typ, buf.Bytes())
+}
+
+// readEntries splits the content of an e2store file into its entries.
+func readEntries(b []byte) ([]entry, error) {
+	var entries []entry
+	offset := int64(0)
+	for offset < int64(len(b)) {
+		if int64(len(b))-offset < headerSize {
+			return nil, errors.Wrapf(errCorruptFile, "truncated entry header at offset %d", offset)
+		}
+		h := b[offset : offset+headerSize]
+		if h[6] != 0 || h[7] != 0 {
+			return nil, errors.Wrapf(errCorruptFile, "reserved header bytes are not zero at offset %d", offset)
+		}
+		length := int64(binary.LittleEndian.Uint32(h[2:6]))
+		start := offset + headerSize
+		if int64(len(b))-start < length {
+			return nil, errors.Wrapf(errCorruptFile, "truncated entry data at offset %d", offset)
+		}
+		entries = append(entries, entry{
+			typ:    entryType{h[0], h[1]},
+			data:   b[start : start+length],
+			offset: offset,
+		})
+		offset = start + length
+	}
+	return entries, nil
+}
+
+// decompress returns the SSZ content of a snappy framed entry.
+func decompress(data []byte) ([]byte, error) {
+	return io.ReadAll(snappy.NewReader(bytes.NewReader(data)))
+}
+
+// encodeSlotIndex encodes a slot index entry starting at the given slot. The offsets of the indexed entries are
+// absolute, with zero for an empty slot, and are stored relative to the offset of the index entry.
+func encodeSlotIndex(start primitives.Slot, offsets []int64, indexOffset int64) []byte {
+	b := make([]byte, 8*(len(offsets)+2))
+	binary.LittleEndian.PutUint64(b[0:8], uint64(start))
+	for i, o := range offsets {
+		rel := int64(0)
+		if o != 0 {
+			rel = o - indexOffset
+		}
+		binary.LittleEndian.PutUint64(b[8*(i+1):8*(i+2)], uint64(rel))
+	}
+	binary.LittleEndian.PutUint64(b[len(b)-8:], uint64(len(offsets)))
+	return b
+}
+
+// decodeSlotIndex decodes a slot index entry and returns its starting slot and the absolute offsets of the indexed
+// entries, with zero for an empty slot.
+func decodeSlotIndex(e entry) (primitives.Slot, []int64, error) {
+	if e.typ != typeSlotIndex {
+		return 0, nil, errors.Wrapf(errCorruptFile, "expected a slot index at offset %d, found a %s", e.offset, e.typ)
+	}
+	if len(e.data) < 16 || len(e.data)%8 != 0 {
+		return 0, nil, errors.Wrapf(errCorruptFile, "slot index of %d bytes at offset %d", len(e.data), e.offset)
+	}
+	count := binary.LittleEndian.Uint64(e.data[len(e.data)-8:])
+	if count != uint64(len(e.data)/8-2) {
+		return 0, nil, errors.Wrapf(errCorruptFile, "slot index at offset %d has %d slots, but room for %d", e.offset, count, len(e.data)/8-2)
+	}
+	start := primitives.Slot(binary.LittleEndian.Uint64(e.data[0:8]))
+	offsets := make([]int64, count)
+	for i := range offsets {
+		rel := int64(binary.LittleEndian.Uint64(e.data[8*(i+1) : 8*(i+2)]))
+		if rel != 0 {
+			offsets[i] = e.offset + rel
+		}
+	}
+	return start, offsets, nil
+}
@@ -0,0 +1,393 @@
+package era
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain/kzg"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state/stateutil"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v5/encoding/ssz/detect"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+)
+
+// FileExtension is the extension of era files.
+const FileExtension = ".era"
+
+var errInvalidEra = errors.New("invalid era")
+
+// Block is a block of an era, along with the blob sidecars stored with it.
+type Block struct {
+	Block interfaces.ReadOnlySignedBeaconBlock
+	Root  [32]byte
+	Blobs []*ethpb.BlobSidecar
+}
+
+// Era is the decoded content of an era file.
+type Era struct {
+	Number  uint64
+	State   state.BeaconState
+	Summary *ethpb.HistoricalSummary
+	Blocks  []Block
+}
+
+// StartSlot returns the first slot covered by the blocks of the era.
+func StartSlot(era uint64) primitives.Slot {
+	if era == 0 {
+		return 0
+	}
+	return primitives.Slot(era-1) * params.BeaconConfig().SlotsPerHistoricalRoot
+}
+
+// StateSlot returns the slot of the state of the era, which is the first slot after the blocks of the era.
+func StateSlot(era uint64) primitives.Slot {
+	return primitives.Slot(era) * params.BeaconConfig().SlotsPerHistoricalRoot
+}
+
+// FileName returns the name of the era file, made of the config name, the era number and the first 4 bytes of the
+// historical root of the era, or of the genesis validators root for era 0.
+func FileName(configName string, era uint64, root [32]byte) string {
+	return fmt.Sprintf("%s-%05d-%x%s", configName, era, root[:4], FileExtension)
+}
+
+// ParseFileName returns the config name and the era number of an era file name.
+func ParseFileName(name string) (string, uint64, error) {
+	name = filepath.Base(name)
+	if !strings.HasSuffix(name, FileExtension) {
+		return "", 0, fmt.Errorf("%s is not an era file", name)
+	}
+	parts := strings.Split(strings.TrimSuffix(name, FileExtension), "-")
+	if len(parts) < 3 {
+		return "", 0, fmt.Errorf("%s is not an era file name", name)
+	}
+	era, err := strconv.ParseUint(parts[len(parts)-2], 10, 64)
+	if err != nil {
+		return "", 0, errors.Wrapf(err, "could not parse era number of %s", name)
+	}
+	return strings.Join(parts[:len(parts)-2], "-"), era, nil
+}
+
+// Files returns the era files in the given directory, ordered by era number.
+func Files(dir string) ([]string, error) {
+	matches, err := filepath.Glob(filepath.Join(dir, "*"+FileExtension))
+	if err != nil {
+		return nil, err
+	}
+	eras := make(map[string]uint64, len(matches))
+	for _, m := range matches {
+		_, n, err := ParseFileName(m)
+		if err != nil {
+			return nil, err
+		}
+		eras[m] = n
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		return eras[matches[i]] < eras[matches[j]]
+	})
+	return matches, nil
+}
+
+// ReadFile reads and decodes an era file.
+func ReadFile(path string) (*Era, error) {
+	b, err := os.ReadFile(path) // #nosec G304
+	if err != nil {
+		return nil, err
+	}
+	e, err := Decode(b)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not decode %s", path)
+	}
+	if _, n, err := ParseFileName(path); err == nil && n != e.Number {
+		return nil, errors.Wrapf(errInvalidEra, "%s holds era %d", path, e.Number)
+	}
+	return e, nil
+}
+
+// Decode decodes the content of an era file, and checks that its slot indices match its entries.
+func Decode(b []byte) (*Era, error) {
+	entries, err := readEntries(b)
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) < 3 || entries[0].typ != typeVersion || len(entries[0].data) != 0 {
+		return nil, errors.Wrap(errCorruptFile, "file does not start with a version entry")
+	}
+	byOffset := make(map[int64]int, len(entries))
+	for i, en := range entries {
+		byOffset[en.offset] = i
+	}
+
+	// The state index is the last entry.
+	stateSlot, stateOffsets, err := decodeSlotIndex(entries[len(entries)-1])
+	if err != nil {
+		return nil, err
+	}
+	if len(stateOffsets) != 1 {
+		return nil, errors.Wrapf(errCorruptFile, "state index has %d slots", len(stateOffsets))
+	}
+	stateIdx, ok := byOffset[stateOffsets[0]]
+	if !ok || entries[stateIdx].typ != typeState {
+		return nil, errors.Wrap(errCorruptFile, "state index does not point to a state")
+	}
+	if stateSlot%params.BeaconConfig().SlotsPerHistoricalRoot != 0 {
+		return nil, errors.Wrapf(errInvalidEra, "state slot %d is not at the start of an era", stateSlot)
+	}
+	e := &Era{Number: uint64(stateSlot / params.BeaconConfig().SlotsPerHistoricalRoot)}
+	e.State, err = decodeState(entries[stateIdx])
+	if err != nil {
+		return nil, err
+	}
+	if e.State.Slot() != stateSlot {
+		return nil, errors.Wrapf(errInvalidEra, "state is at slot %d, but is indexed at slot %d", e.State.Slot(), stateSlot)
+	}
+	if e.Number == 0 {
+		if len(entries) != 3 {
+			return nil, errors.Wrapf(errInvalidEra, "era 0 has %d entries besides the genesis state", len(entries)-3)
+		}
+		return e, nil
+	}
+
+	// The block index precedes the state index, and the historical summary follows the state.
+	if len(entries) < 5 || stateIdx != len(entries)-4 || entries[stateIdx+1].typ != typeHistoricalSummary {
+		return nil, errors.Wrap(errCorruptFile, "state is not followed by the historical summary and the slot indices")
+	}
+	e.Summary = &ethpb.HistoricalSummary{}
+	if err := e.Summary.UnmarshalSSZ(entries[stateIdx+1].data); err != nil {
+		return nil, errors.Wrap(err, "could not decode historical summary")
+	}
+	blockStart, blockOffsets, err := decodeSlotIndex(entries[len(entries)-2])
+	if err != nil {
+		return nil, err
+	}
+	if blockStart != StartSlot(e.Number) || uint64(len(blockOffsets)) != uint64(params.BeaconConfig().SlotsPerHistoricalRoot) {
+		return nil, errors.Wrapf(errCorruptFile, "block index covers %d slots from slot %d", len(blockOffsets), blockStart)
+	}
+	indexed := make(map[int64]primitives.Slot)
+	for i, o := range blockOffsets {
+		if o != 0 {
+			indexed[o] = blockStart + primitives.Slot(i)
+		}
+	}
+
+	for _, en := range entries[1:stateIdx] {
+		switch en.typ {
+		case typeBlock, typeBlindedBlock:
+			slot, ok := indexed[en.offset]
+			if !ok {
+				return nil, errors.Wrapf(errCorruptFile, "block at offset %d is not indexed", en.offset)
+			}
+			delete(indexed, en.offset)
+			blk, err := decodeBlock(en)
+			if err != nil {
+				return nil, err
+			}
+			if blk.Block().Slot() != slot {
+				return nil, errors.Wrapf(errCorruptFile, "block at slot %d is indexed at slot %d", blk.Block().Slot(), slot)
+			}
+			root, err := blk.Block().HashTreeRoot()
+			if err != nil {
+				return nil, err
+			}
+			e.Blocks = append(e.Blocks, Block{Block: blk, Root: root})
+		case typeBlobSidecar:
+			if len(e.Blocks) == 0 {
+				return nil, errors.Wrapf(errCorruptFile, "blob sidecar at offset %d precedes the blocks", en.offset)
+			}
+			ssz, err := decompress(en.data)
+			if err != nil {
+				return nil, errors.Wrap(err, "could not decompress blob sidecar")
+			}
+			sc := &ethpb.BlobSidecar{}
+			if err := sc.UnmarshalSSZ(ssz); err != nil {
+				return nil, errors.Wrap(err, "could not decode blob sidecar")
+			}
+			last := &e.Blocks[len(e.Blocks)-1]
+			last.Blobs = append(last.Blobs, sc)
+		default:
+			return nil, errors.Wrapf(errCorruptFile, "unexpected %s entry at offset %d", en.typ, en.offset)
+		}
+	}
+	if len(indexed) != 0 {
+		return nil, errors.Wrapf(errCorruptFile, "block index points to %d entries that are not blocks", len(indexed))
+	}
+	return e, nil
+}
+
+func decodeState(en entry) (state.BeaconState, error) {
+	ssz, err := decompress(en.data)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not decompress state")
+	}
+	cf, err := detect.FromState(ssz)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not detect the fork of the state")
+	}
+	if cf.Config.ConfigName != params.BeaconConfig().ConfigName {
+		return nil, fmt.Errorf("era is for the %s config, but the %s config is in use", cf.Config.ConfigName, params.BeaconConfig().ConfigName)
+	}
+	return cf.UnmarshalBeaconState(ssz)
+}
+
+func decodeBlock(en entry) (interfaces.ReadOnlySignedBeaconBlock, error) {
+	ssz, err := decompress(en.data)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not decompress block")
+	}
+	cf, err := detect.FromBlock(ssz)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not detect the fork of the block")
+	}
+	if en.typ == typeBlindedBlock {
+		return cf.UnmarshalBlindedBeaconBlock(ssz)
+	}
+	return cf.UnmarshalBeaconBlock(ssz)
+}
+
+// latestBlockRoot returns the root of the latest block at or before the last slot of an era after era 0.
+func (e *Era) latestBlockRoot() [32]byte {
+	sphr := params.BeaconConfig().SlotsPerHistoricalRoot
+	return bytesutil.ToBytes32(e.State.BlockRoots()[(e.State.Slot()-1)%sphr])
+}
+
+// Verify checks that the era is self-consistent: the historical summary matches the block and state roots of the
+// era state and its historical accumulator, the blocks are exactly the blocks of the block roots of the era state,
+// and the blob sidecars are included in their blocks and match their KZG commitments. The KZG trusted setup must be
+// loaded, with kzg.Start, if the era holds blob sidecars.
+func (e *Era) Verify() error {
+	if e.State.Slot() != StateSlot(e.Number) {
+		return errors.Wrapf(errInvalidEra, "state of era %d is at slot %d", e.Number, e.State.Slot())
+	}
+	if e.Number == 0 {
+		if len(e.Blocks) != 0 || e.Summary != nil {
+			return errors.Wrap(errInvalidEra, "era 0 only holds the genesis state")
+		}
+		return nil
+	}
+	summary, err := computeSummary(e.State)
+	if err != nil {
+		return err
+	}
+	if !summaryEqual(summary, e.Summary) {
+		return errors.Wrapf(errInvalidEra, "historical summary of era %d does not match the roots of its state", e.Number)
+	}
+	if err := e.VerifyAccumulator(e.State); err != nil {
+		return err
+	}
+
+	// The block roots of the state must change exactly at the slots of the blocks.
+	sphr := params.BeaconConfig().SlotsPerHistoricalRoot
+	roots := e.State.BlockRoots()
+	next := 0
+	var prev [32]byte
+	for slot := StartSlot(e.Number); slot < StateSlot(e.Number); slot++ {
+		root := bytesutil.ToBytes32(roots[slot%sphr])
+		if next < len(e.Blocks) && e.Blocks[next].Block.Block().Slot() == slot {
+			b := e.Blocks[next]
+			if root != b.Root {
+				return errors.Wrapf(errInvalidEra, "block at slot %d is not in the block roots of the era state", slot)
+			}
+			// The parent of a block at the first slot is the latest block of the previous era.
+			if slot > StartSlot(e.Number) && b.Block.Block().ParentRoot() != prev {
+				return errors.Wrapf(errInvalidEra, "block at slot %d is not a child of the previous block", slot)
+			}
+			if err := verifyBlobs(b); err != nil {
+				return errors.Wrapf(err, "invalid blob sidecars for block at slot %d", slot)
+			}
+			next++
+		} else if slot > StartSlot(e.Number) && root != prev {
+			return errors.Wrapf(errInvalidEra, "block at slot %d is missing from the era", slot)
+		}
+		prev = root
+	}
+	if next != len(e.Blocks) {
+		return errors.Wrapf(errInvalidEra, "block at slot %d is out of order", e.Blocks[next].Block.Block().Slot())
+	}
+	return nil
+}
+
+// VerifyAccumulator checks the historical summary of the era against the historical accumulator of the given state,
+// which is the era state or any later state. States before Capella have no historical summaries, so they are not
+// checked.
+func (e *Era) VerifyAccumulator(st state.ReadOnlyBeaconState) error {
+	if e.Number == 0 || st.Version() < version.Capella {
+		return nil
+	}
+	if st.Slot() < StateSlot(e.Number) {
+		return fmt.Errorf("state at slot %d precedes era %d", st.Slot(), e.Number)
+	}
+	capellaStart, err := slots.EpochStart(params.BeaconConfig().CapellaForkEpoch)
+	if err != nil {
+		return err
+	}
+	capellaEra := uint64(capellaStart / params.BeaconConfig().SlotsPerHistoricalRoot)
+	// Era N holds the blocks of the N-1th period of SLOTS_PER_HISTORICAL_ROOT slots.
+	if e.Number-1 < capellaEra {
+		return nil
+	}
+	summaries, err := st.HistoricalSummaries()
+	if err != nil {
+		return err
+	}
+	i := e.Number - 1 - capellaEra
+	if i >= uint64(len(summaries)) {
+		return errors.Wrapf(errInvalidEra, "historical accumulator of the state at slot %d has no entry for era %d", st.Slot(), e.Number)
+	}
+	if !summaryEqual(summaries[i], e.Summary) {
+		return errors.Wrapf(errInvalidEra, "historical summary of era %d is not in the historical accumulator of the state at slot %d", e.Number, st.Slot())
+	}
+	return nil
+}
+
+func verifyBlobs(b Block) error {
+	if len(b.Blobs) == 0 {
+		return nil
+	}
+	ros := make([]blocks.ROBlob, len(b.Blobs))
+	for i, sc := range b.Blobs {
+		ro, err := blocks.NewROBlob(sc)
+		if err != nil {
+			return err
+		}
+		if ro.BlockRoot() != b.Root {
+			return fmt.Errorf("blob sidecar %d is for block %#x", sc.Index, ro.BlockRoot())
+		}
+		if err := blocks.VerifyKZGInclusionProof(ro); err != nil {
+			return errors.Wrapf(err, "blob sidecar %d", sc.Index)
+		}
+		ros[i] = ro
+	}
+	return kzg.Verify(ros...)
+}
+
+// computeSummary computes the historical summary of the era that ends at the slot of the given state.
+func computeSummary(st state.ReadOnlyBeaconState) (*ethpb.HistoricalSummary, error) {
+	br, err := stateutil.ArraysRoot(st.BlockRoots(), fieldparams.BlockRootsLength)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not compute block roots root")
+	}
+	sr, err := stateutil.ArraysRoot(st.StateRoots(), fieldparams.StateRootsLength)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not compute state roots root")
+	}
+	return &ethpb.HistoricalSummary{BlockSummaryRoot: br[:], StateSummaryRoot: sr[:]}, nil
+}
+
+func summaryEqual(a, b *ethpb.HistoricalSummary) bool {
+	return a != nil && b != nil &&
+		bytes.Equal(a.BlockSummaryRoot, b.BlockSummaryRoot) &&
+		bytes.Equal(a.StateSummaryRoot, b.StateSummaryRoot)
+}
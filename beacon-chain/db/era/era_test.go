@@ -0,0 +1,251 @@
+package era
+
+import (
+	"context"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/time"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/transition"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/kv"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/crypto/bls"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+)
+
+func TestSlotIndex_RoundTrip(t *testing.T) {
+	offsets := []int64{8, 0, 0, 120, 300}
+	e := entry{typ: typeSlotIndex, offset: 1000}
+	e.data = encodeSlotIndex(64, offsets, e.offset)
+	start, got, err := decodeSlotIndex(e)
+	require.NoError(t, err)
+	assert.Equal(t, primitives.Slot(64), start)
+	assert.DeepEqual(t, offsets, got)
+
+	e.data = e.data[:len(e.data)-8]
+	_, _, err = decodeSlotIndex(e)
+	require.ErrorIs(t, err, errCorruptFile)
+}
+
+func TestParseFileName(t *testing.T) {
+	name := FileName("over-mainnet", 12, [32]byte{0xab, 0xcd, 0xef, 0x01, 0x02})
+	assert.Equal(t, "over-mainnet-00012-abcdef01.era", name)
+	cfg, n, err := ParseFileName(filepath.Join("dir", name))
+	require.NoError(t, err)
+	assert.Equal(t, "over-mainnet", cfg)
+	assert.Equal(t, uint64(12), n)
+
+	_, _, err = ParseFileName("mainnet-00012.tar")
+	require.ErrorContains(t, "is not an era file", err)
+}
+
+// setupEraConfig keeps the chain in Capella, whose blocks are generated by the test utilities, and uses a config
+// without an embedded genesis state.
+func setupEraConfig(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.ConfigName = "era-test"
+	for _, v := range [][]byte{
+		cfg.GenesisForkVersion, cfg.AltairForkVersion, cfg.BellatrixForkVersion, cfg.CapellaForkVersion,
+		cfg.DenebForkVersion, cfg.AlpacaForkVersion, cfg.BadgerForkVersion,
+	} {
+		v[3] = 0xee
+	}
+	cfg.DenebForkEpoch = math.MaxUint64
+	cfg.AlpacaForkEpoch = math.MaxUint64
+	cfg.InitializeForkSchedule()
+	params.SetActiveTestCleanup(t, cfg)
+}
+
+// capellaGenesisState returns a genesis state whose fork is Capella, as the fork of era states is detected from it.
+func capellaGenesisState(t *testing.T, numValidators uint64) (state.BeaconState, []bls.SecretKey) {
+	st, keys := util.DeterministicGenesisStateCapella(t, numValidators)
+	v := params.BeaconConfig().CapellaForkVersion
+	require.NoError(t, st.SetFork(&ethpb.Fork{PreviousVersion: v, CurrentVersion: v}))
+	return st, keys
+}
+
+// generateBlock generates an empty block at the given slot on top of the given state, with the withdrawals expected
+// by the state, which the test utilities do not generate.
+func generateBlock(t *testing.T, st state.BeaconState, slot primitives.Slot) interfaces.ReadOnlySignedBeaconBlock {
+	ctx := context.Background()
+	pre, err := transition.ProcessSlots(ctx, st.Copy(), slot)
+	require.NoError(t, err)
+	header, err := pre.LatestExecutionPayloadHeader()
+	require.NoError(t, err)
+	mix, err := helpers.RandaoMix(pre, time.CurrentEpoch(pre))
+	require.NoError(t, err)
+	ts, err := slots.ToTime(pre.GenesisTime(), slot)
+	require.NoError(t, err)
+	withdrawals, _, _, err := pre.ExpectedWithdrawals()
+	require.NoError(t, err)
+	proposer, err := helpers.BeaconProposerIndex(ctx, pre)
+	require.NoError(t, err)
+	parentRoot, err := pre.LatestBlockHeader().HashTreeRoot()
+	require.NoError(t, err)
+
+	b := util.NewBeaconBlockCapella()
+	b.Block.Slot = slot
+	b.Block.ProposerIndex = proposer
+	b.Block.ParentRoot = parentRoot[:]
+	b.Block.Body.Eth1Data = pre.Eth1Data()
+	payload := b.Block.Body.ExecutionPayload
+	payload.ParentHash = header.BlockHash()
+	payload.PrevRandao = mix
+	payload.Timestamp = uint64(ts.Unix())
+	payload.BlockNumber = uint64(slot)
+	payload.BlockHash = bytesutil.PadTo(bytesutil.Uint64ToBytesLittleEndian(uint64(slot)), 32)
+	payload.Withdrawals = withdrawals
+	wsb, err := blocks.NewSignedBeaconBlock(b)
+	require.NoError(t, err)
+	root, err := transition.CalculateStateRoot(ctx, st, wsb)
+	require.NoError(t, err)
+	b.Block.StateRoot = root[:]
+	wsb, err = blocks.NewSignedBeaconBlock(b)
+	require.NoError(t, err)
+	return wsb
+}
+
+// buildChain saves a chain with blocks at the given slots, finalized at the last block, and returns the block roots
+// along with the state at the start of era 1, which must be the slot of a block.
+func buildChain(t *testing.T, db *kv.Store, blockSlots []primitives.Slot) ([][32]byte, state.BeaconState) {
+	ctx := context.Background()
+	st, _ := capellaGenesisState(t, 64)
+	require.NoError(t, db.SaveGenesisData(ctx, st))
+	var (
+		roots    [][32]byte
+		eraState state.BeaconState
+		err      error
+	)
+	for _, slot := range blockSlots {
+		if slot-1 > st.Slot() {
+			st, err = transition.ProcessSlots(ctx, st, slot-1)
+			require.NoError(t, err)
+		}
+		if slot == StateSlot(1) {
+			s, err := transition.ProcessSlots(ctx, st.Copy(), slot)
+			require.NoError(t, err)
+			eraState = s
+		}
+		wsb := generateBlock(t, st, slot)
+		_, st, err = transition.ExecuteStateTransitionNoVerifyAnySig(ctx, st, wsb)
+		require.NoError(t, err)
+		root, err := wsb.Block().HashTreeRoot()
+		require.NoError(t, err)
+		require.NoError(t, db.SaveBlock(ctx, wsb))
+		require.NoError(t, db.SaveStateSummary(ctx, &ethpb.StateSummary{Slot: slot, Root: root[:]}))
+		roots = append(roots, root)
+	}
+	last := roots[len(roots)-1]
+	require.NoError(t, db.SaveState(ctx, st, last))
+	require.NoError(t, db.SaveFinalizedCheckpoint(ctx, &ethpb.Checkpoint{
+		Epoch: primitives.Epoch(blockSlots[len(blockSlots)-1] / params.BeaconConfig().SlotsPerEpoch),
+		Root:  last[:],
+	}))
+	return roots, eraState
+}
+
+func TestExportImport(t *testing.T) {
+	setupEraConfig(t)
+	ctx := context.Background()
+	src, err := kv.NewKVStore(ctx, t.TempDir())
+	require.NoError(t, err)
+	sphr := params.BeaconConfig().SlotsPerHistoricalRoot
+	// The block at the first slot of era 2 must not be part of the state of era 1.
+	roots, eraState := buildChain(t, src, []primitives.Slot{1, 2, 5, sphr - 2, sphr})
+
+	latest, err := LatestFinalizedEra(ctx, src)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), latest)
+
+	dir := t.TempDir()
+	_, err = Export(ctx, &ExportConfig{BeaconDB: src, Dir: dir, StartEra: 0, EndEra: 2})
+	require.ErrorContains(t, "era 2 is not finalized", err)
+	files, err := Export(ctx, &ExportConfig{BeaconDB: src, Dir: dir, StartEra: 0, EndEra: 1})
+	require.NoError(t, err)
+	require.NoError(t, src.Close())
+	require.Equal(t, 2, len(files))
+	listed, err := Files(dir)
+	require.NoError(t, err)
+	require.DeepEqual(t, files, listed)
+
+	e, err := ReadFile(files[1])
+	require.NoError(t, err)
+	require.NoError(t, e.Verify())
+	require.Equal(t, 4, len(e.Blocks))
+	for i, b := range e.Blocks {
+		assert.Equal(t, roots[i], b.Root)
+	}
+	want, err := eraState.HashTreeRoot(ctx)
+	require.NoError(t, err)
+	got, err := e.State.HashTreeRoot(ctx)
+	require.NoError(t, err)
+	require.Equal(t, want, got)
+
+	dst, err := kv.NewKVStore(ctx, t.TempDir())
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, dst.Close())
+	}()
+	err = Import(ctx, &ImportConfig{BeaconDB: dst, Files: files[1:]})
+	require.ErrorContains(t, "is missing from the db", err)
+	require.NoError(t, Import(ctx, &ImportConfig{BeaconDB: dst, Files: files}))
+	for _, r := range roots[:4] {
+		assert.Equal(t, true, dst.HasBlock(ctx, r))
+	}
+	assert.Equal(t, false, dst.HasBlock(ctx, roots[4]))
+	cp, err := dst.FinalizedCheckpoint(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, roots[3], bytesutil.ToBytes32(cp.Root))
+	head, err := dst.HeadBlock(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, sphr-2, head.Block().Slot())
+	st, err := dst.State(ctx, roots[3])
+	require.NoError(t, err)
+	assert.Equal(t, StateSlot(1), st.Slot())
+
+	// Importing again skips the eras that are already in the db.
+	require.NoError(t, Import(ctx, &ImportConfig{BeaconDB: dst, Files: files}))
+}
+
+func TestDecode_Corrupt(t *testing.T) {
+	setupEraConfig(t)
+	ctx := context.Background()
+	st, _ := capellaGenesisState(t, 16)
+	w, err := createEra(t.TempDir(), 0)
+	require.NoError(t, err)
+	path, err := w.finish(st)
+	require.NoError(t, err)
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	e, err := Decode(b)
+	require.NoError(t, err)
+	require.NoError(t, e.Verify())
+	want, err := st.HashTreeRoot(ctx)
+	require.NoError(t, err)
+	got, err := e.State.HashTreeRoot(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+
+	_, err = Decode(b[:len(b)-1])
+	require.ErrorIs(t, err, errCorruptFile)
+
+	// The state index must point to the state.
+	corrupt := append([]byte{}, b...)
+	corrupt[len(corrupt)-16]++
+	_, err = Decode(corrupt)
+	require.ErrorIs(t, err, errCorruptFile)
+}
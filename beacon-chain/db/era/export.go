@@ -0,0 +1,380 @@
+package era
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/transition"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/filesystem"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/iface"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+	"github.com/sirupsen/logrus"
+)
+
+// ExportConfig defines the database to export eras from, the blob storage to read blob sidecars from, which may be
+// nil, the directory to write the era files to, and the inclusive range of eras to export.
+type ExportConfig struct {
+	BeaconDB iface.ReadOnlyDatabase
+	Blobs    *filesystem.BlobStorage
+	Dir      string
+	StartEra uint64
+	EndEra   uint64
+}
+
+// LatestFinalizedEra returns the latest era whose blocks are all finalized in the database.
+func LatestFinalizedEra(ctx context.Context, db iface.ReadOnlyDatabase) (uint64, error) {
+	cp, err := db.FinalizedCheckpoint(ctx)
+	if err != nil {
+		return 0, errors.Wrap(err, "could not get finalized checkpoint")
+	}
+	blk, err := db.Block(ctx, bytesutil.ToBytes32(cp.Root))
+	if err != nil {
+		return 0, errors.Wrap(err, "could not get finalized block")
+	}
+	if blk == nil || blk.IsNil() {
+		return 0, fmt.Errorf("finalized block %#x is missing", cp.Root)
+	}
+	return uint64((blk.Block().Slot() + 1) / params.BeaconConfig().SlotsPerHistoricalRoot), nil
+}
+
+// Export writes an era file for every era of the configured range, and returns their paths. The states of the eras
+// are regenerated by replaying the canonical chain from the closest saved state.
+func Export(ctx context.Context, cfg *ExportConfig) ([]string, error) {
+	if cfg.StartEra > cfg.EndEra {
+		return nil, fmt.Errorf("start era %d is after end era %d", cfg.StartEra, cfg.EndEra)
+	}
+	latest, err := LatestFinalizedEra(ctx, cfg.BeaconDB)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.EndEra > latest {
+		return nil, fmt.Errorf("era %d is not finalized, the latest finalized era is %d", cfg.EndEra, latest)
+	}
+
+	var files []string
+	start := cfg.StartEra
+	if start == 0 {
+		st, err := cfg.BeaconDB.GenesisState(ctx)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not get genesis state")
+		}
+		if st == nil || st.IsNil() {
+			return nil, errors.New("genesis state is missing")
+		}
+		w, err := createEra(cfg.Dir, 0)
+		if err != nil {
+			return nil, err
+		}
+		f, err := w.finish(st)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, f)
+		if cfg.EndEra == 0 {
+			return files, nil
+		}
+		start = 1
+	}
+
+	chain, base, baseRoot, err := exportChain(ctx, cfg.BeaconDB, start, cfg.EndEra)
+	if err != nil {
+		return nil, err
+	}
+	log.WithFields(logrus.Fields{
+		"startEra":  start,
+		"endEra":    cfg.EndEra,
+		"startSlot": base.Slot(),
+		"blocks":    len(chain),
+	}).Info("Replaying chain to export eras")
+
+	era := start
+	w, err := createEra(cfg.Dir, era)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if w != nil {
+			w.abort()
+		}
+	}()
+	finish := func() error {
+		st, err := processSlots(ctx, base, StateSlot(era))
+		if err != nil {
+			return err
+		}
+		base = st
+		f, err := w.finish(st)
+		w = nil
+		if err != nil {
+			return err
+		}
+		files = append(files, f)
+		log.WithFields(logrus.Fields{"era": era, "file": f}).Info("Exported era")
+		era++
+		if era <= cfg.EndEra {
+			w, err = createEra(cfg.Dir, era)
+		}
+		return err
+	}
+
+	replaying := false
+	for _, c := range chain {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		for c.slot >= StateSlot(era) {
+			if err := finish(); err != nil {
+				return nil, err
+			}
+		}
+		blk, err := cfg.BeaconDB.Block(ctx, c.root)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not get block %#x", c.root)
+		}
+		// The genesis block is not exported, as it is derived from the genesis state.
+		if c.slot > 0 && c.slot >= StartSlot(era) {
+			if err := w.addBlock(blk, cfg.Blobs, c.root); err != nil {
+				return nil, err
+			}
+		}
+		if replaying {
+			if _, base, err = transition.ExecuteStateTransitionNoVerifyAnySig(ctx, base, blk); err != nil {
+				return nil, errors.Wrapf(err, "could not replay block at slot %d", c.slot)
+			}
+		}
+		if c.root == baseRoot {
+			replaying = true
+		}
+	}
+	for era <= cfg.EndEra {
+		if err := finish(); err != nil {
+			return nil, err
+		}
+	}
+	return files, nil
+}
+
+type chainBlock struct {
+	root [32]byte
+	slot primitives.Slot
+}
+
+// exportChain returns the canonical blocks from the start of the start era, or from an earlier block with a saved
+// state, to the end of the end era, along with the state to replay them from and the root of its block. The chain is
+// walked back from the finalized block.
+func exportChain(
+	ctx context.Context,
+	db iface.ReadOnlyDatabase,
+	start, end uint64,
+) ([]chainBlock, state.BeaconState, [32]byte, error) {
+	cp, err := db.FinalizedCheckpoint(ctx)
+	if err != nil {
+		return nil, nil, [32]byte{}, errors.Wrap(err, "could not get finalized checkpoint")
+	}
+	var (
+		chain    []chainBlock
+		base     state.BeaconState
+		baseRoot [32]byte
+	)
+	root := bytesutil.ToBytes32(cp.Root)
+	for {
+		if ctx.Err() != nil {
+			return nil, nil, [32]byte{}, ctx.Err()
+		}
+		blk, err := db.Block(ctx, root)
+		if err != nil {
+			return nil, nil, [32]byte{}, errors.Wrapf(err, "could not get block %#x", root)
+		}
+		if blk == nil || blk.IsNil() {
+			return nil, nil, [32]byte{}, fmt.Errorf("block %#x is missing from the db", root)
+		}
+		slot := blk.Block().Slot()
+		if slot < StateSlot(end) {
+			chain = append(chain, chainBlock{root: root, slot: slot})
+		}
+		// The replay must not include a block at the slot of the first era state.
+		if base == nil && slot < StateSlot(start) && db.HasState(ctx, root) {
+			st, err := db.State(ctx, root)
+			if err != nil {
+				return nil, nil, [32]byte{}, errors.Wrapf(err, "could not get state of block %#x", root)
+			}
+			if st.Slot() <= StateSlot(start) {
+				base, baseRoot = st, root
+			}
+		}
+		if base != nil && slot < StartSlot(start) {
+			break
+		}
+		root = blk.Block().ParentRoot()
+		if root == [32]byte{} {
+			if base == nil {
+				return nil, nil, [32]byte{}, fmt.Errorf("no state saved before slot %d", StateSlot(start))
+			}
+			break
+		}
+	}
+	slices.Reverse(chain)
+	return chain, base, baseRoot, nil
+}
+
+func processSlots(ctx context.Context, st state.BeaconState, slot primitives.Slot) (state.BeaconState, error) {
+	if st.Slot() >= slot {
+		return st, nil
+	}
+	st, err := transition.ProcessSlots(ctx, st, slot)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not process slots to %d", slot)
+	}
+	return st, nil
+}
+
+// eraWriter writes an era file to a temporary file, which is renamed once the era state is known.
+type eraWriter struct {
+	dir    string
+	era    uint64
+	f      *os.File
+	buf    *bufio.Writer
+	w      *writer
+	blocks []int64
+}
+
+func createEra(dir string, era uint64) (*eraWriter, error) {
+	f, err := os.CreateTemp(dir, fmt.Sprintf("%05d-*.partial", era))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create era file")
+	}
+	buf := bufio.NewWriter(f)
+	w := &eraWriter{
+		dir:    dir,
+		era:    era,
+		f:      f,
+		buf:    buf,
+		w:      &writer{w: buf},
+		blocks: make([]int64, params.BeaconConfig().SlotsPerHistoricalRoot),
+	}
+	if _, err := w.w.write(typeVersion, nil); err != nil {
+		w.abort()
+		return nil, err
+	}
+	return w, nil
+}
+
+// addBlock writes a block of the era, followed by its blob sidecars.
+func (w *eraWriter) addBlock(blk interfaces.ReadOnlySignedBeaconBlock, blobs *filesystem.BlobStorage, root [32]byte) error {
+	ssz, err := blk.MarshalSSZ()
+	if err != nil {
+		return errors.Wrapf(err, "could not marshal block %#x", root)
+	}
+	typ := typeBlock
+	if blk.IsBlinded() {
+		typ = typeBlindedBlock
+	}
+	offset, err := w.w.writeCompressed(typ, ssz)
+	if err != nil {
+		return err
+	}
+	w.blocks[blk.Block().Slot()-StartSlot(w.era)] = offset
+	if blobs == nil || blk.Version() < version.Deneb {
+		return nil
+	}
+	indices, err := blobs.Indices(root)
+	if err != nil {
+		return errors.Wrapf(err, "could not get blob sidecar indices of block %#x", root)
+	}
+	for i := uint64(0); i < fieldparams.MaxBlobsPerBlock; i++ {
+		if !indices[i] {
+			continue
+		}
+		sc, err := blobs.Get(root, i)
+		if err != nil {
+			return errors.Wrapf(err, "could not get blob sidecar %d of block %#x", i, root)
+		}
+		ssz, err := sc.MarshalSSZ()
+		if err != nil {
+			return err
+		}
+		if _, err := w.w.writeCompressed(typeBlobSidecar, ssz); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// finish writes the era state, the historical summary and the slot indices, and moves the file to its final name.
+func (w *eraWriter) finish(st state.BeaconState) (string, error) {
+	root := bytesutil.ToBytes32(st.GenesisValidatorsRoot())
+	err := func() error {
+		ssz, err := st.MarshalSSZ()
+		if err != nil {
+			return errors.Wrap(err, "could not marshal state")
+		}
+		stateOffset, err := w.w.writeCompressed(typeState, ssz)
+		if err != nil {
+			return err
+		}
+		if w.era > 0 {
+			summary, err := computeSummary(st)
+			if err != nil {
+				return err
+			}
+			if root, err = summary.HashTreeRoot(); err != nil {
+				return err
+			}
+			enc, err := summary.MarshalSSZ()
+			if err != nil {
+				return err
+			}
+			if _, err := w.w.write(typeHistoricalSummary, enc); err != nil {
+				return err
+			}
+			if _, err := w.w.write(typeSlotIndex, encodeSlotIndex(StartSlot(w.era), w.blocks, w.w.offset)); err != nil {
+				return err
+			}
+		}
+		if _, err := w.w.write(typeSlotIndex, encodeSlotIndex(st.Slot(), []int64{stateOffset}, w.w.offset)); err != nil {
+			return err
+		}
+		if err := w.buf.Flush(); err != nil {
+			return err
+		}
+		return w.f.Sync()
+	}()
+	if err != nil {
+		w.abort()
+		return "", err
+	}
+	if err := w.f.Close(); err != nil {
+		w.remove()
+		return "", err
+	}
+	path := filepath.Join(w.dir, FileName(params.BeaconConfig().ConfigName, w.era, root))
+	if err := os.Rename(w.f.Name(), path); err != nil {
+		w.remove()
+		return "", errors.Wrap(err, "could not rename era file")
+	}
+	return path, nil
+}
+
+func (w *eraWriter) abort() {
+	if err := w.f.Close(); err != nil && !errors.Is(err, os.ErrClosed) {
+		log.WithError(err).Error("Could not close era file")
+	}
+	w.remove()
+}
+
+func (w *eraWriter) remove() {
+	if err := os.Remove(w.f.Name()); err != nil && !os.IsNotExist(err) {
+		log.WithError(err).Error("Could not remove partial era file")
+	}
+}
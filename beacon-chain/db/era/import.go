@@ -0,0 +1,177 @@
+package era
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/filesystem"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/iface"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+	"github.com/sirupsen/logrus"
+)
+
+// ImportConfig defines the database to import eras into, the blob storage to save blob sidecars to, which may be nil
+// if the eras hold no blob sidecars, and the era files to import, ordered by era number.
+type ImportConfig struct {
+	BeaconDB iface.HeadAccessDatabase
+	Blobs    *filesystem.BlobStorage
+	Files    []string
+}
+
+// Import verifies and imports consecutive era files into an offline database. The first era must be era 0 for an
+// empty database, otherwise it must follow the blocks in the database. Every era state is saved, and its latest block
+// becomes the head and the finalized checkpoint of the database, so that the node resumes syncing from the last era.
+// Every era is also checked against the historical accumulator of the following eras before it is imported.
+func Import(ctx context.Context, cfg *ImportConfig) error {
+	var (
+		imported []*Era
+		previous *Era
+	)
+	for _, path := range cfg.Files {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		e, err := ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if err := e.Verify(); err != nil {
+			return errors.Wrapf(err, "could not verify %s", path)
+		}
+		if previous != nil && e.Number != previous.Number+1 {
+			return fmt.Errorf("era %d does not follow era %d", e.Number, previous.Number)
+		}
+		for _, p := range imported {
+			if err := p.VerifyAccumulator(e.State); err != nil {
+				return errors.Wrapf(err, "could not verify %s", path)
+			}
+		}
+
+		if e.Number == 0 {
+			err = importGenesis(ctx, cfg.BeaconDB, e)
+		} else {
+			err = importEra(ctx, cfg, e, previous)
+		}
+		if err != nil {
+			return errors.Wrapf(err, "could not import %s", path)
+		}
+		log.WithFields(logrus.Fields{
+			"era":    e.Number,
+			"blocks": len(e.Blocks),
+			"file":   path,
+		}).Info("Imported era")
+
+		// Only the historical summary of the era is needed to verify the following eras.
+		imported = append(imported, &Era{Number: e.Number, Summary: e.Summary})
+		previous = e
+	}
+	return nil
+}
+
+func importGenesis(ctx context.Context, db iface.HeadAccessDatabase, e *Era) error {
+	st, err := db.GenesisState(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not get genesis state")
+	}
+	if st == nil || st.IsNil() {
+		return db.SaveGenesisData(ctx, e.State)
+	}
+	want, err := st.HashTreeRoot(ctx)
+	if err != nil {
+		return err
+	}
+	got, err := e.State.HashTreeRoot(ctx)
+	if err != nil {
+		return err
+	}
+	if want != got {
+		return fmt.Errorf("genesis state %#x differs from the genesis state %#x in the db", got, want)
+	}
+	return nil
+}
+
+func importEra(ctx context.Context, cfg *ImportConfig, e *Era, previous *Era) error {
+	db := cfg.BeaconDB
+	latest := e.latestBlockRoot()
+	if db.HasBlock(ctx, latest) && db.HasState(ctx, latest) {
+		log.WithField("era", e.Number).Info("Era is already in the db, skipping")
+		return nil
+	}
+
+	// The era must follow the previous era, or the blocks in the db.
+	parent := latest
+	if len(e.Blocks) > 0 {
+		parent = e.Blocks[0].Block.Block().ParentRoot()
+	}
+	if previous != nil && previous.Number > 0 && parent != previous.latestBlockRoot() {
+		return fmt.Errorf("era %d does not follow the latest block of era %d", e.Number, previous.Number)
+	}
+	if !db.HasBlock(ctx, parent) {
+		return fmt.Errorf("block %#x preceding era %d is missing from the db", parent, e.Number)
+	}
+
+	blks := make([]interfaces.ReadOnlySignedBeaconBlock, len(e.Blocks))
+	summaries := make([]*ethpb.StateSummary, len(e.Blocks))
+	for i, b := range e.Blocks {
+		blks[i] = b.Block
+		summaries[i] = &ethpb.StateSummary{Slot: b.Block.Block().Slot(), Root: b.Root[:]}
+	}
+	if err := db.SaveBlocks(ctx, blks); err != nil {
+		return errors.Wrap(err, "could not save blocks")
+	}
+	if err := db.SaveStateSummaries(ctx, summaries); err != nil {
+		return errors.Wrap(err, "could not save state summaries")
+	}
+	if err := saveBlobs(cfg.Blobs, e); err != nil {
+		return err
+	}
+	if err := db.SaveState(ctx, e.State, latest); err != nil {
+		return errors.Wrap(err, "could not save era state")
+	}
+
+	blk, err := db.Block(ctx, latest)
+	if err != nil {
+		return errors.Wrapf(err, "could not get block %#x", latest)
+	}
+	cp := &ethpb.Checkpoint{Epoch: slots.ToEpoch(blk.Block().Slot()), Root: latest[:]}
+	finalized, err := db.FinalizedCheckpoint(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not get finalized checkpoint")
+	}
+	if cp.Epoch < finalized.Epoch {
+		return nil
+	}
+	if err := db.SaveJustifiedCheckpoint(ctx, cp); err != nil {
+		return errors.Wrap(err, "could not save justified checkpoint")
+	}
+	if err := db.SaveFinalizedCheckpoint(ctx, cp); err != nil {
+		return errors.Wrap(err, "could not save finalized checkpoint")
+	}
+	return errors.Wrap(db.SaveHeadBlockRoot(ctx, latest), "could not save head block root")
+}
+
+func saveBlobs(bs *filesystem.BlobStorage, e *Era) error {
+	for _, b := range e.Blocks {
+		if len(b.Blobs) == 0 {
+			continue
+		}
+		if bs == nil {
+			return fmt.Errorf("era %d holds blob sidecars, but no blob storage is configured", e.Number)
+		}
+		for _, sc := range b.Blobs {
+			ro, err := blocks.NewROBlobWithRoot(sc, b.Root)
+			if err != nil {
+				return err
+			}
+			// The blob sidecars were verified with the era.
+			if err := bs.Save(blocks.NewVerifiedROBlob(ro)); err != nil {
+				return errors.Wrapf(err, "could not save blob sidecar %d of block %#x", sc.Index, b.Root)
+			}
+		}
+	}
+	return nil
+}
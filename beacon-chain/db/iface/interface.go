@@ -11,6 +11,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/filters"
 	slashertypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/slasher/types"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	tokenomicstypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/tokenomics/types"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
@@ -41,6 +42,10 @@ type ReadOnlyDatabase interface {
 	StateSummary(ctx context.Context, blockRoot [32]byte) (*ethpb.StateSummary, error)
 	HasStateSummary(ctx context.Context, blockRoot [32]byte) bool
 	HighestSlotStatesBelow(ctx context.Context, slot primitives.Slot) ([]state.ReadOnlyBeaconState, error)
+	HierarchicalState(ctx context.Context, slot primitives.Slot) (state.BeaconState, error)
+	HasHierarchicalState(ctx context.Context, slot primitives.Slot) bool
+	IsHierarchicalStateSlot(slot primitives.Slot) bool
+	LowestAvailableSlot(ctx context.Context) (primitives.Slot, error)
 	// Checkpoint operations.
 	JustifiedCheckpoint(ctx context.Context) (*ethpb.Checkpoint, error)
 	FinalizedCheckpoint(ctx context.Context) (*ethpb.Checkpoint, error)
@@ -60,6 +65,10 @@ type ReadOnlyDatabase interface {
 	// origin checkpoint sync support
 	OriginCheckpointBlockRoot(ctx context.Context) ([32]byte, error)
 	BackfillStatus(context.Context) (*dbval.BackfillStatus, error)
+
+	// Tokenomics related methods.
+	TokenomicsSnapshots(ctx context.Context, startEpoch, endEpoch primitives.Epoch) ([]*tokenomicstypes.EpochSnapshot, error)
+	LastTokenomicsSnapshot(ctx context.Context) (*tokenomicstypes.EpochSnapshot, error)
 }
 
 // NoHeadAccessDatabase defines a struct without access to chain head data.
@@ -79,6 +88,8 @@ type NoHeadAccessDatabase interface {
 	DeleteStates(ctx context.Context, blockRoots [][32]byte) error
 	SaveStateSummary(ctx context.Context, summary *ethpb.StateSummary) error
 	SaveStateSummaries(ctx context.Context, summaries []*ethpb.StateSummary) error
+	SaveHierarchicalState(ctx context.Context, state state.ReadOnlyBeaconState) error
+	PruneHistory(ctx context.Context, pruneBefore primitives.Slot, batchSize uint64) (int, error)
 	// Checkpoint operations.
 	SaveJustifiedCheckpoint(ctx context.Context, checkpoint *ethpb.Checkpoint) error
 	SaveFinalizedCheckpoint(ctx context.Context, checkpoint *ethpb.Checkpoint) error
@@ -92,6 +103,8 @@ type NoHeadAccessDatabase interface {
 	// Fee recipients operations.
 	SaveFeeRecipientsByValidatorIDs(ctx context.Context, ids []primitives.ValidatorIndex, addrs []common.Address) error
 	SaveRegistrationsByValidatorIDs(ctx context.Context, ids []primitives.ValidatorIndex, regs []*ethpb.ValidatorRegistrationV1) error
+	// Tokenomics related methods.
+	SaveTokenomicsSnapshots(ctx context.Context, snapshots []*tokenomicstypes.EpochSnapshot) error
 
 	CleanUpDirtyStates(ctx context.Context, slotsPerArchivedPoint primitives.Slot) error
 }
@@ -112,6 +125,10 @@ type HeadAccessDatabase interface {
 	SaveOrigin(ctx context.Context, serState, serBlock []byte) error
 	SaveBackfillStatus(context.Context, *dbval.BackfillStatus) error
 	BackfillFinalizedIndex(ctx context.Context, blocks []blocks.ROBlock, finalizedChildRoot [32]byte) error
+
+	// Fork choice snapshot operations.
+	ForkchoiceSnapshot(ctx context.Context) ([]byte, error)
+	SaveForkchoiceSnapshot(ctx context.Context, enc []byte) error
 }
 
 // SlasherDatabase interface for persisting data related to detecting slashable offenses on Ethereum.
@@ -161,6 +178,8 @@ type SlasherDatabase interface {
 	DatabasePath() string
 	ClearDB() error
 	Migrate(ctx context.Context, headEpoch, maxPruningEpoch primitives.Epoch, batchSize int) error
+	Stats(ctx context.Context) (*slashertypes.DatabaseStats, error)
+	Summary(ctx context.Context) (*slashertypes.DatabaseStats, error)
 }
 
 // Database interface with full access.
@@ -171,4 +190,5 @@ type Database interface {
 
 	DatabasePath() string
 	ClearDB() error
+	Sync() error
 }
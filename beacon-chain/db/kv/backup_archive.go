@@ -0,0 +1,596 @@
+package kv
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v5/monitoring/tracing/trace"
+	"github.com/prysmaticlabs/prysm/v5/proto/dbval"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	bolt "go.etcd.io/bbolt"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	backupArchiveVersion = 1
+	backupManifestName   = "manifest.json"
+	backupBucketsDir     = "buckets/"
+	// backupChunkSize is the size above which the records of a bucket are split into another archive file,
+	// so that a bucket never has to be held in memory at once.
+	backupChunkSize = 64 * 1024 * 1024
+)
+
+var (
+	lastBackupManifestKey = []byte("last-backup-manifest")
+
+	// ErrNoPreviousBackup is returned when an incremental backup is requested from a database
+	// which has never been backed up.
+	ErrNoPreviousBackup = errors.New("no previous backup to take an incremental backup from")
+	errInvalidBackup    = errors.New("invalid backup archive")
+)
+
+// BackupFile describes a file of a backup archive.
+type BackupFile struct {
+	Name     string `json:"name"`
+	Size     int64  `json:"size"`
+	Records  int    `json:"records"`
+	Checksum string `json:"sha256"`
+}
+
+// BackupManifest describes the contents of a backup archive. A full backup has no base. An incremental
+// backup carries the blocks, states and their indices of the slots in (FromSlot, ToSlot], along with a full copy
+// of the small metadata buckets. FromSlot is the slot of the finalized block of its base, as the following
+// finalizations index the blocks and migrate the states after it. The blocks backfilled since its base, from
+// BackfillSlot up to the BackfillSlot of its base, and the state diffs written since its base, whose write sequence
+// is above the StateDiffSequence of its base, are carried too.
+type BackupManifest struct {
+	Version           int              `json:"version"`
+	ID                string           `json:"id"`
+	Base              string           `json:"base,omitempty"`
+	FromSlot          primitives.Slot  `json:"from_slot"`
+	ToSlot            primitives.Slot  `json:"to_slot"`
+	GenesisRoot       string           `json:"genesis_root"`
+	FinalizedEpoch    primitives.Epoch `json:"finalized_epoch"`
+	FinalizedRoot     string           `json:"finalized_root"`
+	FinalizedSlot     primitives.Slot  `json:"finalized_slot"`
+	BackfillSlot      primitives.Slot  `json:"backfill_slot,omitempty"`
+	StateDiffSequence uint64           `json:"state_diff_sequence"`
+	Files             []BackupFile     `json:"files"`
+}
+
+// Incremental returns true if the backup only carries the changes since its base.
+func (m *BackupManifest) Incremental() bool {
+	return m.Base != ""
+}
+
+func (m *BackupManifest) computeID() string {
+	h := sha256.New()
+	h.Write([]byte(m.Base))
+	h.Write(bytesutil.SlotToBytesBigEndian(m.FromSlot))
+	h.Write(bytesutil.SlotToBytesBigEndian(m.ToSlot))
+	for _, f := range m.Files {
+		h.Write([]byte(f.Name))
+		h.Write([]byte(f.Checksum))
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// LastBackupManifest returns the manifest of the last backup streamed from, or restored into, the database.
+// It returns nil if there is none.
+func (s *Store) LastBackupManifest(ctx context.Context) (*BackupManifest, error) {
+	_, span := trace.StartSpan(ctx, "BeaconDB.LastBackupManifest")
+	defer span.End()
+
+	var m *BackupManifest
+	err := s.db.View(func(tx *bolt.Tx) error {
+		enc := tx.Bucket(chainMetadataBucket).Get(lastBackupManifestKey)
+		if enc == nil {
+			return nil
+		}
+		m = &BackupManifest{}
+		return json.Unmarshal(enc, m)
+	})
+	return m, err
+}
+
+func (s *Store) saveLastBackupManifest(m *BackupManifest) error {
+	enc, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(chainMetadataBucket).Put(lastBackupManifestKey, enc)
+	})
+}
+
+// StreamBackup writes a backup archive of the database to w, in the tar format. The archive holds the
+// records of each bucket, followed by a manifest with their checksums. If incremental is set, only the blocks,
+// states and indices of the slots after the finalized block of the last backup, and the ones backfilled or
+// migrated since, are written, and ErrNoPreviousBackup is returned if there is none. Incremental backups only add data, so history pruned since the base backup is still restored.
+// The whole archive is written from a single read transaction, so that it is consistent. The manifest is recorded
+// as the last backup manifest once the archive is written.
+func (s *Store) StreamBackup(ctx context.Context, w io.Writer, incremental bool) (*BackupManifest, error) {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.StreamBackup")
+	defer span.End()
+
+	m := &BackupManifest{Version: backupArchiveVersion}
+	var base *BackupManifest
+	if incremental {
+		var err error
+		base, err = s.LastBackupManifest(ctx)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not get last backup manifest")
+		}
+		if base == nil {
+			return nil, ErrNoPreviousBackup
+		}
+		m.Base = base.ID
+		m.FromSlot = min(base.FinalizedSlot, base.ToSlot)
+	}
+	if err := s.saveCachedStateSummariesDB(ctx); err != nil {
+		return nil, errors.Wrap(err, "could not save cached state summaries")
+	}
+
+	tw := tar.NewWriter(w)
+	err := s.db.View(func(tx *bolt.Tx) error {
+		if err := fillBackupManifest(ctx, tx, m); err != nil {
+			return err
+		}
+		var sel *backupSelection
+		if incremental {
+			var err error
+			if sel, err = newBackupSelection(tx, m, base); err != nil {
+				return err
+			}
+		}
+		return tx.ForEach(func(name []byte, b *bolt.Bucket) error {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return writeBackupBucket(tw, m, name, b, sel)
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	m.ID = m.computeID()
+	enc, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := writeBackupFile(tw, backupManifestName, enc); err != nil {
+		return nil, err
+	}
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	if err := s.saveLastBackupManifest(m); err != nil {
+		return nil, errors.Wrap(err, "could not save backup manifest")
+	}
+	return m, nil
+}
+
+func fillBackupManifest(ctx context.Context, tx *bolt.Tx, m *BackupManifest) error {
+	if k, _ := tx.Bucket(blockSlotIndicesBucket).Cursor().Last(); k != nil {
+		m.ToSlot = bytesutil.BytesToSlotBigEndian(k)
+	}
+	if m.ToSlot < m.FromSlot {
+		m.ToSlot = m.FromSlot
+	}
+	m.GenesisRoot = fmt.Sprintf("%#x", tx.Bucket(blocksBucket).Get(genesisBlockRootKey))
+	if enc := tx.Bucket(checkpointBucket).Get(finalizedCheckpointKey); enc != nil {
+		cp := &ethpb.Checkpoint{}
+		if err := decode(ctx, enc, cp); err != nil {
+			return errors.Wrap(err, "could not decode finalized checkpoint")
+		}
+		m.FinalizedEpoch = cp.Epoch
+		m.FinalizedRoot = fmt.Sprintf("%#x", cp.Root)
+		if enc := tx.Bucket(blocksBucket).Get(cp.Root); enc != nil {
+			blk, err := unmarshalBlock(ctx, enc)
+			if err != nil {
+				return errors.Wrap(err, "could not decode finalized block")
+			}
+			m.FinalizedSlot = blk.Block().Slot()
+		}
+	}
+	if enc := tx.Bucket(blocksBucket).Get(backfillStatusKey); len(enc) != 0 {
+		bf := &dbval.BackfillStatus{}
+		if err := proto.Unmarshal(enc, bf); err != nil {
+			return errors.Wrap(err, "could not decode backfill status")
+		}
+		m.BackfillSlot = primitives.Slot(bf.LowSlot)
+	}
+	m.StateDiffSequence = tx.Bucket(stateDiffsBucket).Sequence()
+	return nil
+}
+
+// backupSelection selects the records of an incremental backup.
+type backupSelection struct {
+	fromSlot primitives.Slot
+	// backfill is set when blocks were backfilled since the base, in the slots [backfillLow, backfillHigh].
+	backfill        bool
+	backfillLow     primitives.Slot
+	backfillHigh    primitives.Slot
+	stateDiffSeq    uint64
+	stateDiffWrites *bolt.Bucket
+	roots           map[[32]byte]bool
+	parents         map[[32]byte]bool
+	validatorHashes map[[32]byte]bool
+}
+
+// rootKeyedBuckets hold records keyed by block root. Keys of other lengths in these buckets,
+// such as the genesis root key, are always selected.
+var rootKeyedBuckets = map[string]bool{
+	string(blocksBucket):                   true,
+	string(stateBucket):                    true,
+	string(stateSummaryBucket):             true,
+	string(blockRootValidatorHashesBucket): true,
+}
+
+// slotKeyedBuckets hold records keyed by slot.
+var slotKeyedBuckets = map[string]bool{
+	string(blockSlotIndicesBucket): true,
+	string(stateSlotIndicesBucket): true,
+}
+
+func newBackupSelection(tx *bolt.Tx, m, base *BackupManifest) (*backupSelection, error) {
+	sel := &backupSelection{
+		fromSlot:        m.FromSlot,
+		stateDiffSeq:    base.StateDiffSequence,
+		stateDiffWrites: tx.Bucket(stateDiffWritesBucket),
+		roots:           make(map[[32]byte]bool),
+		parents:         make(map[[32]byte]bool),
+		validatorHashes: make(map[[32]byte]bool),
+	}
+	if m.BackfillSlot < base.BackfillSlot {
+		sel.backfill = true
+		sel.backfillLow = m.BackfillSlot
+		sel.backfillHigh = base.BackfillSlot
+	}
+	for _, bkt := range [][]byte{blockSlotIndicesBucket, stateSlotIndicesBucket} {
+		c := tx.Bucket(bkt).Cursor()
+		k, v := c.First()
+		if !sel.backfill {
+			k, v = c.Seek(bytesutil.SlotToBytesBigEndian(sel.fromSlot + 1))
+		}
+		for ; k != nil; k, v = c.Next() {
+			if !sel.selectedSlot(bytesutil.BytesToSlotBigEndian(k)) {
+				continue
+			}
+			for i := 0; i+32 <= len(v); i += 32 {
+				sel.roots[bytesutil.ToBytes32(v[i:i+32])] = true
+			}
+		}
+	}
+	// The parent root index is keyed by the parent root, so the parents of the selected blocks are selected too.
+	err := tx.Bucket(blockParentRootIndicesBucket).ForEach(func(k, v []byte) error {
+		for i := 0; i+32 <= len(v); i += 32 {
+			if sel.roots[bytesutil.ToBytes32(v[i:i+32])] {
+				sel.parents[bytesutil.ToBytes32(k)] = true
+				return nil
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	for root := range sel.roots {
+		v := tx.Bucket(blockRootValidatorHashesBucket).Get(root[:])
+		for i := 0; i+32 <= len(v); i += 32 {
+			sel.validatorHashes[bytesutil.ToBytes32(v[i:i+32])] = true
+		}
+	}
+	return sel, nil
+}
+
+func (sel *backupSelection) selected(bucket string, k []byte) bool {
+	switch {
+	case sel == nil:
+		return true
+	case bucket == string(finalizedBlockRootsIndexBucket):
+		// The child root of the last block finalized before the selected slots is updated by the next finalization.
+		return len(k) != 32 || sel.roots[bytesutil.ToBytes32(k)] || sel.parents[bytesutil.ToBytes32(k)]
+	case rootKeyedBuckets[bucket]:
+		return len(k) != 32 || sel.roots[bytesutil.ToBytes32(k)]
+	case slotKeyedBuckets[bucket]:
+		return len(k) != 8 || sel.selectedSlot(bytesutil.BytesToSlotBigEndian(k))
+	case bucket == string(stateDiffsBucket):
+		return len(k) != 8 || bytesutil.BytesToSlotBigEndian(k) > sel.fromSlot || sel.stateDiffWritten(k)
+	case bucket == string(blockParentRootIndicesBucket):
+		return sel.parents[bytesutil.ToBytes32(k)]
+	case bucket == string(stateValidatorsBucket):
+		return sel.validatorHashes[bytesutil.ToBytes32(k)]
+	default:
+		return true
+	}
+}
+
+// selectedSlot returns true if the records of the slot are selected, as they are after the finalized block
+// of the base or were backfilled since.
+func (sel *backupSelection) selectedSlot(slot primitives.Slot) bool {
+	return slot > sel.fromSlot || (sel.backfill && slot >= sel.backfillLow && slot <= sel.backfillHigh)
+}
+
+// stateDiffWritten returns true if the state diff of the slot was written since the base.
+func (sel *backupSelection) stateDiffWritten(k []byte) bool {
+	v := sel.stateDiffWrites.Get(k)
+	return len(v) == 8 && binary.BigEndian.Uint64(v) > sel.stateDiffSeq
+}
+
+func writeBackupBucket(tw *tar.Writer, m *BackupManifest, name []byte, b *bolt.Bucket, sel *backupSelection) error {
+	var buf bytes.Buffer
+	records := 0
+	chunk := 0
+	flush := func() error {
+		if records == 0 && chunk > 0 {
+			return nil
+		}
+		fileName := fmt.Sprintf("%s%s/%06d", backupBucketsDir, name, chunk)
+		sum := sha256.Sum256(buf.Bytes())
+		if err := writeBackupFile(tw, fileName, buf.Bytes()); err != nil {
+			return err
+		}
+		m.Files = append(m.Files, BackupFile{
+			Name:     fileName,
+			Size:     int64(buf.Len()),
+			Records:  records,
+			Checksum: hex.EncodeToString(sum[:]),
+		})
+		buf.Reset()
+		records = 0
+		chunk++
+		return nil
+	}
+	err := b.ForEach(func(k, v []byte) error {
+		// Nested buckets are not used by the beacon database.
+		if v == nil || !sel.selected(string(name), k) {
+			return nil
+		}
+		buf.Write(binary.AppendUvarint(nil, uint64(len(k))))
+		buf.Write(k)
+		buf.Write(binary.AppendUvarint(nil, uint64(len(v))))
+		buf.Write(v)
+		records++
+		if buf.Len() >= backupChunkSize {
+			return flush()
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return flush()
+}
+
+func writeBackupFile(tw *tar.Writer, name string, data []byte) error {
+	if err := tw.WriteHeader(&tar.Header{
+		Name: name,
+		Mode: 0600,
+		Size: int64(len(data)),
+	}); err != nil {
+		return err
+	}
+	_, err := tw.Write(data)
+	return err
+}
+
+// RestoreBackup applies the backup archive read from r to the database. The archive must be a full backup if
+// base is nil, or an incremental backup of base otherwise. The checksums of the archive files are verified against
+// the manifest only once the whole archive is read, so the archive should be restored into a new database which
+// is discarded if an error is returned. The manifest of the archive is returned, and recorded as the last backup
+// manifest, so that incremental backups of the restored database can follow. The chain metadata restored from a full
+// backup replaces the one of the database, such as its block storage type, so the database should be opened
+// WithoutBlockStorageSetup.
+func (s *Store) RestoreBackup(ctx context.Context, r io.Reader, base *BackupManifest) (*BackupManifest, error) {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.RestoreBackup")
+	defer span.End()
+
+	if base == nil {
+		if err := s.db.Update(func(tx *bolt.Tx) error {
+			if err := tx.DeleteBucket(chainMetadataBucket); err != nil {
+				return err
+			}
+			_, err := tx.CreateBucket(chainMetadataBucket)
+			return err
+		}); err != nil {
+			return nil, errors.Wrap(err, "could not clear chain metadata")
+		}
+	}
+
+	tr := tar.NewReader(r)
+	checksums := make(map[string]string)
+	var m *BackupManifest
+	for {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "could not read backup archive")
+		}
+		if m != nil {
+			return nil, errors.Wrapf(errInvalidBackup, "file %s follows the manifest", hdr.Name)
+		}
+		if hdr.Name == backupManifestName {
+			m = &BackupManifest{}
+			if err := json.NewDecoder(tr).Decode(m); err != nil {
+				return nil, errors.Wrap(err, "could not decode backup manifest")
+			}
+			continue
+		}
+		bucket, ok := strings.CutPrefix(hdr.Name, backupBucketsDir)
+		if !ok {
+			return nil, errors.Wrapf(errInvalidBackup, "unexpected file %s", hdr.Name)
+		}
+		// The records of a bucket are split into files named after their position, e.g. buckets/blocks/000001.
+		bucket, _, _ = strings.Cut(bucket, "/")
+		h := sha256.New()
+		if err := s.applyBackupRecords(bucket, io.TeeReader(tr, h)); err != nil {
+			return nil, errors.Wrapf(err, "could not restore %s", hdr.Name)
+		}
+		checksums[hdr.Name] = hex.EncodeToString(h.Sum(nil))
+	}
+	if m == nil {
+		return nil, errors.Wrap(errInvalidBackup, "manifest is missing")
+	}
+	if err := verifyBackupManifest(m, base, checksums); err != nil {
+		return nil, err
+	}
+	// The state diffs written after the restore must have a higher write sequence than the ones in the backup,
+	// for the next incremental backup to select them.
+	if err := s.db.Update(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket(stateDiffsBucket)
+		if bkt.Sequence() >= m.StateDiffSequence {
+			return nil
+		}
+		return bkt.SetSequence(m.StateDiffSequence)
+	}); err != nil {
+		return nil, errors.Wrap(err, "could not restore state diff sequence")
+	}
+	if err := s.saveLastBackupManifest(m); err != nil {
+		return nil, errors.Wrap(err, "could not save backup manifest")
+	}
+	return m, nil
+}
+
+func (s *Store) applyBackupRecords(bucket string, r io.Reader) error {
+	if bucket == "" {
+		return errors.Wrap(errInvalidBackup, "bucket name is missing")
+	}
+	br := bufio.NewReader(r)
+	return s.db.Update(func(tx *bolt.Tx) error {
+		b, err := tx.CreateBucketIfNotExists([]byte(bucket))
+		if err != nil {
+			return err
+		}
+		for {
+			k, err := readBackupField(br)
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			v, err := readBackupField(br)
+			if err != nil {
+				return errors.Wrap(errInvalidBackup, "truncated record")
+			}
+			if err := b.Put(k, v); err != nil {
+				return err
+			}
+		}
+	})
+}
+
+func readBackupField(r *bufio.Reader) ([]byte, error) {
+	n, err := binary.ReadUvarint(r)
+	if err != nil {
+		return nil, err
+	}
+	if n > backupChunkSize*16 {
+		return nil, errors.Wrapf(errInvalidBackup, "record of %d bytes is too large", n)
+	}
+	field := make([]byte, n)
+	if _, err := io.ReadFull(r, field); err != nil {
+		return nil, err
+	}
+	return field, nil
+}
+
+func verifyBackupManifest(m, base *BackupManifest, checksums map[string]string) error {
+	if m.Version != backupArchiveVersion {
+		return errors.Wrapf(errInvalidBackup, "unsupported version %d", m.Version)
+	}
+	if m.ID != m.computeID() {
+		return errors.Wrap(errInvalidBackup, "manifest ID does not match its contents")
+	}
+	if len(m.Files) != len(checksums) {
+		return errors.Wrapf(errInvalidBackup, "manifest lists %d files, but the archive has %d", len(m.Files), len(checksums))
+	}
+	for _, f := range m.Files {
+		sum, ok := checksums[f.Name]
+		if !ok {
+			return errors.Wrapf(errInvalidBackup, "file %s is missing", f.Name)
+		}
+		if sum != f.Checksum {
+			return errors.Wrapf(errInvalidBackup, "checksum of %s is %s, expected %s", f.Name, sum, f.Checksum)
+		}
+	}
+	switch {
+	case base == nil && m.Incremental():
+		return errors.Wrapf(errInvalidBackup, "incremental backup %s needs its base backup %s to be restored first", m.ID, m.Base)
+	case base != nil && m.Base != base.ID:
+		return errors.Wrapf(errInvalidBackup, "backup %s is not an incremental backup of %s", m.ID, base.ID)
+	case base != nil && m.FromSlot > base.ToSlot:
+		return errors.Wrapf(errInvalidBackup, "backup %s starts after slot %d, but its base ends at slot %d", m.ID, m.FromSlot, base.ToSlot)
+	case base != nil && m.GenesisRoot != base.GenesisRoot:
+		return errors.Wrapf(errInvalidBackup, "backup %s has genesis root %s, but its base has %s", m.ID, m.GenesisRoot, base.GenesisRoot)
+	}
+	return nil
+}
+
+// VerifyRestoredBackup checks that the genesis root and the finalized checkpoint of the database match the manifest
+// of the last restored backup, and that the genesis and finalized blocks and their states are stored. If the manifest
+// is nil, as for a database file restored without an archive, only the blocks and states are checked.
+func (s *Store) VerifyRestoredBackup(ctx context.Context, m *BackupManifest) error {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.VerifyRestoredBackup")
+	defer span.End()
+
+	var genesisRoot []byte
+	if err := s.db.View(func(tx *bolt.Tx) error {
+		genesisRoot = bytes.Clone(tx.Bucket(blocksBucket).Get(genesisBlockRootKey))
+		return nil
+	}); err != nil {
+		return err
+	}
+	if got := fmt.Sprintf("%#x", genesisRoot); m != nil && got != m.GenesisRoot {
+		return errors.Errorf("genesis root %s differs from the genesis root %s of the backup", got, m.GenesisRoot)
+	}
+	if len(genesisRoot) != 0 {
+		if !s.HasBlock(ctx, bytesutil.ToBytes32(genesisRoot)) {
+			return errors.Errorf("genesis block %#x is missing", genesisRoot)
+		}
+		if !s.HasState(ctx, bytesutil.ToBytes32(genesisRoot)) {
+			return errors.Errorf("genesis state %#x is missing", genesisRoot)
+		}
+	}
+
+	cp, err := s.FinalizedCheckpoint(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not get finalized checkpoint")
+	}
+	if got := fmt.Sprintf("%#x", cp.Root); m != nil && m.FinalizedRoot != "" && (got != m.FinalizedRoot || cp.Epoch != m.FinalizedEpoch) {
+		return errors.Errorf(
+			"finalized checkpoint %s at epoch %d differs from the finalized checkpoint %s at epoch %d of the backup",
+			got, cp.Epoch, m.FinalizedRoot, m.FinalizedEpoch,
+		)
+	}
+	root := bytesutil.ToBytes32(cp.Root)
+	if root == [32]byte{} {
+		return nil
+	}
+	if !s.HasBlock(ctx, root) {
+		return errors.Errorf("finalized block %#x is missing", root)
+	}
+	if !s.HasState(ctx, root) && !s.HasStateSummary(ctx, root) {
+		return errors.Errorf("state of the finalized block %#x is missing", root)
+	}
+	return nil
+}
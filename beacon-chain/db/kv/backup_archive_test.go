@@ -0,0 +1,238 @@
+package kv
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/proto/dbval"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+// restoreBackups restores the archives in order into a new database.
+func restoreBackups(t *testing.T, archives ...[]byte) (*Store, *BackupManifest, error) {
+	db, err := NewKVStore(context.Background(), t.TempDir(), WithoutBlockStorageSetup())
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, db.Close())
+	})
+	var m *BackupManifest
+	for _, a := range archives {
+		m, err = db.RestoreBackup(context.Background(), bytes.NewReader(a), m)
+		if err != nil {
+			return db, nil, err
+		}
+	}
+	return db, m, nil
+}
+
+func TestStore_StreamBackup(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	db, err := NewKVStore(ctx, dir)
+	require.NoError(t, err)
+
+	genesis, err := blocks.NewSignedBeaconBlock(util.NewBeaconBlock())
+	require.NoError(t, err)
+	gRoot, err := genesis.Block().HashTreeRoot()
+	require.NoError(t, err)
+	require.NoError(t, db.SaveBlock(ctx, genesis))
+	require.NoError(t, db.SaveGenesisBlockRoot(ctx, gRoot))
+	st, err := util.NewBeaconState()
+	require.NoError(t, err)
+	require.NoError(t, db.SaveState(ctx, st, gRoot))
+
+	blks := makeBlocks(t, 0, 20, gRoot)
+	roots := make([][32]byte, len(blks))
+	for i, b := range blks {
+		roots[i], err = b.Block().HashTreeRoot()
+		require.NoError(t, err)
+	}
+	require.NoError(t, db.SaveBlocks(ctx, blks[:10]))
+	require.NoError(t, db.SaveState(ctx, st, roots[7]))
+	require.NoError(t, db.SaveFinalizedCheckpoint(ctx, &ethpb.Checkpoint{Epoch: 0, Root: roots[7][:]}))
+
+	_, err = db.StreamBackup(ctx, io.Discard, true)
+	require.ErrorIs(t, err, ErrNoPreviousBackup)
+
+	var full bytes.Buffer
+	fullManifest, err := db.StreamBackup(ctx, &full, false)
+	require.NoError(t, err)
+	assert.Equal(t, false, fullManifest.Incremental())
+	assert.Equal(t, uint64(10), uint64(fullManifest.ToSlot))
+
+	require.NoError(t, db.SaveBlocks(ctx, blks[10:]))
+	require.NoError(t, db.SaveState(ctx, st, roots[15]))
+	require.NoError(t, db.SaveFinalizedCheckpoint(ctx, &ethpb.Checkpoint{Epoch: 1, Root: roots[15][:]}))
+
+	var incremental bytes.Buffer
+	incManifest, err := db.StreamBackup(ctx, &incremental, true)
+	require.NoError(t, err)
+	assert.Equal(t, fullManifest.ID, incManifest.Base)
+	// The incremental backup starts after the finalized block of its base.
+	assert.Equal(t, uint64(8), uint64(incManifest.FromSlot))
+	assert.Equal(t, uint64(20), uint64(incManifest.ToSlot))
+	blockRecords := 0
+	for _, f := range incManifest.Files {
+		if f.Name == backupBucketsDir+string(blocksBucket)+"/000000" {
+			blockRecords = f.Records
+		}
+	}
+	// The 12 blocks after the finalized block of the base and the genesis block root key.
+	assert.Equal(t, 13, blockRecords)
+	require.NoError(t, db.Close())
+
+	t.Run("full and incremental", func(t *testing.T) {
+		restored, m, err := restoreBackups(t, full.Bytes(), incremental.Bytes())
+		require.NoError(t, err)
+		require.NoError(t, restored.VerifyRestoredBackup(ctx, m))
+		for _, root := range roots {
+			assert.Equal(t, true, restored.HasBlock(ctx, root))
+		}
+		assert.Equal(t, true, restored.HasState(ctx, roots[15]))
+		r, err := restored.VerifyIntegrity(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, true, r.OK(), "unexpected issues: %v", r.Issues)
+		last, err := restored.LastBackupManifest(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, incManifest.ID, last.ID)
+	})
+	t.Run("full only", func(t *testing.T) {
+		restored, m, err := restoreBackups(t, full.Bytes())
+		require.NoError(t, err)
+		require.NoError(t, restored.VerifyRestoredBackup(ctx, m))
+		assert.Equal(t, false, restored.HasBlock(ctx, roots[15]))
+		// The finalized checkpoint differs from the one of the incremental backup.
+		require.ErrorContains(t, "differs from the finalized checkpoint", restored.VerifyRestoredBackup(ctx, incManifest))
+	})
+	t.Run("incremental without base", func(t *testing.T) {
+		_, _, err := restoreBackups(t, incremental.Bytes())
+		require.ErrorContains(t, "needs its base backup", err)
+	})
+	t.Run("out of order", func(t *testing.T) {
+		_, _, err := restoreBackups(t, full.Bytes(), full.Bytes())
+		require.ErrorIs(t, err, errInvalidBackup)
+	})
+	t.Run("corrupted", func(t *testing.T) {
+		var corrupted bytes.Buffer
+		tw := tar.NewWriter(&corrupted)
+		tr := tar.NewReader(bytes.NewReader(full.Bytes()))
+		for {
+			hdr, err := tr.Next()
+			if err == io.EOF {
+				break
+			}
+			require.NoError(t, err)
+			data, err := io.ReadAll(tr)
+			require.NoError(t, err)
+			if hdr.Name == backupBucketsDir+string(checkpointBucket)+"/000000" {
+				data[len(data)-1]++
+			}
+			require.NoError(t, writeBackupFile(tw, hdr.Name, data))
+		}
+		require.NoError(t, tw.Close())
+		_, _, err := restoreBackups(t, corrupted.Bytes())
+		require.ErrorContains(t, "checksum of", err)
+	})
+}
+
+func TestStore_StreamBackup_FinalizeAndMigrate(t *testing.T) {
+	ctx := context.Background()
+	db, err := NewKVStore(ctx, t.TempDir(), WithStateDiffExponents([]uint64{4, 2, 0}))
+	require.NoError(t, err)
+
+	genesis, err := blocks.NewSignedBeaconBlock(util.NewBeaconBlock())
+	require.NoError(t, err)
+	gRoot, err := genesis.Block().HashTreeRoot()
+	require.NoError(t, err)
+	require.NoError(t, db.SaveBlock(ctx, genesis))
+	require.NoError(t, db.SaveGenesisBlockRoot(ctx, gRoot))
+	st, err := util.NewBeaconState()
+	require.NoError(t, err)
+
+	blks := makeBlocks(t, 0, 20, gRoot)
+	roots := make([][32]byte, len(blks))
+	for i, b := range blks {
+		roots[i], err = b.Block().HashTreeRoot()
+		require.NoError(t, err)
+	}
+	// The node synced from the checkpoint at slot 9, and has not backfilled the blocks before it yet.
+	require.NoError(t, db.SaveBlocks(ctx, blks[8:]))
+	require.NoError(t, db.SaveOriginCheckpointBlockRoot(ctx, roots[8]))
+	require.NoError(t, db.SaveBackfillStatus(ctx, &dbval.BackfillStatus{
+		LowSlot:       9,
+		LowRoot:       roots[8][:],
+		LowParentRoot: roots[7][:],
+	}))
+	require.NoError(t, db.SaveState(ctx, st, roots[8]))
+	require.NoError(t, db.SaveFinalizedCheckpoint(ctx, &ethpb.Checkpoint{Epoch: 0, Root: roots[8][:]}))
+	require.NoError(t, db.SaveHierarchicalState(ctx, stateDiffTestState(t, 0)))
+
+	var full bytes.Buffer
+	fullManifest, err := db.StreamBackup(ctx, &full, false)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(20), uint64(fullManifest.ToSlot))
+	assert.Equal(t, uint64(9), uint64(fullManifest.FinalizedSlot))
+	assert.Equal(t, uint64(9), uint64(fullManifest.BackfillSlot))
+
+	// Backfill the blocks before the checkpoint.
+	require.NoError(t, db.SaveBlocks(ctx, blks[:8]))
+	require.NoError(t, db.SaveBackfillStatus(ctx, &dbval.BackfillStatus{
+		LowSlot:       1,
+		LowRoot:       roots[0][:],
+		LowParentRoot: gRoot[:],
+	}))
+	// Finalize slot 16, migrating the state of slot 12 to the cold section, and save a hierarchical state
+	// below the finalized slot of the base, all of them below the last slot of the base.
+	require.NoError(t, db.SaveState(ctx, st, roots[11]))
+	require.NoError(t, db.SaveFinalizedCheckpoint(ctx, &ethpb.Checkpoint{Epoch: 2, Root: roots[15][:]}))
+	require.NoError(t, db.SaveHierarchicalState(ctx, stateDiffTestState(t, 12)))
+	require.NoError(t, db.SaveHierarchicalState(ctx, stateDiffTestState(t, 4)))
+
+	var incremental bytes.Buffer
+	incManifest, err := db.StreamBackup(ctx, &incremental, true)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(9), uint64(incManifest.FromSlot))
+	assert.Equal(t, uint64(1), uint64(incManifest.BackfillSlot))
+	assert.Equal(t, uint64(16), uint64(incManifest.FinalizedSlot))
+	require.NoError(t, db.Close())
+
+	restored := setupStateDiffDB(t, []uint64{4, 2, 0})
+	m, err := restored.RestoreBackup(ctx, bytes.NewReader(full.Bytes()), nil)
+	require.NoError(t, err)
+	m, err = restored.RestoreBackup(ctx, bytes.NewReader(incremental.Bytes()), m)
+	require.NoError(t, err)
+	assert.Equal(t, incManifest.ID, m.ID)
+	for _, root := range roots {
+		assert.Equal(t, true, restored.HasBlock(ctx, root))
+	}
+	assert.Equal(t, true, restored.HasState(ctx, roots[11]))
+	for _, root := range roots[8:16] {
+		assert.Equal(t, true, restored.IsFinalizedBlock(ctx, root))
+	}
+	for _, slot := range []primitives.Slot{0, 4, 12} {
+		assert.Equal(t, true, restored.HasHierarchicalState(ctx, slot))
+	}
+	cp, err := restored.FinalizedCheckpoint(ctx)
+	require.NoError(t, err)
+	assert.DeepEqual(t, roots[15][:], cp.Root)
+
+	// The hierarchical states written after the restore are selected by the next incremental backup.
+	require.NoError(t, restored.SaveHierarchicalState(ctx, stateDiffTestState(t, 8)))
+	nextManifest, err := restored.StreamBackup(ctx, io.Discard, true)
+	require.NoError(t, err)
+	diffRecords := 0
+	for _, f := range nextManifest.Files {
+		if f.Name == backupBucketsDir+string(stateDiffsBucket)+"/000000" {
+			diffRecords = f.Records
+		}
+	}
+	assert.Equal(t, 1, diffRecords)
+}
@@ -0,0 +1,90 @@
+package kv
+
+import (
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	bolt "go.etcd.io/bbolt"
+)
+
+// compactTxMaxSize is the number of bytes copied in a single transaction while compacting.
+const compactTxMaxSize = 64 * 1024 * 1024
+
+// Compact rewrites the database file in the given directory into a new file without the free pages
+// left behind by deleted data, and replaces the original file with it. The database must not be in use.
+// It returns the sizes of the database file before and after the compaction.
+func Compact(dirPath string) (int64, int64, error) {
+	datafile := StoreDatafilePath(dirPath)
+	before, err := fileSize(datafile)
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "could not read database file")
+	}
+
+	src, err := bolt.Open(datafile, params.BeaconIoConfig().ReadWritePermissions, &bolt.Options{
+		Timeout:  1 * time.Second,
+		ReadOnly: true,
+	})
+	if err != nil {
+		if errors.Is(err, bolt.ErrTimeout) {
+			return 0, 0, errors.New("cannot obtain database lock, database may be in use by another process")
+		}
+		return 0, 0, err
+	}
+	defer func() {
+		if err := src.Close(); err != nil {
+			log.WithError(err).Error("Could not close database")
+		}
+	}()
+
+	compacted := datafile + ".compact"
+	if err := os.Remove(compacted); err != nil && !os.IsNotExist(err) {
+		return 0, 0, errors.Wrap(err, "could not remove a previous compaction file")
+	}
+	dst, err := bolt.Open(compacted, params.BeaconIoConfig().ReadWritePermissions, &bolt.Options{
+		NoSync:       true,
+		Timeout:      1 * time.Second,
+		FreelistType: bolt.FreelistMapType,
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+	dst.AllocSize = boltAllocSize
+	log.WithField("path", compacted).Info("Compacting database")
+	if err := bolt.Compact(dst, src, compactTxMaxSize); err != nil {
+		return 0, 0, cleanupCompaction(dst, compacted, errors.Wrap(err, "could not compact database"))
+	}
+	if err := dst.Sync(); err != nil {
+		return 0, 0, cleanupCompaction(dst, compacted, errors.Wrap(err, "could not sync compacted database"))
+	}
+	if err := dst.Close(); err != nil {
+		return 0, 0, errors.Wrap(err, "could not close compacted database")
+	}
+	if err := os.Rename(compacted, datafile); err != nil {
+		return 0, 0, errors.Wrap(err, "could not replace database with the compacted database")
+	}
+	after, err := fileSize(datafile)
+	if err != nil {
+		return 0, 0, err
+	}
+	return before, after, nil
+}
+
+func cleanupCompaction(dst *bolt.DB, path string, err error) error {
+	if closeErr := dst.Close(); closeErr != nil {
+		log.WithError(closeErr).Error("Could not close compacted database")
+	}
+	if rmErr := os.Remove(path); rmErr != nil {
+		log.WithError(rmErr).Error("Could not remove compacted database")
+	}
+	return err
+}
+
+func fileSize(path string) (int64, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return 0, err
+	}
+	return info.Size(), nil
+}
@@ -0,0 +1,56 @@
+package kv
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	bolt "go.etcd.io/bbolt"
+)
+
+func TestCompact(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	db, err := NewKVStore(ctx, dir)
+	require.NoError(t, err)
+	value := make([]byte, 1024*1024)
+	require.NoError(t, db.db.Update(func(tx *bolt.Tx) error {
+		for i := byte(0); i < 32; i++ {
+			if err := tx.Bucket(stateBucket).Put([]byte{i}, value); err != nil {
+				return err
+			}
+		}
+		return nil
+	}))
+	require.NoError(t, db.db.Update(func(tx *bolt.Tx) error {
+		for i := byte(1); i < 32; i++ {
+			if err := tx.Bucket(stateBucket).Delete([]byte{i}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}))
+
+	_, _, err = Compact(dir)
+	require.ErrorContains(t, "database may be in use", err)
+	require.NoError(t, db.Close())
+
+	before, after, err := Compact(dir)
+	require.NoError(t, err)
+	assert.Equal(t, true, after < before, "database was not compacted from %d to %d bytes", before, after)
+	_, err = os.Stat(StoreDatafilePath(dir) + ".compact")
+	assert.Equal(t, true, os.IsNotExist(err))
+
+	db, err = NewKVStore(ctx, dir)
+	require.NoError(t, err)
+	require.NoError(t, db.db.View(func(tx *bolt.Tx) error {
+		assert.DeepEqual(t, value, tx.Bucket(stateBucket).Get([]byte{0}))
+		return nil
+	}))
+	require.NoError(t, db.Close())
+
+	_, _, err = Compact(t.TempDir())
+	require.ErrorContains(t, "could not read database file", err)
+}
@@ -0,0 +1,38 @@
+package kv
+
+import (
+	"context"
+
+	"github.com/prysmaticlabs/prysm/v5/monitoring/tracing/trace"
+	bolt "go.etcd.io/bbolt"
+)
+
+// ForkchoiceSnapshot returns the last snapshot of the fork choice store saved to the database.
+// It returns nil if there is none.
+func (s *Store) ForkchoiceSnapshot(ctx context.Context) ([]byte, error) {
+	_, span := trace.StartSpan(ctx, "BeaconDB.ForkchoiceSnapshot")
+	defer span.End()
+
+	var enc []byte
+	err := s.db.View(func(tx *bolt.Tx) error {
+		v := tx.Bucket(chainMetadataBucket).Get(forkchoiceSnapshotKey)
+		if v == nil {
+			return nil
+		}
+		// The value is only valid during the transaction.
+		enc = make([]byte, len(v))
+		copy(enc, v)
+		return nil
+	})
+	return enc, err
+}
+
+// SaveForkchoiceSnapshot saves a snapshot of the fork choice store, replacing the previous one.
+func (s *Store) SaveForkchoiceSnapshot(ctx context.Context, enc []byte) error {
+	_, span := trace.StartSpan(ctx, "BeaconDB.SaveForkchoiceSnapshot")
+	defer span.End()
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(chainMetadataBucket).Put(forkchoiceSnapshotKey, enc)
+	})
+}
@@ -0,0 +1,23 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestStore_ForkchoiceSnapshot(t *testing.T) {
+	db := setupDB(t)
+	ctx := context.Background()
+
+	enc, err := db.ForkchoiceSnapshot(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(enc))
+
+	require.NoError(t, db.SaveForkchoiceSnapshot(ctx, []byte{1, 2, 3}))
+	require.NoError(t, db.SaveForkchoiceSnapshot(ctx, []byte{4, 5}))
+	enc, err = db.ForkchoiceSnapshot(ctx)
+	require.NoError(t, err)
+	require.DeepEqual(t, []byte{4, 5}, enc)
+}
@@ -89,7 +89,12 @@ type Store struct {
 	blockCache          *ristretto.Cache
 	validatorEntryCache *ristretto.Cache
 	stateSummaryCache   *stateSummaryCache
-	ctx                 context.Context
+	stateDiffExponents  []uint64
+	stateDiffCache      stateDiffCache
+	readOnly            bool
+	// skipBlockStorageSetup leaves the block storage type of the database unset, see WithoutBlockStorageSetup.
+	skipBlockStorageSetup bool
+	ctx                   context.Context
 }
 
 // StoreDatafilePath is the canonical construction of a full
@@ -118,11 +123,22 @@ var Buckets = [][]byte{
 
 	feeRecipientBucket,
 	registrationBucket,
+	tokenomicsSnapshotsBucket,
+	stateDiffsBucket,
+	stateDiffWritesBucket,
 }
 
 // KVStoreOption is a functional option that modifies a kv.Store.
 type KVStoreOption func(*Store)
 
+// WithoutBlockStorageSetup opens the database without recording the type of block storage in a new database,
+// for a database restored from a backup, which must keep the block storage type of the backup.
+func WithoutBlockStorageSetup() KVStoreOption {
+	return func(s *Store) {
+		s.skipBlockStorageSetup = true
+	}
+}
+
 // NewKVStore initializes a new boltDB key-value store at the directory
 // path specified, creates the kv-buckets based on the schema, and stores
 // an open connection db object as a property of the Store struct.
@@ -187,17 +203,73 @@ func NewKVStore(ctx context.Context, dirPath string, opts ...KVStoreOption) (*St
 	}); err != nil {
 		return nil, err
 	}
+	if err := kv.setupStateDiffLayout(); err != nil {
+		if closeErr := kv.db.Close(); closeErr != nil {
+			log.WithError(closeErr).Error("Could not close database")
+		}
+		return nil, err
+	}
 	if err = prometheus.Register(createBoltCollector(kv.db)); err != nil {
 		return nil, err
 	}
 	// Setup the type of block storage used depending on whether or not this is a fresh database.
-	if err := kv.setupBlockStorageType(ctx); err != nil {
-		return nil, err
+	if !kv.skipBlockStorageSetup {
+		if err := kv.setupBlockStorageType(ctx); err != nil {
+			return nil, err
+		}
 	}
 
 	return kv, nil
 }
 
+// NewReadOnlyKVStore opens the existing boltDB key-value store at the directory path in read-only mode,
+// for offline tools that inspect the database. Nothing is written to the database, not even the
+// bucket creation and the block storage type setup done by NewKVStore, so any save fails.
+func NewReadOnlyKVStore(ctx context.Context, dirPath string) (*Store, error) {
+	datafile := StoreDatafilePath(dirPath)
+	log.WithField("path", datafile).Info("Opening Bolt DB in read-only mode")
+	boltDB, err := bolt.Open(
+		datafile,
+		params.BeaconIoConfig().ReadWritePermissions,
+		&bolt.Options{
+			Timeout:         1 * time.Second,
+			InitialMmapSize: mmapSize,
+			ReadOnly:        true,
+		},
+	)
+	if err != nil {
+		if errors.Is(err, bolt.ErrTimeout) {
+			return nil, errors.New("cannot obtain database lock, database may be in use by another process")
+		}
+		return nil, err
+	}
+	blockCache, err := ristretto.NewCache(&ristretto.Config{
+		NumCounters: 1000,
+		MaxCost:     BlockCacheSize,
+		BufferItems: 64,
+	})
+	if err != nil {
+		return nil, err
+	}
+	validatorCache, err := ristretto.NewCache(&ristretto.Config{
+		NumCounters: NumOfValidatorEntries,
+		MaxCost:     ValidatorEntryMaxCost,
+		BufferItems: 64,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &Store{
+		db:                  boltDB,
+		databasePath:        dirPath,
+		blockCache:          blockCache,
+		validatorEntryCache: validatorCache,
+		stateSummaryCache:   newStateSummaryCache(),
+		readOnly:            true,
+		ctx:                 ctx,
+	}, nil
+}
+
 // ClearDB removes the previously stored database in the data directory.
 func (s *Store) ClearDB() error {
 	if err := s.Close(); err != nil {
@@ -217,6 +289,9 @@ func (s *Store) ClearDB() error {
 func (s *Store) Close() error {
 	prometheus.Unregister(createBoltCollector(s.db))
 
+	if s.readOnly {
+		return s.db.Close()
+	}
 	// Before DB closes, we should dump the cached state summary objects to DB.
 	if err := s.saveCachedStateSummariesDB(s.ctx); err != nil {
 		return err
@@ -225,6 +300,14 @@ func (s *Store) Close() error {
 	return s.db.Close()
 }
 
+// Sync saves the cached state summary objects to the database and syncs the database file to disk.
+func (s *Store) Sync() error {
+	if err := s.saveCachedStateSummariesDB(s.ctx); err != nil {
+		return err
+	}
+	return s.db.Sync()
+}
+
 // DatabasePath at which this database writes files.
 func (s *Store) DatabasePath() string {
 	return s.databasePath
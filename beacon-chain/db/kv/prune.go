@@ -0,0 +1,151 @@
+package kv
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v5/monitoring/tracing/trace"
+	bolt "go.etcd.io/bbolt"
+)
+
+// LowestAvailableSlot returns the lowest slot whose blocks and states are stored in the database.
+// It is zero unless the history of the chain was pruned, in which case everything before it was
+// deleted apart from the genesis block and state.
+func (s *Store) LowestAvailableSlot(ctx context.Context) (primitives.Slot, error) {
+	_, span := trace.StartSpan(ctx, "BeaconDB.LowestAvailableSlot")
+	defer span.End()
+
+	var slot primitives.Slot
+	err := s.db.View(func(tx *bolt.Tx) error {
+		enc := tx.Bucket(chainMetadataBucket).Get(lowestAvailableSlotKey)
+		if enc != nil {
+			slot = bytesutil.BytesToSlotBigEndian(enc)
+		}
+		return nil
+	})
+	return slot, err
+}
+
+// PruneHistory deletes the blocks, state summaries, states and their indices of every slot from the lowest
+// available slot up to, but excluding, the given slot. The genesis block and state are always kept. The slots
+// are deleted in transactions of at most batchSize slots, each of which also moves the lowest available slot,
+// so the database remains usable while a long history is pruned. It returns the number of deleted blocks.
+func (s *Store) PruneHistory(ctx context.Context, pruneBefore primitives.Slot, batchSize uint64) (int, error) {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.PruneHistory")
+	defer span.End()
+
+	if batchSize == 0 {
+		return 0, errors.New("batch size must be positive")
+	}
+	lowest, err := s.LowestAvailableSlot(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if pruneBefore <= lowest {
+		return 0, nil
+	}
+	cp, err := s.FinalizedCheckpoint(ctx)
+	if err != nil {
+		return 0, err
+	}
+	finalized, err := s.Block(ctx, bytesutil.ToBytes32(cp.Root))
+	if err != nil {
+		return 0, err
+	}
+	if finalized == nil || finalized.IsNil() {
+		return 0, errors.New("finalized block is missing")
+	}
+	if pruneBefore > finalized.Block().Slot() {
+		return 0, fmt.Errorf("cannot prune history up to slot %d, after the finalized block at slot %d", pruneBefore, finalized.Block().Slot())
+	}
+
+	// The genesis slot is never pruned.
+	start := max(lowest, 1)
+	deleted := 0
+	for start < pruneBefore {
+		if ctx.Err() != nil {
+			return deleted, ctx.Err()
+		}
+		end := min(start+primitives.Slot(batchSize), pruneBefore)
+		n, err := s.pruneSlots(ctx, start, end)
+		if err != nil {
+			return deleted, errors.Wrapf(err, "could not prune slots %d to %d", start, end)
+		}
+		deleted += n
+		start = end
+	}
+	return deleted, nil
+}
+
+// pruneSlots deletes the history of the slots in [start, end) and records end as the lowest available slot.
+func (s *Store) pruneSlots(ctx context.Context, start, end primitives.Slot) (int, error) {
+	var roots [][32]byte
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		genesisRoot := tx.Bucket(blocksBucket).Get(genesisBlockRootKey)
+		startKey := bytesutil.SlotToBytesBigEndian(start)
+		endKey := bytesutil.SlotToBytesBigEndian(end)
+
+		slotIdx := tx.Bucket(blockSlotIndicesBucket)
+		var slotKeys [][]byte
+		c := slotIdx.Cursor()
+		for k, v := c.Seek(startKey); k != nil && bytes.Compare(k, endKey) < 0; k, v = c.Next() {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			slotKeys = append(slotKeys, bytes.Clone(k))
+			for i := 0; i+32 <= len(v); i += 32 {
+				if root := v[i : i+32]; !bytes.Equal(root, genesisRoot) {
+					roots = append(roots, bytesutil.ToBytes32(root))
+				}
+			}
+		}
+		for _, k := range slotKeys {
+			if err := slotIdx.Delete(k); err != nil {
+				return err
+			}
+		}
+
+		stateIdx := tx.Bucket(stateSlotIndicesBucket)
+		var stateKeys [][]byte
+		c = stateIdx.Cursor()
+		for k, _ := c.Seek(startKey); k != nil && bytes.Compare(k, endKey) < 0; k, _ = c.Next() {
+			stateKeys = append(stateKeys, bytes.Clone(k))
+		}
+		for _, k := range stateKeys {
+			if err := stateIdx.Delete(k); err != nil {
+				return err
+			}
+		}
+
+		// The blocks of the pruned slots only have children in the pruned slots or in the first kept slot,
+		// so the parent root index entries of the pruned blocks can be deleted altogether.
+		buckets := [][]byte{
+			blocksBucket,
+			blockParentRootIndicesBucket,
+			finalizedBlockRootsIndexBucket,
+			stateSummaryBucket,
+			stateBucket,
+			blockRootValidatorHashesBucket,
+		}
+		for _, root := range roots {
+			for _, b := range buckets {
+				if err := tx.Bucket(b).Delete(root[:]); err != nil {
+					return err
+				}
+			}
+		}
+		return tx.Bucket(chainMetadataBucket).Put(lowestAvailableSlotKey, endKey)
+	})
+	if err != nil {
+		return 0, err
+	}
+	for _, root := range roots {
+		s.blockCache.Del(string(root[:]))
+		s.stateSummaryCache.delete(root)
+	}
+	return len(roots), nil
+}
@@ -0,0 +1,83 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/filters"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+func TestStore_PruneHistory(t *testing.T) {
+	ctx := context.Background()
+	db := setupDB(t)
+
+	genesis, err := blocks.NewSignedBeaconBlock(util.NewBeaconBlock())
+	require.NoError(t, err)
+	gRoot, err := genesis.Block().HashTreeRoot()
+	require.NoError(t, err)
+	require.NoError(t, db.SaveBlock(ctx, genesis))
+	require.NoError(t, db.SaveGenesisBlockRoot(ctx, gRoot))
+	st, err := util.NewBeaconState()
+	require.NoError(t, err)
+	require.NoError(t, db.SaveState(ctx, st, gRoot))
+
+	// Blocks at slots 1 to 20.
+	blks := makeBlocks(t, 0, 20, gRoot)
+	require.NoError(t, db.SaveBlocks(ctx, blks))
+	roots := make([][32]byte, len(blks))
+	for i, b := range blks {
+		roots[i], err = b.Block().HashTreeRoot()
+		require.NoError(t, err)
+		require.NoError(t, db.SaveStateSummary(ctx, &ethpb.StateSummary{Slot: b.Block().Slot(), Root: roots[i][:]}))
+	}
+	for _, i := range []int{3, 15} {
+		st, err := util.NewBeaconState()
+		require.NoError(t, err)
+		require.NoError(t, st.SetSlot(blks[i].Block().Slot()))
+		require.NoError(t, db.SaveState(ctx, st, roots[i]))
+	}
+	require.NoError(t, db.SaveFinalizedCheckpoint(ctx, &ethpb.Checkpoint{Epoch: 0, Root: roots[15][:]}))
+
+	lowest, err := db.LowestAvailableSlot(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, primitives.Slot(0), lowest)
+
+	_, err = db.PruneHistory(ctx, 17, 3)
+	require.ErrorContains(t, "after the finalized block", err)
+
+	deleted, err := db.PruneHistory(ctx, 10, 3)
+	require.NoError(t, err)
+	assert.Equal(t, 9, deleted)
+	lowest, err = db.LowestAvailableSlot(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, primitives.Slot(10), lowest)
+
+	for i, root := range roots {
+		slot := blks[i].Block().Slot()
+		assert.Equal(t, slot >= 10, db.HasBlock(ctx, root), "wrong block presence at slot %d", slot)
+		assert.Equal(t, slot >= 10, db.HasStateSummary(ctx, root), "wrong state summary presence at slot %d", slot)
+	}
+	assert.Equal(t, false, db.HasState(ctx, roots[3]))
+	assert.Equal(t, true, db.HasState(ctx, roots[15]))
+	assert.Equal(t, true, db.HasBlock(ctx, gRoot))
+	_, err = db.GenesisState(ctx)
+	require.NoError(t, err)
+
+	blksBySlot, _, err := db.Blocks(ctx, filters.NewFilter().SetStartSlot(0).SetEndSlot(20))
+	require.NoError(t, err)
+	assert.Equal(t, 12, len(blksBySlot))
+	has, _, err := db.BlockRootsBySlot(ctx, 4)
+	require.NoError(t, err)
+	assert.Equal(t, false, has)
+
+	// Pruning below the lowest available slot is a no-op.
+	deleted, err = db.PruneHistory(ctx, 5, 3)
+	require.NoError(t, err)
+	assert.Equal(t, 0, deleted)
+}
@@ -17,6 +17,14 @@ var (
 	feeRecipientBucket    = []byte("fee-recipient")
 	registrationBucket    = []byte("registration")
 
+	// Tokenomics values indexed by epoch.
+	tokenomicsSnapshotsBucket = []byte("tokenomics-snapshots")
+
+	// Hierarchical state snapshots and diffs indexed by slot.
+	stateDiffsBucket = []byte("state-diffs")
+	// Write sequence of the hierarchical state snapshots and diffs indexed by slot.
+	stateDiffWritesBucket = []byte("state-diff-writes")
+
 	// Deprecated: This bucket was migrated in PR 6461. Do not use, except for migrations.
 	slotsHasObjectBucket = []byte("slots-has-objects")
 	// Deprecated: This bucket was migrated in PR 6461. Do not use, except for migrations.
@@ -37,6 +45,8 @@ var (
 	finalizedCheckpointKey     = []byte("finalized-checkpoint")
 	powchainDataKey            = []byte("powchain-data")
 	lastValidatedCheckpointKey = []byte("last-validated-checkpoint")
+	lowestAvailableSlotKey     = []byte("lowest-available-slot")
+	forkchoiceSnapshotKey      = []byte("forkchoice-snapshot")
 
 	// Below keys are used to identify objects are to be fork compatible.
 	// Objects that are only compatible with specific forks should be prefixed with such keys.
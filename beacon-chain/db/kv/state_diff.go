@@ -0,0 +1,333 @@
+package kv
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/golang/snappy"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	statenative "github.com/prysmaticlabs/prysm/v5/beacon-chain/state/state-native"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v5/monitoring/tracing/trace"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	bolt "go.etcd.io/bbolt"
+)
+
+// Hierarchical state diffs store the finalized states of an archival node in layers keyed by slot.
+// The layout is a list of decreasing exponents, where the state of a slot belongs to the first
+// layer whose interval 2^exponent divides the slot. The states of the first layer are stored as
+// snapshots, and the states of every other layer as a delta against the state of the closest
+// coarser layer, so any stored state is rebuilt from one snapshot and at most one delta per layer.
+//
+// An entry is a kind byte followed, for a diff, by the slot of its base state, and by the snappy
+// compressed SSZ encoding of the state, or of the delta to the encoding of the base state.
+const (
+	stateDiffSnapshot byte = 0
+	stateDiffDelta    byte = 1
+)
+
+// DefaultStateDiffExponents is the default layout of the hierarchical state diffs: a snapshot
+// every 2^21 slots, and a diff of every slot in between.
+var DefaultStateDiffExponents = []uint64{21, 17, 13, 9, 5, 0}
+
+var stateDiffLayoutKey = []byte("state-diff-layout")
+
+// stateDiffBase is the SSZ encoding of the last state saved in a layer, which is the base of the
+// diffs of the following layer.
+type stateDiffBase struct {
+	slot primitives.Slot
+	enc  []byte
+}
+
+type stateDiffCache struct {
+	lock  sync.Mutex
+	bases map[int]*stateDiffBase
+}
+
+// WithStateDiffExponents enables the storage of hierarchical state diffs with the given layout.
+func WithStateDiffExponents(exponents []uint64) KVStoreOption {
+	return func(s *Store) {
+		s.stateDiffExponents = exponents
+	}
+}
+
+// ValidateStateDiffExponents checks that the exponents of a hierarchical state diff layout are
+// strictly decreasing and define intervals which fit in a slot.
+func ValidateStateDiffExponents(exponents []uint64) error {
+	if len(exponents) == 0 {
+		return errors.New("state diff layout has no layers")
+	}
+	for i, e := range exponents {
+		if e > 63 {
+			return fmt.Errorf("state diff exponent %d is greater than 63", e)
+		}
+		if i > 0 && e >= exponents[i-1] {
+			return fmt.Errorf("state diff exponents must be strictly decreasing, got %v", exponents)
+		}
+	}
+	return nil
+}
+
+// setupStateDiffLayout saves the configured layout of the hierarchical state diffs on first use, and
+// refuses a layout which differs from the one the saved diffs were built with.
+func (s *Store) setupStateDiffLayout() error {
+	if len(s.stateDiffExponents) == 0 {
+		return nil
+	}
+	if err := ValidateStateDiffExponents(s.stateDiffExponents); err != nil {
+		return err
+	}
+	enc := make([]byte, 0, 8*len(s.stateDiffExponents))
+	for _, e := range s.stateDiffExponents {
+		enc = binary.BigEndian.AppendUint64(enc, e)
+	}
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket(chainMetadataBucket)
+		saved := bkt.Get(stateDiffLayoutKey)
+		if saved == nil {
+			return bkt.Put(stateDiffLayoutKey, enc)
+		}
+		if !slices.Equal(saved, enc) {
+			return fmt.Errorf("state diff exponents %v differ from the layout the database was built with", s.stateDiffExponents)
+		}
+		return nil
+	})
+}
+
+// stateDiffLayer returns the layer of the hierarchical state diffs the given slot belongs to,
+// or -1 if the slot is not stored in any layer.
+func (s *Store) stateDiffLayer(slot primitives.Slot) int {
+	for i, e := range s.stateDiffExponents {
+		if uint64(slot)%(uint64(1)<<e) == 0 {
+			return i
+		}
+	}
+	return -1
+}
+
+// IsHierarchicalStateSlot returns true if the storage of hierarchical state diffs is enabled and the
+// state of the given slot belongs to one of its layers.
+func (s *Store) IsHierarchicalStateSlot(slot primitives.Slot) bool {
+	return s.stateDiffLayer(slot) >= 0
+}
+
+// HasHierarchicalState returns true if the state of the given slot is stored as a hierarchical state diff.
+func (s *Store) HasHierarchicalState(ctx context.Context, slot primitives.Slot) bool {
+	_, span := trace.StartSpan(ctx, "BeaconDB.HasHierarchicalState")
+	defer span.End()
+
+	has := false
+	err := s.db.View(func(tx *bolt.Tx) error {
+		has = tx.Bucket(stateDiffsBucket).Get(bytesutil.SlotToBytesBigEndian(slot)) != nil
+		return nil
+	})
+	if err != nil {
+		panic(err)
+	}
+	return has
+}
+
+// SaveHierarchicalState saves the given finalized state in its layer of the hierarchical state diffs.
+// The state is stored as a diff against the state of the closest coarser layer, or as a snapshot if
+// it belongs to the first layer or if no state of a coarser layer is stored.
+func (s *Store) SaveHierarchicalState(ctx context.Context, st state.ReadOnlyBeaconState) error {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.SaveHierarchicalState")
+	defer span.End()
+
+	if st == nil || st.IsNil() {
+		return errors.New("nil state")
+	}
+	slot := st.Slot()
+	layer := s.stateDiffLayer(slot)
+	if layer < 0 {
+		return fmt.Errorf("slot %d is not in any layer of the state diff layout", slot)
+	}
+	enc, err := marshalStateSSZ(ctx, st)
+	if err != nil {
+		return err
+	}
+
+	s.stateDiffCache.lock.Lock()
+	defer s.stateDiffCache.lock.Unlock()
+
+	entry := []byte{stateDiffSnapshot}
+	payload := enc
+	for l := layer - 1; l >= 0; l-- {
+		baseSlot := slot - slot%primitives.Slot(uint64(1)<<s.stateDiffExponents[l])
+		if !s.HasHierarchicalState(ctx, baseSlot) {
+			continue
+		}
+		baseEnc, err := s.cachedStateDiffBase(ctx, baseSlot)
+		if err != nil {
+			return errors.Wrapf(err, "could not rebuild base state at slot %d", baseSlot)
+		}
+		entry = append([]byte{stateDiffDelta}, bytesutil.SlotToBytesBigEndian(baseSlot)...)
+		payload = computeStateDelta(baseEnc, enc)
+		break
+	}
+	entry = append(entry, snappy.Encode(nil, payload)...)
+
+	if err := s.db.Update(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket(stateDiffsBucket)
+		// The write sequence lets an incremental backup select the diffs written since its base, whatever their slot.
+		seq, err := bkt.NextSequence()
+		if err != nil {
+			return err
+		}
+		key := bytesutil.SlotToBytesBigEndian(slot)
+		if err := tx.Bucket(stateDiffWritesBucket).Put(key, bytesutil.Uint64ToBytesBigEndian(seq)); err != nil {
+			return err
+		}
+		return bkt.Put(key, entry)
+	}); err != nil {
+		return err
+	}
+	// The states of the last layer are never the base of a diff.
+	if layer < len(s.stateDiffExponents)-1 {
+		if s.stateDiffCache.bases == nil {
+			s.stateDiffCache.bases = make(map[int]*stateDiffBase)
+		}
+		s.stateDiffCache.bases[layer] = &stateDiffBase{slot: slot, enc: enc}
+	}
+	return nil
+}
+
+// cachedStateDiffBase returns the encoding of the base state of a diff, which is the last state
+// saved in its layer as long as the states are saved in order.
+func (s *Store) cachedStateDiffBase(ctx context.Context, slot primitives.Slot) ([]byte, error) {
+	if base, ok := s.stateDiffCache.bases[s.stateDiffLayer(slot)]; ok && base.slot == slot {
+		return base.enc, nil
+	}
+	return s.hierarchicalStateBytes(ctx, slot)
+}
+
+// HierarchicalState rebuilds the state of the given slot from its snapshot and diffs. It returns
+// ErrNotFoundState if the state of the slot is not stored as a hierarchical state diff.
+func (s *Store) HierarchicalState(ctx context.Context, slot primitives.Slot) (state.BeaconState, error) {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.HierarchicalState")
+	defer span.End()
+
+	enc, err := s.hierarchicalStateBytes(ctx, slot)
+	if err != nil {
+		return nil, err
+	}
+	return unmarshalStateSSZ(enc)
+}
+
+// hierarchicalStateBytes rebuilds the SSZ encoding of the state of the given slot by applying the
+// chain of diffs leading to it to their snapshot.
+func (s *Store) hierarchicalStateBytes(ctx context.Context, slot primitives.Slot) ([]byte, error) {
+	// The payloads are collected from the requested slot down to the snapshot.
+	var payloads [][]byte
+	err := s.db.View(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket(stateDiffsBucket)
+		for {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			entry := bkt.Get(bytesutil.SlotToBytesBigEndian(slot))
+			if entry == nil {
+				if len(payloads) == 0 {
+					return ErrNotFoundState
+				}
+				return fmt.Errorf("base state at slot %d of a state diff is missing", slot)
+			}
+			switch {
+			case len(entry) > 0 && entry[0] == stateDiffSnapshot:
+				payload, err := snappy.Decode(nil, entry[1:])
+				if err != nil {
+					return err
+				}
+				payloads = append(payloads, payload)
+				return nil
+			case len(entry) > 8 && entry[0] == stateDiffDelta:
+				payload, err := snappy.Decode(nil, entry[9:])
+				if err != nil {
+					return err
+				}
+				payloads = append(payloads, payload)
+				baseSlot := bytesutil.BytesToSlotBigEndian(entry[1:9])
+				if baseSlot >= slot {
+					return fmt.Errorf("state diff at slot %d has a base at slot %d", slot, baseSlot)
+				}
+				slot = baseSlot
+			default:
+				return fmt.Errorf("invalid state diff entry at slot %d", slot)
+			}
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	enc := payloads[len(payloads)-1]
+	for i := len(payloads) - 2; i >= 0; i-- {
+		enc, err = applyStateDelta(enc, payloads[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return enc, nil
+}
+
+// marshalStateSSZ returns the fork prefixed SSZ encoding of the state, including its validators.
+func marshalStateSSZ(ctx context.Context, st state.ReadOnlyBeaconState) ([]byte, error) {
+	enc, err := marshalState(ctx, st)
+	if err != nil {
+		return nil, err
+	}
+	return snappy.Decode(nil, enc)
+}
+
+// unmarshalStateSSZ decodes a state from the encoding produced by marshalStateSSZ.
+func unmarshalStateSSZ(enc []byte) (state.BeaconState, error) {
+	switch {
+	case hasBadgerKey(enc):
+		protoState := &ethpb.BeaconStateBadger{}
+		if err := protoState.UnmarshalSSZ(enc[len(badgerKey):]); err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal encoding for Badger")
+		}
+		return statenative.InitializeFromProtoUnsafeBadger(protoState)
+	case hasElectraKey(enc):
+		protoState := &ethpb.BeaconStateElectra{}
+		if err := protoState.UnmarshalSSZ(enc[len(electraKey):]); err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal encoding for Electra")
+		}
+		return statenative.InitializeFromProtoUnsafeElectra(protoState)
+	case hasDenebKey(enc):
+		protoState := &ethpb.BeaconStateDeneb{}
+		if err := protoState.UnmarshalSSZ(enc[len(denebKey):]); err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal encoding for Deneb")
+		}
+		return statenative.InitializeFromProtoUnsafeDeneb(protoState)
+	case hasCapellaKey(enc):
+		protoState := &ethpb.BeaconStateCapella{}
+		if err := protoState.UnmarshalSSZ(enc[len(capellaKey):]); err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal encoding for capella")
+		}
+		return statenative.InitializeFromProtoUnsafeCapella(protoState)
+	case hasBellatrixKey(enc):
+		protoState := &ethpb.BeaconStateBellatrix{}
+		if err := protoState.UnmarshalSSZ(enc[len(bellatrixKey):]); err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal encoding for bellatrix")
+		}
+		return statenative.InitializeFromProtoUnsafeBellatrix(protoState)
+	case hasAltairKey(enc):
+		protoState := &ethpb.BeaconStateAltair{}
+		if err := protoState.UnmarshalSSZ(enc[len(altairKey):]); err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal encoding for altair")
+		}
+		return statenative.InitializeFromProtoUnsafeAltair(protoState)
+	default:
+		protoState := &ethpb.BeaconState{}
+		if err := protoState.UnmarshalSSZ(enc); err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal encoding")
+		}
+		return statenative.InitializeFromProtoUnsafePhase0(protoState)
+	}
+}
@@ -0,0 +1,172 @@
+package kv
+
+import (
+	"bytes"
+	"encoding/binary"
+
+	"github.com/pkg/errors"
+)
+
+// The delta between two SSZ encoded states is a list of operations rebuilding the target
+// encoding from the base encoding. Most of a state is carried over from one slot to the next,
+// either in place or shifted by the growth of the lists in front of it, so the target is
+// matched against the blocks of the base with a rolling checksum, as rsync does.
+//
+// Encoding: uvarint(target length) followed by the operations, where
+//   - a copy is deltaOpCopy, uvarint(base offset), uvarint(length)
+//   - an insert is deltaOpInsert, uvarint(length), the inserted bytes
+const (
+	deltaBlockSize = 64
+
+	deltaOpCopy   byte = 0
+	deltaOpInsert byte = 1
+)
+
+var errInvalidStateDelta = errors.New("invalid state delta")
+
+type deltaWriter struct {
+	buf       []byte
+	copyStart int
+	copyLen   int
+}
+
+func (w *deltaWriter) copy(offset, length int) {
+	if w.copyLen > 0 && w.copyStart+w.copyLen == offset {
+		w.copyLen += length
+		return
+	}
+	w.flushCopy()
+	w.copyStart, w.copyLen = offset, length
+}
+
+func (w *deltaWriter) insert(data []byte) {
+	if len(data) == 0 {
+		return
+	}
+	w.flushCopy()
+	w.buf = append(w.buf, deltaOpInsert)
+	w.buf = binary.AppendUvarint(w.buf, uint64(len(data)))
+	w.buf = append(w.buf, data...)
+}
+
+func (w *deltaWriter) flushCopy() {
+	if w.copyLen == 0 {
+		return
+	}
+	w.buf = append(w.buf, deltaOpCopy)
+	w.buf = binary.AppendUvarint(w.buf, uint64(w.copyStart))
+	w.buf = binary.AppendUvarint(w.buf, uint64(w.copyLen))
+	w.copyLen = 0
+}
+
+// weakChecksum returns the two halves of the rsync rolling checksum of the given block.
+func weakChecksum(block []byte) (uint32, uint32) {
+	var a, b uint32
+	for i, v := range block {
+		a += uint32(v)
+		b += uint32(len(block)-i) * uint32(v)
+	}
+	return a & 0xffff, b & 0xffff
+}
+
+// computeStateDelta returns the delta rebuilding target from base.
+func computeStateDelta(base, target []byte) []byte {
+	w := &deltaWriter{buf: binary.AppendUvarint(nil, uint64(len(target)))}
+
+	index := make(map[uint32]int, len(base)/deltaBlockSize)
+	for off := 0; off+deltaBlockSize <= len(base); off += deltaBlockSize {
+		a, b := weakChecksum(base[off : off+deltaBlockSize])
+		if _, ok := index[b<<16|a]; !ok {
+			index[b<<16|a] = off
+		}
+	}
+
+	// next is the base offset expected to match next, assuming the target is changed in place.
+	next := 0
+	literal := 0
+	rolling := false
+	var a, b uint32
+	for i := 0; i+deltaBlockSize <= len(target); {
+		block := target[i : i+deltaBlockSize]
+		match := -1
+		if next+deltaBlockSize <= len(base) && bytes.Equal(base[next:next+deltaBlockSize], block) {
+			match = next
+		} else {
+			if !rolling {
+				a, b = weakChecksum(block)
+				rolling = true
+			}
+			if off, ok := index[b<<16|a]; ok && bytes.Equal(base[off:off+deltaBlockSize], block) {
+				match = off
+			}
+		}
+		if match >= 0 {
+			w.insert(target[literal:i])
+			w.copy(match, deltaBlockSize)
+			i += deltaBlockSize
+			next = match + deltaBlockSize
+			literal = i
+			rolling = false
+			continue
+		}
+		if rolling && i+deltaBlockSize < len(target) {
+			out, in := uint32(target[i]), uint32(target[i+deltaBlockSize])
+			a = (a - out + in) & 0xffff
+			b = (b - deltaBlockSize*out + a) & 0xffff
+		}
+		i++
+		next++
+	}
+	w.insert(target[literal:])
+	w.flushCopy()
+	return w.buf
+}
+
+// applyStateDelta rebuilds the target encoding from the base encoding and the delta between them.
+func applyStateDelta(base, delta []byte) ([]byte, error) {
+	size, n := binary.Uvarint(delta)
+	if n <= 0 {
+		return nil, errors.Wrap(errInvalidStateDelta, "could not read target length")
+	}
+	delta = delta[n:]
+	// The target length is only a capacity hint, it is checked against the rebuilt target.
+	target := make([]byte, 0, min(size, uint64(len(base))+uint64(len(delta))))
+	for len(delta) > 0 {
+		op := delta[0]
+		delta = delta[1:]
+		switch op {
+		case deltaOpCopy:
+			offset, n := binary.Uvarint(delta)
+			if n <= 0 {
+				return nil, errors.Wrap(errInvalidStateDelta, "could not read copy offset")
+			}
+			delta = delta[n:]
+			length, n := binary.Uvarint(delta)
+			if n <= 0 {
+				return nil, errors.Wrap(errInvalidStateDelta, "could not read copy length")
+			}
+			delta = delta[n:]
+			if offset > uint64(len(base)) || length > uint64(len(base))-offset {
+				return nil, errors.Wrapf(errInvalidStateDelta, "copy of %d bytes at %d is out of the base", length, offset)
+			}
+			target = append(target, base[offset:offset+length]...)
+		case deltaOpInsert:
+			length, n := binary.Uvarint(delta)
+			if n <= 0 {
+				return nil, errors.Wrap(errInvalidStateDelta, "could not read insert length")
+			}
+			delta = delta[n:]
+			if length > uint64(len(delta)) {
+				return nil, errors.Wrapf(errInvalidStateDelta, "insert of %d bytes is out of the delta", length)
+			}
+			target = append(target, delta[:length]...)
+			delta = delta[length:]
+		default:
+			return nil, errors.Wrapf(errInvalidStateDelta, "unknown operation %d", op)
+		}
+	}
+	if uint64(len(target)) != size {
+		return nil, errors.Wrapf(errInvalidStateDelta, "rebuilt %d bytes, expected %d", len(target), size)
+	}
+	return target, nil
+}
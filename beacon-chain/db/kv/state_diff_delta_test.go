@@ -0,0 +1,79 @@
+package kv
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestStateDelta_RoundTrip(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	base := make([]byte, 64*1024)
+	_, err := r.Read(base)
+	require.NoError(t, err)
+
+	inPlace := bytes.Clone(base)
+	for i := 0; i < 16; i++ {
+		inPlace[r.Intn(len(inPlace))]++
+	}
+	extra := make([]byte, 1000)
+	_, err = r.Read(extra)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		target []byte
+	}{
+		{name: "identical", target: bytes.Clone(base)},
+		{name: "changed in place", target: inPlace},
+		{name: "shifted by an insert", target: append(append(bytes.Clone(base[:1000]), extra...), base[1000:]...)},
+		{name: "shifted by a removal", target: append(bytes.Clone(base[:1000]), base[1500:]...)},
+		{name: "appended", target: append(bytes.Clone(base), extra...)},
+		{name: "truncated", target: bytes.Clone(base[:len(base)-333])},
+		{name: "unrelated", target: extra},
+		{name: "empty", target: []byte{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			delta := computeStateDelta(base, tt.target)
+			got, err := applyStateDelta(base, delta)
+			require.NoError(t, err)
+			assert.DeepEqual(t, tt.target, got)
+		})
+	}
+}
+
+func TestStateDelta_Size(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	base := make([]byte, 64*1024)
+	_, err := r.Read(base)
+	require.NoError(t, err)
+	target := append(append(bytes.Clone(base[:1000]), []byte{1, 2, 3}...), base[1000:]...)
+	target[50000]++
+
+	delta := computeStateDelta(base, target)
+	assert.Equal(t, true, len(delta) < 2*deltaBlockSize+64, "delta of %d bytes is too large", len(delta))
+}
+
+func TestApplyStateDelta_Invalid(t *testing.T) {
+	base := bytes.Repeat([]byte{1}, 256)
+	tests := []struct {
+		name  string
+		delta []byte
+	}{
+		{name: "empty", delta: []byte{}},
+		{name: "unknown operation", delta: []byte{1, 7}},
+		{name: "copy out of the base", delta: []byte{10, deltaOpCopy, 250, 1, 10}},
+		{name: "insert out of the delta", delta: []byte{10, deltaOpInsert, 10, 1, 2}},
+		{name: "wrong target length", delta: []byte{10, deltaOpCopy, 0, 5}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := applyStateDelta(base, tt.delta)
+			require.ErrorIs(t, err, errInvalidStateDelta)
+		})
+	}
+}
@@ -0,0 +1,143 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+	bolt "go.etcd.io/bbolt"
+)
+
+func setupStateDiffDB(t testing.TB, exponents []uint64) *Store {
+	db, err := NewKVStore(context.Background(), t.TempDir(), WithStateDiffExponents(exponents))
+	require.NoError(t, err, "Failed to instantiate DB")
+	t.Cleanup(func() {
+		require.NoError(t, db.Close(), "Failed to close database")
+	})
+	return db
+}
+
+// stateDiffTestState returns a state at the given slot whose validator set grows with the slot.
+func stateDiffTestState(t *testing.T, slot primitives.Slot) state.BeaconState {
+	st, err := util.NewBeaconStateElectra()
+	require.NoError(t, err)
+	require.NoError(t, st.SetSlot(slot))
+	for i := primitives.Slot(0); i <= slot; i++ {
+		require.NoError(t, st.AppendValidator(&ethpb.Validator{
+			PublicKey:             bytesutil.PadTo([]byte{byte(i)}, 48),
+			WithdrawalCredentials: make([]byte, 32),
+			EffectiveBalance:      uint64(i),
+		}))
+		require.NoError(t, st.AppendBalance(uint64(i)*1000))
+	}
+	return st
+}
+
+func TestValidateStateDiffExponents(t *testing.T) {
+	require.NoError(t, ValidateStateDiffExponents(DefaultStateDiffExponents))
+	require.ErrorContains(t, "no layers", ValidateStateDiffExponents(nil))
+	require.ErrorContains(t, "strictly decreasing", ValidateStateDiffExponents([]uint64{5, 5}))
+	require.ErrorContains(t, "strictly decreasing", ValidateStateDiffExponents([]uint64{2, 5}))
+	require.ErrorContains(t, "greater than 63", ValidateStateDiffExponents([]uint64{64, 0}))
+}
+
+func TestStore_StateDiffLayout(t *testing.T) {
+	dir := t.TempDir()
+	db, err := NewKVStore(context.Background(), dir, WithStateDiffExponents([]uint64{4, 2, 0}))
+	require.NoError(t, err)
+	require.NoError(t, db.Close())
+
+	db, err = NewKVStore(context.Background(), dir, WithStateDiffExponents([]uint64{4, 2, 0}))
+	require.NoError(t, err)
+	require.NoError(t, db.Close())
+
+	_, err = NewKVStore(context.Background(), dir, WithStateDiffExponents([]uint64{5, 0}))
+	require.ErrorContains(t, "differ from the layout", err)
+}
+
+func TestStore_IsHierarchicalStateSlot(t *testing.T) {
+	db := setupStateDiffDB(t, []uint64{4, 2})
+	assert.Equal(t, true, db.IsHierarchicalStateSlot(0))
+	assert.Equal(t, true, db.IsHierarchicalStateSlot(4))
+	assert.Equal(t, true, db.IsHierarchicalStateSlot(16))
+	assert.Equal(t, false, db.IsHierarchicalStateSlot(5))
+	assert.Equal(t, 0, db.stateDiffLayer(32))
+	assert.Equal(t, 1, db.stateDiffLayer(36))
+	require.ErrorContains(t, "not in any layer", db.SaveHierarchicalState(context.Background(), stateDiffTestState(t, 5)))
+
+	db.stateDiffExponents = nil
+	assert.Equal(t, false, db.IsHierarchicalStateSlot(0))
+}
+
+func TestStore_HierarchicalState(t *testing.T) {
+	ctx := context.Background()
+	db := setupStateDiffDB(t, []uint64{4, 2, 0})
+
+	_, err := db.HierarchicalState(ctx, 0)
+	require.ErrorIs(t, err, ErrNotFoundState)
+
+	want := make(map[primitives.Slot]state.BeaconState)
+	for slot := primitives.Slot(0); slot <= 20; slot++ {
+		st := stateDiffTestState(t, slot)
+		require.NoError(t, db.SaveHierarchicalState(ctx, st))
+		want[slot] = st
+	}
+
+	kinds := make(map[primitives.Slot]byte)
+	require.NoError(t, db.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(stateDiffsBucket).ForEach(func(k, v []byte) error {
+			kinds[bytesutil.BytesToSlotBigEndian(k)] = v[0]
+			return nil
+		})
+	}))
+	assert.Equal(t, stateDiffSnapshot, kinds[0])
+	assert.Equal(t, stateDiffSnapshot, kinds[16])
+	assert.Equal(t, stateDiffDelta, kinds[4])
+	assert.Equal(t, stateDiffDelta, kinds[19])
+
+	// Rebuild without the cached bases, as after a restart.
+	db.stateDiffCache.bases = nil
+	for slot, st := range want {
+		assert.Equal(t, true, db.HasHierarchicalState(ctx, slot))
+		got, err := db.HierarchicalState(ctx, slot)
+		require.NoError(t, err)
+		wantRoot, err := st.HashTreeRoot(ctx)
+		require.NoError(t, err)
+		gotRoot, err := got.HashTreeRoot(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, wantRoot, gotRoot, "wrong state at slot %d", slot)
+	}
+	assert.Equal(t, false, db.HasHierarchicalState(ctx, 21))
+}
+
+func TestStore_HierarchicalState_MissingCoarserLayer(t *testing.T) {
+	ctx := context.Background()
+	db := setupStateDiffDB(t, []uint64{4, 2, 0})
+
+	// Without a state at slot 0, the state at slot 5 is diffed against slot 4, which is a snapshot.
+	st4 := stateDiffTestState(t, 4)
+	require.NoError(t, db.SaveHierarchicalState(ctx, st4))
+	st5 := stateDiffTestState(t, 5)
+	require.NoError(t, db.SaveHierarchicalState(ctx, st5))
+
+	var entry []byte
+	require.NoError(t, db.db.View(func(tx *bolt.Tx) error {
+		entry = tx.Bucket(stateDiffsBucket).Get(bytesutil.SlotToBytesBigEndian(4))
+		return nil
+	}))
+	assert.Equal(t, stateDiffSnapshot, entry[0])
+
+	got, err := db.HierarchicalState(ctx, 5)
+	require.NoError(t, err)
+	wantRoot, err := st5.HashTreeRoot(ctx)
+	require.NoError(t, err)
+	gotRoot, err := got.HashTreeRoot(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, wantRoot, gotRoot)
+}
@@ -63,6 +63,17 @@ func TestStateSummary_CacheToDB(t *testing.T) {
 	}
 }
 
+func TestStateSummary_SyncSavesCache(t *testing.T) {
+	db := setupDB(t)
+	r := bytesutil.ToBytes32([]byte{'A'})
+	require.NoError(t, db.SaveStateSummary(context.Background(), &ethpb.StateSummary{Slot: 1, Root: r[:]}))
+	require.Equal(t, 1, db.stateSummaryCache.len())
+
+	require.NoError(t, db.Sync())
+	require.Equal(t, 0, db.stateSummaryCache.len())
+	require.Equal(t, true, db.HasStateSummary(context.Background(), r))
+}
+
 func TestStateSummary_CanDelete(t *testing.T) {
 	db := setupDB(t)
 	ctx := context.Background()
@@ -0,0 +1,78 @@
+package kv
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	tokenomicstypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/tokenomics/types"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v5/monitoring/tracing/trace"
+	bolt "go.etcd.io/bbolt"
+)
+
+// SaveTokenomicsSnapshots saves the given epoch snapshots, keyed by epoch.
+// A snapshot of an epoch which is already saved is overwritten.
+func (s *Store) SaveTokenomicsSnapshots(ctx context.Context, snapshots []*tokenomicstypes.EpochSnapshot) error {
+	_, span := trace.StartSpan(ctx, "BeaconDB.SaveTokenomicsSnapshots")
+	defer span.End()
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket(tokenomicsSnapshotsBucket)
+		for _, snapshot := range snapshots {
+			enc, err := snapshot.MarshalBinary()
+			if err != nil {
+				return err
+			}
+			if err := bkt.Put(bytesutil.EpochToBytesBigEndian(snapshot.Epoch), enc); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// TokenomicsSnapshots returns the saved epoch snapshots within [startEpoch, endEpoch], sorted by epoch.
+// Epochs without a saved snapshot are skipped.
+func (s *Store) TokenomicsSnapshots(ctx context.Context, startEpoch, endEpoch primitives.Epoch) ([]*tokenomicstypes.EpochSnapshot, error) {
+	_, span := trace.StartSpan(ctx, "BeaconDB.TokenomicsSnapshots")
+	defer span.End()
+
+	if startEpoch > endEpoch {
+		return nil, errors.Errorf("start epoch %d is greater than end epoch %d", startEpoch, endEpoch)
+	}
+	snapshots := make([]*tokenomicstypes.EpochSnapshot, 0)
+	err := s.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket(tokenomicsSnapshotsBucket).Cursor()
+		for k, v := c.Seek(bytesutil.EpochToBytesBigEndian(startEpoch)); k != nil; k, v = c.Next() {
+			if bytesutil.BytesToEpochBigEndian(k) > endEpoch {
+				break
+			}
+			snapshot := &tokenomicstypes.EpochSnapshot{}
+			if err := snapshot.UnmarshalBinary(v); err != nil {
+				return err
+			}
+			snapshots = append(snapshots, snapshot)
+		}
+		return nil
+	})
+	return snapshots, err
+}
+
+// LastTokenomicsSnapshot returns the saved epoch snapshot with the highest epoch.
+// It returns nil if no snapshot has been saved.
+func (s *Store) LastTokenomicsSnapshot(ctx context.Context) (*tokenomicstypes.EpochSnapshot, error) {
+	_, span := trace.StartSpan(ctx, "BeaconDB.LastTokenomicsSnapshot")
+	defer span.End()
+
+	var snapshot *tokenomicstypes.EpochSnapshot
+	err := s.db.View(func(tx *bolt.Tx) error {
+		_, v := tx.Bucket(tokenomicsSnapshotsBucket).Cursor().Last()
+		if v == nil {
+			return nil
+		}
+		snapshot = &tokenomicstypes.EpochSnapshot{}
+		return snapshot.UnmarshalBinary(v)
+	})
+	return snapshot, err
+}
@@ -0,0 +1,53 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	tokenomicstypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/tokenomics/types"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestStore_TokenomicsSnapshots(t *testing.T) {
+	db := setupDB(t)
+	ctx := context.Background()
+
+	last, err := db.LastTokenomicsSnapshot(ctx)
+	require.NoError(t, err)
+	require.Equal(t, (*tokenomicstypes.EpochSnapshot)(nil), last)
+
+	snapshots := make([]*tokenomicstypes.EpochSnapshot, 0)
+	for _, e := range []primitives.Epoch{1, 2, 3, 5, 300} {
+		snapshots = append(snapshots, &tokenomicstypes.EpochSnapshot{
+			Epoch:                  e,
+			BlockRoot:              [32]byte{byte(e)},
+			Reserves:               1000 - uint64(e),
+			RewardAdjustmentFactor: uint64(e) * 150,
+		})
+	}
+	require.NoError(t, db.SaveTokenomicsSnapshots(ctx, snapshots))
+
+	got, err := db.TokenomicsSnapshots(ctx, 2, 10)
+	require.NoError(t, err)
+	require.DeepEqual(t, snapshots[1:4], got)
+
+	got, err = db.TokenomicsSnapshots(ctx, 6, 299)
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(got))
+
+	_, err = db.TokenomicsSnapshots(ctx, 10, 2)
+	require.ErrorContains(t, "start epoch 10 is greater than end epoch 2", err)
+
+	last, err = db.LastTokenomicsSnapshot(ctx)
+	require.NoError(t, err)
+	require.DeepEqual(t, snapshots[4], last)
+
+	// Saving the same epoch again overwrites the snapshot.
+	overwrite := &tokenomicstypes.EpochSnapshot{Epoch: 300, Reserves: 1}
+	require.NoError(t, db.SaveTokenomicsSnapshots(ctx, []*tokenomicstypes.EpochSnapshot{overwrite}))
+	last, err = db.LastTokenomicsSnapshot(ctx)
+	require.NoError(t, err)
+	require.DeepEqual(t, overwrite, last)
+}
@@ -0,0 +1,307 @@
+package kv
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v5/monitoring/tracing/trace"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	bolt "go.etcd.io/bbolt"
+)
+
+// IntegrityIssue describes a single inconsistency found in the database.
+type IntegrityIssue struct {
+	Bucket  string
+	Key     []byte
+	Problem string
+}
+
+// String returns a human readable description of the issue.
+func (i IntegrityIssue) String() string {
+	return fmt.Sprintf("%s %#x: %s", i.Bucket, i.Key, i.Problem)
+}
+
+// IntegrityReport is the result of an integrity verification of the database.
+type IntegrityReport struct {
+	Blocks         int
+	SlotIndices    int
+	StateSummaries int
+	FinalizedRoots int
+	States         int
+	Issues         []IntegrityIssue
+}
+
+// OK returns true if no issue was found.
+func (r *IntegrityReport) OK() bool {
+	return len(r.Issues) == 0
+}
+
+func (r *IntegrityReport) add(bucket []byte, key []byte, format string, args ...interface{}) {
+	r.Issues = append(r.Issues, IntegrityIssue{
+		Bucket:  string(bucket),
+		Key:     bytes.Clone(key),
+		Problem: fmt.Sprintf(format, args...),
+	})
+}
+
+type verifiedBlock struct {
+	slot       primitives.Slot
+	parentRoot [32]byte
+}
+
+// VerifyIntegrity walks the blocks, block slot indices, state summaries, finalized block roots index and states
+// buckets, and checks that they agree with each other:
+//   - every block decodes and is stored under its own root,
+//   - the parent of every block is stored, apart from the blocks at the lowest slot above genesis, whose parents
+//     precede the stored history (checkpoint sync, backfill or pruning),
+//   - the slot index and the blocks agree in both directions,
+//   - every state summary refers to a stored block at the same slot,
+//   - every finalized root refers to a stored block with the parent root of its finalized index entry,
+//   - the finalized checkpoint is in the finalized index, and
+//   - every state decodes.
+//
+// Only the data written to the database is verified, not the state summaries still cached by an open store.
+// Nothing is written, so the verification can run on a store opened with NewReadOnlyKVStore.
+// Problems with the stored data are returned in the report, only a failure to read the database is returned as an error.
+func (s *Store) VerifyIntegrity(ctx context.Context) (*IntegrityReport, error) {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.VerifyIntegrity")
+	defer span.End()
+
+	r := &IntegrityReport{}
+	blks := make(map[[32]byte]verifiedBlock)
+	var stateRoots [][32]byte
+	err := s.db.View(func(tx *bolt.Tx) error {
+		genesisRoot := bytesutil.ToBytes32(tx.Bucket(blocksBucket).Get(genesisBlockRootKey))
+		if err := s.verifyBlocks(ctx, tx, r, blks, genesisRoot); err != nil {
+			return err
+		}
+		if err := verifySlotIndices(ctx, tx, r, blks); err != nil {
+			return err
+		}
+		if err := verifyStateSummaries(ctx, tx, r, blks); err != nil {
+			return err
+		}
+		if err := verifyFinalizedRoots(ctx, tx, r, blks); err != nil {
+			return err
+		}
+		return tx.Bucket(stateBucket).ForEach(func(k, _ []byte) error {
+			stateRoots = append(stateRoots, bytesutil.ToBytes32(k))
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// States are decoded one by one outside of the read transaction, as they can be large.
+	for _, root := range stateRoots {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		r.States++
+		st, err := s.State(ctx, root)
+		if err != nil {
+			r.add(stateBucket, root[:], "could not decode state: %v", err)
+			continue
+		}
+		if st == nil || st.IsNil() {
+			r.add(stateBucket, root[:], "state is empty")
+			continue
+		}
+		if _, ok := blks[root]; !ok {
+			r.add(stateBucket, root[:], "block of the state is missing")
+		}
+	}
+	return r, nil
+}
+
+func (s *Store) verifyBlocks(
+	ctx context.Context,
+	tx *bolt.Tx,
+	r *IntegrityReport,
+	blks map[[32]byte]verifiedBlock,
+	genesisRoot [32]byte,
+) error {
+	err := tx.Bucket(blocksBucket).ForEach(func(k, v []byte) error {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		// The bucket also holds the genesis and origin checkpoint root keys.
+		if len(k) != 32 {
+			return nil
+		}
+		r.Blocks++
+		blk, err := unmarshalBlock(ctx, v)
+		if err != nil {
+			r.add(blocksBucket, k, "could not decode block: %v", err)
+			return nil
+		}
+		root, err := blk.Block().HashTreeRoot()
+		if err != nil {
+			r.add(blocksBucket, k, "could not compute block root: %v", err)
+			return nil
+		}
+		if !bytes.Equal(root[:], k) {
+			r.add(blocksBucket, k, "block is stored under the wrong root, its root is %#x", root)
+			return nil
+		}
+		blks[root] = verifiedBlock{slot: blk.Block().Slot(), parentRoot: blk.Block().ParentRoot()}
+		return nil
+	})
+	if err != nil {
+		return errors.Wrap(err, "could not iterate over blocks")
+	}
+
+	lowest := primitives.Slot(0)
+	for root, b := range blks {
+		if root != genesisRoot && b.slot > 0 && (lowest == 0 || b.slot < lowest) {
+			lowest = b.slot
+		}
+	}
+	for root, b := range blks {
+		if root == genesisRoot || b.parentRoot == [32]byte{} || b.slot == lowest {
+			continue
+		}
+		parent, ok := blks[b.parentRoot]
+		if !ok {
+			r.add(blocksBucket, root[:], "parent block %#x is missing", b.parentRoot)
+			continue
+		}
+		if parent.slot >= b.slot {
+			r.add(blocksBucket, root[:], "parent block %#x at slot %d is not before slot %d", b.parentRoot, parent.slot, b.slot)
+		}
+	}
+	return nil
+}
+
+func verifySlotIndices(ctx context.Context, tx *bolt.Tx, r *IntegrityReport, blks map[[32]byte]verifiedBlock) error {
+	indexed := make(map[[32]byte]bool, len(blks))
+	err := tx.Bucket(blockSlotIndicesBucket).ForEach(func(k, v []byte) error {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		r.SlotIndices++
+		slot := bytesutil.BytesToSlotBigEndian(k)
+		if len(v)%32 != 0 {
+			r.add(blockSlotIndicesBucket, k, "index value of %d bytes is not a list of roots", len(v))
+			return nil
+		}
+		for i := 0; i < len(v); i += 32 {
+			root := bytesutil.ToBytes32(v[i : i+32])
+			indexed[root] = true
+			b, ok := blks[root]
+			if !ok {
+				r.add(blockSlotIndicesBucket, k, "indexed block %#x is missing", root)
+				continue
+			}
+			if b.slot != slot {
+				r.add(blockSlotIndicesBucket, k, "indexed block %#x is at slot %d", root, b.slot)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return errors.Wrap(err, "could not iterate over block slot indices")
+	}
+	for root, b := range blks {
+		if !indexed[root] {
+			r.add(blocksBucket, root[:], "block at slot %d is missing from the slot index", b.slot)
+		}
+	}
+	return nil
+}
+
+func verifyStateSummaries(ctx context.Context, tx *bolt.Tx, r *IntegrityReport, blks map[[32]byte]verifiedBlock) error {
+	err := tx.Bucket(stateSummaryBucket).ForEach(func(k, v []byte) error {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		r.StateSummaries++
+		summary := &ethpb.StateSummary{}
+		if err := decode(ctx, v, summary); err != nil {
+			r.add(stateSummaryBucket, k, "could not decode state summary: %v", err)
+			return nil
+		}
+		b, ok := blks[bytesutil.ToBytes32(k)]
+		if !ok {
+			r.add(stateSummaryBucket, k, "block of the state summary is missing")
+			return nil
+		}
+		if summary.Slot != b.slot {
+			r.add(stateSummaryBucket, k, "state summary is at slot %d, but its block is at slot %d", summary.Slot, b.slot)
+		}
+		return nil
+	})
+	return errors.Wrap(err, "could not iterate over state summaries")
+}
+
+func verifyFinalizedRoots(ctx context.Context, tx *bolt.Tx, r *IntegrityReport, blks map[[32]byte]verifiedBlock) error {
+	bkt := tx.Bucket(finalizedBlockRootsIndexBucket)
+	err := bkt.ForEach(func(k, v []byte) error {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if bytes.Equal(k, previousFinalizedCheckpointKey) {
+			return nil
+		}
+		r.FinalizedRoots++
+		b, ok := blks[bytesutil.ToBytes32(k)]
+		if !ok {
+			r.add(finalizedBlockRootsIndexBucket, k, "finalized block is missing")
+			return nil
+		}
+		// Blocks of the latest finalized epoch are only marked until the next finalized checkpoint.
+		if bytes.Equal(v, containerFinalizedButNotCanonical) {
+			return nil
+		}
+		container := &ethpb.FinalizedBlockRootContainer{}
+		if err := decode(ctx, v, container); err != nil {
+			r.add(finalizedBlockRootsIndexBucket, k, "could not decode finalized root container: %v", err)
+			return nil
+		}
+		if !bytes.Equal(container.ParentRoot, b.parentRoot[:]) {
+			r.add(finalizedBlockRootsIndexBucket, k, "indexed parent root %#x differs from the parent root %#x of the block", container.ParentRoot, b.parentRoot)
+		}
+		if len(container.ChildRoot) == 0 {
+			return nil
+		}
+		child, ok := blks[bytesutil.ToBytes32(container.ChildRoot)]
+		if !ok {
+			r.add(finalizedBlockRootsIndexBucket, k, "finalized child block %#x is missing", container.ChildRoot)
+			return nil
+		}
+		if !bytes.Equal(child.parentRoot[:], k) {
+			r.add(finalizedBlockRootsIndexBucket, k, "finalized child block %#x is not a child of the block", container.ChildRoot)
+		}
+		return nil
+	})
+	if err != nil {
+		return errors.Wrap(err, "could not iterate over finalized block roots")
+	}
+
+	enc := tx.Bucket(checkpointBucket).Get(finalizedCheckpointKey)
+	if enc == nil {
+		return nil
+	}
+	cp := &ethpb.Checkpoint{}
+	if err := decode(ctx, enc, cp); err != nil {
+		r.add(checkpointBucket, finalizedCheckpointKey, "could not decode finalized checkpoint: %v", err)
+		return nil
+	}
+	// The finalized checkpoint of a fresh database is the zero root until the genesis block is saved.
+	if bytesutil.ToBytes32(cp.Root) == [32]byte{} {
+		return nil
+	}
+	if _, ok := blks[bytesutil.ToBytes32(cp.Root)]; !ok {
+		r.add(checkpointBucket, finalizedCheckpointKey, "finalized checkpoint block %#x is missing", cp.Root)
+		return nil
+	}
+	if bkt.Get(cp.Root) == nil {
+		r.add(checkpointBucket, finalizedCheckpointKey, "finalized checkpoint block %#x is missing from the finalized index", cp.Root)
+	}
+	return nil
+}
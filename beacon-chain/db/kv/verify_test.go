@@ -0,0 +1,144 @@
+package kv
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+	bolt "go.etcd.io/bbolt"
+)
+
+// setupVerifyDB saves a genesis block and state, and a chain of blocks at slots 1 to 10 finalized at slot 8.
+func setupVerifyDB(t *testing.T) (*Store, [][32]byte) {
+	db := setupDB(t)
+	return db, saveVerifyChain(t, db)
+}
+
+func saveVerifyChain(t *testing.T, db *Store) [][32]byte {
+	ctx := context.Background()
+
+	genesis, err := blocks.NewSignedBeaconBlock(util.NewBeaconBlock())
+	require.NoError(t, err)
+	gRoot, err := genesis.Block().HashTreeRoot()
+	require.NoError(t, err)
+	require.NoError(t, db.SaveBlock(ctx, genesis))
+	require.NoError(t, db.SaveGenesisBlockRoot(ctx, gRoot))
+	st, err := util.NewBeaconState()
+	require.NoError(t, err)
+	require.NoError(t, db.SaveState(ctx, st, gRoot))
+
+	blks := makeBlocks(t, 0, 10, gRoot)
+	require.NoError(t, db.SaveBlocks(ctx, blks))
+	roots := make([][32]byte, len(blks))
+	for i, b := range blks {
+		roots[i], err = b.Block().HashTreeRoot()
+		require.NoError(t, err)
+		require.NoError(t, db.SaveStateSummary(ctx, &ethpb.StateSummary{Slot: b.Block().Slot(), Root: roots[i][:]}))
+	}
+	require.NoError(t, db.SaveFinalizedCheckpoint(ctx, &ethpb.Checkpoint{Epoch: 0, Root: roots[7][:]}))
+	require.NoError(t, db.saveCachedStateSummariesDB(ctx))
+	return roots
+}
+
+func TestStore_VerifyIntegrity(t *testing.T) {
+	db, _ := setupVerifyDB(t)
+	r, err := db.VerifyIntegrity(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, true, r.OK(), "unexpected issues: %v", r.Issues)
+	assert.Equal(t, 11, r.Blocks)
+	assert.Equal(t, 11, r.SlotIndices)
+	assert.Equal(t, 10, r.StateSummaries)
+	assert.Equal(t, 1, r.States)
+	// The blocks of the finalized epoch are all in the finalized index.
+	assert.Equal(t, 11, r.FinalizedRoots)
+}
+
+func TestStore_VerifyIntegrity_ReadOnly(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	db, err := NewKVStore(ctx, dir)
+	require.NoError(t, err)
+	saveVerifyChain(t, db)
+	require.NoError(t, db.Close())
+
+	ro, err := NewReadOnlyKVStore(ctx, dir)
+	require.NoError(t, err)
+	r, err := ro.VerifyIntegrity(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, true, r.OK(), "unexpected issues: %v", r.Issues)
+	assert.Equal(t, 11, r.Blocks)
+	assert.Equal(t, 10, r.StateSummaries)
+	require.ErrorIs(t, ro.SaveGenesisBlockRoot(ctx, [32]byte{'a'}), bolt.ErrDatabaseReadOnly)
+	require.NoError(t, ro.Close())
+}
+
+func TestStore_VerifyIntegrity_Pruned(t *testing.T) {
+	ctx := context.Background()
+	db, _ := setupVerifyDB(t)
+	_, err := db.PruneHistory(ctx, 4, 64)
+	require.NoError(t, err)
+
+	// The parent of the lowest block after pruning is expected to be missing.
+	r, err := db.VerifyIntegrity(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, true, r.OK(), "unexpected issues: %v", r.Issues)
+}
+
+func TestStore_VerifyIntegrity_Issues(t *testing.T) {
+	ctx := context.Background()
+	db, roots := setupVerifyDB(t)
+	require.NoError(t, db.saveCachedStateSummariesDB(ctx))
+
+	require.NoError(t, db.db.Update(func(tx *bolt.Tx) error {
+		// The block at slot 5 is missing, which breaks the parent link of slot 6 and the index of slot 5.
+		if err := tx.Bucket(blocksBucket).Delete(roots[4][:]); err != nil {
+			return err
+		}
+		// The block at slot 2 is missing from the slot index.
+		if err := tx.Bucket(blockSlotIndicesBucket).Delete(bytesutil.SlotToBytesBigEndian(2)); err != nil {
+			return err
+		}
+		// The block at slot 9 is corrupted.
+		if err := tx.Bucket(blocksBucket).Put(roots[8][:], []byte{1, 2, 3}); err != nil {
+			return err
+		}
+		// The state summary at slot 3 has the wrong slot.
+		enc, err := encode(ctx, &ethpb.StateSummary{Slot: 4, Root: roots[2][:]})
+		if err != nil {
+			return err
+		}
+		if err := tx.Bucket(stateSummaryBucket).Put(roots[2][:], enc); err != nil {
+			return err
+		}
+		// The state of the block at slot 1 is corrupted.
+		return tx.Bucket(stateBucket).Put(roots[0][:], []byte{1, 2, 3})
+	}))
+
+	r, err := db.VerifyIntegrity(ctx)
+	require.NoError(t, err)
+	problems := make(map[[32]byte][]string)
+	for _, issue := range r.Issues {
+		problems[bytesutil.ToBytes32(issue.Key)] = append(problems[bytesutil.ToBytes32(issue.Key)], issue.String())
+	}
+	assertIssue := func(key []byte, substr string) {
+		for _, p := range problems[bytesutil.ToBytes32(key)] {
+			if strings.Contains(p, substr) {
+				return
+			}
+		}
+		t.Errorf("no issue %q for key %#x in %v", substr, key, r.Issues)
+	}
+	assertIssue(roots[5][:], "parent block")
+	assertIssue(bytesutil.SlotToBytesBigEndian(5), "is missing")
+	assertIssue(roots[1][:], "missing from the slot index")
+	assertIssue(roots[8][:], "could not decode block")
+	assertIssue(roots[2][:], "state summary is at slot 4")
+	assertIssue(roots[0][:], "could not decode state")
+	assertIssue(roots[4][:], "finalized block is missing")
+}
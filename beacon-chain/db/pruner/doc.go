@@ -0,0 +1,8 @@
+/*
+Package pruner defines a runtime service which deletes the finalized history
+of the chain older than a retention period from the beacon database, for nodes
+which do not serve as archives. Blocks, state summaries, archived states and
+their indices are deleted in the background after each finalized checkpoint,
+and the database records the lowest slot it still has available.
+*/
+package pruner
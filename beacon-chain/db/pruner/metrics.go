@@ -0,0 +1,17 @@
+package pruner
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	lowestAvailableSlot = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "db_pruner_lowest_available_slot",
+		Help: "The lowest slot whose blocks and states are stored in the database",
+	})
+	prunedBlocksCount = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "db_pruner_pruned_blocks_total",
+		Help: "The number of blocks deleted from the database by the history pruner",
+	})
+)
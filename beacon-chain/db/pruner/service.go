@@ -0,0 +1,147 @@
+package pruner
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/feed"
+	statefeed "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/feed/state"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/startup"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+	"github.com/sirupsen/logrus"
+)
+
+// DefaultBatchSize is the default number of slots pruned in a single database transaction.
+const DefaultBatchSize = 64
+
+// Config contains the dependencies of the history pruner.
+type Config struct {
+	BeaconDB      db.NoHeadAccessDatabase
+	StateNotifier statefeed.Notifier
+	ClockWaiter   startup.ClockWaiter
+	// RetentionEpochs is the number of epochs of history kept before the current epoch.
+	RetentionEpochs primitives.Epoch
+	// BatchSize is the number of slots pruned in a single database transaction.
+	BatchSize uint64
+}
+
+// Service prunes the history of the chain each time a new checkpoint is finalized. Everything before
+// the start of the retention period is deleted, as long as it is finalized.
+type Service struct {
+	cfg    *Config
+	ctx    context.Context
+	cancel context.CancelFunc
+	clock  *startup.Clock
+}
+
+// NewService sets up a new history pruner service.
+func NewService(ctx context.Context, cfg *Config) *Service {
+	ctx, cancel := context.WithCancel(ctx)
+	if cfg.BatchSize == 0 {
+		cfg.BatchSize = DefaultBatchSize
+	}
+	return &Service{
+		cfg:    cfg,
+		ctx:    ctx,
+		cancel: cancel,
+	}
+}
+
+// Start the history pruner service.
+func (s *Service) Start() {
+	go s.run()
+}
+
+// Stop the history pruner service.
+func (s *Service) Stop() error {
+	s.cancel()
+	return nil
+}
+
+// Status of the history pruner service.
+func (*Service) Status() error {
+	return nil
+}
+
+func (s *Service) run() {
+	stateChannel := make(chan *feed.Event, 1)
+	stateSub := s.cfg.StateNotifier.StateFeed().Subscribe(stateChannel)
+	defer stateSub.Unsubscribe()
+
+	clock, err := s.cfg.ClockWaiter.WaitForClock(s.ctx)
+	if err != nil {
+		log.WithError(err).Error("Could not receive the genesis time")
+		return
+	}
+	s.clock = clock
+	log.WithField("retentionEpochs", s.cfg.RetentionEpochs).Info("Starting history pruner")
+	s.prune(s.ctx)
+
+	for {
+		select {
+		case ev := <-stateChannel:
+			if ev.Type == statefeed.FinalizedCheckpoint {
+				s.prune(s.ctx)
+			}
+		case <-s.ctx.Done():
+			log.Debug("Context closed, exiting goroutine")
+			return
+		case err := <-stateSub.Err():
+			log.WithError(err).Error("Could not subscribe to state notifier")
+			return
+		}
+	}
+}
+
+func (s *Service) prune(ctx context.Context) {
+	pruneBefore, err := s.pruneBefore(ctx)
+	if err != nil {
+		log.WithError(err).Error("Could not determine the slot to prune the history up to")
+		return
+	}
+	deleted, err := s.cfg.BeaconDB.PruneHistory(ctx, pruneBefore, s.cfg.BatchSize)
+	prunedBlocksCount.Add(float64(deleted))
+	if err != nil {
+		log.WithError(err).Error("Could not prune history")
+	}
+	lowest, lErr := s.cfg.BeaconDB.LowestAvailableSlot(ctx)
+	if lErr != nil {
+		log.WithError(lErr).Error("Could not get the lowest available slot")
+		return
+	}
+	lowestAvailableSlot.Set(float64(lowest))
+	if deleted > 0 {
+		log.WithFields(logrus.Fields{
+			"deletedBlocks":       deleted,
+			"lowestAvailableSlot": lowest,
+		}).Info("Pruned history")
+	}
+}
+
+// pruneBefore returns the first slot of the retention period, or the slot of the finalized block
+// if the retention period starts after it.
+func (s *Service) pruneBefore(ctx context.Context) (primitives.Slot, error) {
+	current := slots.ToEpoch(s.clock.CurrentSlot())
+	if current <= s.cfg.RetentionEpochs {
+		return 0, nil
+	}
+	start, err := slots.EpochStart(current - s.cfg.RetentionEpochs)
+	if err != nil {
+		return 0, err
+	}
+	cp, err := s.cfg.BeaconDB.FinalizedCheckpoint(ctx)
+	if err != nil {
+		return 0, errors.Wrap(err, "could not get finalized checkpoint")
+	}
+	finalized, err := s.cfg.BeaconDB.Block(ctx, bytesutil.ToBytes32(cp.Root))
+	if err != nil {
+		return 0, errors.Wrap(err, "could not get finalized block")
+	}
+	if finalized == nil || finalized.IsNil() {
+		return 0, errors.New("finalized block is missing")
+	}
+	return min(start, finalized.Block().Slot()), nil
+}
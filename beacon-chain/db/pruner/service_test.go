@@ -0,0 +1,63 @@
+package pruner
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	testDB "github.com/prysmaticlabs/prysm/v5/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/startup"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+func TestService_Prune(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := testDB.SetupDB(t)
+	genesis, _ := util.DeterministicGenesisStateElectra(t, 64)
+	require.NoError(t, beaconDB.SaveGenesisData(ctx, genesis))
+	parent, err := beaconDB.GenesisBlockRoot(ctx)
+	require.NoError(t, err)
+
+	spe := params.BeaconConfig().SlotsPerEpoch
+	var finalizedRoot [32]byte
+	for slot := primitives.Slot(1); slot <= 4*spe; slot++ {
+		b := util.NewBeaconBlock()
+		b.Block.Slot = slot
+		b.Block.ParentRoot = parent[:]
+		wsb, err := blocks.NewSignedBeaconBlock(b)
+		require.NoError(t, err)
+		require.NoError(t, beaconDB.SaveBlock(ctx, wsb))
+		parent, err = wsb.Block().HashTreeRoot()
+		require.NoError(t, err)
+		if slot == 3*spe {
+			finalizedRoot = parent
+		}
+	}
+	require.NoError(t, beaconDB.SaveFinalizedCheckpoint(ctx, &ethpb.Checkpoint{Epoch: 3, Root: finalizedRoot[:]}))
+
+	// The current epoch is 5, so the retention period starts at epoch 4, after the finalized block.
+	genesisTime := time.Now().Add(-time.Duration(5*uint64(spe)*params.BeaconConfig().SecondsPerSlot) * time.Second)
+	s := NewService(ctx, &Config{BeaconDB: beaconDB, RetentionEpochs: 1})
+	s.clock = startup.NewClock(genesisTime, [32]byte{})
+	s.prune(ctx)
+	lowest, err := beaconDB.LowestAvailableSlot(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 3*spe, lowest)
+
+	// A longer retention period keeps more of the history.
+	s = NewService(ctx, &Config{BeaconDB: beaconDB, RetentionEpochs: 10})
+	s.clock = startup.NewClock(genesisTime, [32]byte{})
+	s.prune(ctx)
+	lowest, err = beaconDB.LowestAvailableSlot(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 3*spe, lowest)
+	has, _, err := beaconDB.BlockRootsBySlot(ctx, 3*spe-1)
+	require.NoError(t, err)
+	require.Equal(t, false, has)
+	require.Equal(t, true, beaconDB.HasBlock(ctx, finalizedRoot))
+}
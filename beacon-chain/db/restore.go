@@ -13,13 +13,25 @@ import (
 	"github.com/urfave/cli/v2"
 )
 
+// backupArchiveExtension is the extension of the backup archives written by the backup command.
+const backupArchiveExtension = ".tar"
+
 const dbExistsYesNoPrompt = "A database file already exists in the target directory. " +
 	"Are you sure that you want to overwrite it? [y/n]"
 
-// Restore a beacon chain database.
+// Restore a beacon chain database, either from a database file or from a backup archive followed by its
+// incremental backup archives. The database is first restored into a staging directory, where the manifest
+// checksums of the archives and the consistency of the genesis and finalized checkpoint are verified, and only
+// then replaces the database in the target directory.
 func Restore(cliCtx *cli.Context) error {
 	sourceFile := cliCtx.String(cmd.RestoreSourceFileFlag.Name)
 	targetDir := cliCtx.String(cmd.RestoreTargetDirFlag.Name)
+	incrementalFiles := cliCtx.StringSlice(cmd.RestoreIncrementalFilesFlag.Name)
+
+	isArchive := strings.HasSuffix(sourceFile, backupArchiveExtension)
+	if !isArchive && len(incrementalFiles) > 0 {
+		return errors.New("incremental backup archives can only be restored on top of a backup archive")
+	}
 
 	restoreDir := path.Join(targetDir, kv.BeaconNodeDbDirName)
 	restoreFile := path.Join(restoreDir, kv.DatabaseFileName)
@@ -44,10 +56,44 @@ func Restore(cliCtx *cli.Context) error {
 	if err := file.MkdirAll(restoreDir); err != nil {
 		return err
 	}
-	if err := file.CopyFile(sourceFile, path.Join(restoreDir, kv.DatabaseFileName)); err != nil {
-		return err
+
+	stagingDir, err := os.MkdirTemp(targetDir, "restore-")
+	if err != nil {
+		return errors.Wrap(err, "could not create staging directory")
+	}
+	defer func() {
+		if err := os.RemoveAll(stagingDir); err != nil {
+			log.WithError(err).Error("Could not remove staging directory")
+		}
+	}()
+	if isArchive {
+		err = restoreArchives(cliCtx, stagingDir, append([]string{sourceFile}, incrementalFiles...))
+	} else {
+		err = restoreFileToStaging(cliCtx, stagingDir, sourceFile)
+	}
+	if err != nil {
+		return errors.Wrap(err, "could not verify restored database, the existing database was left untouched")
+	}
+	if err := os.Rename(path.Join(stagingDir, kv.DatabaseFileName), restoreFile); err != nil {
+		return errors.Wrap(err, "could not replace database")
 	}
 
 	log.Info("Restore completed successfully")
 	return nil
 }
+
+func restoreFileToStaging(cliCtx *cli.Context, stagingDir, sourceFile string) error {
+	if err := file.CopyFile(sourceFile, path.Join(stagingDir, kv.DatabaseFileName)); err != nil {
+		return err
+	}
+	d, err := kv.NewKVStore(cliCtx.Context, stagingDir, kv.WithoutBlockStorageSetup())
+	if err != nil {
+		return errors.Wrap(err, "could not open restored database")
+	}
+	defer func() {
+		if err := d.Close(); err != nil {
+			log.WithError(err).Error("Could not close database")
+		}
+	}()
+	return d.VerifyRestoredBackup(cliCtx.Context, nil)
+}
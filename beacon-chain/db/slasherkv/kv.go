@@ -34,6 +34,15 @@ type Store struct {
 	ctx          context.Context
 }
 
+// Buckets of the slasher database, created when the store is opened.
+var Buckets = [][]byte{
+	attestedEpochsByValidator,
+	attestationRecordsBucket,
+	attestationDataRootsBucket,
+	proposalRecordsBucket,
+	slasherChunksBucket,
+}
+
 // NewKVStore initializes a new boltDB key-value store at the directory
 // path specified, creates the kv-buckets based on the schema, and stores
 // an open connection db object as a property of the Store struct.
@@ -70,15 +79,7 @@ func NewKVStore(ctx context.Context, dirPath string) (*Store, error) {
 	}
 
 	if err := kv.db.Update(func(tx *bolt.Tx) error {
-		return createBuckets(
-			tx,
-			// Slasher buckets.
-			attestedEpochsByValidator,
-			attestationRecordsBucket,
-			attestationDataRootsBucket,
-			proposalRecordsBucket,
-			slasherChunksBucket,
-		)
+		return createBuckets(tx, Buckets...)
 	}); err != nil {
 		return nil, err
 	}
@@ -0,0 +1,143 @@
+package slasherkv
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"os"
+	"path"
+
+	"github.com/pkg/errors"
+	ssz "github.com/prysmaticlabs/fastssz"
+	slashertypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/slasher/types"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/monitoring/tracing/trace"
+	bolt "go.etcd.io/bbolt"
+)
+
+// Summary reports the size of the database file and of each bucket but the slasher chunks one, the epochs
+// covered by the attestation and proposal records, the number of attested validators and the keys of the
+// stored min and max span chunks. Unlike Stats, it reads neither the attested epochs nor the values of the
+// chunks, and the sizes of the buckets come from their page headers, so it is cheap enough to be called on
+// a database in use. The lowest and highest epochs written are left unset.
+func (s *Store) Summary(ctx context.Context) (*slashertypes.DatabaseStats, error) {
+	_, span := trace.StartSpan(ctx, "BeaconDB.Summary")
+	defer span.End()
+
+	info, err := os.Stat(path.Join(s.databasePath, DatabaseFileName))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not stat database file")
+	}
+	stats := &slashertypes.DatabaseStats{
+		FileSize: info.Size(),
+		ChunkIDs: map[slashertypes.ChunkKind][]uint64{},
+	}
+	err = s.db.View(func(tx *bolt.Tx) error {
+		for _, name := range Buckets {
+			// The slasher chunks bucket is by far the largest, its chunks are counted by the walk of its keys.
+			if bytes.Equal(name, slasherChunksBucket) {
+				continue
+			}
+			b := bucketStats(tx, name)
+			if bytes.Equal(name, attestedEpochsByValidator) {
+				stats.AttestedValidators = b.Entries
+			}
+			stats.Buckets = append(stats.Buckets, b)
+		}
+		recordRanges(tx, stats)
+		return readChunkIDs(tx, stats)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+func bucketStats(tx *bolt.Tx, name []byte) *slashertypes.BucketStats {
+	bs := tx.Bucket(name).Stats()
+	return &slashertypes.BucketStats{
+		Name:           string(name),
+		Entries:        uint64(bs.KeyN),
+		InUseBytes:     uint64(bs.BranchInuse + bs.LeafInuse),
+		AllocatedBytes: uint64(bs.BranchAlloc + bs.LeafAlloc),
+	}
+}
+
+// readChunkIDs sets the flat slice ids of the stored chunks of each kind. Chunk keys are made of the
+// chunk kind and of the flat slice id of the chunk, so only the keys are read.
+func readChunkIDs(tx *bolt.Tx, stats *slashertypes.DatabaseStats) error {
+	c := tx.Bucket(slasherChunksBucket).Cursor()
+	for k, _ := c.First(); k != nil; k, _ = c.Next() {
+		if len(k) != 9 {
+			return errors.Errorf("invalid slasher chunk key %#x", k)
+		}
+		kind := slashertypes.ChunkKind(k[0])
+		stats.ChunkIDs[kind] = append(stats.ChunkIDs[kind], ssz.UnmarshallUint64(k[1:]))
+	}
+	return nil
+}
+
+// recordRanges sets the epochs covered by the attestation records and the slots covered by the proposal
+// records. Attestation data roots and proposals are keyed by big endian target epoch and slot.
+func recordRanges(tx *bolt.Tx, stats *slashertypes.DatabaseStats) {
+	c := tx.Bucket(attestationDataRootsBucket).Cursor()
+	if first, _ := c.First(); first != nil {
+		last, _ := c.Last()
+		stats.HasAttestations = true
+		stats.LowestTargetEpoch = primitives.Epoch(binary.BigEndian.Uint64(first[:8]))
+		stats.HighestTargetEpoch = primitives.Epoch(binary.BigEndian.Uint64(last[:8]))
+	}
+	c = tx.Bucket(proposalRecordsBucket).Cursor()
+	if first, _ := c.First(); first != nil {
+		last, _ := c.Last()
+		stats.HasProposals = true
+		stats.LowestProposalSlot = slotFromProposalKey(first)
+		stats.HighestProposalSlot = slotFromProposalKey(last)
+	}
+}
+
+// Stats reports the size of the database file and of each bucket, the epochs covered by the
+// attestation and proposal records, and the keys of the stored min and max span chunks.
+// It reads every attested epoch and every chunk key, so it takes a while on large databases.
+func (s *Store) Stats(ctx context.Context) (*slashertypes.DatabaseStats, error) {
+	_, span := trace.StartSpan(ctx, "BeaconDB.Stats")
+	defer span.End()
+
+	info, err := os.Stat(path.Join(s.databasePath, DatabaseFileName))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not stat database file")
+	}
+	stats := &slashertypes.DatabaseStats{
+		FileSize: info.Size(),
+		ChunkIDs: map[slashertypes.ChunkKind][]uint64{},
+	}
+
+	err = s.db.View(func(tx *bolt.Tx) error {
+		for _, name := range Buckets {
+			stats.Buckets = append(stats.Buckets, bucketStats(tx, name))
+		}
+
+		recordRanges(tx, stats)
+
+		if err := tx.Bucket(attestedEpochsByValidator).ForEach(func(_, v []byte) error {
+			var epoch primitives.Epoch
+			if err := epoch.UnmarshalSSZ(v); err != nil {
+				return err
+			}
+			if stats.AttestedValidators == 0 || epoch < stats.LowestEpochWritten {
+				stats.LowestEpochWritten = epoch
+			}
+			stats.HighestEpochWritten = max(stats.HighestEpochWritten, epoch)
+			stats.AttestedValidators++
+			return nil
+		}); err != nil {
+			return errors.Wrap(err, "could not read attested epochs")
+		}
+
+		return readChunkIDs(tx, stats)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
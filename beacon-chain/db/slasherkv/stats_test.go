@@ -0,0 +1,71 @@
+package slasherkv
+
+import (
+	"context"
+	"testing"
+
+	ssz "github.com/prysmaticlabs/fastssz"
+	slashertypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/slasher/types"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestStore_Stats(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := setupDB(t)
+
+	stats, err := beaconDB.Stats(ctx)
+	require.NoError(t, err)
+	require.Equal(t, len(Buckets), len(stats.Buckets))
+	require.Equal(t, false, stats.HasAttestations)
+	require.Equal(t, false, stats.HasProposals)
+	require.Equal(t, true, stats.FileSize > 0)
+
+	require.NoError(t, beaconDB.SaveAttestationRecordsForValidators(ctx, []*slashertypes.IndexedAttestationWrapper{
+		createAttestationWrapper(0, 2, []uint64{1, 2}, nil),
+		createAttestationWrapper(4, 6, []uint64{1}, nil),
+	}))
+	require.NoError(t, beaconDB.SaveBlockProposals(ctx, []*slashertypes.SignedBlockHeaderWrapper{
+		createProposalWrapper(t, 40, 1, nil),
+		createProposalWrapper(t, 9, 2, nil),
+	}))
+	require.NoError(t, beaconDB.SaveLastEpochWrittenForValidators(ctx, map[primitives.ValidatorIndex]primitives.Epoch{
+		1: 6,
+		2: 2,
+	}))
+	require.NoError(t, beaconDB.SaveSlasherChunks(ctx, slashertypes.MaxSpan, [][]byte{
+		ssz.MarshalUint64(nil, 3), ssz.MarshalUint64(nil, 70),
+	}, [][]uint16{{1}, {2}}))
+
+	summary, err := beaconDB.Summary(ctx)
+	require.NoError(t, err)
+	require.Equal(t, true, summary.FileSize > 0)
+	require.Equal(t, primitives.Epoch(2), summary.LowestTargetEpoch)
+	require.Equal(t, primitives.Epoch(6), summary.HighestTargetEpoch)
+	require.Equal(t, primitives.Slot(9), summary.LowestProposalSlot)
+	require.Equal(t, primitives.Slot(40), summary.HighestProposalSlot)
+	// Every bucket but the slasher chunks one is reported.
+	require.Equal(t, len(Buckets)-1, len(summary.Buckets))
+	require.Equal(t, uint64(2), summary.AttestedValidators)
+	require.DeepEqual(t, []uint64{3, 70}, summary.ChunkIDs[slashertypes.MaxSpan])
+	require.Equal(t, 0, len(summary.ChunkIDs[slashertypes.MinSpan]))
+
+	stats, err = beaconDB.Stats(ctx)
+	require.NoError(t, err)
+	require.Equal(t, true, stats.HasAttestations)
+	require.Equal(t, primitives.Epoch(2), stats.LowestTargetEpoch)
+	require.Equal(t, primitives.Epoch(6), stats.HighestTargetEpoch)
+	require.Equal(t, true, stats.HasProposals)
+	require.Equal(t, primitives.Slot(9), stats.LowestProposalSlot)
+	require.Equal(t, primitives.Slot(40), stats.HighestProposalSlot)
+	require.Equal(t, uint64(2), stats.AttestedValidators)
+	require.Equal(t, primitives.Epoch(2), stats.LowestEpochWritten)
+	require.Equal(t, primitives.Epoch(6), stats.HighestEpochWritten)
+	require.DeepEqual(t, []uint64{3, 70}, stats.ChunkIDs[slashertypes.MaxSpan])
+	require.Equal(t, 0, len(stats.ChunkIDs[slashertypes.MinSpan]))
+	for _, b := range stats.Buckets {
+		if b.Name == string(attestationDataRootsBucket) {
+			require.Equal(t, uint64(3), b.Entries)
+		}
+	}
+}
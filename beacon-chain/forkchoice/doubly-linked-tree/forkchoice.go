@@ -3,9 +3,9 @@ package doublylinkedtree
 import (
 	"context"
 	"fmt"
-	"time"
 
 	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/epoch/precompute"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/forkchoice"
 	forkchoicetypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/forkchoice/types"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
@@ -70,7 +70,7 @@ func (f *ForkChoice) Head(
 
 	jc := f.JustifiedCheckpoint()
 	fc := f.FinalizedCheckpoint()
-	currentEpoch := slots.EpochsSinceGenesis(time.Unix(int64(f.store.genesisTime), 0))
+	currentEpoch := slots.ToEpoch(f.store.currentSlot())
 	if err := f.store.treeRootNode.updateBestDescendant(ctx, jc.Epoch, fc.Epoch, currentEpoch); err != nil {
 		return [32]byte{}, errors.Wrap(err, "could not update best descendant")
 	}
@@ -99,6 +99,12 @@ func (f *ForkChoice) ProcessAttestation(ctx context.Context, validatorIndices []
 			f.votes[index].nextRoot = blockRoot
 		}
 	}
+	if event := f.recorder.event(eventAttestation, f.store.now()); event != nil {
+		event.root = blockRoot
+		event.targetEpoch = targetEpoch
+		event.indices = append([]uint64{}, validatorIndices...)
+		f.recorder.record(event)
+	}
 
 	processedAttestationCount.Inc()
 }
@@ -118,12 +124,17 @@ func (f *ForkChoice) InsertNode(ctx context.Context, state state.BeaconState, ro
 		return errInvalidNilCheckpoint
 	}
 	finalizedEpoch := fc.Epoch
+	event := f.recorder.insertEvent(f.store.now(), roblock, state.Slot(), jc, fc, true)
 	node, err := f.store.insert(ctx, roblock, justifiedEpoch, finalizedEpoch)
 	if err != nil {
 		return err
 	}
 
-	jc, fc = f.store.pullTips(state, node, jc, fc)
+	jc, fc = f.store.pullTips(state.Slot(), node, jc, fc, func() (*ethpb.Checkpoint, *ethpb.Checkpoint, error) {
+		uj, uf, err := precompute.UnrealizedCheckpoints(state)
+		event.setUnrealized(uj, uf)
+		return uj, uf, err
+	})
 	if err := f.updateCheckpoints(ctx, jc, fc); err != nil {
 		_, remErr := f.store.removeNode(ctx, node)
 		if remErr != nil {
@@ -131,6 +142,7 @@ func (f *ForkChoice) InsertNode(ctx context.Context, state state.BeaconState, ro
 		}
 		return errors.Wrap(err, "could not update checkpoints")
 	}
+	f.recorder.record(event)
 	return nil
 }
 
@@ -372,6 +384,10 @@ func (f *ForkChoice) InsertSlashedIndex(_ context.Context, index primitives.Vali
 		return
 	}
 	f.store.slashedIndices[index] = true
+	if event := f.recorder.event(eventSlashing, f.store.now()); event != nil {
+		event.indices = []uint64{uint64(index)}
+		f.recorder.record(event)
+	}
 
 	// Subtract last vote from this equivocating validator
 
@@ -473,6 +489,7 @@ func (f *ForkChoice) InsertChain(ctx context.Context, chain []*forkchoicetypes.B
 		return nil
 	}
 	for i := len(chain) - 1; i > 0; i-- {
+		event := f.recorder.insertEvent(f.store.now(), chain[i].Block, chain[i].Block.Block().Slot(), chain[i].JustifiedCheckpoint, chain[i].FinalizedCheckpoint, false)
 		if _, err := f.store.insert(ctx,
 			chain[i].Block,
 			chain[i].JustifiedCheckpoint.Epoch, chain[i].FinalizedCheckpoint.Epoch); err != nil {
@@ -481,6 +498,7 @@ func (f *ForkChoice) InsertChain(ctx context.Context, chain []*forkchoicetypes.B
 		if err := f.updateCheckpoints(ctx, chain[i].JustifiedCheckpoint, chain[i].FinalizedCheckpoint); err != nil {
 			return err
 		}
+		f.recorder.record(event)
 	}
 	return nil
 }
@@ -600,6 +618,11 @@ func (f *ForkChoice) updateJustifiedBalances(ctx context.Context, root [32]byte)
 	if err != nil {
 		return errors.Wrap(err, "could not get justified balances")
 	}
+	if event := f.recorder.event(eventBalances, f.store.now()); event != nil {
+		event.root = root
+		event.balances = append([]uint64{}, balances...)
+		f.recorder.record(event)
+	}
 	f.justifiedBalances = balances
 	f.store.committeeWeight = 0
 	f.numActiveValidators = 0
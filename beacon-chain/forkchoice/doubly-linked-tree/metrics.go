@@ -45,6 +45,13 @@ var (
 			Help: "The number of times an attestation is processed for fork choice.",
 		},
 	)
+	reorgDecisionCount = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "doublylinkedtree_late_block_reorg_decision_count",
+			Help: "The number of late block reorg decisions, by check, outcome and reason.",
+		},
+		[]string{"check", "reorg", "reason"},
+	)
 	prunedCount = promauto.NewCounter(
 		prometheus.CounterOpts{
 			Name: "doublylinkedtree_pruned_count",
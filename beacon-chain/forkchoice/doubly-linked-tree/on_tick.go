@@ -30,6 +30,14 @@ import (
 //	    if ancestor_at_finalized_slot == store.finalized_checkpoint.root:
 //	        store.justified_checkpoint = store.best_justified_checkpoint
 func (f *ForkChoice) NewSlot(ctx context.Context, slot primitives.Slot) error {
+	if slots.IsEpochStart(slot) {
+		f.recorder.startEpoch(f, slot)
+	}
+	if event := f.recorder.event(eventTick, f.store.now()); event != nil {
+		event.slot = slot
+		f.recorder.record(event)
+	}
+
 	// Reset proposer boost root
 	f.store.proposerBoostRoot = [32]byte{}
 
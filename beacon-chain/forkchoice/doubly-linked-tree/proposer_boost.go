@@ -26,7 +26,7 @@ func (f *ForkChoice) applyProposerBoostScore() error {
 		if !ok || currentNode == nil {
 			log.WithError(errInvalidProposerBoostRoot).Errorf(fmt.Sprintf("invalid current root %#x", s.proposerBoostRoot))
 		} else {
-			proposerScore = (s.committeeWeight * params.BeaconConfig().ProposerScoreBoost) / 100
+			proposerScore = (s.committeeWeight * f.policy().ProposerScoreBoost) / 100
 			currentNode.balance += proposerScore
 		}
 	}
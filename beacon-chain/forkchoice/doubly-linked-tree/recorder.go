@@ -0,0 +1,251 @@
+package doublylinkedtree
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+	forkchoicetypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/forkchoice/types"
+	consensus_blocks "github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+)
+
+// recordingVersion is the version of the encoding of the fork choice recordings.
+const recordingVersion = 1
+
+// recordedEventType is the type of a fork choice input.
+type recordedEventType uint8
+
+const (
+	eventTick recordedEventType = iota
+	eventInsertNode
+	eventAttestation
+	eventSlashing
+	eventBalances
+)
+
+// recordedEvent is an input of fork choice, with all the data fork choice derived from the beacon
+// state so that it can be processed again without the state.
+type recordedEvent struct {
+	kind                recordedEventType
+	time                uint64 // unix time at which fork choice received the input.
+	slot                primitives.Slot
+	stateSlot           primitives.Slot
+	root                [32]byte
+	parentRoot          [32]byte
+	payloadHash         [32]byte
+	justified           *forkchoicetypes.Checkpoint
+	finalized           *forkchoicetypes.Checkpoint
+	pullTips            bool                        // whether the unrealized checkpoints of the block are pulled.
+	unrealizedJustified *forkchoicetypes.Checkpoint // nil if the unrealized checkpoints were not computed.
+	unrealizedFinalized *forkchoicetypes.Checkpoint
+	indices             []uint64 // the attesting indices, or the slashed index.
+	targetEpoch         primitives.Epoch
+	balances            []uint64
+}
+
+// recordedEpoch holds a snapshot of the store at the start of an epoch, followed by the inputs
+// of fork choice during the epoch.
+type recordedEpoch struct {
+	slot     primitives.Slot
+	snapshot []byte
+	events   []*recordedEvent
+}
+
+// recorder keeps the inputs of fork choice during the latest epochs, in a ring buffer, so that
+// fork choice can be replayed offline at any slot of these epochs.
+type recorder struct {
+	size   int
+	epochs []*recordedEpoch
+}
+
+// EnableRecording makes fork choice record its inputs during the given number of latest epochs.
+// Every epoch is recorded along with a snapshot of the store, so the memory used grows with the
+// number of validators.
+func (f *ForkChoice) EnableRecording(epochs int) {
+	if epochs <= 0 {
+		f.recorder = nil
+		return
+	}
+	f.recorder = &recorder{size: epochs}
+}
+
+// Recording returns the encoded inputs recorded by fork choice, which can be replayed with Replay.
+func (f *ForkChoice) Recording() ([]byte, error) {
+	if f.recorder == nil {
+		return nil, errors.New("fork choice recording is not enabled")
+	}
+	if len(f.recorder.epochs) == 0 {
+		return nil, errors.New("no epoch has been recorded yet")
+	}
+	return encodeRecording(f.recorder.epochs), nil
+}
+
+// startEpoch starts recording a new epoch from a snapshot of the store, dropping the oldest epoch
+// once the ring buffer is full. The caller must hold the fork choice lock.
+func (r *recorder) startEpoch(f *ForkChoice, slot primitives.Slot) {
+	if r == nil {
+		return
+	}
+	snapshot, err := f.Snapshot()
+	if err != nil {
+		log.WithError(err).Debug("Could not snapshot fork choice store for the recording")
+		return
+	}
+	if len(r.epochs) == r.size {
+		r.epochs[0] = nil
+		r.epochs = r.epochs[1:]
+	}
+	r.epochs = append(r.epochs, &recordedEpoch{slot: slot, snapshot: snapshot})
+}
+
+// record adds the event to the epoch being recorded. Events received before the first epoch
+// starts are dropped, as there is no snapshot to replay them from.
+func (r *recorder) record(e *recordedEvent) {
+	if r == nil || e == nil || len(r.epochs) == 0 {
+		return
+	}
+	current := r.epochs[len(r.epochs)-1]
+	current.events = append(current.events, e)
+}
+
+// event returns a new event received at the given time, or nil if the recording is disabled.
+func (r *recorder) event(kind recordedEventType, now time.Time) *recordedEvent {
+	if r == nil {
+		return nil
+	}
+	return &recordedEvent{kind: kind, time: uint64(now.Unix())}
+}
+
+// setUnrealized records the unrealized checkpoints computed for an inserted block.
+func (e *recordedEvent) setUnrealized(uj, uf *ethpb.Checkpoint) {
+	if e == nil || uj == nil || uf == nil {
+		return
+	}
+	e.unrealizedJustified = &forkchoicetypes.Checkpoint{Epoch: uj.Epoch, Root: bytesutil.ToBytes32(uj.Root)}
+	e.unrealizedFinalized = &forkchoicetypes.Checkpoint{Epoch: uf.Epoch, Root: bytesutil.ToBytes32(uf.Root)}
+}
+
+func encodeRecording(epochs []*recordedEpoch) []byte {
+	w := &snapshotWriter{}
+	w.uint64(recordingVersion)
+	w.uint64(uint64(len(epochs)))
+	for _, epoch := range epochs {
+		w.uint64(uint64(epoch.slot))
+		w.uint64(uint64(len(epoch.snapshot)))
+		w.buf = append(w.buf, epoch.snapshot...)
+		w.uint64(uint64(len(epoch.events)))
+		for _, e := range epoch.events {
+			w.buf = append(w.buf, byte(e.kind))
+			w.uint64(e.time)
+			switch e.kind {
+			case eventTick:
+				w.uint64(uint64(e.slot))
+			case eventInsertNode:
+				w.uint64(uint64(e.slot))
+				w.uint64(uint64(e.stateSlot))
+				w.root(e.root)
+				w.root(e.parentRoot)
+				w.root(e.payloadHash)
+				w.checkpoint(e.justified)
+				w.checkpoint(e.finalized)
+				w.bool(e.pullTips)
+				w.bool(e.unrealizedJustified != nil)
+				if e.unrealizedJustified != nil {
+					w.checkpoint(e.unrealizedJustified)
+					w.checkpoint(e.unrealizedFinalized)
+				}
+			case eventAttestation:
+				w.root(e.root)
+				w.uint64(uint64(e.targetEpoch))
+				w.uint64s(e.indices)
+			case eventSlashing:
+				w.uint64s(e.indices)
+			case eventBalances:
+				w.root(e.root)
+				w.uint64s(e.balances)
+			}
+		}
+	}
+	return w.buf
+}
+
+func decodeRecording(enc []byte) ([]*recordedEpoch, error) {
+	r := &snapshotReader{buf: enc}
+	if v := r.uint64(); r.err == nil && v != recordingVersion {
+		return nil, errors.Errorf("unsupported fork choice recording version %d", v)
+	}
+	epochs := make([]*recordedEpoch, r.length(16))
+	for i := range epochs {
+		epoch := &recordedEpoch{slot: primitives.Slot(r.uint64())}
+		epoch.snapshot = r.next(r.length(1))
+		epoch.events = make([]*recordedEvent, r.length(9))
+		for j := range epoch.events {
+			e := &recordedEvent{kind: recordedEventType(r.next(1)[0]), time: r.uint64()}
+			switch e.kind {
+			case eventTick:
+				e.slot = primitives.Slot(r.uint64())
+			case eventInsertNode:
+				e.slot = primitives.Slot(r.uint64())
+				e.stateSlot = primitives.Slot(r.uint64())
+				e.root = r.root()
+				e.parentRoot = r.root()
+				e.payloadHash = r.root()
+				e.justified = r.checkpoint()
+				e.finalized = r.checkpoint()
+				e.pullTips = r.bool()
+				if r.bool() {
+					e.unrealizedJustified = r.checkpoint()
+					e.unrealizedFinalized = r.checkpoint()
+				}
+			case eventAttestation:
+				e.root = r.root()
+				e.targetEpoch = primitives.Epoch(r.uint64())
+				e.indices = r.uint64s()
+			case eventSlashing:
+				e.indices = r.uint64s()
+			case eventBalances:
+				e.root = r.root()
+				e.balances = r.uint64s()
+			default:
+				if r.err == nil {
+					return nil, errors.Errorf("invalid fork choice recording: unknown event type %d", e.kind)
+				}
+			}
+			epoch.events[j] = e
+		}
+		epochs[i] = epoch
+	}
+	if r.err != nil {
+		return nil, errors.Wrap(r.err, "invalid fork choice recording")
+	}
+	if len(r.buf) != 0 {
+		return nil, errors.Errorf("invalid fork choice recording: %d trailing bytes", len(r.buf))
+	}
+	return epochs, nil
+}
+
+// insertEvent returns a new event for the insertion of the block, or nil if the recording is
+// disabled.
+func (r *recorder) insertEvent(now time.Time, roblock consensus_blocks.ROBlock, stateSlot primitives.Slot, jc, fc *ethpb.Checkpoint, pullTips bool) *recordedEvent {
+	e := r.event(eventInsertNode, now)
+	if e == nil {
+		return nil
+	}
+	block := roblock.Block()
+	e.slot = block.Slot()
+	e.stateSlot = stateSlot
+	e.root = roblock.Root()
+	e.parentRoot = block.ParentRoot()
+	if block.Version() >= version.Bellatrix {
+		if execution, err := block.Body().Execution(); err == nil {
+			copy(e.payloadHash[:], execution.BlockHash())
+		}
+	}
+	e.justified = &forkchoicetypes.Checkpoint{Epoch: jc.Epoch, Root: bytesutil.ToBytes32(jc.Root)}
+	e.finalized = &forkchoicetypes.Checkpoint{Epoch: fc.Epoch, Root: bytesutil.ToBytes32(fc.Root)}
+	e.pullTips = pullTips
+	return e
+}
@@ -1,8 +1,7 @@
 package doublylinkedtree
 
 import (
-	"time"
-
+	forkchoicetypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/forkchoice/types"
 	"github.com/prysmaticlabs/prysm/v5/config/params"
 	"github.com/prysmaticlabs/prysm/v5/time/slots"
 )
@@ -31,46 +30,51 @@ const orphanLateBlockProposingEarly = 2
 // the engine's view of head with the parent block or the incoming block. It
 // does not guarantee an attempted reorg. This will only be decided later at
 // proposal time by calling GetProposerHead.
-func (f *ForkChoice) ShouldOverrideFCU() (override bool) {
-	override = false
-
+func (f *ForkChoice) ShouldOverrideFCU() bool {
 	// We only need to override FCU if our current head is from the current
 	// slot. This differs from the spec implementation in that we assume
 	// that we will call this function in the previous slot to proposing.
 	head := f.store.headNode
 	if head == nil {
-		return
+		return false
 	}
+	decision := f.shouldOverrideFCU(head)
+	f.reportReorgDecision(decision)
+	return decision.Reorg
+}
 
-	if head.slot != slots.CurrentSlot(f.store.genesisTime) {
-		return
+func (f *ForkChoice) shouldOverrideFCU(head *Node) *forkchoicetypes.ReorgDecision {
+	d := newReorgDecision(forkchoicetypes.ReorgCheckOverrideFCU, head)
+	policy := f.policy()
+	if policy.DisableLateBlockReorgs {
+		return d.keep(forkchoicetypes.ReorgReasonDisabled)
+	}
+	if head.slot != f.store.currentSlot() {
+		return d.keep(forkchoicetypes.ReorgReasonHeadSlot)
 	}
 
 	// Do not reorg on epoch boundaries
 	if (head.slot+1)%params.BeaconConfig().SlotsPerEpoch == 0 {
-		return
+		return d.keep(forkchoicetypes.ReorgReasonEpochBoundary)
 	}
 	// Only reorg blocks that arrive late
 	early, err := head.arrivedEarly(f.store.genesisTime)
 	if err != nil {
 		log.WithError(err).Error("Could not check if block arrived early")
-		return
+		return d.keep(forkchoicetypes.ReorgReasonError)
 	}
 	if early {
-		return
+		return d.keep(forkchoicetypes.ReorgReasonHeadOnTime)
 	}
 	// Only reorg if we have been finalizing
 	finalizedEpoch := f.store.finalizedCheckpoint.Epoch
-	if slots.ToEpoch(head.slot+1) > finalizedEpoch+params.BeaconConfig().ReorgMaxEpochsSinceFinalization {
-		return
+	if slots.ToEpoch(head.slot+1) > finalizedEpoch+policy.MaxEpochsSinceFinalization {
+		return d.keep(forkchoicetypes.ReorgReasonNotFinalizing)
 	}
 	// Only orphan a single block
 	parent := head.parent
-	if parent == nil {
-		return
-	}
-	if head.slot > parent.slot+1 {
-		return
+	if parent == nil || head.slot > parent.slot+1 {
+		return d.keep(forkchoicetypes.ReorgReasonParentSlot)
 	}
 	// Do not orphan a block that has higher justification than the parent
 	// if head.unrealizedJustifiedEpoch > parent.unrealizedJustifiedEpoch {
@@ -78,24 +82,24 @@ func (f *ForkChoice) ShouldOverrideFCU() (override bool) {
 	// }
 
 	// Only orphan a block if the head LMD vote is weak
-	if head.weight*100 > f.store.committeeWeight*params.BeaconConfig().ReorgWeightThreshold {
-		return
+	if head.weight*100 > f.store.committeeWeight*policy.HeadWeightThreshold {
+		return d.keep(forkchoicetypes.ReorgReasonStrongHead)
 	}
 
 	// Return early if we are checking before 10 seconds into the slot
-	secs, err := slots.SecondsSinceSlotStart(head.slot, f.store.genesisTime, uint64(time.Now().Unix()))
+	secs, err := slots.SecondsSinceSlotStart(head.slot, f.store.genesisTime, uint64(f.store.now().Unix()))
 	if err != nil {
 		log.WithError(err).Error("could not check current slot")
-		return true
+		return d.reorg(forkchoicetypes.ReorgReasonError)
 	}
 	if secs < ProcessAttestationsThreshold {
-		return true
+		return d.reorg(forkchoicetypes.ReorgReasonPendingAttestations)
 	}
 	// Only orphan a block if the parent LMD vote is strong
-	if parent.weight*100 < f.store.committeeWeight*params.BeaconConfig().ReorgParentWeightThreshold {
-		return
+	if parent.weight*100 < f.store.committeeWeight*policy.ParentWeightThreshold {
+		return d.keep(forkchoicetypes.ReorgReasonWeakParent)
 	}
-	return true
+	return d.reorg(forkchoicetypes.ReorgReasonWeakHead)
 }
 
 // GetProposerHead returns the block root that has to be used as ParentRoot by a
@@ -110,56 +114,66 @@ func (f *ForkChoice) GetProposerHead() [32]byte {
 	if head == nil {
 		return [32]byte{}
 	}
+	decision := f.getProposerHead(head)
+	f.reportReorgDecision(decision)
+	if decision.Reorg {
+		return head.parent.root
+	}
+	return head.root
+}
 
+func (f *ForkChoice) getProposerHead(head *Node) *forkchoicetypes.ReorgDecision {
+	d := newReorgDecision(forkchoicetypes.ReorgCheckProposerHead, head)
+	policy := f.policy()
+	if policy.DisableLateBlockReorgs {
+		return d.keep(forkchoicetypes.ReorgReasonDisabled)
+	}
 	// Only reorg blocks from the previous slot.
-	if head.slot+1 != slots.CurrentSlot(f.store.genesisTime) {
-		return head.root
+	if head.slot+1 != f.store.currentSlot() {
+		return d.keep(forkchoicetypes.ReorgReasonHeadSlot)
 	}
 	// Do not reorg on epoch boundaries
 	if (head.slot+1)%params.BeaconConfig().SlotsPerEpoch == 0 {
-		return head.root
+		return d.keep(forkchoicetypes.ReorgReasonEpochBoundary)
 	}
 	// Only reorg blocks that arrive late
 	early, err := head.arrivedEarly(f.store.genesisTime)
 	if err != nil {
 		log.WithError(err).Error("could not check if block arrived early")
-		return head.root
+		return d.keep(forkchoicetypes.ReorgReasonError)
 	}
 	if early {
-		return head.root
+		return d.keep(forkchoicetypes.ReorgReasonHeadOnTime)
 	}
 	// Only reorg if we have been finalizing
 	finalizedEpoch := f.store.finalizedCheckpoint.Epoch
-	if slots.ToEpoch(head.slot+1) > finalizedEpoch+params.BeaconConfig().ReorgMaxEpochsSinceFinalization {
-		return head.root
+	if slots.ToEpoch(head.slot+1) > finalizedEpoch+policy.MaxEpochsSinceFinalization {
+		return d.keep(forkchoicetypes.ReorgReasonNotFinalizing)
 	}
 	// Only orphan a single block
 	parent := head.parent
-	if parent == nil {
-		return head.root
-	}
-	if head.slot > parent.slot+1 {
-		return head.root
+	if parent == nil || head.slot > parent.slot+1 {
+		return d.keep(forkchoicetypes.ReorgReasonParentSlot)
 	}
 
 	// Only orphan a block if the head LMD vote is weak
-	if head.weight*100 > f.store.committeeWeight*params.BeaconConfig().ReorgWeightThreshold {
-		return head.root
+	if head.weight*100 > f.store.committeeWeight*policy.HeadWeightThreshold {
+		return d.keep(forkchoicetypes.ReorgReasonStrongHead)
 	}
 
 	// Only orphan a block if the parent LMD vote is strong
-	if parent.weight*100 < f.store.committeeWeight*params.BeaconConfig().ReorgParentWeightThreshold {
-		return head.root
+	if parent.weight*100 < f.store.committeeWeight*policy.ParentWeightThreshold {
+		return d.keep(forkchoicetypes.ReorgReasonWeakParent)
 	}
 
 	// Only reorg if we are proposing early
-	secs, err := slots.SecondsSinceSlotStart(head.slot+1, f.store.genesisTime, uint64(time.Now().Unix()))
+	secs, err := slots.SecondsSinceSlotStart(head.slot+1, f.store.genesisTime, uint64(f.store.now().Unix()))
 	if err != nil {
 		log.WithError(err).Error("could not check if proposing early")
-		return head.root
+		return d.keep(forkchoicetypes.ReorgReasonError)
 	}
 	if secs >= orphanLateBlockProposingEarly {
-		return head.root
+		return d.keep(forkchoicetypes.ReorgReasonProposingLate)
 	}
-	return parent.root
+	return d.reorg(forkchoicetypes.ReorgReasonWeakHead)
 }
@@ -4,7 +4,9 @@ import (
 	"context"
 	"testing"
 
+	forkchoicetypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/forkchoice/types"
 	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
 	"github.com/prysmaticlabs/prysm/v5/testing/require"
 )
 
@@ -196,3 +198,76 @@ func TestForkChoice_GetProposerHead(t *testing.T) {
 		require.Equal(t, childRoot, f.GetProposerHead())
 	})
 }
+
+func TestForkChoice_ReorgPolicy(t *testing.T) {
+	f := setup(0, 0)
+	f.numActiveValidators = 640
+	f.justifiedBalances = make([]uint64, f.numActiveValidators)
+	for i := range f.justifiedBalances {
+		f.justifiedBalances[i] = uint64(10)
+		f.store.committeeWeight += uint64(10)
+	}
+	f.store.committeeWeight /= uint64(params.BeaconConfig().SlotsPerEpoch)
+	ctx := context.Background()
+	driftGenesisTime(f, 1, 0)
+	parentRoot := [32]byte{'a'}
+	st, blk, err := prepareForkchoiceState(ctx, 1, parentRoot, [32]byte{}, [32]byte{'A'}, 0, 0)
+	require.NoError(t, err)
+	require.NoError(t, f.InsertNode(ctx, st, blk))
+	attesters := make([]uint64, f.numActiveValidators-64)
+	for i := range attesters {
+		attesters[i] = uint64(i + 64)
+	}
+	f.ProcessAttestation(ctx, attesters, blk.Root(), 0)
+
+	driftGenesisTime(f, 3, 1)
+	childRoot := [32]byte{'b'}
+	st, blk, err = prepareForkchoiceState(ctx, 2, childRoot, [32]byte{'a'}, [32]byte{'B'}, 0, 0)
+	require.NoError(t, err)
+	require.NoError(t, f.InsertNode(ctx, st, blk))
+	_, err = f.Head(ctx)
+	require.NoError(t, err)
+	orphanLateBlockFirstThreshold := params.BeaconConfig().SecondsPerSlot / params.BeaconConfig().IntervalsPerSlot
+	f.store.headNode.timestamp -= params.BeaconConfig().SecondsPerSlot - orphanLateBlockFirstThreshold
+
+	var decisions []*forkchoicetypes.ReorgDecision
+	f.SetReorgDecisionHandler(func(d *forkchoicetypes.ReorgDecision) {
+		decisions = append(decisions, d)
+	})
+	require.DeepEqual(t, forkchoicetypes.DefaultReorgPolicy(), f.ReorgPolicy())
+
+	require.Equal(t, parentRoot, f.GetProposerHead())
+	require.Equal(t, 1, len(decisions))
+	assert.Equal(t, forkchoicetypes.ReorgCheckProposerHead, decisions[0].Check)
+	assert.Equal(t, true, decisions[0].Reorg)
+	assert.Equal(t, forkchoicetypes.ReorgReasonWeakHead, decisions[0].Reason)
+	assert.Equal(t, childRoot, decisions[0].HeadRoot)
+	assert.Equal(t, parentRoot, decisions[0].ParentRoot)
+
+	policy := f.ReorgPolicy()
+	policy.DisableLateBlockReorgs = true
+	require.Equal(t, false, f.ReorgPolicy().DisableLateBlockReorgs)
+	require.NoError(t, f.SetReorgPolicy(policy))
+	require.Equal(t, childRoot, f.GetProposerHead())
+	assert.Equal(t, forkchoicetypes.ReorgReasonDisabled, decisions[1].Reason)
+
+	policy.DisableLateBlockReorgs = false
+	policy.ParentWeightThreshold = 10000
+	require.NoError(t, f.SetReorgPolicy(policy))
+	require.Equal(t, childRoot, f.GetProposerHead())
+	assert.Equal(t, false, decisions[2].Reorg)
+	assert.Equal(t, forkchoicetypes.ReorgReasonWeakParent, decisions[2].Reason)
+
+	policy.HeadWeightThreshold = 101
+	require.ErrorContains(t, "invalid reorg policy", f.SetReorgPolicy(policy))
+	assert.Equal(t, uint64(10000), f.ReorgPolicy().ParentWeightThreshold)
+
+	// The proposer boost follows the policy.
+	policy = forkchoicetypes.DefaultReorgPolicy()
+	policy.ProposerScoreBoost = 10
+	require.NoError(t, f.SetReorgPolicy(policy))
+	f.store.proposerBoostRoot = childRoot
+	_, err = f.Head(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, f.store.committeeWeight/10, f.store.previousProposerBoostScore)
+}
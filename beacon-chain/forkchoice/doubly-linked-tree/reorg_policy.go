@@ -0,0 +1,85 @@
+package doublylinkedtree
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/forkchoice"
+	forkchoicetypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/forkchoice/types"
+	"github.com/sirupsen/logrus"
+)
+
+// ReorgPolicy returns a copy of the policy followed by fork choice for the proposer boost and
+// the late block reorgs.
+func (f *ForkChoice) ReorgPolicy() *forkchoicetypes.ReorgPolicy {
+	policy := *f.policy()
+	return &policy
+}
+
+// policy returns the policy set at runtime, or the one of the beacon chain config if none is set.
+func (f *ForkChoice) policy() *forkchoicetypes.ReorgPolicy {
+	if f.reorgPolicy == nil {
+		return forkchoicetypes.DefaultReorgPolicy()
+	}
+	return f.reorgPolicy
+}
+
+// SetReorgPolicy replaces the policy followed by fork choice. The new proposer boost is applied
+// from the next computation of the head. The caller must hold the fork choice lock.
+func (f *ForkChoice) SetReorgPolicy(policy *forkchoicetypes.ReorgPolicy) error {
+	if err := policy.Validate(); err != nil {
+		return errors.Wrap(err, "invalid reorg policy")
+	}
+	p := *policy
+	f.reorgPolicy = &p
+	return nil
+}
+
+// SetReorgDecisionHandler sets the handler notified of every late block reorg decision. The
+// caller must hold the fork choice lock.
+func (f *ForkChoice) SetReorgDecisionHandler(handler forkchoice.ReorgDecisionHandler) {
+	f.reorgHandler = handler
+}
+
+// reportReorgDecision logs and counts the decision, and notifies the handler of it.
+func (f *ForkChoice) reportReorgDecision(d *forkchoicetypes.ReorgDecision) {
+	reorgDecisionCount.WithLabelValues(d.Check, fmt.Sprintf("%t", d.Reorg), d.Reason).Inc()
+	l := log.WithFields(logrus.Fields{
+		"check":        d.Check,
+		"slot":         d.Slot,
+		"headRoot":     fmt.Sprintf("%#x", d.HeadRoot),
+		"headWeight":   d.HeadWeight,
+		"parentWeight": d.ParentWeight,
+		"reason":       d.Reason,
+	})
+	if d.Reorg {
+		l.Info("Late block reorg decided")
+	} else {
+		l.Debug("Late block reorg declined")
+	}
+	if f.reorgHandler != nil {
+		f.reorgHandler(d)
+	}
+}
+
+type reorgDecision forkchoicetypes.ReorgDecision
+
+func newReorgDecision(check string, head *Node) *reorgDecision {
+	d := &reorgDecision{Check: check, Slot: head.slot, HeadRoot: head.root, HeadWeight: head.weight}
+	if head.parent != nil {
+		d.ParentRoot = head.parent.root
+		d.ParentWeight = head.parent.weight
+	}
+	return d
+}
+
+func (d *reorgDecision) keep(reason string) *forkchoicetypes.ReorgDecision {
+	d.Reason = reason
+	return (*forkchoicetypes.ReorgDecision)(d)
+}
+
+func (d *reorgDecision) reorg(reason string) *forkchoicetypes.ReorgDecision {
+	d.Reorg = true
+	d.Reason = reason
+	return (*forkchoicetypes.ReorgDecision)(d)
+}
@@ -0,0 +1,144 @@
+package doublylinkedtree
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	forkchoicetypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/forkchoice/types"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+)
+
+// Replay processes the inputs of a fork choice recording into a new fork choice store, from the
+// start of the recorded epoch containing the given slot up to the end of the slot. It returns
+// the view of fork choice at the end of the slot: its head, the weights of the nodes, and the
+// proposer boost decisions for the blocks of the slot.
+func Replay(ctx context.Context, recording []byte, slot primitives.Slot) (*forkchoicetypes.ReplayResult, error) {
+	epochs, err := decodeRecording(recording)
+	if err != nil {
+		return nil, err
+	}
+	if len(epochs) == 0 {
+		return nil, errors.New("fork choice recording is empty")
+	}
+	var epoch *recordedEpoch
+	for _, e := range epochs {
+		if e.slot <= slot {
+			epoch = e
+		}
+	}
+	if epoch == nil {
+		return nil, errors.Wrapf(ErrSlotNotRecorded, "slot %d is before the recording, which starts at slot %d", slot, epochs[0].slot)
+	}
+	if lastSlot := lastRecordedSlot(epochs); slot > lastSlot {
+		return nil, errors.Wrapf(ErrSlotNotRecorded, "slot %d is after the recording, which ends at slot %d", slot, lastSlot)
+	}
+
+	// Justified balances are looked up from those fetched when fork choice was recorded.
+	balances := make(map[[32]byte][]uint64)
+	for _, e := range epochs {
+		for _, event := range e.events {
+			if event.kind == eventBalances {
+				balances[event.root] = event.balances
+			}
+		}
+	}
+	f := New()
+	if err := f.LoadSnapshot(epoch.snapshot, nil); err != nil {
+		return nil, errors.Wrapf(err, "could not load snapshot of slot %d", epoch.slot)
+	}
+	f.SetBalancesByRooter(func(_ context.Context, root [32]byte) ([]uint64, error) {
+		b, ok := balances[root]
+		if !ok {
+			return nil, errors.Errorf("no balances recorded for justified root %#x", root)
+		}
+		return b, nil
+	})
+	var now time.Time
+	f.store.clock = func() time.Time { return now }
+
+	secondsPerSlot := params.BeaconConfig().SecondsPerSlot
+	slotStart := f.store.genesisTime + uint64(slot)*secondsPerSlot
+	slotEnd := slotStart + secondsPerSlot
+	result := &forkchoicetypes.ReplayResult{Slot: slot}
+	for _, e := range epoch.events {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if e.time >= slotEnd {
+			break
+		}
+		now = time.Unix(int64(e.time), 0) // lint:ignore uintcast -- Recorded times will not exceed int64 in your lifetime.
+		if err := f.replayEvent(ctx, e); err != nil {
+			return nil, errors.Wrapf(err, "could not replay input received at %s", now)
+		}
+		if e.kind == eventInsertNode && e.slot == slot {
+			decision := &forkchoicetypes.ProposerBoostDecision{
+				Root:    e.root,
+				Slot:    e.slot,
+				Boosted: f.store.proposerBoostRoot == e.root,
+			}
+			if e.time > slotStart {
+				decision.SecondsIntoSlot = e.time - slotStart
+			}
+			result.ProposerBoosts = append(result.ProposerBoosts, decision)
+		}
+	}
+
+	now = time.Unix(int64(slotEnd)-1, 0) // lint:ignore uintcast -- Recorded times will not exceed int64 in your lifetime.
+	if _, err := f.Head(ctx); err != nil {
+		return nil, errors.Wrap(err, "could not compute head")
+	}
+	if result.Dump, err = f.ForkChoiceDump(ctx); err != nil {
+		return nil, errors.Wrap(err, "could not dump fork choice")
+	}
+	return result, nil
+}
+
+// lastRecordedSlot returns the latest slot fork choice was notified of in the recording.
+func lastRecordedSlot(epochs []*recordedEpoch) primitives.Slot {
+	last := epochs[len(epochs)-1]
+	slot := last.slot
+	for _, e := range last.events {
+		if e.kind == eventTick && e.slot > slot {
+			slot = e.slot
+		}
+	}
+	return slot
+}
+
+// replayEvent processes a recorded input of fork choice.
+func (f *ForkChoice) replayEvent(ctx context.Context, e *recordedEvent) error {
+	switch e.kind {
+	case eventTick:
+		return f.NewSlot(ctx, e.slot)
+	case eventInsertNode:
+		node, err := f.store.insertNode(ctx, e.slot, e.root, e.parentRoot, e.payloadHash, e.justified.Epoch, e.finalized.Epoch)
+		if err != nil {
+			return err
+		}
+		jc := &ethpb.Checkpoint{Epoch: e.justified.Epoch, Root: e.justified.Root[:]}
+		fc := &ethpb.Checkpoint{Epoch: e.finalized.Epoch, Root: e.finalized.Root[:]}
+		if e.pullTips {
+			jc, fc = f.store.pullTips(e.stateSlot, node, jc, fc, func() (*ethpb.Checkpoint, *ethpb.Checkpoint, error) {
+				if e.unrealizedJustified == nil {
+					return nil, nil, errors.New("unrealized checkpoints were not recorded")
+				}
+				return &ethpb.Checkpoint{Epoch: e.unrealizedJustified.Epoch, Root: e.unrealizedJustified.Root[:]},
+					&ethpb.Checkpoint{Epoch: e.unrealizedFinalized.Epoch, Root: e.unrealizedFinalized.Root[:]}, nil
+			})
+		}
+		return f.updateCheckpoints(ctx, jc, fc)
+	case eventAttestation:
+		f.ProcessAttestation(ctx, e.indices, e.root, e.targetEpoch)
+	case eventSlashing:
+		for _, index := range e.indices {
+			f.InsertSlashedIndex(ctx, primitives.ValidatorIndex(index))
+		}
+	case eventBalances:
+		// The balances are fetched by root when the justified checkpoint changes.
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+package doublylinkedtree
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestForkChoice_RecordAndReplay(t *testing.T) {
+	ctx := context.Background()
+	f := setup(0, 0)
+	_, err := f.Recording()
+	require.ErrorContains(t, "not enabled", err)
+	f.EnableRecording(2)
+	_, err = f.Recording()
+	require.ErrorContains(t, "no epoch", err)
+
+	genesisTime := uint64(1000)
+	secondsPerSlot := params.BeaconConfig().SecondsPerSlot
+	var now time.Time
+	f.store.clock = func() time.Time { return now }
+	at := func(slot primitives.Slot, seconds uint64) {
+		now = time.Unix(int64(genesisTime+uint64(slot)*secondsPerSlot+seconds), 0)
+	}
+	f.SetGenesisTime(genesisTime)
+	f.justifiedBalances = []uint64{10, 20, 30}
+	f.store.committeeWeight = 60
+
+	insert := func(slot primitives.Slot, root, parent [32]byte) {
+		st, roblock, err := prepareForkchoiceState(ctx, slot, root, parent, root, 0, 0)
+		require.NoError(t, err)
+		require.NoError(t, f.InsertNode(ctx, st, roblock))
+	}
+	at(0, 0)
+	require.NoError(t, f.NewSlot(ctx, 0))
+	at(1, 0)
+	require.NoError(t, f.NewSlot(ctx, 1))
+	at(1, 1)
+	insert(1, indexToHash(1), params.BeaconConfig().ZeroHash)
+	f.ProcessAttestation(ctx, []uint64{0, 1, 2}, indexToHash(1), 0)
+	at(2, 0)
+	require.NoError(t, f.NewSlot(ctx, 2))
+	at(2, 1)
+	insert(2, indexToHash(2), indexToHash(1))
+	at(2, 2)
+	insert(2, indexToHash(3), indexToHash(1))
+	f.ProcessAttestation(ctx, []uint64{0}, indexToHash(2), 0)
+	f.ProcessAttestation(ctx, []uint64{1, 2}, indexToHash(3), 0)
+	f.InsertSlashedIndex(ctx, 1)
+	at(2, secondsPerSlot-1)
+	head, err := f.Head(ctx)
+	require.NoError(t, err)
+	require.Equal(t, indexToHash(2), head)
+	live, err := f.ForkChoiceDump(ctx)
+	require.NoError(t, err)
+	at(3, 0)
+	require.NoError(t, f.NewSlot(ctx, 3))
+
+	recording, err := f.Recording()
+	require.NoError(t, err)
+
+	result, err := Replay(ctx, recording, 2)
+	require.NoError(t, err)
+	assert.Equal(t, primitives.Slot(2), result.Slot)
+	assert.DeepEqual(t, live.HeadRoot, result.Dump.HeadRoot)
+	require.Equal(t, len(live.ForkChoiceNodes), len(result.Dump.ForkChoiceNodes))
+	for i, n := range live.ForkChoiceNodes {
+		assert.DeepEqual(t, n.BlockRoot, result.Dump.ForkChoiceNodes[i].BlockRoot)
+		assert.Equal(t, n.Weight, result.Dump.ForkChoiceNodes[i].Weight)
+	}
+	require.Equal(t, 2, len(result.ProposerBoosts))
+	assert.Equal(t, indexToHash(2), result.ProposerBoosts[0].Root)
+	assert.Equal(t, true, result.ProposerBoosts[0].Boosted)
+	assert.Equal(t, uint64(1), result.ProposerBoosts[0].SecondsIntoSlot)
+	assert.Equal(t, indexToHash(3), result.ProposerBoosts[1].Root)
+	assert.Equal(t, false, result.ProposerBoosts[1].Boosted)
+
+	result, err = Replay(ctx, recording, 1)
+	require.NoError(t, err)
+	head1 := indexToHash(1)
+	assert.DeepEqual(t, head1[:], result.Dump.HeadRoot)
+	assert.Equal(t, 2, len(result.Dump.ForkChoiceNodes))
+	require.Equal(t, 1, len(result.ProposerBoosts))
+	assert.Equal(t, true, result.ProposerBoosts[0].Boosted)
+
+	_, err = Replay(ctx, recording, 4)
+	require.ErrorIs(t, err, ErrSlotNotRecorded)
+	require.ErrorContains(t, "after the recording", err)
+	_, err = Replay(ctx, recording[:len(recording)-1], 2)
+	require.ErrorContains(t, "invalid fork choice recording", err)
+}
+
+func TestRecorder_RingBuffer(t *testing.T) {
+	ctx := context.Background()
+	f := setup(0, 0)
+	f.EnableRecording(2)
+	for epoch := primitives.Epoch(0); epoch < 4; epoch++ {
+		slot := primitives.Slot(epoch) * params.BeaconConfig().SlotsPerEpoch
+		require.NoError(t, f.NewSlot(ctx, slot))
+		require.NoError(t, f.NewSlot(ctx, slot+1))
+	}
+	require.Equal(t, 2, len(f.recorder.epochs))
+	assert.Equal(t, 2*params.BeaconConfig().SlotsPerEpoch, f.recorder.epochs[0].slot)
+	assert.Equal(t, 2, len(f.recorder.epochs[1].events))
+
+	recording, err := f.Recording()
+	require.NoError(t, err)
+	_, err = Replay(ctx, recording, params.BeaconConfig().SlotsPerEpoch)
+	require.ErrorContains(t, "before the recording", err)
+}
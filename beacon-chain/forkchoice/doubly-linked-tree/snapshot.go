@@ -0,0 +1,349 @@
+package doublylinkedtree
+
+import (
+	"encoding/binary"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/forkchoice"
+	forkchoicetypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/forkchoice/types"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+)
+
+// snapshotVersion is the version of the encoding of the fork choice snapshots.
+const snapshotVersion = 1
+
+const (
+	// snapshotVoteSize is the size of an encoded vote.
+	snapshotVoteSize = 2*32 + 8
+	// snapshotNodeSize is the size of an encoded node.
+	snapshotNodeSize = 5*32 + 8*8 + 3
+)
+
+// Snapshot encodes the fork choice store: its checkpoints, its nodes with their weights, and
+// the latest votes and balances of the validators, so that the store can be restored as it
+// is after a restart. The caller must hold the fork choice read lock.
+func (f *ForkChoice) Snapshot() ([]byte, error) {
+	s := f.store
+	if s.treeRootNode == nil || s.headNode == nil {
+		return nil, errors.New("fork choice store is empty")
+	}
+	w := &snapshotWriter{}
+	w.uint64(snapshotVersion)
+	w.checkpoint(s.justifiedCheckpoint)
+	w.checkpoint(s.prevJustifiedCheckpoint)
+	w.checkpoint(s.unrealizedJustifiedCheckpoint)
+	w.checkpoint(s.unrealizedFinalizedCheckpoint)
+	w.checkpoint(s.finalizedCheckpoint)
+	w.root(s.proposerBoostRoot)
+	w.root(s.previousProposerBoostRoot)
+	w.uint64(s.previousProposerBoostScore)
+	w.uint64(s.committeeWeight)
+	w.root(s.originRoot)
+	w.uint64(s.genesisTime)
+	w.bool(s.allTipsAreInvalid)
+	w.uint64(uint64(len(s.receivedBlocksLastEpoch)))
+	for _, slot := range s.receivedBlocksLastEpoch {
+		w.uint64(uint64(slot))
+	}
+	w.root(s.headNode.root)
+	w.nodeRoot(s.highestReceivedNode)
+
+	w.uint64(uint64(len(s.slashedIndices)))
+	for index := range s.slashedIndices {
+		w.uint64(uint64(index))
+	}
+	w.uint64(f.numActiveValidators)
+	w.uint64s(f.balances)
+	w.uint64s(f.justifiedBalances)
+	w.uint64(uint64(len(f.votes)))
+	for _, v := range f.votes {
+		w.root(v.currentRoot)
+		w.root(v.nextRoot)
+		w.uint64(uint64(v.nextEpoch))
+	}
+
+	// The nodes are written parents first, so that the parent of a node is known when it is read.
+	w.uint64(uint64(len(s.nodeByRoot)))
+	queue := []*Node{s.treeRootNode}
+	for len(queue) > 0 {
+		n := queue[0]
+		queue = append(queue[1:], n.children...)
+		w.root(n.root)
+		if n.parent != nil {
+			w.root(n.parent.root)
+		} else {
+			w.root([32]byte{})
+		}
+		w.uint64(uint64(n.slot))
+		w.root(n.payloadHash)
+		w.uint64(uint64(n.justifiedEpoch))
+		w.uint64(uint64(n.unrealizedJustifiedEpoch))
+		w.uint64(uint64(n.finalizedEpoch))
+		w.uint64(uint64(n.unrealizedFinalizedEpoch))
+		w.uint64(n.balance)
+		w.uint64(n.weight)
+		w.nodeRoot(n.target)
+		w.nodeRoot(n.bestDescendant)
+		w.bool(n.optimistic)
+		w.uint64(n.timestamp)
+	}
+	return w.buf, nil
+}
+
+// LoadSnapshot replaces the content of the fork choice store with the encoded snapshot, once
+// it is checked by the given verifier. The store is left unchanged if the snapshot is invalid
+// or rejected. The caller must hold the fork choice lock.
+func (f *ForkChoice) LoadSnapshot(enc []byte, verify forkchoice.SnapshotVerifier) error {
+	r := &snapshotReader{buf: enc}
+	if v := r.uint64(); r.err == nil && v != snapshotVersion {
+		return errors.Errorf("unsupported fork choice snapshot version %d", v)
+	}
+	s := &Store{
+		justifiedCheckpoint:           r.checkpoint(),
+		prevJustifiedCheckpoint:       r.checkpoint(),
+		unrealizedJustifiedCheckpoint: r.checkpoint(),
+		unrealizedFinalizedCheckpoint: r.checkpoint(),
+		finalizedCheckpoint:           r.checkpoint(),
+		proposerBoostRoot:             r.root(),
+		previousProposerBoostRoot:     r.root(),
+		previousProposerBoostScore:    r.uint64(),
+		committeeWeight:               r.uint64(),
+		originRoot:                    r.root(),
+		genesisTime:                   r.uint64(),
+		allTipsAreInvalid:             r.bool(),
+		nodeByRoot:                    make(map[[fieldparams.RootLength]byte]*Node),
+		nodeByPayload:                 make(map[[fieldparams.RootLength]byte]*Node),
+		slashedIndices:                make(map[primitives.ValidatorIndex]bool),
+	}
+	if n := r.uint64(); r.err == nil && n != uint64(len(s.receivedBlocksLastEpoch)) {
+		return errors.Errorf("fork choice snapshot has %d slots per epoch, expected %d", n, len(s.receivedBlocksLastEpoch))
+	}
+	for i := range s.receivedBlocksLastEpoch {
+		s.receivedBlocksLastEpoch[i] = primitives.Slot(r.uint64())
+	}
+	headRoot := r.root()
+	highestReceivedRoot := r.nodeRoot()
+
+	slashed := r.length(8)
+	for i := 0; i < slashed; i++ {
+		s.slashedIndices[primitives.ValidatorIndex(r.uint64())] = true
+	}
+	numActiveValidators := r.uint64()
+	balances := r.uint64s()
+	justifiedBalances := r.uint64s()
+	votes := make([]Vote, r.length(snapshotVoteSize))
+	for i := range votes {
+		votes[i] = Vote{currentRoot: r.root(), nextRoot: r.root(), nextEpoch: primitives.Epoch(r.uint64())}
+	}
+
+	count := r.length(snapshotNodeSize)
+	if r.err == nil && count == 0 {
+		return errors.Wrap(errInvalidSnapshot, "no nodes")
+	}
+	// The targets and best descendants may only be known once all the nodes are read.
+	targets := make(map[*Node]*[32]byte, count)
+	bestDescendants := make(map[*Node]*[32]byte, count)
+	for i := 0; i < count && r.err == nil; i++ {
+		n := &Node{root: r.root()}
+		parentRoot := r.root()
+		n.slot = primitives.Slot(r.uint64())
+		n.payloadHash = r.root()
+		n.justifiedEpoch = primitives.Epoch(r.uint64())
+		n.unrealizedJustifiedEpoch = primitives.Epoch(r.uint64())
+		n.finalizedEpoch = primitives.Epoch(r.uint64())
+		n.unrealizedFinalizedEpoch = primitives.Epoch(r.uint64())
+		n.balance = r.uint64()
+		n.weight = r.uint64()
+		targets[n] = r.nodeRoot()
+		bestDescendants[n] = r.nodeRoot()
+		n.optimistic = r.bool()
+		n.timestamp = r.uint64()
+		if r.err != nil {
+			break
+		}
+		if _, ok := s.nodeByRoot[n.root]; ok {
+			return errors.Wrapf(errInvalidSnapshot, "duplicate node %#x", n.root)
+		}
+		if i == 0 {
+			s.treeRootNode = n
+		} else {
+			parent, ok := s.nodeByRoot[parentRoot]
+			if !ok {
+				return errors.Wrapf(errInvalidSnapshot, "unknown parent %#x of node %#x", parentRoot, n.root)
+			}
+			n.parent = parent
+			parent.children = append(parent.children, n)
+		}
+		s.nodeByRoot[n.root] = n
+		s.nodeByPayload[n.payloadHash] = n
+	}
+	if r.err != nil {
+		return r.err
+	}
+	if len(r.buf) != 0 {
+		return errors.Wrapf(errInvalidSnapshot, "%d trailing bytes", len(r.buf))
+	}
+	for n, root := range targets {
+		if root == nil {
+			continue
+		}
+		// The target of a node may have been pruned, in which case only its root is used.
+		if n.target = s.nodeByRoot[*root]; n.target == nil {
+			n.target = &Node{root: *root}
+		}
+	}
+	for n, root := range bestDescendants {
+		if root == nil {
+			continue
+		}
+		if n.bestDescendant = s.nodeByRoot[*root]; n.bestDescendant == nil {
+			return errors.Wrapf(errInvalidSnapshot, "unknown best descendant %#x of node %#x", *root, n.root)
+		}
+	}
+	var ok bool
+	if s.headNode, ok = s.nodeByRoot[headRoot]; !ok {
+		return errors.Wrapf(errInvalidSnapshot, "unknown head %#x", headRoot)
+	}
+	if highestReceivedRoot != nil {
+		if s.highestReceivedNode, ok = s.nodeByRoot[*highestReceivedRoot]; !ok {
+			return errors.Wrapf(errInvalidSnapshot, "unknown highest received node %#x", *highestReceivedRoot)
+		}
+	}
+	if s.finalizedCheckpoint.Root != s.treeRootNode.root {
+		return errors.Wrap(errInvalidSnapshot, "tree root is not the finalized block")
+	}
+
+	if verify != nil {
+		roots := make([][32]byte, 0, len(s.nodeByRoot))
+		for root := range s.nodeByRoot {
+			roots = append(roots, root)
+		}
+		if err := verify(&forkchoicetypes.SnapshotInfo{
+			JustifiedCheckpoint: s.justifiedCheckpoint,
+			FinalizedCheckpoint: s.finalizedCheckpoint,
+			OriginRoot:          s.originRoot,
+			GenesisTime:         s.genesisTime,
+			HeadRoot:            headRoot,
+			Roots:               roots,
+		}); err != nil {
+			return err
+		}
+	}
+
+	f.store = s
+	f.votes = votes
+	f.balances = balances
+	f.justifiedBalances = justifiedBalances
+	f.numActiveValidators = numActiveValidators
+	nodeCount.Set(float64(len(s.nodeByRoot)))
+	return nil
+}
+
+type snapshotWriter struct {
+	buf []byte
+}
+
+func (w *snapshotWriter) uint64(v uint64) {
+	w.buf = binary.LittleEndian.AppendUint64(w.buf, v)
+}
+
+func (w *snapshotWriter) uint64s(values []uint64) {
+	w.uint64(uint64(len(values)))
+	for _, v := range values {
+		w.uint64(v)
+	}
+}
+
+func (w *snapshotWriter) root(root [32]byte) {
+	w.buf = append(w.buf, root[:]...)
+}
+
+// nodeRoot writes whether there is a node, followed by its root.
+func (w *snapshotWriter) nodeRoot(n *Node) {
+	w.bool(n != nil)
+	if n == nil {
+		w.root([32]byte{})
+		return
+	}
+	w.root(n.root)
+}
+
+func (w *snapshotWriter) bool(b bool) {
+	if b {
+		w.buf = append(w.buf, 1)
+	} else {
+		w.buf = append(w.buf, 0)
+	}
+}
+
+func (w *snapshotWriter) checkpoint(cp *forkchoicetypes.Checkpoint) {
+	w.uint64(uint64(cp.Epoch))
+	w.root(cp.Root)
+}
+
+// snapshotReader reads an encoded snapshot. Once the end of the snapshot is reached, all the
+// values read are zero and err is set.
+type snapshotReader struct {
+	buf []byte
+	err error
+}
+
+func (r *snapshotReader) next(n int) []byte {
+	if r.err == nil && len(r.buf) < n {
+		r.err = errors.Wrap(errInvalidSnapshot, "unexpected end of snapshot")
+	}
+	if r.err != nil {
+		return make([]byte, n)
+	}
+	b := r.buf[:n]
+	r.buf = r.buf[n:]
+	return b
+}
+
+func (r *snapshotReader) uint64() uint64 {
+	return binary.LittleEndian.Uint64(r.next(8))
+}
+
+func (r *snapshotReader) uint64s() []uint64 {
+	values := make([]uint64, r.length(8))
+	for i := range values {
+		values[i] = r.uint64()
+	}
+	return values
+}
+
+func (r *snapshotReader) root() [32]byte {
+	return [32]byte(r.next(32))
+}
+
+func (r *snapshotReader) bool() bool {
+	return r.next(1)[0] == 1
+}
+
+// nodeRoot reads the root of an optional node, which is nil if there is no node.
+func (r *snapshotReader) nodeRoot() *[32]byte {
+	ok := r.bool()
+	root := r.root()
+	if !ok {
+		return nil
+	}
+	return &root
+}
+
+func (r *snapshotReader) checkpoint() *forkchoicetypes.Checkpoint {
+	return &forkchoicetypes.Checkpoint{Epoch: primitives.Epoch(r.uint64()), Root: r.root()}
+}
+
+// length reads the number of elements of a list, checking that the snapshot is long enough
+// for that many elements of the given size, so that a corrupted length is not allocated.
+func (r *snapshotReader) length(elemSize int) int {
+	n := r.uint64()
+	if r.err == nil && n > uint64(len(r.buf)/elemSize) {
+		r.err = errors.Wrapf(errInvalidSnapshot, "list of %d elements exceeds the snapshot", n)
+	}
+	if r.err != nil {
+		return 0
+	}
+	return int(n)
+}
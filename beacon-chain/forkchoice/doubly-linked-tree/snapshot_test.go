@@ -0,0 +1,106 @@
+package doublylinkedtree
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	forkchoicetypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/forkchoice/types"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestForkChoice_SnapshotRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	f := setup(0, 0)
+	// Two branches from the genesis block: 1 <- 2 <- 3 and 1 <- 4.
+	for _, n := range []struct {
+		slot         primitives.Slot
+		root, parent uint64
+	}{{1, 1, 0}, {2, 2, 1}, {3, 3, 2}, {2, 4, 1}} {
+		parent := indexToHash(n.parent)
+		if n.parent == 0 {
+			parent = params.BeaconConfig().ZeroHash
+		}
+		st, roblock, err := prepareForkchoiceState(ctx, n.slot, indexToHash(n.root), parent, indexToHash(100+n.root), 0, 0)
+		require.NoError(t, err)
+		require.NoError(t, f.InsertNode(ctx, st, roblock))
+	}
+	f.justifiedBalances = []uint64{10, 20, 30}
+	f.ProcessAttestation(ctx, []uint64{0}, indexToHash(3), 0)
+	f.ProcessAttestation(ctx, []uint64{1, 2}, indexToHash(4), 0)
+	f.store.slashedIndices[5] = true
+	head, err := f.Head(ctx)
+	require.NoError(t, err)
+	require.Equal(t, indexToHash(4), head)
+
+	enc, err := f.Snapshot()
+	require.NoError(t, err)
+
+	var info *forkchoicetypes.SnapshotInfo
+	loaded := New()
+	require.NoError(t, loaded.LoadSnapshot(enc, func(i *forkchoicetypes.SnapshotInfo) error {
+		info = i
+		return nil
+	}))
+	assert.Equal(t, head, info.HeadRoot)
+	assert.Equal(t, 5, len(info.Roots))
+	assert.DeepEqual(t, f.FinalizedCheckpoint(), info.FinalizedCheckpoint)
+
+	assert.Equal(t, f.NodeCount(), loaded.NodeCount())
+	assert.DeepEqual(t, f.votes, loaded.votes)
+	assert.DeepEqual(t, f.balances, loaded.balances)
+	assert.DeepEqual(t, f.justifiedBalances, loaded.justifiedBalances)
+	assert.DeepEqual(t, f.store.slashedIndices, loaded.store.slashedIndices)
+	for root, n := range f.store.nodeByRoot {
+		l, ok := loaded.store.nodeByRoot[root]
+		require.Equal(t, true, ok)
+		assert.Equal(t, n.weight, l.weight)
+		assert.Equal(t, n.slot, l.slot)
+		assert.Equal(t, len(n.children), len(l.children))
+		assert.Equal(t, n.bestDescendant == nil, l.bestDescendant == nil)
+		require.Equal(t, l, loaded.store.nodeByPayload[n.payloadHash])
+	}
+	assert.Equal(t, indexToHash(4), loaded.store.headNode.root)
+	assert.Equal(t, indexToHash(3), loaded.store.highestReceivedNode.root)
+
+	loaded.SetBalancesByRooter(func(_ context.Context, _ [32]byte) ([]uint64, error) { return loaded.justifiedBalances, nil })
+	loadedHead, err := loaded.Head(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, head, loadedHead)
+
+	// The restored store keeps processing new blocks.
+	st, roblock, err := prepareForkchoiceState(ctx, 4, indexToHash(5), indexToHash(3), indexToHash(105), 0, 0)
+	require.NoError(t, err)
+	require.NoError(t, loaded.InsertNode(ctx, st, roblock))
+	assert.Equal(t, 6, loaded.NodeCount())
+}
+
+func TestForkChoice_LoadSnapshotRejected(t *testing.T) {
+	ctx := context.Background()
+	f := setup(0, 0)
+	st, roblock, err := prepareForkchoiceState(ctx, 1, indexToHash(1), params.BeaconConfig().ZeroHash, indexToHash(101), 0, 0)
+	require.NoError(t, err)
+	require.NoError(t, f.InsertNode(ctx, st, roblock))
+	enc, err := f.Snapshot()
+	require.NoError(t, err)
+
+	loaded := setup(0, 0)
+	wanted := errors.New("unknown block")
+	err = loaded.LoadSnapshot(enc, func(_ *forkchoicetypes.SnapshotInfo) error { return wanted })
+	require.ErrorIs(t, err, wanted)
+	assert.Equal(t, 1, loaded.NodeCount())
+
+	err = loaded.LoadSnapshot(enc[:len(enc)-1], nil)
+	require.ErrorIs(t, err, errInvalidSnapshot)
+	assert.Equal(t, 1, loaded.NodeCount())
+
+	err = loaded.LoadSnapshot(append(enc, 0), nil)
+	require.ErrorIs(t, err, errInvalidSnapshot)
+	assert.Equal(t, 1, loaded.NodeCount())
+
+	_, err = New().Snapshot()
+	require.ErrorContains(t, "empty", err)
+}
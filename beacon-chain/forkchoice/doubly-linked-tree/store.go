@@ -15,6 +15,24 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/time/slots"
 )
 
+// now returns the current time of the store, which is the time at which the recorded inputs were
+// received when they are replayed.
+func (s *Store) now() time.Time {
+	if s.clock != nil {
+		return s.clock()
+	}
+	return time.Now()
+}
+
+// currentSlot returns the current slot according to the time of the store.
+func (s *Store) currentSlot() primitives.Slot {
+	now := uint64(s.now().Unix())
+	if now < s.genesisTime {
+		return 0
+	}
+	return primitives.Slot((now - s.genesisTime) / params.BeaconConfig().SecondsPerSlot)
+}
+
 // head starts from justified root and then follows the best descendant links
 // to find the best block for head.
 func (s *Store) head(ctx context.Context) ([32]byte, error) {
@@ -44,7 +62,7 @@ func (s *Store) head(ctx context.Context) ([32]byte, error) {
 	if bestDescendant == nil {
 		bestDescendant = justifiedNode
 	}
-	currentEpoch := slots.EpochsSinceGenesis(time.Unix(int64(s.genesisTime), 0))
+	currentEpoch := slots.ToEpoch(s.currentSlot())
 	if !bestDescendant.viableForHead(s.justifiedCheckpoint.Epoch, currentEpoch) {
 		s.allTipsAreInvalid = true
 		return [32]byte{}, fmt.Errorf("head at slot %d with weight %d is not eligible, finalizedEpoch, justified Epoch %d, %d != %d, %d",
@@ -72,8 +90,6 @@ func (s *Store) insert(ctx context.Context,
 
 	root := roblock.Root()
 	block := roblock.Block()
-	slot := block.Slot()
-	parentRoot := block.ParentRoot()
 	var payloadHash [32]byte
 	if block.Version() >= version.Bellatrix {
 		execution, err := block.Body().Execution()
@@ -82,7 +98,14 @@ func (s *Store) insert(ctx context.Context,
 		}
 		copy(payloadHash[:], execution.BlockHash())
 	}
+	return s.insertNode(ctx, block.Slot(), root, block.ParentRoot(), payloadHash, justifiedEpoch, finalizedEpoch)
+}
 
+// insertNode registers a new node for the block with the given roots.
+func (s *Store) insertNode(ctx context.Context,
+	slot primitives.Slot,
+	root, parentRoot, payloadHash [fieldparams.RootLength]byte,
+	justifiedEpoch, finalizedEpoch primitives.Epoch) (*Node, error) {
 	// Return if the block has been inserted into Store before.
 	if n, ok := s.nodeByRoot[root]; ok {
 		return n, nil
@@ -99,7 +122,7 @@ func (s *Store) insert(ctx context.Context,
 		unrealizedFinalizedEpoch: finalizedEpoch,
 		optimistic:               true,
 		payloadHash:              payloadHash,
-		timestamp:                uint64(time.Now().Unix()),
+		timestamp:                uint64(s.now().Unix()),
 	}
 
 	// Set the node's target checkpoint
@@ -128,12 +151,12 @@ func (s *Store) insert(ctx context.Context,
 	} else {
 		parent.children = append(parent.children, n)
 		// Apply proposer boost
-		timeNow := uint64(time.Now().Unix())
+		timeNow := uint64(s.now().Unix())
 		if timeNow < s.genesisTime {
 			return n, nil
 		}
 		secondsIntoSlot := (timeNow - s.genesisTime) % params.BeaconConfig().SecondsPerSlot
-		currentSlot := slots.CurrentSlot(s.genesisTime)
+		currentSlot := s.currentSlot()
 		boostThreshold := params.BeaconConfig().SecondsPerSlot / params.BeaconConfig().IntervalsPerSlot
 		isFirstBlock := s.proposerBoostRoot == [32]byte{}
 		if currentSlot == slot && secondsIntoSlot < boostThreshold && isFirstBlock {
@@ -156,7 +179,7 @@ func (s *Store) insert(ctx context.Context,
 	nodeCount.Set(float64(len(s.nodeByRoot)))
 
 	// Only update received block slot if it's within epoch from current time.
-	if slot+params.BeaconConfig().SlotsPerEpoch > slots.CurrentSlot(s.genesisTime) {
+	if slot+params.BeaconConfig().SlotsPerEpoch > s.currentSlot() {
 		s.receivedBlocksLastEpoch[slot%params.BeaconConfig().SlotsPerEpoch] = slot
 	}
 	// Update highest slot tracking.
@@ -277,7 +300,7 @@ func (f *ForkChoice) HighestReceivedBlockDelay() primitives.Slot {
 // ReceivedBlocksLastEpoch returns the number of blocks received in the last epoch
 func (f *ForkChoice) ReceivedBlocksLastEpoch() (uint64, error) {
 	count := uint64(0)
-	lowerBound := slots.CurrentSlot(f.store.genesisTime)
+	lowerBound := f.store.currentSlot()
 	var err error
 	if lowerBound > fieldparams.SlotsPerEpoch {
 		lowerBound, err = lowerBound.SafeSub(fieldparams.SlotsPerEpoch)
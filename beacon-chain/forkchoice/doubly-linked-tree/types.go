@@ -2,6 +2,7 @@ package doublylinkedtree
 
 import (
 	"sync"
+	"time"
 
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/forkchoice"
 	forkchoicetypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/forkchoice/types"
@@ -13,11 +14,14 @@ import (
 type ForkChoice struct {
 	sync.RWMutex
 	store               *Store
-	votes               []Vote                      // tracks individual validator's last vote.
-	balances            []uint64                    // tracks individual validator's balances last accounted in votes.
-	justifiedBalances   []uint64                    // tracks individual validator's last justified balances.
-	numActiveValidators uint64                      // tracks the total number of active validators.
-	balancesByRoot      forkchoice.BalancesByRooter // handler to obtain balances for the state with a given root
+	votes               []Vote                          // tracks individual validator's last vote.
+	balances            []uint64                        // tracks individual validator's balances last accounted in votes.
+	justifiedBalances   []uint64                        // tracks individual validator's last justified balances.
+	numActiveValidators uint64                          // tracks the total number of active validators.
+	balancesByRoot      forkchoice.BalancesByRooter     // handler to obtain balances for the state with a given root
+	recorder            *recorder                       // records the inputs of fork choice when enabled.
+	reorgPolicy         *forkchoicetypes.ReorgPolicy    // the proposer boost and late block reorg parameters, or nil for those of the config.
+	reorgHandler        forkchoice.ReorgDecisionHandler // notified of every late block reorg decision.
 }
 
 // Store defines the fork choice store which includes block nodes and the last view of checkpoint information.
@@ -41,6 +45,7 @@ type Store struct {
 	highestReceivedNode           *Node                                      // The highest slot node.
 	receivedBlocksLastEpoch       [fieldparams.SlotsPerEpoch]primitives.Slot // Using `highestReceivedSlot`. The slot of blocks received in the last epoch.
 	allTipsAreInvalid             bool                                       // tracks if all tips are not viable for head
+	clock                         func() time.Time                           // the current time, which is only set when replaying recorded inputs
 }
 
 // Node defines the individual block which includes its block parent, ancestor and how much weight accounted for it.
@@ -4,9 +4,7 @@ import (
 	"context"
 
 	"github.com/pkg/errors"
-	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/epoch/precompute"
 	forkchoicetypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/forkchoice/types"
-	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
 	"github.com/prysmaticlabs/prysm/v5/config/params"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
@@ -58,12 +56,16 @@ func (f *ForkChoice) updateUnrealizedCheckpoints(ctx context.Context) error {
 	return nil
 }
 
-func (s *Store) pullTips(state state.BeaconState, node *Node, jc, fc *ethpb.Checkpoint) (*ethpb.Checkpoint, *ethpb.Checkpoint) {
+// unrealizedCheckpointsFunc computes the unrealized justified and finalized checkpoints of a block.
+type unrealizedCheckpointsFunc func() (*ethpb.Checkpoint, *ethpb.Checkpoint, error)
+
+// pullTips updates the unrealized checkpoints of the node, computing them only when they may
+// have changed from those of its parent, and returns the checkpoints to update the store with.
+func (s *Store) pullTips(stateSlot primitives.Slot, node *Node, jc, fc *ethpb.Checkpoint, unrealized unrealizedCheckpointsFunc) (*ethpb.Checkpoint, *ethpb.Checkpoint) {
 	if node.parent == nil { // Nothing to do if the parent is nil.
 		return jc, fc
 	}
-	currentEpoch := slots.ToEpoch(slots.CurrentSlot(s.genesisTime))
-	stateSlot := state.Slot()
+	currentEpoch := slots.ToEpoch(s.currentSlot())
 	stateEpoch := slots.ToEpoch(stateSlot)
 	currJustified := node.parent.unrealizedJustifiedEpoch == currentEpoch
 	prevJustified := node.parent.unrealizedJustifiedEpoch+1 == currentEpoch
@@ -75,7 +77,7 @@ func (s *Store) pullTips(state state.BeaconState, node *Node, jc, fc *ethpb.Chec
 		return jc, fc
 	}
 
-	uj, uf, err := precompute.UnrealizedCheckpoints(state)
+	uj, uf, err := unrealized()
 	if err != nil {
 		log.WithError(err).Debug("could not compute unrealized checkpoints")
 		uj, uf = jc, fc
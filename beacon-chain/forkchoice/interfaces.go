@@ -15,6 +15,14 @@ import (
 // with the given block root
 type BalancesByRooter func(context.Context, [32]byte) ([]uint64, error)
 
+// SnapshotVerifier checks a snapshot of the fork choice store before it is restored.
+type SnapshotVerifier func(*forkchoicetypes.SnapshotInfo) error
+
+// ReorgDecisionHandler is notified of the late block reorg decisions of fork choice. It is called
+// while the fork choice lock is held, so it must neither block nor call fork choice. Decisions
+// are meant to be queued and published once the lock is released.
+type ReorgDecisionHandler func(*forkchoicetypes.ReorgDecision)
+
 // ForkChoicer represents the full fork choice interface composed of all the sub-interfaces.
 type ForkChoicer interface {
 	RLocker // separate interface isolates  read locking for ROForkChoice.
@@ -25,6 +33,8 @@ type ForkChoicer interface {
 	AttestationProcessor // to track new attestation for fork choice.
 	Getter               // to retrieve fork choice information.
 	Setter               // to set fork choice information.
+	Snapshotter          // to save and restore fork choice across restarts.
+	ReorgPolicyManager   // to configure the proposer boost and late block reorgs.
 }
 
 // RLocker represents forkchoice's internal RWMutex read-only lock/unlock methods.
@@ -57,6 +67,7 @@ type Getter interface {
 	AncestorRoot(ctx context.Context, root [32]byte, slot primitives.Slot) ([32]byte, error)
 	CommonAncestor(ctx context.Context, root1 [32]byte, root2 [32]byte) ([32]byte, primitives.Slot, error)
 	ForkChoiceDump(context.Context) (*forkchoice2.Dump, error)
+	Recording() ([]byte, error)
 	Tips() ([][32]byte, []primitives.Slot)
 }
 
@@ -84,6 +95,20 @@ type FastGetter interface {
 	ParentRoot(root [32]byte) ([32]byte, error)
 }
 
+// Snapshotter encodes the fork choice store, and restores it from an encoded snapshot.
+type Snapshotter interface {
+	Snapshot() ([]byte, error)
+	LoadSnapshot([]byte, SnapshotVerifier) error
+}
+
+// ReorgPolicyManager sets the policy fork choice follows for the proposer boost and the late block
+// reorgs, which can be changed at runtime, and reports the reorg decisions taken under it.
+type ReorgPolicyManager interface {
+	ReorgPolicy() *forkchoicetypes.ReorgPolicy
+	SetReorgPolicy(*forkchoicetypes.ReorgPolicy) error
+	SetReorgDecisionHandler(ReorgDecisionHandler)
+}
+
 // Setter allows to set forkchoice information
 type Setter interface {
 	SetOptimisticToValid(context.Context, [fieldparams.RootLength]byte) error
@@ -0,0 +1,88 @@
+package types
+
+import (
+	"github.com/pkg/errors"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+)
+
+// ReorgPolicy holds the parameters of the proposer boost and of the late block reorgs of fork
+// choice. The boost and the weight thresholds are percentages of the committee weight of a slot.
+type ReorgPolicy struct {
+	// DisableLateBlockReorgs prevents the proposers from orphaning weak late blocks.
+	DisableLateBlockReorgs bool `yaml:"DISABLE_LATE_BLOCK_REORGS" json:"disable_late_block_reorgs"`
+	// ProposerScoreBoost is the weight given to a block received on time in its slot.
+	ProposerScoreBoost uint64 `yaml:"PROPOSER_SCORE_BOOST" json:"proposer_score_boost"`
+	// HeadWeightThreshold is the weight under which a late head block is orphaned.
+	HeadWeightThreshold uint64 `yaml:"REORG_HEAD_WEIGHT_THRESHOLD" json:"reorg_head_weight_threshold"`
+	// ParentWeightThreshold is the weight the parent of a late head block must have for the head to be orphaned.
+	ParentWeightThreshold uint64 `yaml:"REORG_PARENT_WEIGHT_THRESHOLD" json:"reorg_parent_weight_threshold"`
+	// MaxEpochsSinceFinalization is the number of epochs without finality after which blocks are no longer orphaned.
+	MaxEpochsSinceFinalization primitives.Epoch `yaml:"REORG_MAX_EPOCHS_SINCE_FINALIZATION" json:"reorg_max_epochs_since_finalization"`
+}
+
+// DefaultReorgPolicy returns the policy defined by the beacon chain config.
+func DefaultReorgPolicy() *ReorgPolicy {
+	cfg := params.BeaconConfig()
+	return &ReorgPolicy{
+		ProposerScoreBoost:         cfg.ProposerScoreBoost,
+		HeadWeightThreshold:        cfg.ReorgWeightThreshold,
+		ParentWeightThreshold:      cfg.ReorgParentWeightThreshold,
+		MaxEpochsSinceFinalization: cfg.ReorgMaxEpochsSinceFinalization,
+	}
+}
+
+// Validate checks that the percentages of the policy are within bounds.
+func (p *ReorgPolicy) Validate() error {
+	if p == nil {
+		return errors.New("nil reorg policy")
+	}
+	if p.ProposerScoreBoost > 100 {
+		return errors.Errorf("proposer score boost %d is more than 100%% of the committee weight", p.ProposerScoreBoost)
+	}
+	if p.HeadWeightThreshold > 100 {
+		return errors.Errorf("head weight threshold %d is more than 100%% of the committee weight", p.HeadWeightThreshold)
+	}
+	return nil
+}
+
+// The checks of fork choice which evaluate a late block reorg.
+const (
+	// ReorgCheckOverrideFCU is the check made before the slot of a proposal, to decide whether the
+	// execution engine is notified of the new head or keeps building on its parent.
+	ReorgCheckOverrideFCU = "override_fcu"
+	// ReorgCheckProposerHead is the check made when proposing, to decide the parent of the block.
+	ReorgCheckProposerHead = "proposer_head"
+)
+
+// The reasons of the late block reorg decisions.
+const (
+	ReorgReasonDisabled            = "disabled"
+	ReorgReasonHeadSlot            = "head_slot"
+	ReorgReasonEpochBoundary       = "epoch_boundary"
+	ReorgReasonHeadOnTime          = "head_on_time"
+	ReorgReasonNotFinalizing       = "not_finalizing"
+	ReorgReasonParentSlot          = "parent_slot"
+	ReorgReasonStrongHead          = "strong_head"
+	ReorgReasonWeakParent          = "weak_parent"
+	ReorgReasonProposingLate       = "proposing_late"
+	ReorgReasonPendingAttestations = "pending_attestations"
+	ReorgReasonWeakHead            = "weak_head"
+	ReorgReasonError               = "error"
+)
+
+// ReorgDecision is the outcome of a check of fork choice on whether the late head block is orphaned.
+type ReorgDecision struct {
+	// Check is one of the ReorgCheck constants.
+	Check      string
+	Slot       primitives.Slot
+	HeadRoot   [fieldparams.RootLength]byte
+	ParentRoot [fieldparams.RootLength]byte
+	// HeadWeight and ParentWeight are the weights of the nodes, in Gwei.
+	HeadWeight   uint64
+	ParentWeight uint64
+	Reorg        bool
+	// Reason is one of the ReorgReason constants.
+	Reason string
+}
@@ -3,6 +3,7 @@ package types
 import (
 	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
 	consensus_blocks "github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	forkchoice2 "github.com/prysmaticlabs/prysm/v5/consensus-types/forkchoice"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
 	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
 )
@@ -21,3 +22,30 @@ type BlockAndCheckpoints struct {
 	JustifiedCheckpoint *ethpb.Checkpoint
 	FinalizedCheckpoint *ethpb.Checkpoint
 }
+
+// SnapshotInfo summarizes a snapshot of the fork choice store, for it to be checked
+// against the database before it is restored.
+type SnapshotInfo struct {
+	JustifiedCheckpoint *Checkpoint
+	FinalizedCheckpoint *Checkpoint
+	OriginRoot          [fieldparams.RootLength]byte
+	GenesisTime         uint64
+	HeadRoot            [fieldparams.RootLength]byte
+	Roots               [][fieldparams.RootLength]byte
+}
+
+// ProposerBoostDecision tells whether a block received at a given time of its slot got the
+// proposer boost.
+type ProposerBoostDecision struct {
+	Root            [fieldparams.RootLength]byte
+	Slot            primitives.Slot
+	SecondsIntoSlot uint64
+	Boosted         bool
+}
+
+// ReplayResult is the view of fork choice at the end of a slot, replayed from its recorded inputs.
+type ReplayResult struct {
+	Slot           primitives.Slot
+	Dump           *forkchoice2.Dump
+	ProposerBoosts []*ProposerBoostDecision
+}
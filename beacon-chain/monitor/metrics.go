@@ -78,4 +78,37 @@ var (
 			"validator_index",
 		},
 	)
+	// bailoutBufferRemainingGauge used to track how much a validator can still lose before it is bailed out
+	bailoutBufferRemainingGauge = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "monitor",
+			Name:      "bailout_buffer_remaining_gwei",
+			Help:      "The balance a validator can still lose before it is below the bailout threshold",
+		},
+		[]string{
+			"validator_index",
+		},
+	)
+	// inactivityScoreGauge used to track the inactivity score compared to the bailout score threshold
+	inactivityScoreGauge = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "monitor",
+			Name:      "inactivity_score",
+			Help:      "The inactivity score of a validator, which bails it out during an inactivity leak once above INACTIVITY_LEAK_BAILOUT_SCORE_THRESHOLD",
+		},
+		[]string{
+			"validator_index",
+		},
+	)
+	// epochsToBailoutGauge used to track the projected number of epochs until a validator is bailed out
+	epochsToBailoutGauge = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "monitor",
+			Name:      "epochs_to_bailout",
+			Help:      "The projected number of epochs until a validator is bailed out if it stays offline, -1 if it can not be bailed out",
+		},
+		[]string{
+			"validator_index",
+		},
+	)
 )
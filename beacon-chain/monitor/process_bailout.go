@@ -0,0 +1,91 @@
+package monitor
+
+import (
+	"fmt"
+
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/time"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+	"github.com/sirupsen/logrus"
+)
+
+// processBailoutRisk updates the bailout metrics of the tracked validators once per epoch,
+// and warns when a tracked validator is projected to be bailed out within BailoutWarningEpochs.
+// Both conditions of IsEligibleForBailOut are reported: the balance dropping below the bailout buffer,
+// and the inactivity score passing INACTIVITY_LEAK_BAILOUT_SCORE_THRESHOLD during an inactivity leak.
+func (s *Service) processBailoutRisk(st state.BeaconState) {
+	if st.Version() < version.Altair {
+		return
+	}
+	currentEpoch := time.CurrentEpoch(st)
+
+	s.Lock()
+	defer s.Unlock()
+	if currentEpoch < s.nextBailoutCheckEpoch {
+		return
+	}
+	s.nextBailoutCheckEpoch = currentEpoch + 1
+
+	leak := helpers.IsInInactivityLeak(time.PrevEpoch(st), st.FinalizedCheckpointEpoch())
+	for idx := range s.TrackedValidators {
+		if err := s.processBailoutRiskForValidator(st, idx, currentEpoch, leak); err != nil {
+			log.WithError(err).WithField("validatorIndex", idx).Error("Could not process bailout risk")
+		}
+	}
+}
+
+// processBailoutRiskForValidator reports the bailout risk of a tracked validator.
+// It assumes the caller holds the service Lock.
+func (s *Service) processBailoutRiskForValidator(st state.BeaconState, idx primitives.ValidatorIndex, currentEpoch primitives.Epoch, leak bool) error {
+	if uint64(idx) >= uint64(st.NumValidators()) {
+		return nil
+	}
+	val, err := st.ValidatorAtIndexReadOnly(idx)
+	if err != nil {
+		return err
+	}
+	// The bailout does not apply to validators which are not active or have initiated their exit.
+	if !helpers.IsActiveValidatorUsingTrie(val, currentEpoch) || val.ExitEpoch() != params.BeaconConfig().FarFutureEpoch {
+		return nil
+	}
+	balance, err := st.BalanceAtIndex(idx)
+	if err != nil {
+		return err
+	}
+	inactivityScore, err := st.InactivityScoreAtIndex(idx)
+	if err != nil {
+		return err
+	}
+
+	principal := val.PrincipalBalance()
+	bufferRemaining := helpers.BailOutBufferRemaining(balance, principal)
+	epochs, ok := helpers.EpochsToBailOut(balance, principal, inactivityScore, leak)
+
+	label := fmt.Sprintf("%d", idx)
+	bailoutBufferRemainingGauge.WithLabelValues(label).Set(float64(bufferRemaining))
+	inactivityScoreGauge.WithLabelValues(label).Set(float64(inactivityScore))
+	if !ok {
+		epochsToBailoutGauge.WithLabelValues(label).Set(-1)
+		return nil
+	}
+	epochsToBailoutGauge.WithLabelValues(label).Set(float64(epochs))
+
+	if epochs > s.config.BailoutWarningEpochs {
+		return nil
+	}
+	log.WithFields(logrus.Fields{
+		"validatorIndex":           idx,
+		"epoch":                    currentEpoch,
+		"balance":                  balance,
+		"principalBalance":         principal,
+		"bailoutBufferRemaining":   bufferRemaining,
+		"inactivityScore":          inactivityScore,
+		"inactivityScoreThreshold": params.BeaconConfig().InactivityLeakBailoutScoreThreshold,
+		"inactivityLeak":           leak,
+		"projectedEpochsToBailout": epochs,
+	}).Warn("Validator is close to being bailed out, make sure it is online and attesting")
+	return nil
+}
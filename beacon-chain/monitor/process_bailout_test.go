@@ -0,0 +1,71 @@
+package monitor
+
+import (
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+	logTest "github.com/sirupsen/logrus/hooks/test"
+)
+
+func TestProcessBailoutRisk(t *testing.T) {
+	cfg := params.BeaconConfig()
+	st, _ := util.DeterministicGenesisStateElectra(t, 64)
+	slot, err := slots.EpochStart(10)
+	require.NoError(t, err)
+	require.NoError(t, st.SetSlot(slot))
+	require.NoError(t, st.SetFinalizedCheckpoint(&ethpb.Checkpoint{Epoch: 8, Root: make([]byte, 32)}))
+
+	val, err := st.ValidatorAtIndexReadOnly(1)
+	require.NoError(t, err)
+	principal := val.PrincipalBalance()
+	// Validator 1 is one penalty away from the bailout, validator 2 is healthy.
+	require.NoError(t, st.UpdateBalancesAtIndex(1, principal-helpers.BailOutBuffer(principal)))
+	scores := make([]uint64, 64)
+	scores[1] = cfg.InactivityScorePenaltyThreshold
+	require.NoError(t, st.SetInactivityScores(scores))
+
+	hook := logTest.NewGlobal()
+	s := &Service{
+		config: &ValidatorMonitorConfig{BailoutWarningEpochs: 2},
+		TrackedValidators: map[primitives.ValidatorIndex]bool{
+			1: true,
+			2: true,
+		},
+	}
+	s.processBailoutRisk(st)
+	require.LogsContain(t, hook, "Validator is close to being bailed out")
+	require.LogsContain(t, hook, "validatorIndex=1")
+	require.LogsContain(t, hook, "projectedEpochsToBailout=1")
+	require.LogsDoNotContain(t, hook, "validatorIndex=2")
+	require.Equal(t, primitives.Epoch(11), s.nextBailoutCheckEpoch)
+
+	// Only checked once per epoch.
+	hook.Reset()
+	s.processBailoutRisk(st)
+	require.LogsDoNotContain(t, hook, "bailed out")
+
+	t.Run("inactivity leak", func(t *testing.T) {
+		hook := logTest.NewGlobal()
+		leakSt := st.Copy()
+		require.NoError(t, leakSt.SetFinalizedCheckpoint(&ethpb.Checkpoint{Epoch: 0, Root: make([]byte, 32)}))
+		scores := make([]uint64, 64)
+		scores[2] = cfg.InactivityLeakBailoutScoreThreshold
+		require.NoError(t, leakSt.SetInactivityScores(scores))
+		s := &Service{
+			config: &ValidatorMonitorConfig{BailoutWarningEpochs: 2},
+			TrackedValidators: map[primitives.ValidatorIndex]bool{
+				2: true,
+			},
+		}
+		s.processBailoutRisk(leakSt)
+		require.LogsContain(t, hook, "Validator is close to being bailed out")
+		require.LogsContain(t, hook, "inactivityLeak=true")
+		require.LogsContain(t, hook, "validatorIndex=2")
+	})
+}
@@ -44,6 +44,7 @@ func (s *Service) processBlock(ctx context.Context, b interfaces.ReadOnlySignedB
 
 	s.processProposedBlock(st, root, blk)
 	s.processAttestations(ctx, st, blk)
+	s.processBailoutRisk(st)
 
 	if blk.Slot()%(AggregateReportingPeriod*params.BeaconConfig().SlotsPerEpoch) == 0 {
 		s.logAggregatedPerformance()
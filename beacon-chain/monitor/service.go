@@ -56,6 +56,9 @@ type ValidatorMonitorConfig struct {
 	HeadFetcher         blockchain.HeadFetcher
 	StateGen            stategen.StateManager
 	InitialSyncComplete chan struct{}
+	// BailoutWarningEpochs is the number of epochs before the projected bailout
+	// from which the monitor warns about a tracked validator.
+	BailoutWarningEpochs primitives.Epoch
 }
 
 // Service is the main structure that tracks validators and reports logs and
@@ -67,13 +70,14 @@ type Service struct {
 	isLogging bool
 
 	// Locks access to TrackedValidators, latestPerformance, aggregatedPerformance,
-	// trackedSyncedCommitteeIndices and lastSyncedEpoch
+	// trackedSyncedCommitteeIndices, lastSyncedEpoch and nextBailoutCheckEpoch
 	sync.RWMutex
 
 	TrackedValidators     map[primitives.ValidatorIndex]bool
 	latestPerformance     map[primitives.ValidatorIndex]ValidatorLatestPerformance
 	aggregatedPerformance map[primitives.ValidatorIndex]ValidatorAggregatedPerformance
 	lastSyncedEpoch       primitives.Epoch
+	nextBailoutCheckEpoch primitives.Epoch
 }
 
 // NewService sets up a new validator monitor service instance when given a list of validator indices to track.
@@ -1,9 +1,10 @@
 package closehandler
 
+import "github.com/prysmaticlabs/prysm/v5/runtime"
+
 // CloseHandler is a struct used to manage the graceful shutdown of a node.
-// It contains a function to trigger the closing process and a channel to
-// wait for the completion of the shutdown.
+// It contains the shutdown of the node, which is triggered in the background
+// and reports its progress per service while the node is shutting down.
 type CloseHandler struct {
-	CloseFunc func()
-	CloseCh   chan struct{}
+	Shutdown *runtime.Shutdown
 }
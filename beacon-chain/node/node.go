@@ -29,6 +29,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/filesystem"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/kv"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/pruner"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/slasherkv"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/execution"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/forkchoice"
@@ -52,6 +53,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/sync/checkpoint"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/sync/genesis"
 	initialsync "github.com/prysmaticlabs/prysm/v5/beacon-chain/sync/initial-sync"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/tokenomics"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/verification"
 	"github.com/prysmaticlabs/prysm/v5/cmd"
 	"github.com/prysmaticlabs/prysm/v5/cmd/beacon-chain/flags"
@@ -97,6 +99,7 @@ type BeaconNode struct {
 	slashingsPool           slashings.PoolManager
 	depositCache            cache.DepositCache
 	trackedValidatorsCache  *cache.TrackedValidatorsCache
+	eventSubscribers        *cache.EventSubscribersCache
 	payloadIDCache          *cache.PayloadIDCache
 	stateFeed               *event.Feed
 	blockFeed               *event.Feed
@@ -115,6 +118,7 @@ type BeaconNode struct {
 	initialSyncComplete     chan struct{}
 	BlobStorage             *filesystem.BlobStorage
 	BlobStorageOptions      []filesystem.BlobStorageOption
+	historyRetentionEpochs  primitives.Epoch
 	verifyInitWaiter        *verification.InitializerWaiter
 	syncChecker             *initialsync.SyncChecker
 }
@@ -145,6 +149,7 @@ func New(cliCtx *cli.Context, cancel context.CancelFunc, opts ...Option) (*Beaco
 		exitPool:                voluntaryexits.NewPool(),
 		slashingsPool:           slashings.NewPool(),
 		trackedValidatorsCache:  cache.NewTrackedValidatorsCache(),
+		eventSubscribers:        cache.NewEventSubscribersCache(),
 		payloadIDCache:          cache.NewPayloadIDCache(),
 		slasherBlockHeadersFeed: new(event.Feed),
 		slasherAttestationsFeed: new(event.Feed),
@@ -161,7 +166,9 @@ func New(cliCtx *cli.Context, cancel context.CancelFunc, opts ...Option) (*Beaco
 
 	synchronizer := startup.NewClockSynchronizer()
 	beacon.clockWaiter = synchronizer
-	beacon.forkChoicer = doublylinkedtree.New()
+	forkChoicer := doublylinkedtree.New()
+	forkChoicer.EnableRecording(cliCtx.Int(flags.ForkChoiceRecordingEpochs.Name))
+	beacon.forkChoicer = forkChoicer
 
 	depositAddress, err := execution.DepositContractAddress()
 	if err != nil {
@@ -355,6 +362,16 @@ func registerServices(cliCtx *cli.Context, beacon *BeaconNode, synchronizer *sta
 		return errors.Wrap(err, "could not register validator monitoring service")
 	}
 
+	log.Debugln("Registering Tokenomics Indexer Service")
+	if err := beacon.registerTokenomicsIndexerService(cliCtx); err != nil {
+		return errors.Wrap(err, "could not register tokenomics indexer service")
+	}
+
+	log.Debugln("Registering History Pruner Service")
+	if err := beacon.registerPrunerService(); err != nil {
+		return errors.Wrap(err, "could not register history pruner service")
+	}
+
 	if !cliCtx.Bool(cmd.DisableMonitoringFlag.Name) {
 		log.Debugln("Registering Prometheus Service")
 		if err := beacon.registerPrometheusService(cliCtx); err != nil {
@@ -440,7 +457,7 @@ func (b *BeaconNode) Close() {
 	close(b.stop)
 }
 
-func (b *BeaconNode) clearDB(clearDB, forceClearDB bool, d *kv.Store, dbPath string) (*kv.Store, error) {
+func (b *BeaconNode) clearDB(clearDB, forceClearDB bool, d *kv.Store, dbPath string, opts ...kv.KVStoreOption) (*kv.Store, error) {
 	var err error
 	clearDBConfirmed := false
 
@@ -468,7 +485,7 @@ func (b *BeaconNode) clearDB(clearDB, forceClearDB bool, d *kv.Store, dbPath str
 			return nil, errors.Wrap(err, "could not clear blob storage")
 		}
 
-		d, err = kv.NewKVStore(b.ctx, dbPath)
+		d, err = kv.NewKVStore(b.ctx, dbPath, opts...)
 		if err != nil {
 			return nil, errors.Wrap(err, "could not create new database")
 		}
@@ -510,13 +527,25 @@ func (b *BeaconNode) startDB(cliCtx *cli.Context, depositAddress string) error {
 
 	log.WithField("databasePath", dbPath).Info("Checking DB")
 
-	d, err := kv.NewKVStore(b.ctx, dbPath)
+	var opts []kv.KVStoreOption
+	if features.Get().EnableStateDiff {
+		exponents := kv.DefaultStateDiffExponents
+		if cliCtx.IsSet(flags.StateDiffExponents.Name) {
+			exponents = cliCtx.Uint64Slice(flags.StateDiffExponents.Name)
+		}
+		if err := kv.ValidateStateDiffExponents(exponents); err != nil {
+			return errors.Wrapf(err, "invalid --%s", flags.StateDiffExponents.Name)
+		}
+		opts = append(opts, kv.WithStateDiffExponents(exponents))
+	}
+
+	d, err := kv.NewKVStore(b.ctx, dbPath, opts...)
 	if err != nil {
 		return errors.Wrapf(err, "could not create database at %s", dbPath)
 	}
 
 	if clearDBRequired || forceClearDBRequired {
-		d, err = b.clearDB(clearDBRequired, forceClearDBRequired, d, dbPath)
+		d, err = b.clearDB(clearDBRequired, forceClearDBRequired, d, dbPath, opts...)
 		if err != nil {
 			return errors.Wrap(err, "could not clear database")
 		}
@@ -755,6 +784,7 @@ func (b *BeaconNode) registerBlockchainService(fc forkchoice.ForkChoicer, gs *st
 		blockchain.WithSyncComplete(syncComplete),
 		blockchain.WithBlobStorage(b.BlobStorage),
 		blockchain.WithTrackedValidatorsCache(b.trackedValidatorsCache),
+		blockchain.WithEventSubscribersCache(b.eventSubscribers),
 		blockchain.WithPayloadIDCache(b.payloadIDCache),
 		blockchain.WithSyncChecker(b.syncChecker),
 	)
@@ -852,6 +882,12 @@ func (b *BeaconNode) registerInitialSyncService(complete chan struct{}) error {
 		initialsync.WithVerifierWaiter(b.verifyInitWaiter),
 		initialsync.WithSyncChecker(b.syncChecker),
 	}
+	if urls := b.cliCtx.StringSlice(flags.InitialSyncBeaconAPIURLs.Name); len(urls) > 0 {
+		opts = append(opts,
+			initialsync.WithRESTEndpoints(urls...),
+			initialsync.WithRESTTimeout(b.cliCtx.Duration(flags.InitialSyncBeaconAPITimeout.Name)),
+		)
+	}
 	is := initialsync.NewService(b.ctx, &initialsync.Config{
 		DB:                  b.db,
 		Chain:               chainService,
@@ -889,6 +925,9 @@ func (b *BeaconNode) registerSlasherService() error {
 		SyncChecker:             syncService,
 		HeadStateFetcher:        chainService,
 		ClockWaiter:             b.clockWaiter,
+		BeaconDB:                b.db,
+		HistoricalBackfill:      b.cliCtx.Bool(flags.SlasherHistoricalBackfill.Name),
+		BackfillEpochsPerSlot:   b.cliCtx.Uint64(flags.SlasherBackfillEpochsPerSlot.Name),
 	})
 	if err != nil {
 		return err
@@ -912,6 +951,16 @@ func (b *BeaconNode) registerRPCService(router *http.ServeMux) error {
 		return err
 	}
 
+	var regularSyncService *regularsync.Service
+	if err := b.services.FetchService(&regularSyncService); err != nil {
+		return err
+	}
+
+	var backfillService *backfill.Service
+	if err := b.services.FetchService(&backfillService); err != nil {
+		return err
+	}
+
 	var slasherService *slasher.Service
 	if features.Get().EnableSlasher {
 		if err := b.services.FetchService(&slasherService); err != nil {
@@ -942,8 +991,7 @@ func (b *BeaconNode) registerRPCService(router *http.ServeMux) error {
 	p2pService := b.fetchP2P()
 
 	closeHandler := &closehandler.CloseHandler{
-		CloseFunc: b.Close,
-		CloseCh:   b.stop,
+		Shutdown: runtime.NewShutdown(b.Close, b.drain(chainService), b.services),
 	}
 
 	rpcService := rpc.NewService(b.ctx, &rpc.Config{
@@ -956,10 +1004,14 @@ func (b *BeaconNode) registerRPCService(router *http.ServeMux) error {
 		CertFlag:                   cert,
 		KeyFlag:                    key,
 		BeaconDB:                   b.db,
+		SlasherDB:                  b.slasherDB,
 		Broadcaster:                p2pService,
 		PeersFetcher:               p2pService,
 		PeerManager:                p2pService,
+		PeerBanner:                 p2pService,
 		MetadataProvider:           p2pService,
+		RateLimitUsageFetcher:      regularSyncService,
+		BackfillRepairFetcher:      backfillService,
 		ChainInfoFetcher:           chainService,
 		HeadFetcher:                chainService,
 		CanonicalFetcher:           chainService,
@@ -994,6 +1046,7 @@ func (b *BeaconNode) registerRPCService(router *http.ServeMux) error {
 		ClockWaiter:                b.clockWaiter,
 		BlobStorage:                b.BlobStorage,
 		TrackedValidatorsCache:     b.trackedValidatorsCache,
+		EventSubscribers:           b.eventSubscribers,
 		PayloadIDCache:             b.payloadIDCache,
 		CloseHandler:               closeHandler,
 		AuthTokenPath:              authTokenPath,
@@ -1002,6 +1055,26 @@ func (b *BeaconNode) registerRPCService(router *http.ServeMux) error {
 	return b.services.RegisterService(rpcService)
 }
 
+// drain returns the drain step of a graceful shutdown. It waits for the blocks being imported, then
+// flushes the blocks cached by initial sync, the finalized state and the fork choice store to the
+// database and syncs the database to disk. The blocks cached by a batch import after the flush stay
+// in the cache, and are saved when the blockchain service stops.
+func (b *BeaconNode) drain(chainService *blockchain.Service) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		if err := chainService.WaitForBlockImports(ctx); err != nil {
+			return err
+		}
+		log.Info("Flushing blockchain state to the database")
+		if err := chainService.Flush(ctx); err != nil {
+			return errors.Wrap(err, "could not flush blockchain state")
+		}
+		if err := b.db.Sync(); err != nil {
+			return errors.Wrap(err, "could not sync database")
+		}
+		return nil
+	}
+}
+
 func (b *BeaconNode) registerPrometheusService(_ *cli.Context) error {
 	var additionalHandlers []prometheus.Handler
 	var p *p2p.Service
@@ -1071,11 +1144,12 @@ func (b *BeaconNode) registerValidatorMonitorService(initialSyncComplete chan st
 		return err
 	}
 	monitorConfig := &monitor.ValidatorMonitorConfig{
-		StateNotifier:       b,
-		AttestationNotifier: b,
-		StateGen:            b.stateGen,
-		HeadFetcher:         chainService,
-		InitialSyncComplete: initialSyncComplete,
+		StateNotifier:        b,
+		AttestationNotifier:  b,
+		StateGen:             b.stateGen,
+		HeadFetcher:          chainService,
+		InitialSyncComplete:  initialSyncComplete,
+		BailoutWarningEpochs: primitives.Epoch(b.cliCtx.Uint64(cmd.ValidatorMonitorBailoutWarningEpochsFlag.Name)),
 	}
 	svc, err := monitor.NewService(b.ctx, monitorConfig, tracked)
 	if err != nil {
@@ -1084,6 +1158,42 @@ func (b *BeaconNode) registerValidatorMonitorService(initialSyncComplete chan st
 	return b.services.RegisterService(svc)
 }
 
+func (b *BeaconNode) registerTokenomicsIndexerService(cliCtx *cli.Context) error {
+	if !cliCtx.Bool(flags.EnableTokenomicsIndexer.Name) {
+		return nil
+	}
+
+	var chainService *blockchain.Service
+	if err := b.services.FetchService(&chainService); err != nil {
+		return err
+	}
+	svc := tokenomics.NewService(b.ctx, &tokenomics.Config{
+		BeaconDB:        b.db,
+		StateNotifier:   b,
+		ReplayerBuilder: stategen.NewCanonicalHistory(b.db, chainService, chainService),
+		Backfill:        cliCtx.Bool(flags.TokenomicsIndexerBackfill.Name),
+	})
+	return b.services.RegisterService(svc)
+}
+
+func (b *BeaconNode) registerPrunerService() error {
+	if b.historyRetentionEpochs == 0 {
+		return nil
+	}
+	// Hierarchical states are kept for historical state lookups, which pruning would defeat.
+	if features.Get().EnableStateDiff {
+		return errors.New("history retention cannot be used together with hierarchical state diffs")
+	}
+
+	svc := pruner.NewService(b.ctx, &pruner.Config{
+		BeaconDB:        b.db,
+		StateNotifier:   b,
+		ClockWaiter:     b.clockWaiter,
+		RetentionEpochs: b.historyRetentionEpochs,
+	})
+	return b.services.RegisterService(svc)
+}
+
 func (b *BeaconNode) registerBuilderService(cliCtx *cli.Context) error {
 	var chainService *blockchain.Service
 	if err := b.services.FetchService(&chainService); err != nil {
@@ -0,0 +1,79 @@
+package p2p
+
+import (
+	"net"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// BanPeer bans a peer by its ID. The peer is disconnected, and it is not dialed nor accepted
+// until the ban is lifted. The ban is saved to disk along with the known peers.
+func (s *Service) BanPeer(pid peer.ID) error {
+	s.peers.BanPeer(pid)
+	log.WithField("peer", pid).Info("Banned peer")
+	s.disconnectBannedPeers()
+	return s.writePeersFile()
+}
+
+// UnbanPeer lifts the ban of a peer.
+func (s *Service) UnbanPeer(pid peer.ID) error {
+	if !s.peers.UnbanPeer(pid) {
+		return nil
+	}
+	log.WithField("peer", pid).Info("Lifted peer ban")
+	return s.writePeersFile()
+}
+
+// BanIPNet bans all the peers with an IP address in the given range. Those peers are
+// disconnected, and they are not dialed nor accepted until the ban is lifted. The ban
+// is saved to disk along with the known peers.
+func (s *Service) BanIPNet(ipNet *net.IPNet) error {
+	s.peers.BanIPNet(ipNet)
+	log.WithField("ipRange", ipNet).Info("Banned IP range")
+	s.disconnectBannedPeers()
+	return s.writePeersFile()
+}
+
+// UnbanIPNet lifts the ban of a range of IP addresses.
+func (s *Service) UnbanIPNet(ipNet *net.IPNet) error {
+	if !s.peers.UnbanIPNet(ipNet) {
+		return nil
+	}
+	log.WithField("ipRange", ipNet).Info("Lifted IP range ban")
+	return s.writePeersFile()
+}
+
+// ParseIPNet parses an IP address or a range of IP addresses in CIDR notation.
+// A single IP address is the range containing only that address.
+func ParseIPNet(s string) (*net.IPNet, error) {
+	if _, ipNet, err := net.ParseCIDR(s); err == nil {
+		return ipNet, nil
+	}
+	ip := net.ParseIP(s)
+	if ip == nil {
+		return nil, errors.Errorf("invalid IP address or CIDR range %q", s)
+	}
+	if ip4 := ip.To4(); ip4 != nil {
+		return &net.IPNet{IP: ip4, Mask: net.CIDRMask(32, 32)}, nil
+	}
+	return &net.IPNet{IP: ip, Mask: net.CIDRMask(128, 128)}, nil
+}
+
+// disconnectBannedPeers closes the connections with the peers which are banned.
+func (s *Service) disconnectBannedPeers() {
+	for _, pid := range s.peers.Active() {
+		err := s.peers.IsBanned(pid)
+		if err == nil {
+			continue
+		}
+		log.WithFields(logrus.Fields{
+			"peer":   pid,
+			"reason": err.Error(),
+		}).Debug("Initiate peer disconnection")
+		if err := s.Disconnect(pid); err != nil {
+			log.WithError(err).WithField("peer", pid).Debug("Could not disconnect banned peer")
+		}
+	}
+}
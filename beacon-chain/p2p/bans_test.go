@@ -0,0 +1,77 @@
+package p2p
+
+import (
+	"context"
+	"crypto/rand"
+	"net"
+	"path"
+	"testing"
+
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/peers"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/peers/scorers"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestParseIPNet(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{input: "192.168.1.1", want: "192.168.1.1/32"},
+		{input: "10.1.2.3/8", want: "10.0.0.0/8"},
+		{input: "2001:db8::1", want: "2001:db8::1/128"},
+		{input: "2001:db8::/32", want: "2001:db8::/32"},
+		{input: "", wantErr: true},
+		{input: "10.0.0.0/33", wantErr: true},
+		{input: "foo", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			ipNet, err := ParseIPNet(tt.input)
+			if tt.wantErr {
+				assert.NotNil(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, ipNet.String())
+		})
+	}
+}
+
+func TestService_Bans(t *testing.T) {
+	ctx := context.Background()
+	newStatus := func() *peers.Status {
+		return peers.NewStatus(ctx, &peers.StatusConfig{PeerLimit: 30, ScorerParams: &scorers.Config{}})
+	}
+	s := &Service{peers: newStatus(), cfg: &Config{DataDir: t.TempDir()}}
+	_, pub, err := crypto.GenerateSecp256k1Key(rand.Reader)
+	require.NoError(t, err)
+	pid, err := peer.IDFromPublicKey(pub)
+	require.NoError(t, err)
+	_, ipNet, err := net.ParseCIDR("10.0.0.0/8")
+	require.NoError(t, err)
+
+	require.NoError(t, s.BanPeer(pid))
+	require.NoError(t, s.BanIPNet(ipNet))
+	assert.NotNil(t, s.peers.IsBad(pid), "Banned peer should be bad")
+
+	// The bans are saved along with the known peers.
+	restored := newStatus()
+	_, err = restored.Load(path.Join(s.cfg.DataDir, peersPath))
+	require.NoError(t, err)
+	assert.DeepEqual(t, []peer.ID{pid}, restored.BannedPeers())
+	require.Equal(t, 1, len(restored.BannedIPNets()))
+	assert.Equal(t, "10.0.0.0/8", restored.BannedIPNets()[0].String())
+
+	require.NoError(t, s.UnbanPeer(pid))
+	require.NoError(t, s.UnbanIPNet(ipNet))
+	restored = newStatus()
+	_, err = restored.Load(path.Join(s.cfg.DataDir, peersPath))
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(restored.BannedPeers()))
+	assert.Equal(t, 0, len(restored.BannedIPNets()))
+}
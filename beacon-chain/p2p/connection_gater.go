@@ -25,8 +25,9 @@ const (
 )
 
 // InterceptPeerDial tests whether we're permitted to Dial the specified peer.
-func (*Service) InterceptPeerDial(_ peer.ID) (allow bool) {
-	return true
+func (s *Service) InterceptPeerDial(pid peer.ID) (allow bool) {
+	// Disallow dialing banned peers.
+	return s.peers.IsBanned(pid) == nil
 }
 
 // InterceptAddrDial tests whether we're permitted to dial the specified
@@ -36,6 +37,9 @@ func (s *Service) InterceptAddrDial(pid peer.ID, m multiaddr.Multiaddr) (allow b
 	if s.peers.IsBad(pid) != nil {
 		return false
 	}
+	if s.peers.IsBannedAddr(m) {
+		return false
+	}
 	return filterConnections(s.addrFilter, m)
 }
 
@@ -45,6 +49,11 @@ func (s *Service) InterceptAccept(n network.ConnMultiaddrs) (allow bool) {
 	if !s.started {
 		return false
 	}
+	if s.peers.IsBannedAddr(n.RemoteMultiaddr()) {
+		log.WithFields(logrus.Fields{"peer": n.RemoteMultiaddr(),
+			"reason": "banned ip address"}).Trace("Not accepting inbound dial")
+		return false
+	}
 	if !s.validateDial(n.RemoteMultiaddr()) {
 		// Allow other go-routines to run in the event
 		// we receive a large amount of junk connections.
@@ -63,8 +72,9 @@ func (s *Service) InterceptAccept(n network.ConnMultiaddrs) (allow bool) {
 
 // InterceptSecured tests whether a given connection, now authenticated,
 // is allowed.
-func (*Service) InterceptSecured(_ network.Direction, _ peer.ID, _ network.ConnMultiaddrs) (allow bool) {
-	return true
+func (s *Service) InterceptSecured(_ network.Direction, pid peer.ID, _ network.ConnMultiaddrs) (allow bool) {
+	// Disallow banned peers, which are only known once the connection is authenticated when inbound.
+	return s.peers.IsBanned(pid) == nil
 }
 
 // InterceptUpgraded tests whether a fully capable connection is allowed.
@@ -3,10 +3,12 @@ package p2p
 import (
 	"context"
 	"fmt"
+	"net"
 	"testing"
 	"time"
 
 	"github.com/libp2p/go-libp2p"
+	"github.com/libp2p/go-libp2p/core/network"
 	"github.com/libp2p/go-libp2p/core/peer"
 	ma "github.com/multiformats/go-multiaddr"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/peers"
@@ -98,6 +100,38 @@ func TestService_InterceptBannedIP(t *testing.T) {
 	}
 }
 
+func TestService_InterceptBannedPeers(t *testing.T) {
+	s := &Service{
+		ipLimiter: leakybucket.NewCollector(ipLimit, ipBurst, 1*time.Second, false),
+		peers: peers.NewStatus(context.Background(), &peers.StatusConfig{
+			PeerLimit:    20,
+			ScorerParams: &scorers.Config{},
+		}),
+	}
+	var err error
+	s.addrFilter, err = configureFilter(&Config{})
+	require.NoError(t, err)
+	s.started = true
+	banned := addPeer(t, s.peers, peers.Disconnected, false)
+	allowed := addPeer(t, s.peers, peers.Disconnected, false)
+	s.peers.BanPeer(banned)
+	_, ipNet, err := net.ParseCIDR("212.67.0.0/16")
+	require.NoError(t, err)
+	s.peers.BanIPNet(ipNet)
+	bannedAddr, err := ma.NewMultiaddr("/ip4/212.67.10.122/tcp/3000")
+	require.NoError(t, err)
+	allowedAddr, err := ma.NewMultiaddr("/ip4/213.202.254.180/tcp/3000")
+	require.NoError(t, err)
+
+	assert.Equal(t, false, s.InterceptPeerDial(banned))
+	assert.Equal(t, true, s.InterceptPeerDial(allowed))
+	assert.Equal(t, false, s.InterceptSecured(network.DirInbound, banned, nil))
+	assert.Equal(t, true, s.InterceptSecured(network.DirInbound, allowed, nil))
+	assert.Equal(t, false, s.InterceptAddrDial(allowed, bannedAddr))
+	assert.Equal(t, true, s.InterceptAddrDial(allowed, allowedAddr))
+	assert.Equal(t, false, s.InterceptAccept(&maEndpoints{raddr: bannedAddr}))
+}
+
 func TestService_RejectInboundConnectionBeforeStarted(t *testing.T) {
 	limit := 1
 	s := &Service{
@@ -373,6 +373,8 @@ func (s *Service) createListener(
 
 		bootNodes = append(bootNodes, bootNode)
 	}
+	// Seed the routing table with the best peers known from the previous runs.
+	bootNodes = append(bootNodes, s.knownPeerNodes()...)
 
 	dv5Cfg := discover.Config{
 		PrivateKey: privKey,
@@ -2,6 +2,7 @@ package p2p
 
 import (
 	"context"
+	"net"
 
 	"github.com/ethereum/go-ethereum/p2p/enr"
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
@@ -25,6 +26,7 @@ type P2P interface {
 	PubSubTopicUser
 	SenderEncoder
 	PeerManager
+	PeerBanner
 	ConnectionHandler
 	PeersProvider
 	MetadataProvider
@@ -86,6 +88,14 @@ type PeerManager interface {
 	AddPingMethod(reqFunc func(ctx context.Context, id peer.ID) error)
 }
 
+// PeerBanner bans peers by their ID or IP address, and lifts the bans.
+type PeerBanner interface {
+	BanPeer(peer.ID) error
+	UnbanPeer(peer.ID) error
+	BanIPNet(*net.IPNet) error
+	UnbanIPNet(*net.IPNet) error
+}
+
 // Sender abstracts the sending functionality from libp2p.
 type Sender interface {
 	Send(context.Context, interface{}, string, peer.ID) (network.Stream, error)
@@ -0,0 +1,94 @@
+package p2p
+
+import (
+	"path"
+	"time"
+
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/pkg/errors"
+)
+
+// peersSaveInterval defines how often the known peers are saved to disk.
+const peersSaveInterval = 5 * time.Minute
+
+// peersFilePath returns the path of the file the known peers are saved to, which is
+// empty when the node has no data directory.
+func (s *Service) peersFilePath() string {
+	if s.peers == nil || s.cfg.DataDir == "" {
+		return ""
+	}
+	return path.Join(s.cfg.DataDir, peersPath)
+}
+
+// loadPeers restores the peers known from the previous runs of the node, so that
+// peers found bad remain banned and the best peers can be dialed on start.
+func (s *Service) loadPeers() {
+	peersFile := s.peersFilePath()
+	if peersFile == "" {
+		return
+	}
+	count, err := s.peers.Load(peersFile)
+	if err != nil {
+		log.WithError(err).Error("Could not load known peers")
+		return
+	}
+	if count > 0 {
+		log.WithField("count", count).Info("Restored known peers")
+	}
+}
+
+// writePeersFile writes the known peers with their scores, and the bans, to disk. The writes of the
+// periodic saves and of the ban updates are serialized, and each replaces the file atomically.
+func (s *Service) writePeersFile() error {
+	peersFile := s.peersFilePath()
+	if peersFile == "" {
+		return nil
+	}
+	return errors.Wrap(s.peers.Save(peersFile), "could not save known peers")
+}
+
+// savePeers saves the known peers with their scores to disk.
+func (s *Service) savePeers() {
+	if err := s.writePeersFile(); err != nil {
+		log.WithError(err).Error("Could not save known peers")
+	}
+}
+
+// knownPeerNodes returns the nodes of the best peers known from the previous runs,
+// which are used to warm-start discovery.
+func (s *Service) knownPeerNodes() []*enode.Node {
+	if s.peers == nil {
+		return nil
+	}
+	records := s.peers.BestKnown(int(s.cfg.MaxPeers))
+	nodes := make([]*enode.Node, 0, len(records))
+	for _, record := range records {
+		node, err := enode.New(enode.ValidSchemes, record)
+		if err != nil {
+			log.WithError(err).Trace("Could not create node from known peer ENR")
+			continue
+		}
+		// Discovery rejects all its bootstrap nodes if one of them lacks an IP or a UDP port.
+		if err := node.ValidateComplete(); err != nil {
+			log.WithError(err).Trace("Known peer ENR is incomplete")
+			continue
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes
+}
+
+// connectToKnownPeers dials the best known peers which are still valid protocol peers.
+func (s *Service) connectToKnownPeers() {
+	var nodes []*enode.Node
+	for _, node := range s.knownPeerNodes() {
+		if s.filterPeer(node) {
+			nodes = append(nodes, node)
+		}
+	}
+	if len(nodes) == 0 {
+		return
+	}
+	log.WithField("count", len(nodes)).Debug("Dialing known peers")
+	s.connectWithAllPeers(convertToMultiAddr(nodes))
+}
@@ -0,0 +1,51 @@
+package p2p
+
+import (
+	"context"
+	"crypto/rand"
+	"net"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/ethereum/go-ethereum/p2p/enr"
+	libp2pcrypto "github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/peers"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/peers/scorers"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestService_KnownPeerNodes(t *testing.T) {
+	s := &Service{
+		peers: peers.NewStatus(context.Background(), &peers.StatusConfig{PeerLimit: 30, ScorerParams: &scorers.Config{}}),
+		cfg:   &Config{MaxPeers: 30},
+	}
+	addPeer := func(entries ...enr.Entry) *enode.Node {
+		key, err := crypto.GenerateKey()
+		require.NoError(t, err)
+		record := &enr.Record{}
+		for _, e := range entries {
+			record.Set(e)
+		}
+		require.NoError(t, enode.SignV4(record, key))
+		node, err := enode.New(enode.ValidSchemes, record)
+		require.NoError(t, err)
+		_, pub, err := libp2pcrypto.GenerateSecp256k1Key(rand.Reader)
+		require.NoError(t, err)
+		pid, err := peer.IDFromPublicKey(pub)
+		require.NoError(t, err)
+		s.peers.Add(record, pid, nil, network.DirOutbound)
+		s.peers.SetConnectionState(pid, peers.Disconnected)
+		return node
+	}
+	complete := addPeer(enr.IPv4(net.IPv4(213, 202, 254, 180)), enr.UDP(12000), enr.TCP(13000))
+	// A record without a UDP port is valid, but it can't be used to bootstrap discovery.
+	addPeer(enr.IPv4(net.IPv4(213, 202, 254, 181)), enr.TCP(13000))
+
+	nodes := s.knownPeerNodes()
+	require.Equal(t, 1, len(nodes))
+	assert.Equal(t, complete.ID(), nodes[0].ID())
+}
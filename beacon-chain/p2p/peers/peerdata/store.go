@@ -3,6 +3,7 @@ package peerdata
 import (
 	"context"
 	"errors"
+	"net"
 	"sync"
 	"time"
 
@@ -42,6 +43,8 @@ type Store struct {
 	config       *StoreConfig
 	peers        map[peer.ID]*PeerData
 	trustedPeers map[peer.ID]bool
+	bannedPeers  map[peer.ID]bool
+	bannedNets   map[string]*net.IPNet
 }
 
 // PeerData aggregates protocol and application level info about a single peer.
@@ -52,6 +55,7 @@ type PeerData struct {
 	ConnState     ConnectionState
 	Enr           *enr.Record
 	NextValidTime time.Time
+	LastSeen      time.Time
 	// Chain related data.
 	MetaData                  metadata.Metadata
 	ChainState                *ethpb.Status
@@ -62,9 +66,21 @@ type PeerData struct {
 	ProcessedBlocks      uint64
 	BlockProviderUpdated time.Time
 	// Gossip Scoring data.
-	TopicScores      map[string]*ethpb.TopicScoreSnapshot
-	GossipScore      float64
-	BehaviourPenalty float64
+	TopicScores        map[string]*ethpb.TopicScoreSnapshot
+	GossipScore        float64
+	BehaviourPenalty   float64
+	GossipScoreUpdated time.Time
+	// Last goodbye message exchanged with the peer.
+	LastGoodbye *Goodbye
+}
+
+// Goodbye is a goodbye message sent to or received from a peer.
+type Goodbye struct {
+	Code   uint64
+	Reason string
+	// Sent is true if the goodbye was sent by us, false if it was received from the peer.
+	Sent bool
+	Time time.Time
 }
 
 // NewStore creates new peer data store.
@@ -74,6 +90,8 @@ func NewStore(ctx context.Context, config *StoreConfig) *Store {
 		config:       config,
 		peers:        make(map[peer.ID]*PeerData),
 		trustedPeers: make(map[peer.ID]bool),
+		bannedPeers:  make(map[peer.ID]bool),
+		bannedNets:   make(map[string]*net.IPNet),
 	}
 }
 
@@ -145,6 +163,75 @@ func (s *Store) IsTrustedPeer(p peer.ID) bool {
 	return s.trustedPeers[p]
 }
 
+// BanPeer adds a peer to the set of peers banned by the operator.
+// Important: it is assumed that store mutex is locked when calling this method.
+func (s *Store) BanPeer(pid peer.ID) {
+	s.bannedPeers[pid] = true
+}
+
+// UnbanPeer removes a peer from the banned peer set, returning false if it was not banned.
+// Important: it is assumed that store mutex is locked when calling this method.
+func (s *Store) UnbanPeer(pid peer.ID) bool {
+	if !s.bannedPeers[pid] {
+		return false
+	}
+	delete(s.bannedPeers, pid)
+	return true
+}
+
+// IsBannedPeer checks that the provided peer is in the banned peer set.
+// Important: it is assumed that store mutex is locked when calling this method.
+func (s *Store) IsBannedPeer(pid peer.ID) bool {
+	return s.bannedPeers[pid]
+}
+
+// BannedPeers gets the banned peer ids.
+// Important: it is assumed that store mutex is locked when calling this method.
+func (s *Store) BannedPeers() []peer.ID {
+	peers := make([]peer.ID, 0, len(s.bannedPeers))
+	for p := range s.bannedPeers {
+		peers = append(peers, p)
+	}
+	return peers
+}
+
+// BanIPNet adds an IP range to the set of ranges banned by the operator.
+// Important: it is assumed that store mutex is locked when calling this method.
+func (s *Store) BanIPNet(ipNet *net.IPNet) {
+	s.bannedNets[ipNet.String()] = ipNet
+}
+
+// UnbanIPNet removes an IP range from the banned ranges, returning false if it was not banned.
+// Important: it is assumed that store mutex is locked when calling this method.
+func (s *Store) UnbanIPNet(ipNet *net.IPNet) bool {
+	if _, ok := s.bannedNets[ipNet.String()]; !ok {
+		return false
+	}
+	delete(s.bannedNets, ipNet.String())
+	return true
+}
+
+// BannedIPNets gets the banned IP ranges.
+// Important: it is assumed that store mutex is locked when calling this method.
+func (s *Store) BannedIPNets() []*net.IPNet {
+	nets := make([]*net.IPNet, 0, len(s.bannedNets))
+	for _, n := range s.bannedNets {
+		nets = append(nets, n)
+	}
+	return nets
+}
+
+// IsBannedIP checks whether the provided IP is in one of the banned ranges.
+// Important: it is assumed that store mutex is locked when calling this method.
+func (s *Store) IsBannedIP(ip net.IP) bool {
+	for _, n := range s.bannedNets {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
 // Config exposes store configuration params.
 func (s *Store) Config() *StoreConfig {
 	return s.config
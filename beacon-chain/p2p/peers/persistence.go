@@ -0,0 +1,289 @@
+package peers
+
+import (
+	"encoding/json"
+	"net"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/p2p/enr"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/libp2p/go-libp2p/core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/peers/peerdata"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+)
+
+const (
+	// peerRecordsVersion is the version of the format of the saved peers.
+	peerRecordsVersion = 1
+	// MaxPeerRecordAge is the time after which a peer not seen anymore is not restored from disk.
+	MaxPeerRecordAge = 7 * 24 * time.Hour
+)
+
+// peerRecord is the saved reputation of a known peer.
+type peerRecord struct {
+	ID                   string    `json:"id"`
+	ENR                  []byte    `json:"enr,omitempty"`
+	Address              string    `json:"address,omitempty"`
+	LastSeen             time.Time `json:"last_seen"`
+	BadResponses         int       `json:"bad_responses,omitempty"`
+	ProcessedBlocks      uint64    `json:"processed_blocks,omitempty"`
+	BlockProviderUpdated time.Time `json:"block_provider_updated"`
+	GossipScore          float64   `json:"gossip_score,omitempty"`
+	BehaviourPenalty     float64   `json:"behaviour_penalty,omitempty"`
+	GossipScoreUpdated   time.Time `json:"gossip_score_updated"`
+}
+
+// peerRecords is the content of the file the known peers are saved to.
+type peerRecords struct {
+	Version     int           `json:"version"`
+	SavedAt     time.Time     `json:"saved_at"`
+	Peers       []*peerRecord `json:"peers"`
+	BannedPeers []string      `json:"banned_peers,omitempty"`
+	BannedIPs   []string      `json:"banned_ips,omitempty"`
+}
+
+// Save writes the addresses and the scores of the known peers, and the bans set by the operator,
+// to the file at the given path. The file is replaced atomically, and concurrent saves are serialized,
+// so that a crash or a concurrent save never leaves a partially written file.
+func (p *Status) Save(path string) error {
+	p.store.RLock()
+	now := time.Now()
+	records := &peerRecords{
+		Version: peerRecordsVersion,
+		SavedAt: now,
+		Peers:   make([]*peerRecord, 0, len(p.store.Peers())),
+	}
+	for pid, peerData := range p.store.Peers() {
+		if peerData.Address == nil && peerData.Enr == nil {
+			continue
+		}
+		record := &peerRecord{
+			ID:                   pid.String(),
+			LastSeen:             peerData.LastSeen,
+			BadResponses:         peerData.BadResponses,
+			ProcessedBlocks:      peerData.ProcessedBlocks,
+			BlockProviderUpdated: peerData.BlockProviderUpdated,
+			GossipScore:          peerData.GossipScore,
+			BehaviourPenalty:     peerData.BehaviourPenalty,
+			GossipScoreUpdated:   peerData.GossipScoreUpdated,
+		}
+		if peerData.ConnState == Connected {
+			record.LastSeen = now
+		}
+		if peerData.Address != nil {
+			record.Address = peerData.Address.String()
+		}
+		if peerData.Enr != nil {
+			// Records which are not signed, e.g. of peers we did not discover, cannot be encoded.
+			enc, err := rlp.EncodeToBytes(peerData.Enr)
+			if err != nil {
+				log.WithError(err).WithField("peer", pid).Trace("Could not encode peer ENR")
+			}
+			record.ENR = enc
+		}
+		records.Peers = append(records.Peers, record)
+	}
+	for _, pid := range p.store.BannedPeers() {
+		records.BannedPeers = append(records.BannedPeers, pid.String())
+	}
+	for _, ipNet := range p.store.BannedIPNets() {
+		records.BannedIPs = append(records.BannedIPs, ipNet.String())
+	}
+	p.store.RUnlock()
+
+	enc, err := json.Marshal(records)
+	if err != nil {
+		return errors.Wrap(err, "could not encode peers")
+	}
+	p.saveLock.Lock()
+	defer p.saveLock.Unlock()
+	return writeFileAtomic(path, enc)
+}
+
+// writeFileAtomic writes the data to a temporary file next to the given path, syncs it
+// and renames it over the file at the given path.
+func writeFileAtomic(path string, data []byte) error {
+	tmp := path + ".tmp"
+	f, err := os.OpenFile(tmp, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, params.BeaconIoConfig().ReadWritePermissions) // #nosec G304
+	if err != nil {
+		return errors.Wrap(err, "could not create peers file")
+	}
+	_, err = f.Write(data)
+	if err == nil {
+		err = f.Sync()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		if rmErr := os.Remove(tmp); rmErr != nil {
+			log.WithError(rmErr).Error("Could not remove partial peers file")
+		}
+		return errors.Wrap(err, "could not write peers file")
+	}
+	return errors.Wrap(os.Rename(tmp, path), "could not rename peers file")
+}
+
+// Load restores the peers saved to the file at the given path, returning the number of peers
+// restored. Peers which are already known, or which have not been seen for longer than
+// MaxPeerRecordAge, are skipped. Bad responses are decayed as they would have been while the
+// peers were not tracked, so that bad peers remain bad until their score has decayed. The bad
+// peers and the bans set by the operator are all restored, and the other peers fill the remaining
+// slots of the peer store from the highest to the lowest score. Invalid entries are skipped.
+// A missing file is not an error.
+func (p *Status) Load(path string) (int, error) {
+	enc, err := os.ReadFile(path) // #nosec G304
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, nil
+		}
+		return 0, errors.Wrap(err, "could not read peers")
+	}
+	records := &peerRecords{}
+	if err := json.Unmarshal(enc, records); err != nil {
+		return 0, errors.Wrap(err, "could not decode peers")
+	}
+	if records.Version != peerRecordsVersion {
+		return 0, errors.Errorf("unsupported peers file version %d", records.Version)
+	}
+
+	badResponsesDecays := 0
+	if elapsed := time.Since(records.SavedAt); elapsed > 0 {
+		badResponsesDecays = int(elapsed / p.scorers.BadResponsesScorer().Params().DecayInterval)
+	}
+
+	p.store.Lock()
+	defer p.store.Unlock()
+	for _, id := range records.BannedPeers {
+		pid, err := peer.Decode(id)
+		if err != nil {
+			log.WithError(err).WithField("peer", id).Warn("Could not decode saved banned peer ID")
+			continue
+		}
+		p.store.BanPeer(pid)
+	}
+	for _, cidr := range records.BannedIPs {
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			log.WithError(err).WithField("ipRange", cidr).Warn("Could not decode saved banned IP range")
+			continue
+		}
+		p.store.BanIPNet(ipNet)
+	}
+
+	// All the saved peers are added to the store first, so that they can be scored like the known peers.
+	added := make([]peer.ID, 0, len(records.Peers))
+	for _, record := range records.Peers {
+		if time.Since(record.LastSeen) > MaxPeerRecordAge {
+			continue
+		}
+		pid, peerData, err := record.peerData(badResponsesDecays)
+		if err != nil {
+			log.WithError(err).WithField("peer", record.ID).Debug("Could not decode saved peer")
+			continue
+		}
+		if _, ok := p.store.PeerData(pid); ok {
+			continue
+		}
+		p.store.SetPeerData(pid, peerData)
+		added = append(added, pid)
+	}
+
+	type scoredPeer struct {
+		pid   peer.ID
+		score float64
+	}
+	restored := make([]peer.ID, 0, len(added))
+	good := make([]scoredPeer, 0, len(added))
+	for _, pid := range added {
+		if p.store.IsBannedPeer(pid) || p.scorers.IsBadPeerNoLock(pid) != nil {
+			restored = append(restored, pid)
+			continue
+		}
+		good = append(good, scoredPeer{pid: pid, score: p.scorers.ScoreNoLock(pid)})
+	}
+	sort.SliceStable(good, func(i, j int) bool {
+		return good[i].score > good[j].score
+	})
+	slots := max(p.store.Config().MaxPeers-len(restored), 0)
+	for i, sp := range good {
+		if i < slots {
+			restored = append(restored, sp.pid)
+			continue
+		}
+		p.store.DeletePeerData(sp.pid)
+	}
+	for _, pid := range restored {
+		if peerData, ok := p.store.PeerData(pid); ok && peerData.Address != nil {
+			p.addIpToTracker(pid)
+		}
+	}
+	return len(restored), nil
+}
+
+// peerData decodes the saved peer, with its bad responses decayed the given number of times.
+func (r *peerRecord) peerData(badResponsesDecays int) (peer.ID, *peerdata.PeerData, error) {
+	pid, err := peer.Decode(r.ID)
+	if err != nil {
+		return "", nil, errors.Wrap(err, "invalid peer ID")
+	}
+	peerData := &peerdata.PeerData{
+		ConnState:            Disconnected,
+		LastSeen:             r.LastSeen,
+		BadResponses:         max(r.BadResponses-badResponsesDecays, 0),
+		ProcessedBlocks:      r.ProcessedBlocks,
+		BlockProviderUpdated: r.BlockProviderUpdated,
+		GossipScore:          r.GossipScore,
+		BehaviourPenalty:     r.BehaviourPenalty,
+		GossipScoreUpdated:   r.GossipScoreUpdated,
+	}
+	if r.Address != "" {
+		if peerData.Address, err = ma.NewMultiaddr(r.Address); err != nil {
+			return "", nil, errors.Wrap(err, "invalid address")
+		}
+	}
+	if len(r.ENR) > 0 {
+		peerData.Enr = &enr.Record{}
+		if err := rlp.DecodeBytes(r.ENR, peerData.Enr); err != nil {
+			return "", nil, errors.Wrap(err, "invalid ENR")
+		}
+	}
+	return pid, peerData, nil
+}
+
+// BestKnown returns the ENRs of up to n known peers we are not connected to which are not bad,
+// from the highest to the lowest score.
+func (p *Status) BestKnown(n int) []*enr.Record {
+	p.store.RLock()
+	defer p.store.RUnlock()
+
+	type peerResp struct {
+		record *enr.Record
+		score  float64
+	}
+	candidates := make([]*peerResp, 0)
+	for pid, peerData := range p.store.Peers() {
+		if peerData.Enr == nil || peerData.ConnState != Disconnected || p.isBad(pid) != nil {
+			continue
+		}
+		candidates = append(candidates, &peerResp{
+			record: peerData.Enr,
+			score:  p.scorers.ScoreNoLock(pid),
+		})
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].score > candidates[j].score
+	})
+	if len(candidates) > n {
+		candidates = candidates[:n]
+	}
+	records := make([]*enr.Record, len(candidates))
+	for i, c := range candidates {
+		records[i] = c.record
+	}
+	return records
+}
@@ -0,0 +1,284 @@
+package peers_test
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/ethereum/go-ethereum/p2p/enr"
+	libp2pcrypto "github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/peers"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/peers/peerdata"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/peers/scorers"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func newPersistedStatus(ctx context.Context) *peers.Status {
+	return peers.NewStatus(ctx, &peers.StatusConfig{
+		PeerLimit: 30,
+		ScorerParams: &scorers.Config{
+			BadResponsesScorerConfig: &scorers.BadResponsesScorerConfig{
+				Threshold:     2,
+				DecayInterval: time.Hour,
+			},
+		},
+	})
+}
+
+// addKnownPeer adds a peer with an ID derived from a key, as the IDs of the peers found on the network.
+func addKnownPeer(t *testing.T, p *peers.Status, addr ma.Multiaddr, state peerdata.ConnectionState) peer.ID {
+	_, pub, err := libp2pcrypto.GenerateSecp256k1Key(rand.Reader)
+	require.NoError(t, err)
+	id, err := peer.IDFromPublicKey(pub)
+	require.NoError(t, err)
+	p.Add(new(enr.Record), id, addr, network.DirOutbound)
+	p.SetConnectionState(id, state)
+	return id
+}
+
+func TestStatus_SaveLoad(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	path := filepath.Join(t.TempDir(), "peers.json")
+
+	p := newPersistedStatus(ctx)
+	addr, err := ma.NewMultiaddr("/ip4/213.202.254.180/tcp/13000")
+	require.NoError(t, err)
+	bad := addKnownPeer(t, p, addr, peers.Disconnected)
+	p.Scorers().BadResponsesScorer().Increment(bad)
+	p.Scorers().BadResponsesScorer().Increment(bad)
+	good := addKnownPeer(t, p, addr, peers.Connected)
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	record := &enr.Record{}
+	record.Set(enr.TCP(13000))
+	require.NoError(t, enode.SignV4(record, key))
+	p.UpdateENR(record, good)
+	p.Scorers().GossipScorer().SetGossipData(good, 10, 0, nil)
+	require.NoError(t, p.Save(path))
+
+	// The peers are restored disconnected, with their reputation.
+	restored := newPersistedStatus(ctx)
+	count, err := restored.Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+	assert.NotNil(t, restored.IsBad(bad), "Bad peer should remain bad")
+	assert.NoError(t, restored.IsBad(good))
+	state, err := restored.ConnectionState(good)
+	require.NoError(t, err)
+	assert.Equal(t, peers.Disconnected, state)
+	gotAddr, err := restored.Address(good)
+	require.NoError(t, err)
+	assert.Equal(t, addr.String(), gotAddr.String())
+	gotENR, err := restored.ENR(good)
+	require.NoError(t, err)
+	var port enr.TCP
+	require.NoError(t, gotENR.Load(&port))
+	assert.Equal(t, enr.TCP(13000), port)
+	assert.Equal(t, 10.0, restored.Scorers().GossipScorer().Score(good))
+
+	// Peers already known are not overwritten.
+	count, err = restored.Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+
+	// A missing file is not an error.
+	count, err = newPersistedStatus(ctx).Load(filepath.Join(t.TempDir(), "missing.json"))
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+}
+
+func TestStatus_LoadDecaysReputation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	path := filepath.Join(t.TempDir(), "peers.json")
+
+	p := newPersistedStatus(ctx)
+	bad := addKnownPeer(t, p, nil, peers.Disconnected)
+	for i := 0; i < 3; i++ {
+		p.Scorers().BadResponsesScorer().Increment(bad)
+	}
+	stale := addKnownPeer(t, p, nil, peers.Disconnected)
+	require.NoError(t, p.Save(path))
+
+	// Pretend the peers were saved two hours ago, and the stale peer last seen long ago.
+	enc, err := os.ReadFile(path)
+	require.NoError(t, err)
+	records := make(map[string]interface{})
+	require.NoError(t, json.Unmarshal(enc, &records))
+	records["saved_at"] = time.Now().Add(-2 * time.Hour)
+	for _, r := range records["peers"].([]interface{}) {
+		record := r.(map[string]interface{})
+		if record["id"] == stale.String() {
+			record["last_seen"] = time.Now().Add(-peers.MaxPeerRecordAge - time.Hour)
+		}
+	}
+	enc, err = json.Marshal(records)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, enc, 0600))
+
+	restored := newPersistedStatus(ctx)
+	count, err := restored.Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+	badResponses, err := restored.Scorers().BadResponsesScorer().Count(bad)
+	require.NoError(t, err)
+	assert.Equal(t, 1, badResponses)
+	assert.NoError(t, restored.IsBad(bad), "Peer should not be bad once its score decayed")
+	_, err = restored.ConnectionState(stale)
+	assert.NotNil(t, err, "Stale peer should not be restored")
+}
+
+func TestStatus_BestKnown(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	p := newPersistedStatus(ctx)
+	low := addKnownPeer(t, p, nil, peers.Disconnected)
+	high := addKnownPeer(t, p, nil, peers.Disconnected)
+	bad := addKnownPeer(t, p, nil, peers.Disconnected)
+	connected := addKnownPeer(t, p, nil, peers.Connected)
+	for port, pid := range map[int]peer.ID{1: low, 2: high} {
+		record := &enr.Record{}
+		record.Set(enr.TCP(port))
+		p.UpdateENR(record, pid)
+	}
+	p.Scorers().GossipScorer().SetGossipData(low, 1, 0, nil)
+	p.Scorers().GossipScorer().SetGossipData(high, 20, 0, nil)
+	p.Scorers().GossipScorer().SetGossipData(connected, 30, 0, nil)
+	p.Scorers().BadResponsesScorer().Increment(bad)
+	p.Scorers().BadResponsesScorer().Increment(bad)
+
+	records := p.BestKnown(10)
+	require.Equal(t, 2, len(records))
+	for i, want := range []enr.TCP{2, 1} {
+		var port enr.TCP
+		require.NoError(t, records[i].Load(&port))
+		assert.Equal(t, want, port)
+	}
+	assert.Equal(t, 1, len(p.BestKnown(1)))
+}
+
+func TestStatus_LoadKeepsBadAndBestPeers(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	path := filepath.Join(t.TempDir(), "peers.json")
+
+	// The peer store holds 151 peers with a limit of a single connected peer.
+	newStatus := func() *peers.Status {
+		return peers.NewStatus(ctx, &peers.StatusConfig{
+			PeerLimit: 1,
+			ScorerParams: &scorers.Config{
+				BadResponsesScorerConfig: &scorers.BadResponsesScorerConfig{Threshold: 2, DecayInterval: time.Hour},
+			},
+		})
+	}
+	p := newStatus()
+	maxPeers := p.MaxPeerLimit()
+	good := make([]peer.ID, maxPeers)
+	for i := range good {
+		good[i] = addKnownPeer(t, p, nil, peers.Disconnected)
+		p.Scorers().GossipScorer().SetGossipData(good[i], float64(i+1), 0, nil)
+	}
+	bad := make([]peer.ID, 2)
+	for i := range bad {
+		bad[i] = addKnownPeer(t, p, nil, peers.Disconnected)
+		p.Scorers().BadResponsesScorer().Increment(bad[i])
+		p.Scorers().BadResponsesScorer().Increment(bad[i])
+	}
+	require.NoError(t, p.Save(path))
+
+	// The bad peers are all restored, and the remaining slots are taken by the best peers.
+	restored := newStatus()
+	count, err := restored.Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, maxPeers, count)
+	for _, pid := range bad {
+		assert.NotNil(t, restored.IsBad(pid), "Bad peer should be restored")
+	}
+	for i, pid := range good {
+		_, err := restored.ConnectionState(pid)
+		if i < len(bad) {
+			assert.NotNil(t, err, "Worst peer should not be restored")
+			continue
+		}
+		assert.NoError(t, err, "Best peer should be restored")
+	}
+}
+
+func TestStatus_LoadSkipsInvalidBans(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	path := filepath.Join(t.TempDir(), "peers.json")
+
+	p := newPersistedStatus(ctx)
+	known := addKnownPeer(t, p, nil, peers.Disconnected)
+	banned := addKnownPeer(t, p, nil, peers.Disconnected)
+	p.BanPeer(banned)
+	require.NoError(t, p.Save(path))
+
+	enc, err := os.ReadFile(path)
+	require.NoError(t, err)
+	records := make(map[string]interface{})
+	require.NoError(t, json.Unmarshal(enc, &records))
+	records["banned_peers"] = []string{"foo", banned.String()}
+	records["banned_ips"] = []string{"10.0.0.0/33", "10.0.0.0/8"}
+	enc, err = json.Marshal(records)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, enc, 0600))
+
+	restored := newPersistedStatus(ctx)
+	count, err := restored.Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+	_, err = restored.ConnectionState(known)
+	assert.NoError(t, err)
+	assert.DeepEqual(t, []peer.ID{banned}, restored.BannedPeers())
+	require.Equal(t, 1, len(restored.BannedIPNets()))
+	assert.Equal(t, "10.0.0.0/8", restored.BannedIPNets()[0].String())
+}
+
+func TestStatus_SaveConcurrently(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "peers.json")
+
+	p := newPersistedStatus(ctx)
+	for i := 0; i < 10; i++ {
+		addKnownPeer(t, p, nil, peers.Disconnected)
+	}
+	var wg sync.WaitGroup
+	errs := make(chan error, 10)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- p.Save(path)
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		require.NoError(t, err)
+	}
+
+	// Only the complete file remains.
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(entries))
+	count, err := newPersistedStatus(ctx).Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, 10, count)
+}
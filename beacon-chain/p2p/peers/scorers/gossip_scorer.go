@@ -1,6 +1,8 @@
 package scorers
 
 import (
+	"time"
+
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/peers/peerdata"
@@ -12,6 +14,11 @@ var _ Scorer = (*GossipScorer)(nil)
 const (
 	// The boundary till which a peer's gossip score is acceptable.
 	gossipThreshold = -100.0
+	// DefaultGossipDecayInterval defines how often stale gossip scores are looked for.
+	DefaultGossipDecayInterval = 10 * time.Minute
+	// DefaultGossipRetainInterval defines how long the gossip score of a peer is retained after
+	// libp2p's pubsub module stopped updating it.
+	DefaultGossipRetainInterval = 12 * time.Hour
 )
 
 // GossipScorer represents scorer that evaluates peers based on their gossip performance.
@@ -22,17 +29,31 @@ type GossipScorer struct {
 }
 
 // GossipScorerConfig holds configuration parameters for gossip scoring service.
-type GossipScorerConfig struct{}
+type GossipScorerConfig struct {
+	// DecayInterval defines how often stale gossip scores are looked for.
+	DecayInterval time.Duration
+	// RetainInterval defines how long a gossip score which is no longer updated is retained. Scores
+	// of connected peers are updated by pubsub, so this only applies to peers we are not talking to,
+	// e.g. the peers banned for their gossip score which were restored from disk.
+	RetainInterval time.Duration
+}
 
 // newGossipScorer creates new gossip scoring service.
 func newGossipScorer(store *peerdata.Store, config *GossipScorerConfig) *GossipScorer {
 	if config == nil {
 		config = &GossipScorerConfig{}
 	}
-	return &GossipScorer{
+	scorer := &GossipScorer{
 		config: config,
 		store:  store,
 	}
+	if scorer.config.DecayInterval == 0 {
+		scorer.config.DecayInterval = DefaultGossipDecayInterval
+	}
+	if scorer.config.RetainInterval == 0 {
+		scorer.config.RetainInterval = DefaultGossipRetainInterval
+	}
+	return scorer
 }
 
 // Score returns calculated peer score.
@@ -86,6 +107,11 @@ func (s *GossipScorer) BadPeers() []peer.ID {
 	return badPeers
 }
 
+// Params exposes scorer's parameters.
+func (s *GossipScorer) Params() *GossipScorerConfig {
+	return s.config
+}
+
 // SetGossipData sets the gossip related data of a peer.
 func (s *GossipScorer) SetGossipData(pid peer.ID, gScore float64,
 	bPenalty float64, topicScores map[string]*pbrpc.TopicScoreSnapshot) {
@@ -96,6 +122,7 @@ func (s *GossipScorer) SetGossipData(pid peer.ID, gScore float64,
 	peerData.GossipScore = gScore
 	peerData.BehaviourPenalty = bPenalty
 	peerData.TopicScores = topicScores
+	peerData.GossipScoreUpdated = time.Now()
 }
 
 // GossipData gets the gossip related information of the given remote peer.
@@ -114,3 +141,21 @@ func (s *GossipScorer) gossipDataNoLock(pid peer.ID) (float64, float64, map[stri
 	}
 	return 0, 0, nil, peerdata.ErrPeerUnknown
 }
+
+// Decay resets the gossip scores which have not been updated for longer than the retain interval,
+// so that peers banned for their gossip score are given another chance once it is stale.
+func (s *GossipScorer) Decay() {
+	s.store.Lock()
+	defer s.store.Unlock()
+
+	for _, peerData := range s.store.Peers() {
+		if peerData.GossipScore == 0 && peerData.BehaviourPenalty == 0 {
+			continue
+		}
+		if time.Since(peerData.GossipScoreUpdated) >= s.config.RetainInterval {
+			peerData.GossipScore = 0
+			peerData.BehaviourPenalty = 0
+			peerData.TopicScores = nil
+		}
+	}
+}
@@ -3,6 +3,7 @@ package scorers_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/peers"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/peers/scorers"
@@ -65,3 +66,26 @@ func TestScorers_Gossip_Score(t *testing.T) {
 		})
 	}
 }
+
+func TestScorers_Gossip_Decay(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	peerStatuses := peers.NewStatus(ctx, &peers.StatusConfig{
+		ScorerParams: &scorers.Config{
+			GossipScorerConfig: &scorers.GossipScorerConfig{
+				RetainInterval: time.Hour,
+			},
+		},
+	})
+	scorer := peerStatuses.Scorers().GossipScorer()
+	scorer.SetGossipData("peer1", -101.0, 1, nil)
+	scorer.Decay()
+	assert.NotNil(t, scorer.IsBadPeer("peer1"), "Score should be retained")
+
+	// Scores which are not updated anymore are reset once the retain interval has passed.
+	scorer.Params().RetainInterval = 0
+	scorer.Decay()
+	assert.Equal(t, 0.0, scorer.Score("peer1"), "Unexpected score")
+	assert.NoError(t, scorer.IsBadPeer("peer1"))
+}
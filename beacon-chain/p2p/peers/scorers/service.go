@@ -183,6 +183,8 @@ func (s *Service) loop(ctx context.Context) {
 	defer decayBadResponsesStats.Stop()
 	decayBlockProviderStats := time.NewTicker(s.scorers.blockProviderScorer.Params().DecayInterval)
 	defer decayBlockProviderStats.Stop()
+	decayGossipStats := time.NewTicker(s.scorers.gossipScorer.Params().DecayInterval)
+	defer decayGossipStats.Stop()
 
 	for {
 		select {
@@ -198,6 +200,12 @@ func (s *Service) loop(ctx context.Context) {
 				return
 			}
 			s.scorers.blockProviderScorer.Decay()
+		case <-decayGossipStats.C:
+			// Exit early if context is canceled.
+			if ctx.Err() != nil {
+				return
+			}
+			s.scorers.gossipScorer.Decay()
 		case <-ctx.Done():
 			return
 		}
@@ -19,7 +19,9 @@
 //
 // Peer information is persistent for the run of the service. This allows for collection of useful
 // long-term statistics such as number of bad responses obtained from the peer, giving the basis for
-// decisions to not talk to known-bad peers (by de-scoring them).
+// decisions to not talk to known-bad peers (by de-scoring them). The addresses and the scores of the
+// known peers can also be saved to disk and restored on the next run, so that known-bad peers remain
+// bad and the best known peers can be dialed right away.
 package peers
 
 import (
@@ -28,6 +30,7 @@ import (
 	"net"
 	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/ethereum/go-ethereum/p2p/enr"
@@ -98,6 +101,7 @@ type Status struct {
 	lastSeen        map[string]time.Time
 	rand            *rand.Rand
 	ipTrackerConfig *IpTrackerConfig
+	saveLock        sync.Mutex
 }
 
 type IpTrackerConfig struct {
@@ -361,6 +365,7 @@ func (p *Status) SetConnectionState(pid peer.ID, state peerdata.ConnectionState)
 
 	peerData := p.store.PeerDataGetOrCreate(pid)
 	peerData.ConnState = state
+	peerData.LastSeen = time.Now()
 }
 
 // ConnectionState gets the connection state of the given remote peer.
@@ -398,6 +403,11 @@ func (p *Status) IsBad(pid peer.ID) error {
 
 // isBad is the lock-free version of IsBad.
 func (p *Status) isBad(pid peer.ID) error {
+	// Bans set by the operator apply to trusted peers as well.
+	if err := p.isBanned(pid); err != nil {
+		return err
+	}
+
 	// Do not disconnect from trusted peers.
 	if p.store.IsTrustedPeer(pid) {
 		return nil
@@ -1044,6 +1054,107 @@ func (p *Status) isTrustedPeers(pid peer.ID) bool {
 	return p.store.IsTrustedPeer(pid)
 }
 
+// BanPeer bans a peer, which is considered bad until the ban is lifted.
+func (p *Status) BanPeer(pid peer.ID) {
+	p.store.Lock()
+	defer p.store.Unlock()
+	p.store.BanPeer(pid)
+}
+
+// UnbanPeer lifts the ban of a peer, returning false if the peer was not banned.
+func (p *Status) UnbanPeer(pid peer.ID) bool {
+	p.store.Lock()
+	defer p.store.Unlock()
+	return p.store.UnbanPeer(pid)
+}
+
+// BannedPeers returns a list of all banned peers' ids.
+func (p *Status) BannedPeers() []peer.ID {
+	p.store.RLock()
+	defer p.store.RUnlock()
+	return p.store.BannedPeers()
+}
+
+// BanIPNet bans a range of IP addresses. Peers with an address in the range are considered
+// bad until the ban is lifted.
+func (p *Status) BanIPNet(ipNet *net.IPNet) {
+	p.store.Lock()
+	defer p.store.Unlock()
+	p.store.BanIPNet(ipNet)
+}
+
+// UnbanIPNet lifts the ban of a range of IP addresses, returning false if the range was not banned.
+func (p *Status) UnbanIPNet(ipNet *net.IPNet) bool {
+	p.store.Lock()
+	defer p.store.Unlock()
+	return p.store.UnbanIPNet(ipNet)
+}
+
+// BannedIPNets returns a list of all banned IP ranges.
+func (p *Status) BannedIPNets() []*net.IPNet {
+	p.store.RLock()
+	defer p.store.RUnlock()
+	return p.store.BannedIPNets()
+}
+
+// IsBannedAddr returns true if the IP of the given address is in a banned range.
+func (p *Status) IsBannedAddr(addr ma.Multiaddr) bool {
+	ip, err := manet.ToIP(addr)
+	if err != nil {
+		return false
+	}
+	p.store.RLock()
+	defer p.store.RUnlock()
+	return p.store.IsBannedIP(ip)
+}
+
+// IsBanned returns an error if the peer, or the IP address it is known with, is banned.
+func (p *Status) IsBanned(pid peer.ID) error {
+	p.store.RLock()
+	defer p.store.RUnlock()
+	return p.isBanned(pid)
+}
+
+// isBanned is the lock-free version of IsBanned.
+func (p *Status) isBanned(pid peer.ID) error {
+	if p.store.IsBannedPeer(pid) {
+		return errors.New("peer is banned")
+	}
+	peerData, ok := p.store.PeerData(pid)
+	if !ok || peerData.Address == nil {
+		return nil
+	}
+	ip, err := manet.ToIP(peerData.Address)
+	if err != nil {
+		return nil
+	}
+	if p.store.IsBannedIP(ip) {
+		return errors.Errorf("peer IP %s is banned", ip)
+	}
+	return nil
+}
+
+// SetLastGoodbye records the last goodbye message sent to or received from the given peer.
+func (p *Status) SetLastGoodbye(pid peer.ID, goodbye *peerdata.Goodbye) {
+	p.store.Lock()
+	defer p.store.Unlock()
+
+	peerData := p.store.PeerDataGetOrCreate(pid)
+	peerData.LastGoodbye = goodbye
+}
+
+// LastGoodbye returns the last goodbye message sent to or received from the given peer,
+// which is nil if no goodbye was exchanged. This will error if the peer does not exist.
+func (p *Status) LastGoodbye(pid peer.ID) (*peerdata.Goodbye, error) {
+	p.store.RLock()
+	defer p.store.RUnlock()
+
+	if peerData, ok := p.store.PeerData(pid); ok {
+		return peerData.LastGoodbye, nil
+	}
+	return nil, peerdata.ErrPeerUnknown
+}
+
 // this method assumes the store lock is acquired before
 // executing the method.
 func (p *Status) isfromBadIP(pid peer.ID) error {
@@ -3,6 +3,7 @@ package peers_test
 import (
 	"context"
 	"crypto/rand"
+	"net"
 	"strconv"
 	"testing"
 	"time"
@@ -1412,6 +1413,53 @@ func TestOutboundConnectedWithProtocol(t *testing.T) {
 }
 
 // addPeer is a helper to add a peer with a given connection state)
+func TestStatus_Bans(t *testing.T) {
+	p := peers.NewStatus(context.Background(), &peers.StatusConfig{
+		PeerLimit:    30,
+		ScorerParams: &scorers.Config{},
+	})
+	banned := addPeer(t, p, peers.Connected)
+	trusted := addPeer(t, p, peers.Connected)
+	p.SetTrustedPeers([]peer.ID{trusted})
+	p.BanPeer(banned)
+	p.BanPeer(trusted)
+	assert.ErrorContains(t, "peer is banned", p.IsBanned(banned))
+	assert.NotNil(t, p.IsBad(banned), "Banned peer should be bad")
+	assert.NotNil(t, p.IsBad(trusted), "Banned trusted peer should be bad")
+	assert.Equal(t, 2, len(p.BannedPeers()))
+	assert.Equal(t, true, p.UnbanPeer(trusted))
+	assert.Equal(t, false, p.UnbanPeer(trusted), "Peer should not be banned anymore")
+	assert.NoError(t, p.IsBad(trusted))
+
+	addr, err := ma.NewMultiaddr("/ip4/10.1.2.3/tcp/13000")
+	require.NoError(t, err)
+	inRange := createPeer(t, p, addr, network.DirInbound, peers.Connected)
+	_, ipNet, err := net.ParseCIDR("10.0.0.0/8")
+	require.NoError(t, err)
+	p.BanIPNet(ipNet)
+	assert.Equal(t, true, p.IsBannedAddr(addr))
+	assert.ErrorContains(t, "peer IP 10.1.2.3 is banned", p.IsBanned(inRange))
+	assert.Equal(t, true, p.UnbanIPNet(ipNet))
+	assert.Equal(t, false, p.IsBannedAddr(addr))
+	assert.NoError(t, p.IsBanned(inRange))
+}
+
+func TestStatus_LastGoodbye(t *testing.T) {
+	p := peers.NewStatus(context.Background(), &peers.StatusConfig{
+		PeerLimit:    30,
+		ScorerParams: &scorers.Config{},
+	})
+	id := addPeer(t, p, peers.Connected)
+	goodbye, err := p.LastGoodbye(id)
+	require.NoError(t, err)
+	assert.Equal(t, (*peerdata.Goodbye)(nil), goodbye)
+	p.SetLastGoodbye(id, &peerdata.Goodbye{Code: 1, Reason: "client shutdown", Sent: true})
+	goodbye, err = p.LastGoodbye(id)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), goodbye.Code)
+	assert.Equal(t, true, goodbye.Sent)
+}
+
 func addPeer(t *testing.T, p *peers.Status, state peerdata.ConnectionState) peer.ID {
 	// Set up some peers with different states
 	mhBytes := []byte{0x11, 0x04}
@@ -165,6 +165,9 @@ func NewService(ctx context.Context, cfg *Config) (*Service, error) {
 				Threshold:     maxBadResponses,
 				DecayInterval: time.Hour,
 			},
+			GossipScorerConfig: &scorers.GossipScorerConfig{
+				RetainInterval: oneHundredEpochs,
+			},
 		},
 		IpTrackerConfig: &peers.IpTrackerConfig{
 			ColocationLimit:         s.cfg.ColocationLimit,
@@ -172,6 +175,7 @@ func NewService(ctx context.Context, cfg *Config) (*Service, error) {
 			ColocationWhitelistCIDR: s.cfg.ColocationWhitelist,
 		},
 	})
+	s.loadPeers()
 
 	// Initialize Data maps.
 	types.InitializeDataMaps()
@@ -216,6 +220,7 @@ func (s *Service) Start() {
 			s.startupErr = err
 			return
 		}
+		s.connectToKnownPeers()
 
 		s.dv5Listener = listener
 		go s.listenForNewNodes()
@@ -242,6 +247,7 @@ func (s *Service) Start() {
 		ensurePeerConnections(s.ctx, s.host, s.peers, relayNodes...)
 	})
 	async.RunEvery(s.ctx, 30*time.Minute, s.Peers().Prune)
+	async.RunEvery(s.ctx, peersSaveInterval, s.savePeers)
 	async.RunEvery(s.ctx, time.Duration(params.BeaconConfig().RespTimeout)*time.Second, s.updateMetrics)
 	async.RunEvery(s.ctx, s.Peers().IPTrackerBanTime()/2, s.Peers().DecayBadIps) // run every IPBanTime /2
 	async.RunEvery(s.ctx, refreshRate, s.RefreshPersistentSubnets)
@@ -292,6 +298,7 @@ func (s *Service) Stop() error {
 	if s.dv5Listener != nil {
 		s.dv5Listener.Close()
 	}
+	s.savePeers()
 
 	// Save metadata to file if static peer id is enabled.
 	if s.cfg.StaticPeerID {
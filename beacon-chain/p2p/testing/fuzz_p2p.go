@@ -2,6 +2,7 @@ package testing
 
 import (
 	"context"
+	"net"
 
 	"github.com/ethereum/go-ethereum/p2p/enr"
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
@@ -68,6 +69,26 @@ func (*FakeP2P) FindPeersWithSubnet(_ context.Context, _ string, _ uint64, _ int
 // RefreshPersistentSubnets mocks the p2p func.
 func (*FakeP2P) RefreshPersistentSubnets() {}
 
+// BanPeer -- fake.
+func (*FakeP2P) BanPeer(_ peer.ID) error {
+	return nil
+}
+
+// UnbanPeer -- fake.
+func (*FakeP2P) UnbanPeer(_ peer.ID) error {
+	return nil
+}
+
+// BanIPNet -- fake.
+func (*FakeP2P) BanIPNet(_ *net.IPNet) error {
+	return nil
+}
+
+// UnbanIPNet -- fake.
+func (*FakeP2P) UnbanIPNet(_ *net.IPNet) error {
+	return nil
+}
+
 // LeaveTopic -- fake.
 func (*FakeP2P) LeaveTopic(_ string) error {
 	return nil
@@ -6,6 +6,7 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"net"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -385,6 +386,30 @@ func (*TestP2P) FindPeersWithSubnet(_ context.Context, _ string, _ uint64, _ int
 // RefreshPersistentSubnets mocks the p2p func.
 func (*TestP2P) RefreshPersistentSubnets() {}
 
+// BanPeer bans the peer in the peer status.
+func (p *TestP2P) BanPeer(pid peer.ID) error {
+	p.peers.BanPeer(pid)
+	return nil
+}
+
+// UnbanPeer lifts the ban of the peer in the peer status.
+func (p *TestP2P) UnbanPeer(pid peer.ID) error {
+	p.peers.UnbanPeer(pid)
+	return nil
+}
+
+// BanIPNet bans the IP range in the peer status.
+func (p *TestP2P) BanIPNet(ipNet *net.IPNet) error {
+	p.peers.BanIPNet(ipNet)
+	return nil
+}
+
+// UnbanIPNet lifts the ban of the IP range in the peer status.
+func (p *TestP2P) UnbanIPNet(ipNet *net.IPNet) error {
+	p.peers.UnbanIPNet(ipNet)
+	return nil
+}
+
 // ForkDigest mocks the p2p func.
 func (p *TestP2P) ForkDigest() ([4]byte, error) {
 	return p.Digest, nil
@@ -105,7 +105,7 @@ func (s *Service) endpoints(
 	endpoints = append(endpoints, s.prysmValidatorEndpoints(stater, coreService)...)
 
 	// custom endpoints for OverProtocol.
-	endpoints = append(endpoints, s.overEndpoints(stater)...)
+	endpoints = append(endpoints, s.overEndpoints(blocker, stater, rewardFetcher)...)
 
 	if enableDebug {
 		endpoints = append(endpoints, s.debugEndpoints(stater)...)
@@ -869,6 +869,24 @@ func (s *Service) debugEndpoints(stater lookup.Stater) []endpoint {
 			handler: server.GetForkChoice,
 			methods: []string{http.MethodGet},
 		},
+		{
+			template: "/prysm/v1/debug/fork_choice/recording",
+			name:     namespace + ".GetForkChoiceRecording",
+			middleware: []middleware.Middleware{
+				middleware.AcceptHeaderHandler([]string{api.OctetStreamMediaType}),
+			},
+			handler: server.GetForkChoiceRecording,
+			methods: []string{http.MethodGet},
+		},
+		{
+			template: "/prysm/v1/debug/fork_choice/replay",
+			name:     namespace + ".ReplayForkChoice",
+			middleware: []middleware.Middleware{
+				middleware.AcceptHeaderHandler([]string{api.JsonMediaType}),
+			},
+			handler: server.ReplayForkChoice,
+			methods: []string{http.MethodGet},
+		},
 	}
 }
 
@@ -879,6 +897,7 @@ func (s *Service) eventsEndpoints() []endpoint {
 		HeadFetcher:            s.cfg.HeadFetcher,
 		ChainInfoFetcher:       s.cfg.ChainInfoFetcher,
 		TrackedValidatorsCache: s.cfg.TrackedValidatorsCache,
+		EventSubscribers:       s.cfg.EventSubscribers,
 	}
 
 	const namespace = "events"
@@ -985,9 +1004,12 @@ func (s *Service) prysmNodeEndpoints() []endpoint {
 		GenesisTimeFetcher:        s.cfg.GenesisTimeFetcher,
 		PeersFetcher:              s.cfg.PeersFetcher,
 		PeerManager:               s.cfg.PeerManager,
+		PeerBanner:                s.cfg.PeerBanner,
 		MetadataProvider:          s.cfg.MetadataProvider,
+		RateLimitUsageFetcher:     s.cfg.RateLimitUsageFetcher,
 		HeadFetcher:               s.cfg.HeadFetcher,
 		ExecutionChainInfoFetcher: s.cfg.ExecutionChainInfoFetcher,
+		BackfillRepairFetcher:     s.cfg.BackfillRepairFetcher,
 	}
 
 	const namespace = "prysm.node"
@@ -1048,6 +1070,80 @@ func (s *Service) prysmNodeEndpoints() []endpoint {
 			handler: server.RemoveTrustedPeer,
 			methods: []string{http.MethodDelete},
 		},
+		{
+			template: "/prysm/v1/node/peers/{peer_id}",
+			name:     namespace + ".GetPeer",
+			middleware: []middleware.Middleware{
+				middleware.AcceptHeaderHandler([]string{api.JsonMediaType}),
+			},
+			handler: server.GetPeer,
+			methods: []string{http.MethodGet},
+		},
+		{
+			template: "/prysm/v1/node/banned_peers",
+			name:     namespace + ".ListBannedPeers",
+			middleware: []middleware.Middleware{
+				middleware.AcceptHeaderHandler([]string{api.JsonMediaType}),
+			},
+			handler: server.ListBannedPeers,
+			methods: []string{http.MethodGet},
+		},
+		{
+			template: "/prysm/v1/node/banned_peers",
+			name:     namespace + ".BanPeer",
+			middleware: []middleware.Middleware{
+				middleware.ContentTypeHandler([]string{api.JsonMediaType}),
+				middleware.AcceptHeaderHandler([]string{api.JsonMediaType}),
+			},
+			handler: server.BanPeer,
+			methods: []string{http.MethodPost},
+		},
+		{
+			template: "/prysm/v1/node/banned_peers/{peer_id}",
+			name:     namespace + ".UnbanPeer",
+			middleware: []middleware.Middleware{
+				middleware.AcceptHeaderHandler([]string{api.JsonMediaType}),
+			},
+			handler: server.UnbanPeer,
+			methods: []string{http.MethodDelete},
+		},
+		{
+			template: "/prysm/v1/node/banned_ips",
+			name:     namespace + ".ListBannedIPs",
+			middleware: []middleware.Middleware{
+				middleware.AcceptHeaderHandler([]string{api.JsonMediaType}),
+			},
+			handler: server.ListBannedIPs,
+			methods: []string{http.MethodGet},
+		},
+		{
+			template: "/prysm/v1/node/banned_ips",
+			name:     namespace + ".BanIP",
+			middleware: []middleware.Middleware{
+				middleware.ContentTypeHandler([]string{api.JsonMediaType}),
+				middleware.AcceptHeaderHandler([]string{api.JsonMediaType}),
+			},
+			handler: server.BanIP,
+			methods: []string{http.MethodPost},
+		},
+		{
+			template: "/prysm/v1/node/banned_ips",
+			name:     namespace + ".UnbanIP",
+			middleware: []middleware.Middleware{
+				middleware.AcceptHeaderHandler([]string{api.JsonMediaType}),
+			},
+			handler: server.UnbanIP,
+			methods: []string{http.MethodDelete},
+		},
+		{
+			template: "/prysm/v1/node/backfill/repair",
+			name:     namespace + ".GetBackfillRepair",
+			middleware: []middleware.Middleware{
+				middleware.AcceptHeaderHandler([]string{api.JsonMediaType}),
+			},
+			handler: server.GetBackfillRepair,
+			methods: []string{http.MethodGet},
+		},
 	}
 }
 
@@ -1101,13 +1197,18 @@ func (s *Service) prysmValidatorEndpoints(stater lookup.Stater, coreService *cor
 	}
 }
 
-func (s *Service) overEndpoints(stater lookup.Stater) []endpoint {
+func (s *Service) overEndpoints(blocker lookup.Blocker, stater lookup.Stater, rewardFetcher rewards.BlockRewardsFetcher) []endpoint {
 	server := &over.Server{
-		Stater:                stater,
-		GenesisTimeFetcher:    s.cfg.GenesisTimeFetcher,
-		HeadFetcher:           s.cfg.HeadFetcher,
-		OptimisticModeFetcher: s.cfg.OptimisticModeFetcher,
-		FinalizationFetcher:   s.cfg.FinalizationFetcher,
+		Blocker:                blocker,
+		Stater:                 stater,
+		GenesisTimeFetcher:     s.cfg.GenesisTimeFetcher,
+		HeadFetcher:            s.cfg.HeadFetcher,
+		OptimisticModeFetcher:  s.cfg.OptimisticModeFetcher,
+		FinalizationFetcher:    s.cfg.FinalizationFetcher,
+		BeaconDB:               s.cfg.BeaconDB,
+		ExecutionReconstructor: s.cfg.ExecutionReconstructor,
+		BlockRewardFetcher:     rewardFetcher,
+		SlasherDB:              s.cfg.SlasherDB,
 	}
 
 	const namespace = "over"
@@ -1137,18 +1238,66 @@ func (s *Service) overEndpoints(stater lookup.Stater) []endpoint {
 			handler:  server.GetDepositEstimation,
 			methods:  []string{http.MethodGet},
 		},
+		{
+			template: "/over/v1/beacon/states/{state_id}/deposit_estimations",
+			name:     namespace + ".PostDepositEstimations",
+			handler:  server.PostDepositEstimations,
+			methods:  []string{http.MethodPost},
+		},
 		{
 			template: "/over/v1/beacon/states/{state_id}/withdrawal_estimation/{validator_id}",
 			name:     namespace + ".GetWithdrawalEstimation",
 			handler:  server.GetWithdrawalEstimation,
 			methods:  []string{http.MethodGet},
 		},
+		{
+			template: "/over/v1/beacon/states/{state_id}/withdrawal_estimations",
+			name:     namespace + ".PostWithdrawalEstimations",
+			handler:  server.PostWithdrawalEstimations,
+			methods:  []string{http.MethodPost},
+		},
 		{
 			template: "/over/v1/beacon/states/{state_id}/exit/queue_epoch",
 			name:     namespace + ".GetExitQueueEpoch",
 			handler:  server.GetExitQueueEpoch,
 			methods:  []string{http.MethodGet},
 		},
+		{
+			template: "/over/v1/beacon/states/{state_id}/bailout_risk",
+			name:     namespace + ".GetBailoutRisk",
+			handler:  server.GetBailoutRisk,
+			methods:  []string{http.MethodGet},
+		},
+		{
+			template: "/over/v1/beacon/rewards/epochs/{epoch}",
+			name:     namespace + ".GetEpochRewardsBreakdown",
+			handler:  server.GetEpochRewardsBreakdown,
+			methods:  []string{http.MethodGet},
+		},
+		{
+			template: "/over/v1/validators/{id}/principal_history",
+			name:     namespace + ".GetPrincipalHistory",
+			handler:  server.GetPrincipalHistory,
+			methods:  []string{http.MethodGet},
+		},
+		{
+			template: "/over/v1/tokenomics/projection",
+			name:     namespace + ".GetTokenomicsProjection",
+			handler:  server.GetTokenomicsProjection,
+			methods:  []string{http.MethodGet},
+		},
+		{
+			template: "/over/v1/tokenomics/history",
+			name:     namespace + ".GetTokenomicsHistory",
+			handler:  server.GetTokenomicsHistory,
+			methods:  []string{http.MethodGet},
+		},
+		{
+			template: "/over/v1/slasher/database",
+			name:     namespace + ".GetSlasherDatabase",
+			handler:  server.GetSlasherDatabase,
+			methods:  []string{http.MethodGet},
+		},
 	}
 }
 
@@ -1159,7 +1308,7 @@ func (s *Service) overNodeEndpoints(handler *closehandler.CloseHandler) []endpoi
 
 	authToken, err := getAuthToken(s.cfg.AuthTokenPath)
 	if err != nil {
-		log.WithError(err).Warnf("Failed to get auth token, /over-node/close endpoint will not be enabled")
+		log.WithError(err).Warnf("Failed to get auth token, /over-node endpoints will not be enabled")
 		return []endpoint{}
 	}
 
@@ -1174,5 +1323,14 @@ func (s *Service) overNodeEndpoints(handler *closehandler.CloseHandler) []endpoi
 			},
 			methods: []string{http.MethodPost},
 		},
+		{
+			template: "/over-node/close/status",
+			name:     namespace + ".GetCloseStatus",
+			handler:  server.GetCloseStatus,
+			middleware: []middleware.Middleware{
+				middleware.AuthTokenHandler(authToken),
+			},
+			methods: []string{http.MethodGet},
+		},
 	}
 }
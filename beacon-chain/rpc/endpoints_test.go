@@ -68,6 +68,8 @@ func Test_endpoints(t *testing.T) {
 		"/eth/v2/debug/beacon/states/{state_id}": {http.MethodGet},
 		"/eth/v2/debug/beacon/heads":             {http.MethodGet},
 		"/eth/v1/debug/fork_choice":              {http.MethodGet},
+		"/prysm/v1/debug/fork_choice/recording":  {http.MethodGet},
+		"/prysm/v1/debug/fork_choice/replay":     {http.MethodGet},
 	}
 
 	eventsRoutes := map[string][]string{
@@ -115,6 +117,11 @@ func Test_endpoints(t *testing.T) {
 		"/prysm/v1/node/trusted_peers":           {http.MethodGet, http.MethodPost},
 		"/prysm/node/trusted_peers/{peer_id}":    {http.MethodDelete},
 		"/prysm/v1/node/trusted_peers/{peer_id}": {http.MethodDelete},
+		"/prysm/v1/node/peers/{peer_id}":         {http.MethodGet},
+		"/prysm/v1/node/banned_peers":            {http.MethodGet, http.MethodPost},
+		"/prysm/v1/node/banned_peers/{peer_id}":  {http.MethodDelete},
+		"/prysm/v1/node/banned_ips":              {http.MethodGet, http.MethodPost, http.MethodDelete},
+		"/prysm/v1/node/backfill/repair":         {http.MethodGet},
 	}
 
 	prysmValidatorRoutes := map[string][]string{
@@ -129,12 +136,21 @@ func Test_endpoints(t *testing.T) {
 		"/over/v1/beacon/states/{state_id}/reserves":                             {http.MethodGet},
 		"/over/v1/beacon/states/{state_id}/deposit_estimation":                   {http.MethodGet},
 		"/over/v1/beacon/states/{state_id}/deposit_estimation/{pubkey}":          {http.MethodGet},
+		"/over/v1/beacon/states/{state_id}/deposit_estimations":                  {http.MethodPost},
 		"/over/v1/beacon/states/{state_id}/withdrawal_estimation/{validator_id}": {http.MethodGet},
+		"/over/v1/beacon/states/{state_id}/withdrawal_estimations":               {http.MethodPost},
 		"/over/v1/beacon/states/{state_id}/exit/queue_epoch":                     {http.MethodGet},
+		"/over/v1/beacon/states/{state_id}/bailout_risk":                         {http.MethodGet},
+		"/over/v1/beacon/rewards/epochs/{epoch}":                                 {http.MethodGet},
+		"/over/v1/validators/{id}/principal_history":                             {http.MethodGet},
+		"/over/v1/tokenomics/projection":                                         {http.MethodGet},
+		"/over/v1/tokenomics/history":                                            {http.MethodGet},
+		"/over/v1/slasher/database":                                              {http.MethodGet},
 	}
 
 	overNodeRoutes := map[string][]string{
-		"/over-node/close": {http.MethodPost},
+		"/over-node/close":        {http.MethodPost},
+		"/over-node/close/status": {http.MethodGet},
 	}
 
 	s := &Service{cfg: &Config{
@@ -174,7 +190,7 @@ func Test_overNodeRoutes(t *testing.T) {
 			name:          "Auth token path is provided (empty)",
 			authTokenPath: filepath.Join(t.TempDir(), "auth_token"),
 			prepare:       nil, // No preparation needed for an empty file
-			expectedCount: 1,
+			expectedCount: 2,
 		},
 		{
 			name:          "Auth token path is provided (non-empty)",
@@ -186,7 +202,7 @@ func Test_overNodeRoutes(t *testing.T) {
 				}
 				return saveAuthToken(authTokenPath, token)
 			},
-			expectedCount: 1,
+			expectedCount: 2,
 		},
 	}
 
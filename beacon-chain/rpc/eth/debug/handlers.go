@@ -7,10 +7,13 @@ import (
 	"net/http"
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v5/api"
 	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	doublylinkedtree "github.com/prysmaticlabs/prysm/v5/beacon-chain/forkchoice/doubly-linked-tree"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/eth/helpers"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/eth/shared"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v5/monitoring/tracing/trace"
 	"github.com/prysmaticlabs/prysm/v5/network/httputil"
 	"github.com/prysmaticlabs/prysm/v5/runtime/version"
@@ -173,37 +176,46 @@ func (s *Server) GetForkChoice(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	nodes := make([]*structs.ForkChoiceNode, len(dump.ForkChoiceNodes))
-	for i, n := range dump.ForkChoiceNodes {
-		nodes[i] = &structs.ForkChoiceNode{
-			Slot:               fmt.Sprintf("%d", n.Slot),
-			BlockRoot:          hexutil.Encode(n.BlockRoot),
-			ParentRoot:         hexutil.Encode(n.ParentRoot),
-			JustifiedEpoch:     fmt.Sprintf("%d", n.JustifiedEpoch),
-			FinalizedEpoch:     fmt.Sprintf("%d", n.FinalizedEpoch),
-			Weight:             fmt.Sprintf("%d", n.Weight),
-			ExecutionBlockHash: hexutil.Encode(n.ExecutionBlockHash),
-			Validity:           n.Validity.String(),
-			ExtraData: &structs.ForkChoiceNodeExtraData{
-				UnrealizedJustifiedEpoch: fmt.Sprintf("%d", n.UnrealizedJustifiedEpoch),
-				UnrealizedFinalizedEpoch: fmt.Sprintf("%d", n.UnrealizedFinalizedEpoch),
-				Balance:                  fmt.Sprintf("%d", n.Balance),
-				ExecutionOptimistic:      n.ExecutionOptimistic,
-				TimeStamp:                fmt.Sprintf("%d", n.Timestamp),
-			},
-		}
+	httputil.WriteJson(w, structs.ForkChoiceDumpFromConsensus(dump))
+}
+
+// GetForkChoiceRecording returns the inputs recorded by fork choice during the latest epochs, which
+// can be replayed offline.
+func (s *Server) GetForkChoiceRecording(w http.ResponseWriter, r *http.Request) {
+	_, span := trace.StartSpan(r.Context(), "debug.GetForkChoiceRecording")
+	defer span.End()
+
+	recording, err := s.ForkchoiceFetcher.ForkChoiceRecording()
+	if err != nil {
+		httputil.HandleError(w, "Could not get forkchoice recording: "+err.Error(), http.StatusNotFound)
+		return
+	}
+	httputil.WriteSsz(w, recording, "forkchoice_recording.bin")
+}
+
+// ReplayForkChoice replays the inputs recorded by fork choice up to the end of the given slot, and
+// returns the resulting fork choice store along with the proposer boost decisions of the slot.
+func (s *Server) ReplayForkChoice(w http.ResponseWriter, r *http.Request) {
+	ctx, span := trace.StartSpan(r.Context(), "debug.ReplayForkChoice")
+	defer span.End()
+
+	_, slot, ok := shared.UintFromQuery(w, r, "slot", true)
+	if !ok {
+		return
+	}
+	recording, err := s.ForkchoiceFetcher.ForkChoiceRecording()
+	if err != nil {
+		httputil.HandleError(w, "Could not get forkchoice recording: "+err.Error(), http.StatusNotFound)
+		return
 	}
-	resp := &structs.GetForkChoiceDumpResponse{
-		JustifiedCheckpoint: structs.CheckpointFromConsensus(dump.JustifiedCheckpoint),
-		FinalizedCheckpoint: structs.CheckpointFromConsensus(dump.FinalizedCheckpoint),
-		ForkChoiceNodes:     nodes,
-		ExtraData: &structs.ForkChoiceDumpExtraData{
-			UnrealizedJustifiedCheckpoint: structs.CheckpointFromConsensus(dump.UnrealizedJustifiedCheckpoint),
-			UnrealizedFinalizedCheckpoint: structs.CheckpointFromConsensus(dump.UnrealizedFinalizedCheckpoint),
-			ProposerBoostRoot:             hexutil.Encode(dump.ProposerBoostRoot),
-			PreviousProposerBoostRoot:     hexutil.Encode(dump.PreviousProposerBoostRoot),
-			HeadRoot:                      hexutil.Encode(dump.HeadRoot),
-		},
+	result, err := doublylinkedtree.Replay(ctx, recording, primitives.Slot(slot))
+	if err != nil {
+		code := http.StatusInternalServerError
+		if errors.Is(err, doublylinkedtree.ErrSlotNotRecorded) {
+			code = http.StatusBadRequest
+		}
+		httputil.HandleError(w, "Could not replay forkchoice: "+err.Error(), code)
+		return
 	}
-	httputil.WriteJson(w, resp)
+	httputil.WriteJson(w, structs.ForkChoiceReplayFromConsensus(result))
 }
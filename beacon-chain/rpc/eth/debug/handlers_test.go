@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/prysmaticlabs/prysm/v5/api"
@@ -16,6 +17,7 @@ import (
 	doublylinkedtree "github.com/prysmaticlabs/prysm/v5/beacon-chain/forkchoice/doubly-linked-tree"
 	forkchoicetypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/forkchoice/types"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/testutil"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
 	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
 	"github.com/prysmaticlabs/prysm/v5/runtime/version"
 	"github.com/prysmaticlabs/prysm/v5/testing/assert"
@@ -514,3 +516,76 @@ func TestGetForkChoice(t *testing.T) {
 	require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
 	require.Equal(t, "2", resp.FinalizedCheckpoint.Epoch)
 }
+
+func TestForkChoiceRecording(t *testing.T) {
+	ctx := context.Background()
+	st, err := util.NewBeaconState()
+	require.NoError(t, err)
+	blk, err := blocks.NewSignedBeaconBlock(util.NewBeaconBlock())
+	require.NoError(t, err)
+	root := bytesutil.ToBytes32([]byte("root"))
+	roblock, err := blocks.NewROBlockWithRoot(blk, root)
+	require.NoError(t, err)
+	store := doublylinkedtree.New()
+	store.SetGenesisTime(uint64(time.Now().Unix()))
+	store.SetBalancesByRooter(func(context.Context, [32]byte) ([]uint64, error) { return []uint64{}, nil })
+	require.NoError(t, store.InsertNode(ctx, st, roblock))
+	require.NoError(t, store.UpdateJustifiedCheckpoint(ctx, &forkchoicetypes.Checkpoint{Root: root}))
+	require.NoError(t, store.UpdateFinalizedCheckpoint(&forkchoicetypes.Checkpoint{Root: root}))
+	s := &Server{ForkchoiceFetcher: &blockchainmock.ChainService{ForkChoiceStore: store}}
+
+	t.Run("not enabled", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/debug/fork_choice/recording", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetForkChoiceRecording(writer, request)
+		require.Equal(t, http.StatusNotFound, writer.Code)
+		assert.StringContains(t, "not enabled", writer.Body.String())
+	})
+
+	store.EnableRecording(1)
+	require.NoError(t, store.NewSlot(ctx, 0))
+
+	t.Run("recording", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/debug/fork_choice/recording", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetForkChoiceRecording(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		recording, err := store.Recording()
+		require.NoError(t, err)
+		assert.DeepEqual(t, recording, writer.Body.Bytes())
+	})
+	t.Run("replay", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/debug/fork_choice/replay?slot=0", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.ReplayForkChoice(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &structs.GetForkChoiceReplayResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		assert.Equal(t, "0", resp.Slot)
+		assert.Equal(t, hexutil.Encode(root[:]), resp.ForkChoice.ExtraData.HeadRoot)
+		require.Equal(t, 1, len(resp.ForkChoice.ForkChoiceNodes))
+	})
+	t.Run("slot not recorded", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/debug/fork_choice/replay?slot=100", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.ReplayForkChoice(writer, request)
+		require.Equal(t, http.StatusBadRequest, writer.Code)
+		assert.StringContains(t, "after the recording", writer.Body.String())
+	})
+	t.Run("no slot", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/debug/fork_choice/replay", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.ReplayForkChoice(writer, request)
+		require.Equal(t, http.StatusBadRequest, writer.Code)
+	})
+}
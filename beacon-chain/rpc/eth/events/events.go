@@ -58,6 +58,18 @@ const (
 	ProposerSlashingTopic = "proposer_slashing"
 	// AttesterSlashingTopic represents a new attester slashing event topic
 	AttesterSlashingTopic = "attester_slashing"
+	// PendingDepositEnqueuedTopic represents a new deposit appended to the pending deposit queue event topic.
+	PendingDepositEnqueuedTopic = "pending_deposit_enqueued"
+	// PendingDepositProcessedTopic represents a pending deposit processed by the epoch processing event topic.
+	PendingDepositProcessedTopic = "pending_deposit_processed"
+	// ValidatorActivatedTopic represents a validator activation event topic.
+	ValidatorActivatedTopic = "validator_activated"
+	// ValidatorExitInitiatedTopic represents an initiated validator exit event topic, including bailouts.
+	ValidatorExitInitiatedTopic = "validator_exit_initiated"
+	// WithdrawalProcessedTopic represents a withdrawal processed by a block event topic.
+	WithdrawalProcessedTopic = "withdrawal_processed"
+	// LateBlockReorgDecisionTopic represents a fork choice decision on orphaning a late head block event topic.
+	LateBlockReorgDecisionTopic = "late_block_reorg_decision"
 )
 
 var (
@@ -95,6 +107,13 @@ var stateFeedEventTopics = map[feed.EventType]string{
 	statefeed.Reorg:               ChainReorgTopic,
 	statefeed.BlockProcessed:      BlockTopic,
 	statefeed.PayloadAttributes:   PayloadAttributesTopic,
+
+	statefeed.PendingDepositsEnqueued:  PendingDepositEnqueuedTopic,
+	statefeed.PendingDepositsProcessed: PendingDepositProcessedTopic,
+	statefeed.ValidatorsActivated:      ValidatorActivatedTopic,
+	statefeed.ValidatorExitsInitiated:  ValidatorExitInitiatedTopic,
+	statefeed.WithdrawalsProcessed:     WithdrawalProcessedTopic,
+	statefeed.LateBlockReorgDecision:   LateBlockReorgDecisionTopic,
 }
 
 var topicsForStateFeed = topicsForFeed(stateFeedEventTopics)
@@ -118,6 +137,17 @@ func (req *topicRequest) requested(topic string) bool {
 	return req.topics[topic]
 }
 
+// stateFeedEventTypes returns the types of the state feed events of the requested topics.
+func (req *topicRequest) stateFeedEventTypes() []feed.EventType {
+	types := make([]feed.EventType, 0)
+	for t, topic := range stateFeedEventTopics {
+		if req.requested(topic) {
+			types = append(types, t)
+		}
+	}
+	return types
+}
+
 func newTopicRequest(topics []string) (*topicRequest, error) {
 	req := &topicRequest{topics: make(map[string]bool)}
 	for _, name := range topics {
@@ -178,6 +208,9 @@ func (s *Server) StreamEvents(w http.ResponseWriter, r *http.Request) {
 	defer cancel()
 	es := newEventStreamer(buffSize, ka)
 
+	// The blockchain service only derives the events which are costly to derive while a stream is subscribed to them.
+	unsubscribe := s.EventSubscribers.Subscribe(topics.stateFeedEventTypes())
+	defer unsubscribe()
 	go es.outboxWriteLoop(ctx, cancel, sw, r.URL.Path)
 	if err := es.recvEventLoop(ctx, cancel, topics, s); err != nil {
 		log.WithError(err).Debug("Shutting down StreamEvents handler.")
@@ -396,6 +429,17 @@ func jsonMarshalReader(name string, v any) io.Reader {
 	return bytes.NewBufferString("event: " + name + "\ndata: " + string(d) + "\n\n")
 }
 
+// jsonMarshalReaders writes an event message for each of the values.
+func jsonMarshalReaders(name string, vs []any) io.Reader {
+	readers := make([]io.Reader, 0, len(vs))
+	for _, v := range vs {
+		if r := jsonMarshalReader(name, v); r != nil {
+			readers = append(readers, r)
+		}
+	}
+	return io.MultiReader(readers...)
+}
+
 func topicForEvent(event *feed.Event) string {
 	switch event.Data.(type) {
 	case *operation.AggregatedAttReceivedData:
@@ -420,6 +464,18 @@ func topicForEvent(event *feed.Event) string {
 		return BlockTopic
 	case payloadattribute.EventData:
 		return PayloadAttributesTopic
+	case *statefeed.PendingDepositsEnqueuedData:
+		return PendingDepositEnqueuedTopic
+	case *statefeed.PendingDepositsProcessedData:
+		return PendingDepositProcessedTopic
+	case *statefeed.ValidatorsActivatedData:
+		return ValidatorActivatedTopic
+	case *statefeed.ValidatorExitsInitiatedData:
+		return ValidatorExitInitiatedTopic
+	case *statefeed.WithdrawalsProcessedData:
+		return WithdrawalProcessedTopic
+	case *statefeed.LateBlockReorgDecisionData:
+		return LateBlockReorgDecisionTopic
 	default:
 		return InvalidTopic
 	}
@@ -515,6 +571,101 @@ func (s *Server) lazyReaderForEvent(ctx context.Context, event *feed.Event, topi
 			}
 			return jsonMarshalReader(eventName, blk)
 		}, nil
+	// The queue events carry every change made by a block, which are written as one event message each.
+	case *statefeed.PendingDepositsEnqueuedData:
+		return func() io.Reader {
+			evs := make([]any, len(v.PendingDeposits))
+			for i, pd := range v.PendingDeposits {
+				evs[i] = &structs.PendingDepositEnqueuedEvent{
+					Slot:                  fmt.Sprintf("%d", v.Slot),
+					Block:                 hexutil.Encode(v.BlockRoot[:]),
+					Pubkey:                hexutil.Encode(pd.PublicKey),
+					WithdrawalCredentials: hexutil.Encode(pd.WithdrawalCredentials),
+					Amount:                fmt.Sprintf("%d", pd.Amount),
+					DepositSlot:           fmt.Sprintf("%d", pd.Slot),
+				}
+			}
+			return jsonMarshalReaders(eventName, evs)
+		}, nil
+	case *statefeed.PendingDepositsProcessedData:
+		return func() io.Reader {
+			evs := make([]any, len(v.Deposits))
+			for i, d := range v.Deposits {
+				ev := &structs.PendingDepositProcessedEvent{
+					Slot:                  fmt.Sprintf("%d", v.Slot),
+					Block:                 hexutil.Encode(v.BlockRoot[:]),
+					Pubkey:                hexutil.Encode(d.PendingDeposit.PublicKey),
+					WithdrawalCredentials: hexutil.Encode(d.PendingDeposit.WithdrawalCredentials),
+					Amount:                fmt.Sprintf("%d", d.PendingDeposit.Amount),
+					DepositSlot:           fmt.Sprintf("%d", d.PendingDeposit.Slot),
+					Credited:              d.Credited,
+				}
+				if d.Credited {
+					ev.ValidatorIndex = fmt.Sprintf("%d", d.ValidatorIndex)
+				}
+				evs[i] = ev
+			}
+			return jsonMarshalReaders(eventName, evs)
+		}, nil
+	case *statefeed.ValidatorsActivatedData:
+		return func() io.Reader {
+			evs := make([]any, len(v.Activations))
+			for i, a := range v.Activations {
+				evs[i] = &structs.ValidatorActivatedEvent{
+					Slot:            fmt.Sprintf("%d", v.Slot),
+					Block:           hexutil.Encode(v.BlockRoot[:]),
+					ValidatorIndex:  fmt.Sprintf("%d", a.ValidatorIndex),
+					Pubkey:          hexutil.Encode(a.Pubkey[:]),
+					ActivationEpoch: fmt.Sprintf("%d", a.ActivationEpoch),
+				}
+			}
+			return jsonMarshalReaders(eventName, evs)
+		}, nil
+	case *statefeed.ValidatorExitsInitiatedData:
+		return func() io.Reader {
+			evs := make([]any, len(v.Exits))
+			for i, e := range v.Exits {
+				evs[i] = &structs.ValidatorExitInitiatedEvent{
+					Slot:              fmt.Sprintf("%d", v.Slot),
+					Block:             hexutil.Encode(v.BlockRoot[:]),
+					ValidatorIndex:    fmt.Sprintf("%d", e.ValidatorIndex),
+					Pubkey:            hexutil.Encode(e.Pubkey[:]),
+					ExitEpoch:         fmt.Sprintf("%d", e.ExitEpoch),
+					WithdrawableEpoch: fmt.Sprintf("%d", e.WithdrawableEpoch),
+					Reason:            e.Reason,
+					Bailout:           e.Reason == statefeed.ExitReasonBailout,
+				}
+			}
+			return jsonMarshalReaders(eventName, evs)
+		}, nil
+	case *statefeed.WithdrawalsProcessedData:
+		return func() io.Reader {
+			evs := make([]any, len(v.Withdrawals))
+			for i, w := range v.Withdrawals {
+				evs[i] = &structs.WithdrawalProcessedEvent{
+					Slot:           fmt.Sprintf("%d", v.Slot),
+					Block:          hexutil.Encode(v.BlockRoot[:]),
+					Index:          fmt.Sprintf("%d", w.Index),
+					ValidatorIndex: fmt.Sprintf("%d", w.ValidatorIndex),
+					Address:        hexutil.Encode(w.Address),
+					Amount:         fmt.Sprintf("%d", w.Amount),
+				}
+			}
+			return jsonMarshalReaders(eventName, evs)
+		}, nil
+	case *statefeed.LateBlockReorgDecisionData:
+		return func() io.Reader {
+			return jsonMarshalReader(eventName, &structs.LateBlockReorgDecisionEvent{
+				Check:        v.Check,
+				Slot:         fmt.Sprintf("%d", v.Slot),
+				HeadBlock:    hexutil.Encode(v.HeadRoot[:]),
+				ParentBlock:  hexutil.Encode(v.ParentRoot[:]),
+				HeadWeight:   fmt.Sprintf("%d", v.HeadWeight),
+				ParentWeight: fmt.Sprintf("%d", v.ParentWeight),
+				Reorg:        v.Reorg,
+				Reason:       v.Reason,
+			})
+		}, nil
 	default:
 		return nil, errors.Wrapf(errUnhandledEventData, "event data type %T unsupported", v)
 	}
@@ -7,6 +7,7 @@ import (
 	"math"
 	"net/http"
 	"net/http/httptest"
+	"slices"
 	"strings"
 	"testing"
 	"time"
@@ -23,6 +24,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
 	payloadattribute "github.com/prysmaticlabs/prysm/v5/consensus-types/payload-attribute"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	enginev1 "github.com/prysmaticlabs/prysm/v5/proto/engine/v1"
 	ethpb "github.com/prysmaticlabs/prysm/v5/proto/eth/v1"
 	eth "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v5/testing/require"
@@ -352,6 +354,118 @@ func TestStreamEvents_OperationsEvents(t *testing.T) {
 
 		requireAllEventsReceived(t, stn, opn, events, topics, s, w, testSync.logs)
 	})
+	t.Run("queues", func(t *testing.T) {
+		testSync := newStreamTestSync(t)
+		defer testSync.cleanup()
+
+		stn := mockChain.NewEventFeedWrapper()
+		opn := mockChain.NewEventFeedWrapper()
+		s := &Server{
+			StateNotifier:     &mockChain.SimpleNotifier{Feed: stn},
+			OperationNotifier: &mockChain.SimpleNotifier{Feed: opn},
+			EventWriteTimeout: testEventWriteTimeout,
+		}
+
+		topics, err := newTopicRequest([]string{
+			PendingDepositEnqueuedTopic,
+			PendingDepositProcessedTopic,
+			ValidatorActivatedTopic,
+			ValidatorExitInitiatedTopic,
+			WithdrawalProcessedTopic,
+		})
+		require.NoError(t, err)
+		request := topics.testHttpRequest(testSync.ctx, t)
+		w := NewStreamingResponseWriterRecorder(testSync.ctx)
+
+		deposit := &eth.PendingDeposit{
+			PublicKey:             make([]byte, fieldparams.BLSPubkeyLength),
+			WithdrawalCredentials: make([]byte, 32),
+			Amount:                32,
+			Signature:             make([]byte, fieldparams.BLSSignatureLength),
+			Slot:                  1,
+		}
+		events := []*feed.Event{
+			{
+				Type: statefeed.PendingDepositsEnqueued,
+				Data: &statefeed.PendingDepositsEnqueuedData{Slot: 1, PendingDeposits: []*eth.PendingDeposit{deposit}},
+			},
+			{
+				Type: statefeed.PendingDepositsProcessed,
+				Data: &statefeed.PendingDepositsProcessedData{
+					Slot:     32,
+					Deposits: []*statefeed.ProcessedDeposit{{PendingDeposit: deposit, Credited: true, ValidatorIndex: 5}},
+				},
+			},
+			{
+				Type: statefeed.ValidatorsActivated,
+				Data: &statefeed.ValidatorsActivatedData{
+					Slot:        32,
+					Activations: []*statefeed.ValidatorActivation{{ValidatorIndex: 5, ActivationEpoch: 6}},
+				},
+			},
+			{
+				Type: statefeed.ValidatorExitsInitiated,
+				Data: &statefeed.ValidatorExitsInitiatedData{
+					Slot: 32,
+					Exits: []*statefeed.ValidatorExit{
+						{ValidatorIndex: 3, ExitEpoch: 6, WithdrawableEpoch: 10, Reason: statefeed.ExitReasonBailout},
+					},
+				},
+			},
+			{
+				Type: statefeed.WithdrawalsProcessed,
+				Data: &statefeed.WithdrawalsProcessedData{
+					Slot:        33,
+					Withdrawals: []*enginev1.Withdrawal{{Index: 1, ValidatorIndex: 3, Address: make([]byte, 20), Amount: 10}},
+				},
+			},
+		}
+
+		go func() {
+			s.StreamEvents(w, request)
+			testSync.markDone()
+		}()
+
+		requireAllEventsReceived(t, stn, opn, events, topics, s, w, testSync.logs)
+	})
+	t.Run("late block reorg decision", func(t *testing.T) {
+		testSync := newStreamTestSync(t)
+		defer testSync.cleanup()
+
+		stn := mockChain.NewEventFeedWrapper()
+		opn := mockChain.NewEventFeedWrapper()
+		s := &Server{
+			StateNotifier:     &mockChain.SimpleNotifier{Feed: stn},
+			OperationNotifier: &mockChain.SimpleNotifier{Feed: opn},
+			EventWriteTimeout: testEventWriteTimeout,
+		}
+
+		topics, err := newTopicRequest([]string{LateBlockReorgDecisionTopic})
+		require.NoError(t, err)
+		request := topics.testHttpRequest(testSync.ctx, t)
+		w := NewStreamingResponseWriterRecorder(testSync.ctx)
+
+		events := []*feed.Event{
+			{
+				Type: statefeed.LateBlockReorgDecision,
+				Data: &statefeed.LateBlockReorgDecisionData{
+					Check:        "proposer_head",
+					Slot:         10,
+					HeadWeight:   1,
+					ParentWeight: 100,
+					Reorg:        true,
+					Reason:       "weak_head",
+				},
+			},
+		}
+
+		go func() {
+			s.StreamEvents(w, request)
+			testSync.markDone()
+		}()
+
+		requireAllEventsReceived(t, stn, opn, events, topics, s, w, testSync.logs)
+	})
 	t.Run("payload attributes", func(t *testing.T) {
 		type testCase struct {
 			name                      string
@@ -482,6 +596,19 @@ func TestStreamEvents_OperationsEvents(t *testing.T) {
 	})
 }
 
+func TestTopicRequest_StateFeedEventTypes(t *testing.T) {
+	topics, err := newTopicRequest([]string{AttestationTopic, ValidatorExitInitiatedTopic, WithdrawalProcessedTopic})
+	require.NoError(t, err)
+	types := topics.stateFeedEventTypes()
+	require.Equal(t, 2, len(types))
+	require.Equal(t, true, slices.Contains(types, statefeed.ValidatorExitsInitiated))
+	require.Equal(t, true, slices.Contains(types, statefeed.WithdrawalsProcessed))
+
+	topics, err = newTopicRequest([]string{AttestationTopic})
+	require.NoError(t, err)
+	require.Equal(t, 0, len(topics.stateFeedEventTypes()))
+}
+
 func TestStuckReaderScenarios(t *testing.T) {
 	cases := []struct {
 		name       string
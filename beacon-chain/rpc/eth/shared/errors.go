@@ -34,6 +34,11 @@ func WriteBlockFetchError(w http.ResponseWriter, blk interfaces.ReadOnlySignedBe
 		httputil.HandleError(w, "Invalid block ID: "+invalidBlockIdErr.Error(), http.StatusBadRequest)
 		return false
 	}
+	var prunedErr *lookup.BlockPrunedError
+	if errors.As(err, &prunedErr) {
+		httputil.HandleError(w, "Could not find requested block: "+prunedErr.Error(), http.StatusNotFound)
+		return false
+	}
 	if err != nil {
 		httputil.HandleError(w, "Could not get block from block ID: "+err.Error(), http.StatusInternalServerError)
 		return false
@@ -49,3 +49,40 @@ func TestWriteStateFetchError(t *testing.T) {
 		assert.NoError(t, json.Unmarshal(writer.Body.Bytes(), e), "failed to unmarshal response")
 	}
 }
+
+func TestWriteBlockFetchError(t *testing.T) {
+	pruned := lookup.NewBlockPrunedError(4, 5)
+	cases := []struct {
+		err             error
+		expectedMessage string
+		expectedCode    int
+	}{
+		{
+			err:             &lookup.BlockIdParseError{},
+			expectedMessage: "Invalid block ID",
+			expectedCode:    http.StatusBadRequest,
+		},
+		{
+			err:             &pruned,
+			expectedMessage: "the lowest available slot is 5",
+			expectedCode:    http.StatusNotFound,
+		},
+		{
+			err:             errors.New("block not found"),
+			expectedMessage: "Could not get block from block ID",
+			expectedCode:    http.StatusInternalServerError,
+		},
+		{
+			expectedMessage: "Could not find requested block",
+			expectedCode:    http.StatusNotFound,
+		},
+	}
+
+	for _, c := range cases {
+		writer := httptest.NewRecorder()
+		assert.Equal(t, false, WriteBlockFetchError(writer, nil, c.err))
+
+		assert.Equal(t, c.expectedCode, writer.Code, "incorrect status code")
+		assert.StringContains(t, c.expectedMessage, writer.Body.String(), "incorrect error message")
+	}
+}
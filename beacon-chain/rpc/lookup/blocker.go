@@ -39,6 +39,24 @@ func (e BlockIdParseError) Error() string {
 	return e.message
 }
 
+// BlockPrunedError represents an error scenario where a block was requested for a slot
+// whose history was pruned from the database.
+type BlockPrunedError struct {
+	message string
+}
+
+// NewBlockPrunedError creates a new error instance.
+func NewBlockPrunedError(slot, lowestAvailableSlot primitives.Slot) BlockPrunedError {
+	return BlockPrunedError{
+		message: fmt.Sprintf("block at slot %d was pruned, the lowest available slot is %d", slot, lowestAvailableSlot),
+	}
+}
+
+// Error returns the underlying error message.
+func (e BlockPrunedError) Error() string {
+	return e.message
+}
+
 // Blocker is responsible for retrieving blocks.
 type Blocker interface {
 	Block(ctx context.Context, id []byte) (interfaces.ReadOnlySignedBeaconBlock, error)
@@ -115,6 +133,14 @@ func (p *BeaconDbBlocker) Block(ctx context.Context, id []byte) (interfaces.Read
 			}
 			numBlks := len(blks)
 			if numBlks == 0 {
+				lowest, err := p.BeaconDB.LowestAvailableSlot(ctx)
+				if err != nil {
+					return nil, errors.Wrap(err, "could not retrieve lowest available slot")
+				}
+				if slot > 0 && primitives.Slot(slot) < lowest {
+					e := NewBlockPrunedError(primitives.Slot(slot), lowest)
+					return nil, &e
+				}
 				return nil, nil
 			}
 			for i, b := range blks {
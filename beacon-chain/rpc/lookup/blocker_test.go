@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
 	mockChain "github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain/testing"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/filesystem"
 	testDB "github.com/prysmaticlabs/prysm/v5/beacon-chain/db/testing"
@@ -17,6 +18,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/verification"
 	"github.com/prysmaticlabs/prysm/v5/config/params"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
 	ethpbalpha "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v5/testing/assert"
@@ -157,6 +159,47 @@ func TestGetBlock(t *testing.T) {
 	}
 }
 
+func TestGetBlock_Pruned(t *testing.T) {
+	beaconDB := testDB.SetupDB(t)
+	ctx := context.Background()
+
+	root, err := util.SaveBlock(t, ctx, beaconDB, util.NewBeaconBlock()).Block().HashTreeRoot()
+	require.NoError(t, err)
+	require.NoError(t, beaconDB.SaveGenesisBlockRoot(ctx, root))
+	canonicalRoots := map[[32]byte]bool{root: true}
+	for i := primitives.Slot(1); i <= 10; i++ {
+		b := util.NewBeaconBlock()
+		b.Block.Slot = i
+		b.Block.ParentRoot = root[:]
+		root, err = util.SaveBlock(t, ctx, beaconDB, b).Block().HashTreeRoot()
+		require.NoError(t, err)
+		canonicalRoots[root] = true
+	}
+	require.NoError(t, beaconDB.SaveFinalizedCheckpoint(ctx, &ethpbalpha.Checkpoint{Root: root[:]}))
+	_, err = beaconDB.PruneHistory(ctx, 5, 64)
+	require.NoError(t, err)
+
+	fetcher := &BeaconDbBlocker{
+		BeaconDB:         beaconDB,
+		ChainInfoFetcher: &mockChain.ChainService{DB: beaconDB, CanonicalRoots: canonicalRoots},
+	}
+
+	_, err = fetcher.Block(ctx, []byte("4"))
+	var prunedErr *BlockPrunedError
+	require.Equal(t, true, errors.As(err, &prunedErr))
+	assert.StringContains(t, "the lowest available slot is 5", err.Error())
+
+	blk, err := fetcher.Block(ctx, []byte("0"))
+	require.NoError(t, err)
+	assert.Equal(t, primitives.Slot(0), blk.Block().Slot())
+	blk, err = fetcher.Block(ctx, []byte("5"))
+	require.NoError(t, err)
+	assert.Equal(t, primitives.Slot(5), blk.Block().Slot())
+	blk, err = fetcher.Block(ctx, []byte("11"))
+	require.NoError(t, err)
+	assert.Equal(t, nil, blk)
+}
+
 func TestGetBlob(t *testing.T) {
 	params.SetupTestConfigCleanup(t)
 	cfg := params.BeaconConfig().Copy()
@@ -0,0 +1,143 @@
+package over
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/time"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/monitoring/tracing/trace"
+	"github.com/prysmaticlabs/prysm/v5/network/httputil"
+)
+
+// GetBailoutRisk returns how close the requested validators are to being bailed out.
+// Accepts validator IDs as either validator indices or public keys in the `id` query param.
+// If no ID is given, every active validator which has not initiated its exit is included.
+// The projected epochs to bailout assume the validator stays offline from the requested state,
+// and that the inactivity leak status of the state does not change.
+func (s *Server) GetBailoutRisk(w http.ResponseWriter, r *http.Request) {
+	ctx, span := trace.StartSpan(r.Context(), "over.GetBailoutRisk")
+	defer span.End()
+
+	// Parse state_id and replay to the state
+	stateId := r.PathValue("state_id")
+	if stateId == "" {
+		httputil.HandleError(w, "state_id is required in URL params", http.StatusBadRequest)
+		return
+	}
+	st, err := s.Stater.State(ctx, []byte(stateId))
+	if err != nil {
+		httputil.WriteError(w, handleWrapError(err, "could not retrieve state", http.StatusNotFound))
+		return
+	}
+
+	// Parse validator ids from URL params
+	rawIds := r.URL.Query()["id"]
+	ids := make([]primitives.ValidatorIndex, 0, len(rawIds))
+	for _, rawId := range rawIds {
+		id, ok := decodeId(w, rawId, st)
+		if !ok {
+			return
+		}
+		ids = append(ids, id)
+	}
+	currentEpoch := time.CurrentEpoch(st)
+	if len(ids) == 0 {
+		if err := st.ReadFromEveryValidator(func(idx int, val state.ReadOnlyValidator) error {
+			if helpers.IsActiveValidatorUsingTrie(val, currentEpoch) && val.ExitEpoch() == params.BeaconConfig().FarFutureEpoch {
+				ids = append(ids, primitives.ValidatorIndex(idx))
+			}
+			return nil
+		}); err != nil {
+			httputil.WriteError(w, handleWrapError(err, "could not read validators", http.StatusInternalServerError))
+			return
+		}
+	}
+
+	// Get metadata for response
+	isOptimistic, err := s.OptimisticModeFetcher.IsOptimistic(r.Context())
+	if err != nil {
+		httputil.WriteError(w, handleWrapError(err, "could not get optimistic mode info", http.StatusInternalServerError))
+		return
+	}
+	blockRoot, err := st.LatestBlockHeader().HashTreeRoot()
+	if err != nil {
+		httputil.WriteError(w, handleWrapError(err, "could not calculate root of latest block header", http.StatusInternalServerError))
+		return
+	}
+	isFinalized := s.FinalizationFetcher.IsFinalized(ctx, blockRoot)
+
+	// Registry updates use the leak status of the previous epoch.
+	leak := helpers.IsInInactivityLeak(time.PrevEpoch(st), st.FinalizedCheckpointEpoch())
+	data := make([]*structs.BailoutRisk, len(ids))
+	for i, id := range ids {
+		data[i], err = bailoutRisk(st, id, leak)
+		if err != nil {
+			httputil.WriteError(w, handleWrapError(err, "could not get bailout risk", http.StatusInternalServerError))
+			return
+		}
+	}
+
+	httputil.WriteJson(w, &structs.GetBailoutRiskResponse{
+		Data:                data,
+		InactivityLeak:      leak,
+		ExecutionOptimistic: isOptimistic,
+		Finalized:           isFinalized,
+	})
+}
+
+// bailoutRisk builds the bailout risk of the validator at the given index.
+// The projection is only included for active validators which have not initiated their exit,
+// as the bailout does not apply to the others.
+func bailoutRisk(st state.BeaconState, idx primitives.ValidatorIndex, leak bool) (*structs.BailoutRisk, error) {
+	val, err := st.ValidatorAtIndexReadOnly(idx)
+	if err != nil {
+		return nil, err
+	}
+	balance, err := st.BalanceAtIndex(idx)
+	if err != nil {
+		return nil, err
+	}
+	inactivityScore, err := st.InactivityScoreAtIndex(idx)
+	if err != nil {
+		return nil, err
+	}
+	eligible, err := helpers.IsEligibleForBailOut(st, val, int(idx), leak)
+	if err != nil {
+		return nil, err
+	}
+
+	principal := val.PrincipalBalance()
+	pubkey := val.PublicKey()
+	risk := &structs.BailoutRisk{
+		Index:              strconv.FormatUint(uint64(idx), 10),
+		Pubkey:             hexutil.Encode(pubkey[:]),
+		PrincipalBalance:   strconv.FormatUint(principal, 10),
+		Balance:            strconv.FormatUint(balance, 10),
+		BailoutBuffer:      strconv.FormatUint(helpers.BailOutBuffer(principal), 10),
+		BufferRemaining:    strconv.FormatUint(helpers.BailOutBufferRemaining(balance, principal), 10),
+		InactivityScore:    strconv.FormatUint(inactivityScore, 10),
+		EligibleForBailout: eligible,
+	}
+
+	currentEpoch := time.CurrentEpoch(st)
+	if !helpers.IsActiveValidatorUsingTrie(val, currentEpoch) || val.ExitEpoch() != params.BeaconConfig().FarFutureEpoch {
+		return risk, nil
+	}
+	epochs, ok := primitives.Epoch(0), true
+	if !eligible {
+		epochs, ok = helpers.EpochsToBailOut(balance, principal, inactivityScore, leak)
+	}
+	if ok {
+		// The exit is initiated by the epoch processing in which the validator becomes eligible,
+		// which is at least the one at the end of the current epoch.
+		risk.ProjectedEpochsToBailout = strconv.FormatUint(uint64(epochs), 10)
+		risk.ProjectedBailoutEpoch = strconv.FormatUint(uint64(currentEpoch+max(epochs, 1)), 10)
+	}
+	return risk, nil
+}
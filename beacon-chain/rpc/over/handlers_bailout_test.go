@@ -0,0 +1,144 @@
+package over
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	chainMock "github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/testutil"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/network/httputil"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+)
+
+func TestGetBailoutRisk(t *testing.T) {
+	cfg := params.BeaconConfig()
+	st, _ := util.DeterministicGenesisStateElectra(t, 4)
+	currentEpoch := primitives.Epoch(10)
+	currentSlot, err := slots.EpochStart(currentEpoch)
+	require.NoError(t, err)
+	require.NoError(t, st.SetSlot(currentSlot))
+	require.NoError(t, st.SetFinalizedCheckpoint(&ethpb.Checkpoint{Epoch: currentEpoch - 2, Root: make([]byte, 32)}))
+
+	val, err := st.ValidatorAtIndexReadOnly(0)
+	require.NoError(t, err)
+	principal := val.PrincipalBalance()
+	buffer := helpers.BailOutBuffer(principal)
+	// Validator 1 has used up its buffer, validator 2 has started to be penalized.
+	require.NoError(t, st.UpdateBalancesAtIndex(1, principal-buffer-1))
+	require.NoError(t, st.UpdateBalancesAtIndex(2, principal-buffer/2))
+	require.NoError(t, st.SetInactivityScores([]uint64{0, 0, cfg.InactivityScorePenaltyThreshold + 1, 0}))
+	// Validator 3 has initiated its exit.
+	exited, err := st.ValidatorAtIndex(3)
+	require.NoError(t, err)
+	exited.ExitEpoch = currentEpoch + 5
+	require.NoError(t, st.UpdateValidatorAtIndex(3, exited))
+
+	chainService := &chainMock.ChainService{}
+	s := &Server{
+		Stater:                &testutil.MockStater{BeaconState: st},
+		OptimisticModeFetcher: chainService,
+		FinalizationFetcher:   chainService,
+	}
+
+	t.Run("all active validators", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/over/v1/beacon/states/{state_id}/bailout_risk", nil)
+		request.SetPathValue("state_id", "head")
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetBailoutRisk(writer, request)
+		assert.Equal(t, http.StatusOK, writer.Code)
+		resp := &structs.GetBailoutRiskResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		assert.Equal(t, false, resp.InactivityLeak)
+		require.Equal(t, 3, len(resp.Data))
+
+		healthy := resp.Data[0]
+		assert.Equal(t, "0", healthy.Index)
+		assert.Equal(t, strconv.FormatUint(buffer, 10), healthy.BufferRemaining)
+		assert.Equal(t, false, healthy.EligibleForBailout)
+		wantEpochs, ok := helpers.EpochsToBailOut(principal, principal, 0, false)
+		require.Equal(t, true, ok)
+		assert.Equal(t, strconv.FormatUint(uint64(wantEpochs), 10), healthy.ProjectedEpochsToBailout)
+		assert.Equal(t, strconv.FormatUint(uint64(currentEpoch+wantEpochs), 10), healthy.ProjectedBailoutEpoch)
+
+		eligible := resp.Data[1]
+		assert.Equal(t, "0", eligible.BufferRemaining)
+		assert.Equal(t, true, eligible.EligibleForBailout)
+		assert.Equal(t, "0", eligible.ProjectedEpochsToBailout)
+		assert.Equal(t, strconv.FormatUint(uint64(currentEpoch+1), 10), eligible.ProjectedBailoutEpoch)
+
+		penalized := resp.Data[2]
+		assert.Equal(t, strconv.FormatUint(buffer-buffer/2, 10), penalized.BufferRemaining)
+		assert.Equal(t, strconv.FormatUint(cfg.InactivityScorePenaltyThreshold+1, 10), penalized.InactivityScore)
+		wantEpochs, ok = helpers.EpochsToBailOut(principal-buffer/2, principal, cfg.InactivityScorePenaltyThreshold+1, false)
+		require.Equal(t, true, ok)
+		assert.Equal(t, strconv.FormatUint(uint64(wantEpochs), 10), penalized.ProjectedEpochsToBailout)
+	})
+
+	t.Run("requested validators", func(t *testing.T) {
+		pubkey := st.PubkeyAtIndex(2)
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/over/v1/beacon/states/{state_id}/bailout_risk?id=3&id="+hexutil.Encode(pubkey[:]), nil)
+		request.SetPathValue("state_id", "head")
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetBailoutRisk(writer, request)
+		assert.Equal(t, http.StatusOK, writer.Code)
+		resp := &structs.GetBailoutRiskResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.Equal(t, 2, len(resp.Data))
+		assert.Equal(t, "3", resp.Data[0].Index)
+		// The bailout does not apply to a validator which has initiated its exit.
+		assert.Equal(t, "", resp.Data[0].ProjectedEpochsToBailout)
+		assert.Equal(t, "2", resp.Data[1].Index)
+	})
+
+	t.Run("inactivity leak", func(t *testing.T) {
+		leakSt := st.Copy()
+		require.NoError(t, leakSt.SetFinalizedCheckpoint(&ethpb.Checkpoint{Epoch: 0, Root: make([]byte, 32)}))
+		s := &Server{
+			Stater:                &testutil.MockStater{BeaconState: leakSt},
+			OptimisticModeFetcher: chainService,
+			FinalizationFetcher:   chainService,
+		}
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/over/v1/beacon/states/{state_id}/bailout_risk?id=0", nil)
+		request.SetPathValue("state_id", "head")
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetBailoutRisk(writer, request)
+		assert.Equal(t, http.StatusOK, writer.Code)
+		resp := &structs.GetBailoutRiskResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		assert.Equal(t, true, resp.InactivityLeak)
+		require.Equal(t, 1, len(resp.Data))
+		assert.Equal(t, strconv.FormatUint(cfg.InactivityLeakBailoutScoreThreshold/cfg.InactivityScoreBias+1, 10), resp.Data[0].ProjectedEpochsToBailout)
+	})
+
+	t.Run("invalid validator id", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/over/v1/beacon/states/{state_id}/bailout_risk?id=100", nil)
+		request.SetPathValue("state_id", "head")
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetBailoutRisk(writer, request)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+		e := &httputil.DefaultJsonError{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+		assert.StringContains(t, "Invalid validator index", e.Message)
+	})
+}
@@ -1,7 +1,6 @@
 package over
 
 import (
-	"bytes"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -76,7 +75,7 @@ func (s *Server) GetDepositPreEstimation(w http.ResponseWriter, r *http.Request)
 	}
 	isFinalized := s.FinalizationFetcher.IsFinalized(ctx, blockRoot)
 
-	_, lastEpoch, err := buildPendingDepositEstimations(st, nil, true, 0)
+	lastEpoch, err := buildPendingDepositEstimations(st, nil, 0)
 	if err != nil {
 		httputil.WriteError(w, handleWrapError(err, "could not get expected epoch for pre-estimation", http.StatusBadRequest))
 		return
@@ -143,21 +142,9 @@ func (s *Server) GetDepositEstimation(w http.ResponseWriter, r *http.Request) {
 	isFinalized := s.FinalizationFetcher.IsFinalized(ctx, blockRoot)
 
 	// Parse search_limit from URL params
-	searchLimit := defaultSearchLimit
-	rawSearchLimit := r.URL.Query().Get("search_limit")
-	if rawSearchLimit != "" {
-		_limit, err := strconv.Atoi(rawSearchLimit)
-		if err != nil {
-			httputil.HandleError(w, "search_limit must be a number", http.StatusBadRequest)
-			return
-		}
-		if _limit < minSearchLimit {
-			searchLimit = minSearchLimit
-		} else if _limit > maxSearchLimit {
-			searchLimit = maxSearchLimit
-		} else {
-			searchLimit = _limit
-		}
+	searchLimit, ok := parseSearchLimit(w, r)
+	if !ok {
+		return
 	}
 
 	// Decode pubkey
@@ -172,80 +159,232 @@ func (s *Server) GetDepositEstimation(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	epoch := slots.ToEpoch(st.Slot())
-	valIndex, found := st.ValidatorIndexByPubkey(bytesutil.ToBytes48(pubkey))
+	data, found, err := depositEstimationContainer(st, pubkey)
+	if err != nil {
+		httputil.WriteError(w, handleWrapError(err, "could not build deposit estimation", http.StatusBadRequest))
+		return
+	}
+	data.Pubkey = hexId
 
-	data := &structs.DepositEstimationContainer{
-		Pubkey: hexId,
+	key := bytesutil.ToBytes48(pubkey)
+	filters := map[[fieldparams.BLSPubkeyLength]byte]*pendingDepositFilter{
+		key: {initial: !found},
+	}
+	if _, err := buildPendingDepositEstimations(st, filters, searchLimit); err != nil {
+		httputil.WriteError(w, handleWrapError(err, "could not build pending deposit estimations", http.StatusBadRequest))
+		return
 	}
+	data.PendingDeposits = filters[key].estimations
 
-	// if the validator is found in registry, add the validator data to the response
-	if found {
-		val, err := st.ValidatorAtIndexReadOnly(valIndex)
-		if err != nil {
-			httputil.WriteError(w, handleWrapError(err, "could not get validator at index", http.StatusBadRequest))
+	httputil.WriteJson(w, &structs.GetDepositEstimationResponse{
+		ExecutionOptimistic: isOptimistic,
+		Finalized:           isFinalized,
+		Data:                data,
+	})
+}
+
+// PostDepositEstimations returns the deposit estimations for a list of validator IDs in the request body.
+// Accepts validator IDs as either public keys or validator indices. Unlike validator indices,
+// public keys do not need to be in the registry, so the deposits of new validators can be estimated.
+// The estimations of all requested validators in a page are calculated in a single pass over the pending deposit queue.
+// The response is paginated by the page_size and page_token query params.
+func (s *Server) PostDepositEstimations(w http.ResponseWriter, r *http.Request) {
+	ctx, span := trace.StartSpan(r.Context(), "over.PostDepositEstimations")
+	defer span.End()
+
+	// Parse state_id and replay to the state
+	stateId := r.PathValue("state_id")
+	if stateId == "" {
+		httputil.HandleError(w, "state_id is required in URL params", http.StatusBadRequest)
+		return
+	}
+	st, err := s.Stater.State(ctx, []byte(stateId))
+	if err != nil {
+		httputil.WriteError(w, handleWrapError(err, "could not retrieve state", http.StatusNotFound))
+		return
+	}
+
+	// Deposit estimation is only supported for Electra and later versions.
+	if st.Version() < version.Alpaca {
+		httputil.HandleError(w, "Deposit estimation is not supported for pre-Electra.", http.StatusBadRequest)
+		return
+	}
+
+	rawIds, ok := decodeEstimationIds(w, r)
+	if !ok {
+		return
+	}
+	searchLimit, ok := parseSearchLimit(w, r)
+	if !ok {
+		return
+	}
+	start, end, nextPageToken, ok := estimationPage(w, r, len(rawIds))
+	if !ok {
+		return
+	}
+
+	// Resolve the pubkeys of the requested page
+	pubkeys := make([][]byte, 0, end-start)
+	for _, rawId := range rawIds[start:end] {
+		pubkey, ok := decodeDepositPubkey(w, rawId, st)
+		if !ok {
 			return
 		}
-		valSubStatus, err := valhelpers.ValidatorSubStatus(val, epoch)
+		pubkeys = append(pubkeys, pubkey)
+	}
+
+	// Get metadata for response
+	isOptimistic, err := s.OptimisticModeFetcher.IsOptimistic(ctx)
+	if err != nil {
+		httputil.WriteError(w, handleWrapError(err, "could not get optimistic mode info", http.StatusInternalServerError))
+		return
+	}
+	blockRoot, err := st.LatestBlockHeader().HashTreeRoot()
+	if err != nil {
+		httputil.WriteError(w, handleWrapError(err, "could not calculate root of latest block header", http.StatusInternalServerError))
+		return
+	}
+	isFinalized := s.FinalizationFetcher.IsFinalized(ctx, blockRoot)
+
+	data := make([]*structs.DepositEstimationContainer, len(pubkeys))
+	filters := make(map[[fieldparams.BLSPubkeyLength]byte]*pendingDepositFilter, len(pubkeys))
+	for i, pubkey := range pubkeys {
+		d, found, err := depositEstimationContainer(st, pubkey)
 		if err != nil {
-			httputil.WriteError(w, handleWrapError(err, "could not get validator sub status", http.StatusBadRequest))
+			httputil.WriteError(w, handleWrapError(err, "could not build deposit estimation", http.StatusBadRequest))
 			return
 		}
+		data[i] = d
+		filters[bytesutil.ToBytes48(pubkey)] = &pendingDepositFilter{initial: !found}
+	}
+	if _, err := buildPendingDepositEstimations(st, filters, searchLimit); err != nil {
+		httputil.WriteError(w, handleWrapError(err, "could not build pending deposit estimations", http.StatusBadRequest))
+		return
+	}
+	for i, pubkey := range pubkeys {
+		data[i].PendingDeposits = filters[bytesutil.ToBytes48(pubkey)].estimations
+	}
+
+	httputil.WriteJson(w, &structs.GetDepositEstimationsResponse{
+		ExecutionOptimistic: isOptimistic,
+		Finalized:           isFinalized,
+		Data:                data,
+		NextPageToken:       nextPageToken,
+		TotalSize:           len(rawIds),
+	})
+}
 
-		expectedActivationEpoch := params.BeaconConfig().FarFutureEpoch
-		if valSubStatus == validator.PendingInitialized && helpers.IsEligibleForActivationQueue(val, epoch) {
-			estimatedActivationEligibilityEpoch := epoch + 1
-			estimatedEligibleEpochForActivation := estimatedActivationEligibilityEpoch + expectedFinalityDelay
+// depositEstimationContainer returns the deposit estimation of a pubkey without the pending deposits.
+// If the validator is already in the registry, it includes the validator info and its expected activation epoch.
+// It also returns whether the validator is found in the registry.
+func depositEstimationContainer(st state.BeaconState, pubkey []byte) (*structs.DepositEstimationContainer, bool, error) {
+	epoch := slots.ToEpoch(st.Slot())
+	valIndex, found := st.ValidatorIndexByPubkey(bytesutil.ToBytes48(pubkey))
+
+	data := &structs.DepositEstimationContainer{
+		Pubkey: hexutil.Encode(pubkey),
+	}
+	if !found {
+		return data, false, nil
+	}
+
+	// if the validator is found in registry, add the validator data to the response
+	val, err := st.ValidatorAtIndexReadOnly(valIndex)
+	if err != nil {
+		return nil, true, errors.Wrap(err, "could not get validator at index")
+	}
+	valSubStatus, err := valhelpers.ValidatorSubStatus(val, epoch)
+	if err != nil {
+		return nil, true, errors.Wrap(err, "could not get validator sub status")
+	}
+
+	expectedActivationEpoch := params.BeaconConfig().FarFutureEpoch
+	if valSubStatus == validator.PendingInitialized && helpers.IsEligibleForActivationQueue(val, epoch) {
+		estimatedActivationEligibilityEpoch := epoch + 1
+		estimatedEligibleEpochForActivation := estimatedActivationEligibilityEpoch + expectedFinalityDelay
+		expectedActivationEpoch = helpers.ActivationExitEpoch(estimatedEligibleEpochForActivation)
+	} else if valSubStatus == validator.PendingQueued {
+		if val.ActivationEpoch() == params.BeaconConfig().FarFutureEpoch {
+			estimatedEligibleEpochForActivation := val.ActivationEligibilityEpoch() + expectedFinalityDelay
 			expectedActivationEpoch = helpers.ActivationExitEpoch(estimatedEligibleEpochForActivation)
-		} else if valSubStatus == validator.PendingQueued {
-			if val.ActivationEpoch() == params.BeaconConfig().FarFutureEpoch {
-				estimatedEligibleEpochForActivation := val.ActivationEligibilityEpoch() + expectedFinalityDelay
-				expectedActivationEpoch = helpers.ActivationExitEpoch(estimatedEligibleEpochForActivation)
-			} else {
-				expectedActivationEpoch = val.ActivationEpoch()
-			}
+		} else {
+			expectedActivationEpoch = val.ActivationEpoch()
 		}
+	}
 
-		data.Validator = validatorFromROVal(val)
-		// If validator is already in the registry and ready to be activated,
-		// expectedActivationEpoch will be set to the epoch when the validator is assigned/expected to be activated.
-		if expectedActivationEpoch < params.BeaconConfig().FarFutureEpoch {
-			data.ExpectedActivationEpoch = uint64(expectedActivationEpoch)
+	data.Validator = validatorFromROVal(val)
+	// If validator is already in the registry and ready to be activated,
+	// expectedActivationEpoch will be set to the epoch when the validator is assigned/expected to be activated.
+	if expectedActivationEpoch < params.BeaconConfig().FarFutureEpoch {
+		data.ExpectedActivationEpoch = uint64(expectedActivationEpoch)
+	}
+	return data, true, nil
+}
+
+// decodeDepositPubkey takes in a validator ID string (as either a pubkey or a validator index)
+// and returns the corresponding pubkey. Unlike decodeId, a pubkey does not need to be in the registry.
+func decodeDepositPubkey(w http.ResponseWriter, rawId string, st state.BeaconState) ([]byte, bool) {
+	pubkey, err := hexutil.Decode(rawId)
+	if err == nil {
+		if len(pubkey) != fieldparams.BLSPubkeyLength {
+			httputil.HandleError(w, fmt.Sprintf("Pubkey length is %d instead of %d", len(pubkey), fieldparams.BLSPubkeyLength), http.StatusBadRequest)
+			return nil, false
 		}
+		return pubkey, true
+	}
+	index, ok := decodeId(w, rawId, st)
+	if !ok {
+		return nil, false
 	}
+	pk := st.PubkeyAtIndex(index)
+	return pk[:], true
+}
 
-	pdes, _, err := buildPendingDepositEstimations(st, pubkey, !found /* initial */, searchLimit)
+// parseSearchLimit parses the search_limit query param, which limits the number of queued items to search.
+// The limit is clamped to [minSearchLimit, maxSearchLimit], and defaultSearchLimit is used if it is not given.
+func parseSearchLimit(w http.ResponseWriter, r *http.Request) (int, bool) {
+	rawSearchLimit := r.URL.Query().Get("search_limit")
+	if rawSearchLimit == "" {
+		return defaultSearchLimit, true
+	}
+	limit, err := strconv.Atoi(rawSearchLimit)
 	if err != nil {
-		httputil.WriteError(w, handleWrapError(err, "could not build pending deposit estimations", http.StatusBadRequest))
-		return
+		httputil.HandleError(w, "search_limit must be a number", http.StatusBadRequest)
+		return 0, false
 	}
-	data.PendingDeposits = pdes
+	return min(max(limit, minSearchLimit), maxSearchLimit), true
+}
 
-	httputil.WriteJson(w, &structs.GetDepositEstimationResponse{
-		ExecutionOptimistic: isOptimistic,
-		Finalized:           isFinalized,
-		Data:                data,
-	})
+// pendingDepositFilter collects the estimations of the pending deposits for a pubkey.
+type pendingDepositFilter struct {
+	// initial is true if the pubkey is not in the registry, so the first pending deposit is the initial one.
+	initial     bool
+	estimations []*structs.PendingDepositEstimationContainer
 }
 
 // buildPendingDepositEstimations iterates through the pending deposits and calculates the expected epoch.
-func buildPendingDepositEstimations(st state.BeaconState, pubkeyFilter []byte, initial bool, searchLimit int) ([]*structs.PendingDepositEstimationContainer, primitives.Epoch, error) {
+// The estimations of the deposits for the pubkeys in filters are collected into the filters,
+// so any number of pubkeys are estimated in a single pass over the queue.
+// It returns the epoch that the last searched deposit is expected to be processed.
+func buildPendingDepositEstimations(st state.BeaconState, filters map[[fieldparams.BLSPubkeyLength]byte]*pendingDepositFilter, searchLimit int) (primitives.Epoch, error) {
 	currentEpoch := slots.ToEpoch(st.Slot())
 	activeBalance, err := helpers.TotalActiveBalance(st)
 	if err != nil {
-		return nil, currentEpoch, errors.Wrap(err, "could not get total active balance")
+		return currentEpoch, errors.Wrap(err, "could not get total active balance")
 	}
 	balanceChurnLimit := helpers.ActivationBalanceChurnLimit(primitives.Gwei(activeBalance))
 
-	pdes := make([]*structs.PendingDepositEstimationContainer, 0)
+	for _, f := range filters {
+		f.estimations = make([]*structs.PendingDepositEstimationContainer, 0)
+	}
 	pds, err := st.PendingDeposits()
 	if err != nil {
-		return nil, currentEpoch, errors.Wrap(err, "could not get pending deposits from state")
+		return currentEpoch, errors.Wrap(err, "could not get pending deposits from state")
 	}
 
 	// Return early if there are no pending deposits
 	if len(pds) == 0 {
-		return pdes, currentEpoch + expectedFinalityDelay, nil
+		return currentEpoch + expectedFinalityDelay, nil
 	}
 
 	// Limit the number of pending deposits to search
@@ -258,12 +397,12 @@ func buildPendingDepositEstimations(st state.BeaconState, pubkeyFilter []byte, i
 	finalizedEpoch := st.FinalizedCheckpointEpoch()
 	finalizedSlot, err := slots.EpochStart(finalizedEpoch)
 	if err != nil {
-		return nil, currentEpoch, errors.Wrap(err, "could not get finalized slot")
+		return currentEpoch, errors.Wrap(err, "could not get finalized slot")
 	}
 
 	depBalToConsume, err := st.DepositBalanceToConsume()
 	if err != nil {
-		return nil, currentEpoch, errors.Wrap(err, "could not get deposit balance to consume")
+		return currentEpoch, errors.Wrap(err, "could not get deposit balance to consume")
 	}
 	availableForProcessing := depBalToConsume + balanceChurnLimit
 
@@ -299,11 +438,12 @@ func buildPendingDepositEstimations(st state.BeaconState, pubkeyFilter []byte, i
 
 		var isValidatorExited bool
 		var isValidatorWithdrawn bool
-		index, found := st.ValidatorIndexByPubkey(bytesutil.ToBytes48(pd.PublicKey))
+		pubkey := bytesutil.ToBytes48(pd.PublicKey)
+		index, found := st.ValidatorIndexByPubkey(pubkey)
 		if found {
 			val, err := st.ValidatorAtIndexReadOnly(index)
 			if err != nil {
-				return nil, currentEpoch, errors.Wrap(err, "could not get validator")
+				return currentEpoch, errors.Wrap(err, "could not get validator")
 			}
 			isValidatorExited = val.ExitEpoch() < params.BeaconConfig().FarFutureEpoch
 			withdrawableEpoch := helpers.GetWithdrawableEpoch(val.ExitEpoch(), val.Slashed())
@@ -330,42 +470,40 @@ func buildPendingDepositEstimations(st state.BeaconState, pubkeyFilter []byte, i
 		// Regardless of how the pendingDeposit was handled, we move on in the queue.
 		depositCount++
 
-		// If the pending deposit has same pubkey as the one we are looking for
-		// append it to the response
-		if pubkeyFilter != nil && bytes.Equal(pd.PublicKey, pubkeyFilter) {
-			pde := &structs.PendingDepositEstimationContainer{}
-			if initial {
-				pde.Type = "initial"
-			} else {
-				pde.Type = "top-up"
-			}
-
-			data := &structs.PendingDepositEstimation{
-				Amount: pd.Amount,
-				Slot:   uint64(pd.Slot),
-			}
-			if isValidatorExited {
-				// if the validator is already exited, it is hard to predict the expected epoch(postponed).
-				data.ExpectedEpoch = uint64(params.BeaconConfig().FarFutureEpoch)
-			} else {
-				data.ExpectedEpoch = uint64(currentEpoch)
-			}
-			if initial && pd.Amount >= params.BeaconConfig().MinActivationBalance {
-				data.ExpectedActivationEpoch = uint64(getExpectedActivationEpoch(currentEpoch))
-			}
-			pde.Data = data
-
-			pdes = append(pdes, pde)
-			initial = false // only one initial deposit is expected in the queue.
+		// If the pending deposit has one of the pubkeys we are looking for
+		// append it to the estimations of the pubkey.
+		// Limit the number of pending deposits in the response.
+		f, ok := filters[pubkey]
+		if !ok || len(f.estimations) >= pendingDepositResponseLimit {
+			continue
+		}
+		pde := &structs.PendingDepositEstimationContainer{}
+		if f.initial {
+			pde.Type = "initial"
+		} else {
+			pde.Type = "top-up"
+		}
 
-			if len(pdes) >= pendingDepositResponseLimit {
-				// Limit the number of pending deposits in the response.
-				break
-			}
+		data := &structs.PendingDepositEstimation{
+			Amount: pd.Amount,
+			Slot:   uint64(pd.Slot),
 		}
+		if isValidatorExited {
+			// if the validator is already exited, it is hard to predict the expected epoch(postponed).
+			data.ExpectedEpoch = uint64(params.BeaconConfig().FarFutureEpoch)
+		} else {
+			data.ExpectedEpoch = uint64(currentEpoch)
+		}
+		if f.initial && pd.Amount >= params.BeaconConfig().MinActivationBalance {
+			data.ExpectedActivationEpoch = uint64(getExpectedActivationEpoch(currentEpoch))
+		}
+		pde.Data = data
+
+		f.estimations = append(f.estimations, pde)
+		f.initial = false // only one initial deposit is expected in the queue.
 	}
 
-	return pdes, currentEpoch, nil
+	return currentEpoch, nil
 }
 
 // getExpectedActivationEpoch calculates the expected activation epoch for a given epoch
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -806,6 +807,123 @@ func TestGetDepositEstimation(t *testing.T) {
 	}
 }
 
+func TestPostDepositEstimations(t *testing.T) {
+	// initial deposit keypair
+	sk0, err := bls.RandKey()
+	require.NoError(t, err)
+	pubkey0 := hexutil.Encode(sk0.PublicKey().Marshal())
+
+	st, privKeys := util.DeterministicGenesisStateElectra(t, 10)
+	require.NoError(t, st.SetSlot(384)) // current epoch = 12
+	require.NoError(t, st.SetFinalizedCheckpoint(&ethpb.Checkpoint{
+		Epoch: 10,
+		Root:  []byte("finalized"),
+	}))
+	initial, err := signedPendingDeposit(sk0, &ethpb.PendingDeposit{
+		PublicKey:             sk0.PublicKey().Marshal(),
+		WithdrawalCredentials: make([]byte, 32),
+		Amount:                params.BeaconConfig().MinActivationBalance,
+		Slot:                  primitives.Slot(321),
+	})
+	require.NoError(t, err)
+	require.NoError(t, st.AppendPendingDeposit(initial))
+	for i := 0; i < 2; i++ {
+		topUp, err := signedPendingDeposit(privKeys[0], &ethpb.PendingDeposit{
+			PublicKey:             privKeys[0].PublicKey().Marshal(),
+			WithdrawalCredentials: make([]byte, 32),
+			Amount:                params.BeaconConfig().MinActivationBalance,
+			Slot:                  primitives.Slot(322 + i),
+		})
+		require.NoError(t, err)
+		require.NoError(t, st.AppendPendingDeposit(topUp))
+	}
+
+	chainService := &chainMock.ChainService{}
+	s := Server{
+		Stater: &testutil.MockStater{
+			BeaconState: st,
+		},
+		HeadFetcher:           chainService,
+		OptimisticModeFetcher: chainService,
+		FinalizationFetcher:   chainService,
+	}
+
+	postEstimations := func(t *testing.T, query string, body string) (*httptest.ResponseRecorder, *structs.GetDepositEstimationsResponse) {
+		request := httptest.NewRequest(http.MethodPost,
+			"http://example.com/over/v1/beacon/states/{state_id}/deposit_estimations?"+query, bytes.NewBufferString(body))
+		request.SetPathValue("state_id", "head")
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.PostDepositEstimations(writer, request)
+		resp := &structs.GetDepositEstimationsResponse{}
+		if writer.Code == http.StatusOK {
+			require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		}
+		return writer, resp
+	}
+	getEstimation := func(t *testing.T, pubkey string) *structs.DepositEstimationContainer {
+		request := httptest.NewRequest(http.MethodGet,
+			"http://example.com/over/v1/beacon/states/{state_id}/deposit_estimation/{pubkey}", nil)
+		request.SetPathValue("state_id", "head")
+		request.SetPathValue("pubkey", pubkey)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.GetDepositEstimation(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &structs.GetDepositEstimationResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		return resp.Data
+	}
+
+	pubkey1 := hexutil.Encode(privKeys[0].PublicKey().Marshal())
+	pubkey2 := hexutil.Encode(privKeys[2].PublicKey().Marshal())
+
+	t.Run("same as single estimations", func(t *testing.T) {
+		writer, resp := postEstimations(t, "", fmt.Sprintf(`["%s","0","%s"]`, pubkey0, pubkey2))
+		assert.Equal(t, http.StatusOK, writer.Code)
+		assert.Equal(t, 3, resp.TotalSize)
+		assert.Equal(t, "", resp.NextPageToken)
+		require.Equal(t, 3, len(resp.Data))
+		require.DeepEqual(t, getEstimation(t, pubkey0), resp.Data[0])
+		require.DeepEqual(t, getEstimation(t, pubkey1), resp.Data[1])
+		require.DeepEqual(t, getEstimation(t, pubkey2), resp.Data[2])
+
+		assert.Equal(t, "initial", resp.Data[0].PendingDeposits[0].Type)
+		assert.Equal(t, 2, len(resp.Data[1].PendingDeposits))
+		assert.Equal(t, 0, len(resp.Data[2].PendingDeposits))
+	})
+	t.Run("pagination", func(t *testing.T) {
+		body := fmt.Sprintf(`["%s","0","%s"]`, pubkey0, pubkey2)
+		writer, resp := postEstimations(t, "page_size=2", body)
+		assert.Equal(t, http.StatusOK, writer.Code)
+		assert.Equal(t, "1", resp.NextPageToken)
+		require.Equal(t, 2, len(resp.Data))
+		assert.Equal(t, pubkey0, resp.Data[0].Pubkey)
+		assert.Equal(t, pubkey1, resp.Data[1].Pubkey)
+
+		writer, resp = postEstimations(t, "page_size=2&page_token=1", body)
+		assert.Equal(t, http.StatusOK, writer.Code)
+		assert.Equal(t, "", resp.NextPageToken)
+		require.Equal(t, 1, len(resp.Data))
+		assert.Equal(t, pubkey2, resp.Data[0].Pubkey)
+
+		writer, _ = postEstimations(t, "page_size=2&page_token=2", body)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+		writer, _ = postEstimations(t, fmt.Sprintf("page_size=%d", maxEstimationPageSize+1), body)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+	})
+	t.Run("bad requests", func(t *testing.T) {
+		writer, _ := postEstimations(t, "", "")
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+		writer, _ = postEstimations(t, "", "[]")
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+		writer, _ = postEstimations(t, "", `["0x1234"]`)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+		writer, _ = postEstimations(t, "", `["10"]`)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+	})
+}
+
 func signedPendingDeposit(sk common.SecretKey, pd *ethpb.PendingDeposit) (*ethpb.PendingDeposit, error) {
 	domain, err := signing.ComputeDomain(params.BeaconConfig().DomainDeposit, nil, nil)
 	if err != nil {
@@ -0,0 +1,407 @@
+package over
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/big"
+	"net/http"
+	"slices"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/filters"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/monitoring/tracing/trace"
+	"github.com/prysmaticlabs/prysm/v5/network/httputil"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+)
+
+const (
+	// maxPrincipalHistoryPoints is the maximum number of epochs sampled for a principal history,
+	// as a state is replayed for each of them.
+	maxPrincipalHistoryPoints = 256
+
+	principalChangeDeposit    = "deposit"
+	principalChangeWithdrawal = "withdrawal"
+)
+
+// principalPoint holds the balances of a validator at the start of an epoch.
+type principalPoint struct {
+	epoch     primitives.Epoch
+	slot      primitives.Slot
+	principal uint64
+	balance   uint64
+	// pendingDeposits is the amount of the deposits to the validator which are queued but not applied yet.
+	pendingDeposits uint64
+}
+
+// principalEvent is a deposit to or a withdrawal from a validator included in a block.
+type principalEvent struct {
+	slot       primitives.Slot
+	changeType string
+	amount     uint64
+}
+
+// GetPrincipalHistory returns the principal balance and the actual balance of a validator
+// at the start of the epochs within [start_epoch, end_epoch], sampled every `interval` epochs,
+// along with the deposits and withdrawals which changed the principal balance, and the realized APR.
+// When end_epoch is not given, the epoch of the head is used.
+//
+// The deposits and withdrawals are read from the canonical blocks between the first and the last sampled epoch,
+// so every one of them is reported at the slot of its block, whatever the interval.
+func (s *Server) GetPrincipalHistory(w http.ResponseWriter, r *http.Request) {
+	ctx, span := trace.StartSpan(r.Context(), "over.GetPrincipalHistory")
+	defer span.End()
+
+	query := r.URL.Query()
+
+	// Parse epoch range from URL params
+	rawStart := query.Get("start_epoch")
+	if rawStart == "" {
+		httputil.HandleError(w, "start_epoch is required in query params", http.StatusBadRequest)
+		return
+	}
+	start, err := strconv.ParseUint(rawStart, 10, 64)
+	if err != nil {
+		httputil.HandleError(w, "start_epoch must be a number", http.StatusBadRequest)
+		return
+	}
+	headEpoch := uint64(slots.ToEpoch(s.HeadFetcher.HeadSlot()))
+	end := headEpoch
+	if rawEnd := query.Get("end_epoch"); rawEnd != "" {
+		end, err = strconv.ParseUint(rawEnd, 10, 64)
+		if err != nil {
+			httputil.HandleError(w, "end_epoch must be a number", http.StatusBadRequest)
+			return
+		}
+	}
+	if start > end {
+		httputil.HandleError(w, "start_epoch must not be greater than end_epoch", http.StatusBadRequest)
+		return
+	}
+	if end > headEpoch {
+		httputil.HandleError(w, fmt.Sprintf("Cannot retrieve information for a future epoch, head epoch %d, requesting %d", headEpoch, end), http.StatusBadRequest)
+		return
+	}
+	interval := uint64(1)
+	if rawInterval := query.Get("interval"); rawInterval != "" {
+		interval, err = strconv.ParseUint(rawInterval, 10, 64)
+		if err != nil || interval == 0 {
+			httputil.HandleError(w, "interval must be a positive number", http.StatusBadRequest)
+			return
+		}
+	}
+	if (end-start)/interval+1 > maxPrincipalHistoryPoints {
+		httputil.HandleError(w, fmt.Sprintf("At most %d epochs can be sampled at once, increase the interval", maxPrincipalHistoryPoints), http.StatusBadRequest)
+		return
+	}
+
+	// The validator is looked up in the state of the end epoch, which holds every validator of the range.
+	endSlot, err := slots.EpochStart(primitives.Epoch(end))
+	if err != nil {
+		httputil.HandleError(w, "Could not get start slot of end_epoch: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	endState, err := s.Stater.StateBySlot(ctx, endSlot)
+	if err != nil {
+		httputil.WriteError(w, handleWrapError(err, "could not retrieve state", http.StatusNotFound))
+		return
+	}
+	idx, ok := decodeId(w, r.PathValue("id"), endState)
+	if !ok {
+		return
+	}
+
+	// Sample the balances of the validator, skipping the epochs before it was added to the registry.
+	points := make([]*principalPoint, 0, (end-start)/interval+1)
+	for epoch := start; ; epoch += interval {
+		if epoch > end {
+			epoch = end
+		}
+		slot, err := slots.EpochStart(primitives.Epoch(epoch))
+		if err != nil {
+			httputil.HandleError(w, "Could not get start slot of epoch: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		st := endState
+		if epoch != end {
+			st, err = s.Stater.StateBySlot(ctx, slot)
+			if err != nil {
+				httputil.WriteError(w, handleWrapError(err, "could not retrieve state", http.StatusNotFound))
+				return
+			}
+		}
+		if uint64(idx) < uint64(st.NumValidators()) {
+			val, err := st.ValidatorAtIndexReadOnly(idx)
+			if err != nil {
+				httputil.WriteError(w, handleWrapError(err, "could not get validator", http.StatusInternalServerError))
+				return
+			}
+			balance, err := st.BalanceAtIndex(idx)
+			if err != nil {
+				httputil.WriteError(w, handleWrapError(err, "could not get balance", http.StatusInternalServerError))
+				return
+			}
+			pending, err := pendingDepositsAmount(st, val.PublicKey())
+			if err != nil {
+				httputil.WriteError(w, handleWrapError(err, "could not get pending deposits", http.StatusInternalServerError))
+				return
+			}
+			points = append(points, &principalPoint{
+				epoch:           primitives.Epoch(epoch),
+				slot:            slot,
+				principal:       val.PrincipalBalance(),
+				balance:         balance,
+				pendingDeposits: pending,
+			})
+		}
+		if epoch == end {
+			break
+		}
+	}
+
+	// Get metadata for response
+	isOptimistic, err := s.OptimisticModeFetcher.IsOptimistic(ctx)
+	if err != nil {
+		httputil.WriteError(w, handleWrapError(err, "could not get optimistic mode info", http.StatusInternalServerError))
+		return
+	}
+	headRoot, err := latestBlockRoot(ctx, endState)
+	if err != nil {
+		httputil.WriteError(w, handleWrapError(err, "could not calculate root of latest block header", http.StatusInternalServerError))
+		return
+	}
+	isFinalized := s.FinalizationFetcher.IsFinalized(ctx, headRoot)
+
+	val, err := endState.ValidatorAtIndexReadOnly(idx)
+	if err != nil {
+		httputil.WriteError(w, handleWrapError(err, "could not get validator", http.StatusInternalServerError))
+		return
+	}
+	pubkey := val.PublicKey()
+	events, err := s.principalEvents(ctx, points, headRoot, idx, pubkey)
+	if err != nil {
+		httputil.WriteError(w, handleWrapError(err, "could not get deposits and withdrawals", http.StatusInternalServerError))
+		return
+	}
+	history := principalHistory(points, events)
+	history.Index = strconv.FormatUint(uint64(idx), 10)
+	history.Pubkey = hexutil.Encode(pubkey[:])
+	history.StartEpoch = strconv.FormatUint(start, 10)
+	history.EndEpoch = strconv.FormatUint(end, 10)
+
+	httputil.WriteJson(w, &structs.GetPrincipalHistoryResponse{
+		Data:                history,
+		ExecutionOptimistic: isOptimistic,
+		Finalized:           isFinalized,
+	})
+}
+
+// principalEvents returns the deposits to and the withdrawals from the validator in the canonical blocks after
+// the first sampled epoch up to the last one, in the order they were included. The canonical blocks are found by
+// walking the parent roots back from the head root, reading the blocks of one interval at a time. The execution
+// payloads of the blinded blocks are reconstructed in a batch per interval, as they hold the withdrawals.
+func (s *Server) principalEvents(
+	ctx context.Context,
+	points []*principalPoint,
+	headRoot [32]byte,
+	idx primitives.ValidatorIndex,
+	pubkey [fieldparams.BLSPubkeyLength]byte,
+) ([]*principalEvent, error) {
+	var events []*principalEvent
+	root := headRoot
+	for i := len(points) - 1; i > 0; i-- {
+		f := filters.NewFilter().SetStartSlot(points[i-1].slot + 1).SetEndSlot(points[i].slot)
+		blks, roots, err := s.BeaconDB.Blocks(ctx, f)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not get blocks of slots %d to %d", points[i-1].slot+1, points[i].slot)
+		}
+		byRoot := make(map[[32]byte]interfaces.ReadOnlySignedBeaconBlock, len(blks))
+		for j, blk := range blks {
+			byRoot[roots[j]] = blk
+		}
+		// The walk stops at the first block before the interval, which the next interval starts from.
+		var canonical, blinded []interfaces.ReadOnlySignedBeaconBlock
+		var blindedAt []int
+		for blk, ok := byRoot[root]; ok; blk, ok = byRoot[root] {
+			if blk.Version() >= version.Capella && blk.IsBlinded() {
+				blinded = append(blinded, blk)
+				blindedAt = append(blindedAt, len(canonical))
+			}
+			canonical = append(canonical, blk)
+			root = blk.Block().ParentRoot()
+		}
+		if len(blinded) > 0 {
+			full, err := s.ExecutionReconstructor.ReconstructFullBellatrixBlockBatch(ctx, blinded)
+			if err != nil {
+				return nil, errors.Wrap(err, "could not reconstruct execution payloads")
+			}
+			for j, blk := range full {
+				canonical[blindedAt[j]] = blk
+			}
+		}
+		// The events are collected backwards, and reversed once the walk is done.
+		for _, blk := range canonical {
+			blkEvents, err := blockPrincipalEvents(blk, idx, pubkey)
+			if err != nil {
+				return nil, err
+			}
+			slices.Reverse(blkEvents)
+			events = append(events, blkEvents...)
+		}
+	}
+	slices.Reverse(events)
+	return events, nil
+}
+
+// blockPrincipalEvents returns the deposits to and the withdrawals from the validator in a block. Since Alpaca,
+// the deposits are queued, so they are applied to the balance at a later epoch than the one they are reported at.
+func blockPrincipalEvents(
+	blk interfaces.ReadOnlySignedBeaconBlock,
+	idx primitives.ValidatorIndex,
+	pubkey [fieldparams.BLSPubkeyLength]byte,
+) ([]*principalEvent, error) {
+	slot := blk.Block().Slot()
+	body := blk.Block().Body()
+	var events []*principalEvent
+	for _, d := range body.Deposits() {
+		if d.Data != nil && bytes.Equal(d.Data.PublicKey, pubkey[:]) {
+			events = append(events, &principalEvent{slot: slot, changeType: principalChangeDeposit, amount: d.Data.Amount})
+		}
+	}
+	if blk.Version() >= version.Alpaca {
+		requests, err := body.ExecutionRequests()
+		if err != nil {
+			return nil, errors.Wrap(err, "could not get execution requests")
+		}
+		if requests != nil {
+			for _, r := range requests.Deposits {
+				if bytes.Equal(r.Pubkey, pubkey[:]) {
+					events = append(events, &principalEvent{slot: slot, changeType: principalChangeDeposit, amount: r.Amount})
+				}
+			}
+		}
+	}
+	if blk.Version() >= version.Capella {
+		payload, err := body.Execution()
+		if err != nil {
+			return nil, errors.Wrap(err, "could not get execution payload")
+		}
+		withdrawals, err := payload.Withdrawals()
+		if err != nil {
+			return nil, errors.Wrap(err, "could not get withdrawals")
+		}
+		for _, wd := range withdrawals {
+			if wd.ValidatorIndex == idx {
+				events = append(events, &principalEvent{slot: slot, changeType: principalChangeWithdrawal, amount: wd.Amount})
+			}
+		}
+	}
+	return events, nil
+}
+
+// pendingDepositsAmount returns the amount of the deposits to the validator queued in the state.
+func pendingDepositsAmount(st state.ReadOnlyBeaconState, pubkey [fieldparams.BLSPubkeyLength]byte) (uint64, error) {
+	if st.Version() < version.Alpaca {
+		return 0, nil
+	}
+	pending, err := st.PendingDeposits()
+	if err != nil {
+		return 0, err
+	}
+	amount := uint64(0)
+	for _, d := range pending {
+		if bytes.Equal(d.PublicKey, pubkey[:]) {
+			amount += d.Amount
+		}
+	}
+	return amount, nil
+}
+
+// latestBlockRoot returns the root of the latest block applied to the state. The state root of the latest block
+// header is only filled in by the next slot processing, so it is computed if the state is at the slot of the block.
+func latestBlockRoot(ctx context.Context, st state.BeaconState) ([32]byte, error) {
+	header := st.LatestBlockHeader()
+	if bytes.Equal(header.StateRoot, params.BeaconConfig().ZeroHash[:]) {
+		stateRoot, err := st.HashTreeRoot(ctx)
+		if err != nil {
+			return [32]byte{}, errors.Wrap(err, "could not compute state root")
+		}
+		header.StateRoot = stateRoot[:]
+	}
+	return header.HashTreeRoot()
+}
+
+// principalHistory builds the principal history from the sampled balances and the deposits and withdrawals
+// included in between.
+//
+// The earnings of an interval are the change of the balance minus the principal flows into the balance, i.e.
+// the deposits applied and the withdrawals paid out within it. The deposits applied are the ones included in
+// the interval plus the ones queued at its start minus the ones still queued at its end.
+// The realized APR is the earnings per the time weighted average principal, scaled to a year.
+func principalHistory(points []*principalPoint, events []*principalEvent) *structs.PrincipalHistory {
+	history := &structs.PrincipalHistory{
+		Points: make([]*structs.PrincipalHistoryPoint, len(points)),
+		Events: make([]*structs.PrincipalChangeEvent, 0, len(events)),
+	}
+	earnings := new(big.Int)
+	principalEpochs := new(big.Int)
+	next := 0
+	for i, p := range points {
+		history.Points[i] = &structs.PrincipalHistoryPoint{
+			Epoch:            strconv.FormatUint(uint64(p.epoch), 10),
+			PrincipalBalance: strconv.FormatUint(p.principal, 10),
+			Balance:          strconv.FormatUint(p.balance, 10),
+		}
+		deposits := new(big.Int)
+		withdrawals := new(big.Int)
+		for ; next < len(events) && events[next].slot <= p.slot; next++ {
+			e := events[next]
+			if i == 0 {
+				continue
+			}
+			history.Events = append(history.Events, &structs.PrincipalChangeEvent{
+				Epoch:  strconv.FormatUint(uint64(slots.ToEpoch(e.slot)), 10),
+				Slot:   strconv.FormatUint(uint64(e.slot), 10),
+				Type:   e.changeType,
+				Amount: strconv.FormatUint(e.amount, 10),
+			})
+			if e.changeType == principalChangeDeposit {
+				deposits.Add(deposits, new(big.Int).SetUint64(e.amount))
+			} else {
+				withdrawals.Add(withdrawals, new(big.Int).SetUint64(e.amount))
+			}
+		}
+		if i == 0 {
+			continue
+		}
+		prev := points[i-1]
+		applied := deposits.Add(deposits, new(big.Int).SetUint64(prev.pendingDeposits))
+		applied.Sub(applied, new(big.Int).SetUint64(p.pendingDeposits))
+		change := new(big.Int).Sub(new(big.Int).SetUint64(p.balance), new(big.Int).SetUint64(prev.balance))
+		earnings.Add(earnings, change.Sub(change, applied).Add(change, withdrawals))
+		principalEpochs.Add(principalEpochs, new(big.Int).Mul(
+			new(big.Int).SetUint64(prev.principal),
+			new(big.Int).SetUint64(uint64(p.epoch-prev.epoch)),
+		))
+	}
+
+	history.Earnings = earnings.String()
+	if principalEpochs.Sign() > 0 {
+		apr := new(big.Float).Quo(
+			new(big.Float).Mul(new(big.Float).SetInt(earnings), new(big.Float).SetUint64(params.BeaconConfig().EpochsPerYear)),
+			new(big.Float).SetInt(principalEpochs),
+		)
+		f, _ := apr.Float64()
+		history.RealizedApr = strconv.FormatFloat(f, 'f', 6, 64)
+	}
+	return history
+}
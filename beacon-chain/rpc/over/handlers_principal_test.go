@@ -0,0 +1,215 @@
+package over
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	chainMock "github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain/testing"
+	dbtest "github.com/prysmaticlabs/prysm/v5/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/testutil"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v5/config/features"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	enginev1 "github.com/prysmaticlabs/prysm/v5/proto/engine/v1"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+)
+
+func TestGetPrincipalHistory(t *testing.T) {
+	const gwei = uint64(1e9)
+	principal := params.BeaconConfig().MinActivationBalance
+	startEpoch := primitives.Epoch(10)
+
+	// Validator 0 earns 1 ETH, deposits 10 ETH, withdraws half of its balance and earns 1 ETH again.
+	// The deposit is included in epoch 10, and applied from the deposit queue at the start of epoch 12.
+	// Validator 3 is only added to the registry after the first epoch.
+	history := []struct {
+		principal uint64
+		balance   uint64
+	}{
+		{principal, principal + gwei},
+		{principal, principal + 2*gwei},
+		{principal + 10*gwei, principal + 12*gwei},
+		{(principal + 10*gwei) / 2, (principal + 12*gwei) / 2},
+		{(principal + 10*gwei) / 2, (principal+12*gwei)/2 + gwei},
+	}
+	withdrawn := (principal + 12*gwei) - (principal+12*gwei)/2
+	ctx := context.Background()
+	resetCfg := features.InitWithReset(&features.Flags{SaveFullExecutionPayloads: true})
+	defer resetCfg()
+	beaconDB := dbtest.SetupDB(t)
+	epochSlot := func(epoch primitives.Epoch, offset primitives.Slot) primitives.Slot {
+		slot, err := slots.EpochStart(epoch)
+		require.NoError(t, err)
+		return slot + offset
+	}
+	genesis, _ := util.DeterministicGenesisStateElectra(t, 4)
+	pubkey := genesis.PubkeyAtIndex(0)
+	saveBlock := func(slot primitives.Slot, parent [32]byte, modify func(b *ethpb.SignedBeaconBlockElectra)) [32]byte {
+		b := util.NewBeaconBlockElectra()
+		b.Block.Slot = slot
+		b.Block.ParentRoot = parent[:]
+		b.Block.StateRoot = bytesutil.PadTo([]byte{byte(slot)}, 32)
+		if modify != nil {
+			modify(b)
+		}
+		blk, err := blocks.NewSignedBeaconBlock(b)
+		require.NoError(t, err)
+		require.NoError(t, beaconDB.SaveBlock(ctx, blk))
+		root, err := blk.Block().HashTreeRoot()
+		require.NoError(t, err)
+		return root
+	}
+	root := saveBlock(epochSlot(startEpoch, 0), [32]byte{}, nil)
+	root = saveBlock(epochSlot(startEpoch, 2), root, func(b *ethpb.SignedBeaconBlockElectra) {
+		b.Block.Body.ExecutionRequests = &enginev1.ExecutionRequests{
+			Deposits: []*enginev1.DepositRequest{{
+				Pubkey:                pubkey[:],
+				WithdrawalCredentials: make([]byte, 32),
+				Amount:                10 * gwei,
+				Signature:             make([]byte, 96),
+			}},
+		}
+	})
+	forkParent := saveBlock(epochSlot(startEpoch+2, 5), root, func(b *ethpb.SignedBeaconBlockElectra) {
+		b.Block.Body.ExecutionPayload.Withdrawals = []*enginev1.Withdrawal{
+			{Index: 0, ValidatorIndex: 1, Address: make([]byte, 20), Amount: gwei},
+			{Index: 1, ValidatorIndex: 0, Address: make([]byte, 20), Amount: withdrawn},
+		}
+	})
+	// A withdrawal in a block which is not canonical is not reported.
+	saveBlock(epochSlot(startEpoch+2, 6), root, func(b *ethpb.SignedBeaconBlockElectra) {
+		b.Block.Body.ExecutionPayload.Withdrawals = []*enginev1.Withdrawal{{Index: 1, ValidatorIndex: 0, Address: make([]byte, 20), Amount: gwei}}
+	})
+	headRoot := saveBlock(epochSlot(startEpoch+4, 0), forkParent, nil)
+	headBlock, err := beaconDB.Block(ctx, headRoot)
+	require.NoError(t, err)
+	headHeader, err := headBlock.Header()
+	require.NoError(t, err)
+
+	states := make(map[primitives.Slot]state.BeaconState)
+	var headState state.BeaconState
+	for i, h := range history {
+		numValidators := uint64(4)
+		if i == 0 {
+			numValidators = 3
+		}
+		st, _ := util.DeterministicGenesisStateElectra(t, numValidators)
+		slot, err := slots.EpochStart(startEpoch + primitives.Epoch(i))
+		require.NoError(t, err)
+		require.NoError(t, st.SetSlot(slot))
+		val, err := st.ValidatorAtIndex(0)
+		require.NoError(t, err)
+		val.PrincipalBalance = h.principal
+		require.NoError(t, st.UpdateValidatorAtIndex(0, val))
+		require.NoError(t, st.UpdateBalancesAtIndex(0, h.balance))
+		if i == 1 {
+			require.NoError(t, st.AppendPendingDeposit(&ethpb.PendingDeposit{
+				PublicKey:             pubkey[:],
+				WithdrawalCredentials: make([]byte, 32),
+				Amount:                10 * gwei,
+				Signature:             make([]byte, 96),
+				Slot:                  epochSlot(startEpoch, 2),
+			}))
+		}
+		require.NoError(t, st.SetLatestBlockHeader(headHeader.Header))
+		states[slot] = st
+		headState = st
+	}
+
+	chainService := &chainMock.ChainService{State: headState}
+	s := &Server{
+		Stater:                &testutil.MockStater{StatesBySlot: states},
+		HeadFetcher:           chainService,
+		OptimisticModeFetcher: chainService,
+		FinalizationFetcher:   chainService,
+		BeaconDB:              beaconDB,
+	}
+
+	getHistory := func(t *testing.T, query string, id string) (*httptest.ResponseRecorder, *structs.GetPrincipalHistoryResponse) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/over/v1/validators/{id}/principal_history?"+query, nil)
+		request.SetPathValue("id", id)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.GetPrincipalHistory(writer, request)
+		resp := &structs.GetPrincipalHistoryResponse{}
+		if writer.Code == http.StatusOK {
+			require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		}
+		return writer, resp
+	}
+
+	assertEvents := func(t *testing.T, events []*structs.PrincipalChangeEvent) {
+		require.Equal(t, 2, len(events))
+		assert.Equal(t, "10", events[0].Epoch)
+		assert.Equal(t, strconv.FormatUint(uint64(epochSlot(startEpoch, 2)), 10), events[0].Slot)
+		assert.Equal(t, principalChangeDeposit, events[0].Type)
+		assert.Equal(t, strconv.FormatUint(10*gwei, 10), events[0].Amount)
+		assert.Equal(t, "12", events[1].Epoch)
+		assert.Equal(t, strconv.FormatUint(uint64(epochSlot(startEpoch+2, 5)), 10), events[1].Slot)
+		assert.Equal(t, principalChangeWithdrawal, events[1].Type)
+		assert.Equal(t, strconv.FormatUint(withdrawn, 10), events[1].Amount)
+	}
+
+	t.Run("events and apr", func(t *testing.T) {
+		writer, resp := getHistory(t, "start_epoch=10", "0")
+		assert.Equal(t, http.StatusOK, writer.Code)
+		require.Equal(t, len(history), len(resp.Data.Points))
+		assert.Equal(t, "10", resp.Data.StartEpoch)
+		assert.Equal(t, "14", resp.Data.EndEpoch)
+		for i, p := range resp.Data.Points {
+			assert.Equal(t, strconv.FormatUint(uint64(startEpoch)+uint64(i), 10), p.Epoch)
+			assert.Equal(t, strconv.FormatUint(history[i].principal, 10), p.PrincipalBalance)
+			assert.Equal(t, strconv.FormatUint(history[i].balance, 10), p.Balance)
+		}
+		assertEvents(t, resp.Data.Events)
+
+		// Only the rewards count as earnings, not the deposit nor the withdrawal.
+		assert.Equal(t, strconv.FormatUint(2*gwei, 10), resp.Data.Earnings)
+		principalEpochs := 2*principal + (principal + 10*gwei) + (principal+10*gwei)/2
+		wantApr := float64(2*gwei) * float64(params.BeaconConfig().EpochsPerYear) / float64(principalEpochs)
+		assert.Equal(t, strconv.FormatFloat(wantApr, 'f', 6, 64), resp.Data.RealizedApr)
+	})
+	t.Run("validator added within the range", func(t *testing.T) {
+		writer, resp := getHistory(t, "start_epoch=10&end_epoch=12", "3")
+		assert.Equal(t, http.StatusOK, writer.Code)
+		require.Equal(t, 2, len(resp.Data.Points))
+		assert.Equal(t, "11", resp.Data.Points[0].Epoch)
+		assert.Equal(t, 0, len(resp.Data.Events))
+		assert.Equal(t, "0", resp.Data.Earnings)
+	})
+	t.Run("interval", func(t *testing.T) {
+		writer, resp := getHistory(t, "start_epoch=10&interval=3", "0")
+		assert.Equal(t, http.StatusOK, writer.Code)
+		// The end epoch is always sampled.
+		require.Equal(t, 3, len(resp.Data.Points))
+		assert.Equal(t, "10", resp.Data.Points[0].Epoch)
+		assert.Equal(t, "13", resp.Data.Points[1].Epoch)
+		assert.Equal(t, "14", resp.Data.Points[2].Epoch)
+		// The deposit and the withdrawal within the same interval are both reported, and not counted as earnings.
+		assertEvents(t, resp.Data.Events)
+		assert.Equal(t, strconv.FormatUint(2*gwei, 10), resp.Data.Earnings)
+	})
+	t.Run("bad requests", func(t *testing.T) {
+		writer, _ := getHistory(t, "", "0")
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+		writer, _ = getHistory(t, "start_epoch=12&end_epoch=11", "0")
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+		writer, _ = getHistory(t, "start_epoch=10&end_epoch=15", "0")
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+		writer, _ = getHistory(t, "start_epoch=10&interval=0", "0")
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+	})
+}
@@ -0,0 +1,228 @@
+package over
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/altair"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/epoch/precompute"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/lookup"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/monitoring/tracing/trace"
+	"github.com/prysmaticlabs/prysm/v5/network/httputil"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+)
+
+// proposerReward holds the rewards a validator earned by proposing blocks,
+// and the share of them funded by the reserves.
+type proposerReward struct {
+	reward       uint64
+	reserveUsage uint64
+}
+
+// GetEpochRewardsBreakdown returns the rewards and penalties of the requested validators for the given epoch.
+// Accepts validator IDs as either validator indices or public keys in the `id` query param.
+// If no ID is given, every validator active in the epoch is included.
+//
+// The attestation rewards and the inactivity penalties are the ones applied by the epoch processing
+// at the end of the next epoch, computed with the same precompute steps as the state transition.
+// The head reward is split into the head and the light layer components by their weights.
+// The proposer rewards are the ones earned by including attestations in the canonical blocks of the epoch.
+func (s *Server) GetEpochRewardsBreakdown(w http.ResponseWriter, r *http.Request) {
+	ctx, span := trace.StartSpan(r.Context(), "over.GetEpochRewardsBreakdown")
+	defer span.End()
+
+	rawEpoch := r.PathValue("epoch")
+	requestedEpoch, err := strconv.ParseUint(rawEpoch, 10, 64)
+	if err != nil {
+		httputil.HandleError(w, "Could not parse uint: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	epoch := primitives.Epoch(requestedEpoch)
+	if epoch < params.BeaconConfig().AltairForkEpoch {
+		httputil.HandleError(w, "Epoch rewards are not supported for Phase 0", http.StatusNotFound)
+		return
+	}
+	currentEpoch := slots.ToEpoch(s.GenesisTimeFetcher.CurrentSlot())
+	if epoch+1 >= currentEpoch {
+		httputil.HandleError(w,
+			"Epoch rewards are available after two epoch transitions to ensure all attestations have a chance of inclusion",
+			http.StatusNotFound)
+		return
+	}
+
+	// The attestations of the epoch are rewarded by the epoch processing at the end of the next epoch.
+	nextEpochEnd, err := slots.EpochEnd(epoch + 1)
+	if err != nil {
+		httputil.HandleError(w, "Could not get next epoch's ending slot: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	st, err := s.Stater.StateBySlot(ctx, nextEpochEnd)
+	if err != nil {
+		httputil.WriteError(w, handleWrapError(err, "could not retrieve state", http.StatusNotFound))
+		return
+	}
+
+	// Parse validator ids from URL params
+	rawIds := r.URL.Query()["id"]
+	ids := make([]primitives.ValidatorIndex, 0, len(rawIds))
+	for _, rawId := range rawIds {
+		id, ok := decodeId(w, rawId, st)
+		if !ok {
+			return
+		}
+		ids = append(ids, id)
+	}
+
+	vals, attDeltas, attReserves, err := altair.EpochAttestationsDelta(ctx, st)
+	if err != nil {
+		httputil.WriteError(w, handleWrapError(err, "could not get attestation rewards", http.StatusInternalServerError))
+		return
+	}
+	if len(ids) == 0 {
+		for i, v := range vals {
+			if v.IsActivePrevEpoch {
+				ids = append(ids, primitives.ValidatorIndex(i))
+			}
+		}
+	}
+	proposerRewards, httpErr := s.proposerRewards(ctx, epoch)
+	if httpErr != nil {
+		httputil.WriteError(w, httpErr)
+		return
+	}
+
+	// Get metadata for response
+	isOptimistic, err := s.OptimisticModeFetcher.IsOptimistic(ctx)
+	if err != nil {
+		httputil.WriteError(w, handleWrapError(err, "could not get optimistic mode info", http.StatusInternalServerError))
+		return
+	}
+	blockRoot, err := st.LatestBlockHeader().HashTreeRoot()
+	if err != nil {
+		httputil.WriteError(w, handleWrapError(err, "could not calculate root of latest block header", http.StatusInternalServerError))
+		return
+	}
+	isFinalized := s.FinalizationFetcher.IsFinalized(ctx, blockRoot)
+
+	data := make([]*structs.ValidatorEpochRewards, len(ids))
+	for i, id := range ids {
+		data[i] = validatorEpochRewards(id, vals[id], attDeltas[id], attReserves[id], proposerRewards[id])
+	}
+
+	httputil.WriteJson(w, &structs.GetEpochRewardsBreakdownResponse{
+		Data:                data,
+		ExecutionOptimistic: isOptimistic,
+		Finalized:           isFinalized,
+	})
+}
+
+// proposerRewards returns the rewards of the proposers of the canonical blocks in the given epoch.
+// Each block is processed on top of its pre-state with the same attestation processing as the state transition,
+// and the rewards are taken from the balance change of the proposer and the reserve usage.
+func (s *Server) proposerRewards(ctx context.Context, epoch primitives.Epoch) (map[primitives.ValidatorIndex]*proposerReward, *httputil.DefaultJsonError) {
+	startSlot, err := slots.EpochStart(epoch)
+	if err != nil {
+		return nil, handleWrapError(err, "could not get epoch's starting slot", http.StatusInternalServerError)
+	}
+	endSlot, err := slots.EpochEnd(epoch)
+	if err != nil {
+		return nil, handleWrapError(err, "could not get epoch's ending slot", http.StatusInternalServerError)
+	}
+
+	rewards := make(map[primitives.ValidatorIndex]*proposerReward)
+	for slot := max(startSlot, 1); slot <= endSlot; slot++ {
+		blk, err := s.Blocker.Block(ctx, []byte(strconv.FormatUint(uint64(slot), 10)))
+		var prunedErr *lookup.BlockPrunedError
+		if errors.As(err, &prunedErr) {
+			return nil, handleWrapError(err, "could not find the blocks of the epoch", http.StatusNotFound)
+		}
+		if err != nil {
+			return nil, handleWrapError(err, fmt.Sprintf("could not get block at slot %d", slot), http.StatusInternalServerError)
+		}
+		if blk == nil || blk.IsNil() {
+			continue
+		}
+		st, httpErr := s.BlockRewardFetcher.GetStateForRewards(ctx, blk.Block())
+		if httpErr != nil {
+			return nil, httpErr
+		}
+		proposerIndex := blk.Block().ProposerIndex()
+		initBalance, err := st.BalanceAtIndex(proposerIndex)
+		if err != nil {
+			return nil, handleWrapError(err, "could not get proposer's balance", http.StatusInternalServerError)
+		}
+		initReserves := st.Reserves()
+		st, err = altair.ProcessAttestationsNoVerifySignature(ctx, st, blk.Block())
+		if err != nil {
+			return nil, handleWrapError(err, "could not process attestations", http.StatusInternalServerError)
+		}
+		balance, err := st.BalanceAtIndex(proposerIndex)
+		if err != nil {
+			return nil, handleWrapError(err, "could not get proposer's balance", http.StatusInternalServerError)
+		}
+		if balance < initBalance || st.Reserves() > initReserves {
+			return nil, handleWrapError(errors.New("unexpected balance change"), fmt.Sprintf("could not get proposer reward at slot %d", slot), http.StatusInternalServerError)
+		}
+
+		reward, ok := rewards[proposerIndex]
+		if !ok {
+			reward = &proposerReward{}
+			rewards[proposerIndex] = reward
+		}
+		reward.reward += balance - initBalance
+		reward.reserveUsage += initReserves - st.Reserves()
+	}
+	return rewards, nil
+}
+
+// validatorEpochRewards builds the reward breakdown of a validator.
+// The light layer reward is paid together with the head reward, so it is split out by the weights.
+func validatorEpochRewards(
+	idx primitives.ValidatorIndex,
+	val *precompute.Validator,
+	attDelta *altair.AttDelta,
+	attReserveUsage uint64,
+	proposer *proposerReward,
+) *structs.ValidatorEpochRewards {
+	cfg := params.BeaconConfig()
+	lightLayer := attDelta.HeadReward * cfg.LightLayerWeight / (cfg.TimelyHeadWeight + cfg.LightLayerWeight)
+	head := attDelta.HeadReward - lightLayer
+
+	rewards := attDelta.SourceReward + attDelta.TargetReward + attDelta.HeadReward
+	reserveUsage := attReserveUsage
+	var proposerRewards uint64
+	if proposer != nil {
+		proposerRewards = proposer.reward
+		rewards += proposer.reward
+		reserveUsage += proposer.reserveUsage
+	}
+	// The reserve usage is rounded up, so it can exceed the rewards of a validator.
+	reserveUsage = min(reserveUsage, rewards)
+	penalties := attDelta.SourcePenalty + attDelta.TargetPenalty
+
+	var total string
+	if penalties > rewards {
+		total = "-" + strconv.FormatUint(penalties-rewards, 10)
+	} else {
+		total = strconv.FormatUint(rewards-penalties, 10)
+	}
+	return &structs.ValidatorEpochRewards{
+		ValidatorIndex:    strconv.FormatUint(uint64(idx), 10),
+		Source:            strconv.FormatUint(attDelta.SourceReward, 10),
+		Target:            strconv.FormatUint(attDelta.TargetReward, 10),
+		Head:              strconv.FormatUint(head, 10),
+		LightLayer:        strconv.FormatUint(lightLayer, 10),
+		Proposer:          strconv.FormatUint(proposerRewards, 10),
+		ReserveShare:      strconv.FormatUint(reserveUsage, 10),
+		IssuanceShare:     strconv.FormatUint(rewards-reserveUsage, 10),
+		InactivityPenalty: strconv.FormatUint(penalties, 10),
+		InactivityScore:   strconv.FormatUint(val.InactivityScore, 10),
+		Total:             total,
+	}
+}
@@ -0,0 +1,172 @@
+package over
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	chainMock "github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/altair"
+	rewardtesting "github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/eth/rewards/testing"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/lookup"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/testutil"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+)
+
+func TestGetEpochRewardsBreakdown(t *testing.T) {
+	cfg := params.BeaconConfig()
+	requestedEpoch := primitives.Epoch(5)
+	st, _ := util.DeterministicGenesisStateElectra(t, 4)
+	nextEpochEnd, err := slots.EpochEnd(requestedEpoch + 1)
+	require.NoError(t, err)
+	require.NoError(t, st.SetSlot(nextEpochEnd))
+	require.NoError(t, st.SetFinalizedCheckpoint(&ethpb.Checkpoint{Epoch: requestedEpoch - 1, Root: make([]byte, 32)}))
+
+	// Validator 0 is a perfect attester, validator 1 has been offline for a while,
+	// validator 2 missed the head vote and validator 3 missed every vote.
+	allFlags := uint8(0)
+	for _, flag := range []uint8{cfg.TimelySourceFlagIndex, cfg.TimelyTargetFlagIndex, cfg.TimelyHeadFlagIndex} {
+		allFlags, err = altair.AddValidatorFlag(allFlags, flag)
+		require.NoError(t, err)
+	}
+	sourceAndTarget, err := altair.AddValidatorFlag(0, cfg.TimelySourceFlagIndex)
+	require.NoError(t, err)
+	sourceAndTarget, err = altair.AddValidatorFlag(sourceAndTarget, cfg.TimelyTargetFlagIndex)
+	require.NoError(t, err)
+	require.NoError(t, st.SetPreviousParticipationBits([]byte{allFlags, 0, sourceAndTarget, 0}))
+	require.NoError(t, st.SetInactivityScores([]uint64{0, cfg.InactivityScorePenaltyThreshold + 100, 0, 0}))
+
+	// The canonical block of the epoch without attestations earns its proposer nothing.
+	blockSlot, err := slots.EpochStart(requestedEpoch)
+	require.NoError(t, err)
+	b := util.NewBeaconBlockElectra()
+	b.Block.Slot = blockSlot
+	b.Block.ProposerIndex = 3
+	blk, err := blocks.NewSignedBeaconBlock(b)
+	require.NoError(t, err)
+	blockPreState := st.Copy()
+	require.NoError(t, blockPreState.SetSlot(blockSlot))
+
+	currentSlot, err := slots.EpochStart(requestedEpoch + 3)
+	require.NoError(t, err)
+	chainService := &chainMock.ChainService{Slot: &currentSlot}
+	s := &Server{
+		Blocker: &testutil.MockBlocker{SlotBlockMap: map[primitives.Slot]interfaces.ReadOnlySignedBeaconBlock{
+			blockSlot: blk,
+		}},
+		BlockRewardFetcher:    &rewardtesting.MockBlockRewardFetcher{State: blockPreState},
+		Stater:                &testutil.MockStater{StatesBySlot: map[primitives.Slot]state.BeaconState{nextEpochEnd: st}},
+		GenesisTimeFetcher:    chainService,
+		OptimisticModeFetcher: chainService,
+		FinalizationFetcher:   chainService,
+	}
+
+	_, wantDeltas, _, err := altair.EpochAttestationsDelta(context.Background(), st)
+	require.NoError(t, err)
+
+	t.Run("all active validators", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/over/v1/beacon/rewards/epochs/{epoch}", nil)
+		request.SetPathValue("epoch", strconv.FormatUint(uint64(requestedEpoch), 10))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetEpochRewardsBreakdown(writer, request)
+		assert.Equal(t, http.StatusOK, writer.Code)
+		resp := &structs.GetEpochRewardsBreakdownResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.Equal(t, 4, len(resp.Data))
+
+		for i, d := range resp.Data {
+			assert.Equal(t, strconv.Itoa(i), d.ValidatorIndex)
+			assert.Equal(t, strconv.FormatUint(wantDeltas[i].SourceReward, 10), d.Source)
+			assert.Equal(t, strconv.FormatUint(wantDeltas[i].TargetReward, 10), d.Target)
+			assert.Equal(t, "0", d.Proposer)
+
+			head, err := strconv.ParseUint(d.Head, 10, 64)
+			require.NoError(t, err)
+			lightLayer, err := strconv.ParseUint(d.LightLayer, 10, 64)
+			require.NoError(t, err)
+			assert.Equal(t, wantDeltas[i].HeadReward, head+lightLayer)
+
+			reserveShare, err := strconv.ParseUint(d.ReserveShare, 10, 64)
+			require.NoError(t, err)
+			issuanceShare, err := strconv.ParseUint(d.IssuanceShare, 10, 64)
+			require.NoError(t, err)
+			assert.Equal(t, wantDeltas[i].SourceReward+wantDeltas[i].TargetReward+wantDeltas[i].HeadReward, reserveShare+issuanceShare)
+		}
+
+		perfect := resp.Data[0]
+		assert.NotEqual(t, "0", perfect.Head)
+		assert.NotEqual(t, "0", perfect.LightLayer)
+		assert.Equal(t, "0", perfect.InactivityPenalty)
+
+		offline := resp.Data[1]
+		penalty := wantDeltas[1].SourcePenalty + wantDeltas[1].TargetPenalty
+		require.NotEqual(t, uint64(0), penalty)
+		assert.Equal(t, strconv.FormatUint(penalty, 10), offline.InactivityPenalty)
+		assert.Equal(t, "-"+strconv.FormatUint(penalty, 10), offline.Total)
+		// The inactivity score is the one after the inactivity updates of the epoch processing.
+		assert.Equal(t, strconv.FormatUint(cfg.InactivityScorePenaltyThreshold+100+cfg.InactivityScoreBias, 10), offline.InactivityScore)
+
+		noHead := resp.Data[2]
+		assert.Equal(t, "0", noHead.Head)
+		assert.Equal(t, "0", noHead.LightLayer)
+	})
+	t.Run("requested validator", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/over/v1/beacon/rewards/epochs/{epoch}?id=2", nil)
+		request.SetPathValue("epoch", strconv.FormatUint(uint64(requestedEpoch), 10))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetEpochRewardsBreakdown(writer, request)
+		assert.Equal(t, http.StatusOK, writer.Code)
+		resp := &structs.GetEpochRewardsBreakdownResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.Equal(t, 1, len(resp.Data))
+		assert.Equal(t, "2", resp.Data[0].ValidatorIndex)
+	})
+	t.Run("epoch not yet rewarded", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/over/v1/beacon/rewards/epochs/{epoch}", nil)
+		request.SetPathValue("epoch", strconv.FormatUint(uint64(requestedEpoch+2), 10))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetEpochRewardsBreakdown(writer, request)
+		assert.Equal(t, http.StatusNotFound, writer.Code)
+	})
+	t.Run("pruned blocks", func(t *testing.T) {
+		pruned := lookup.NewBlockPrunedError(blockSlot, blockSlot+params.BeaconConfig().SlotsPerEpoch)
+		ps := *s
+		ps.Blocker = &testutil.MockBlocker{ErrorToReturn: &pruned}
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/over/v1/beacon/rewards/epochs/{epoch}", nil)
+		request.SetPathValue("epoch", strconv.FormatUint(uint64(requestedEpoch), 10))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		ps.GetEpochRewardsBreakdown(writer, request)
+		assert.Equal(t, http.StatusNotFound, writer.Code)
+	})
+	t.Run("invalid epoch", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/over/v1/beacon/rewards/epochs/{epoch}", nil)
+		request.SetPathValue("epoch", "foo")
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetEpochRewardsBreakdown(writer, request)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+	})
+}
@@ -0,0 +1,72 @@
+package over
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/slasher"
+	"github.com/prysmaticlabs/prysm/v5/monitoring/tracing/trace"
+	"github.com/prysmaticlabs/prysm/v5/network/httputil"
+)
+
+// GetSlasherDatabase reports the size of the slasher database and of its buckets, the epochs covered by its
+// attestations, proposals and spans, and which validator chunks hold span chunks. The database is in use by the
+// slasher, so only the bounds of its records, the page headers of its buckets and the keys of its span chunks are
+// read. The size of the slasher chunks bucket is left out, and is reported offline by the prysmctl db slasher-stats
+// command along with the epochs written.
+func (s *Server) GetSlasherDatabase(w http.ResponseWriter, r *http.Request) {
+	ctx, span := trace.StartSpan(r.Context(), "over.GetSlasherDatabase")
+	defer span.End()
+
+	if s.SlasherDB == nil {
+		httputil.HandleError(w, "Slasher is not enabled", http.StatusNotFound)
+		return
+	}
+	report, err := slasher.SummarizeDatabase(ctx, s.SlasherDB, slasher.DefaultParams())
+	if err != nil {
+		httputil.HandleError(w, "Could not report slasher database: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	data := &structs.SlasherDatabase{
+		FileSize:           strconv.FormatInt(report.FileSize, 10),
+		AttestedValidators: strconv.FormatUint(report.AttestedValidators, 10),
+	}
+	for _, b := range report.Buckets {
+		data.Buckets = append(data.Buckets, &structs.SlasherBucket{
+			Name:           b.Name,
+			Entries:        strconv.FormatUint(b.Entries, 10),
+			InUseBytes:     strconv.FormatUint(b.InUseBytes, 10),
+			AllocatedBytes: strconv.FormatUint(b.AllocatedBytes, 10),
+		})
+	}
+	if report.HasAttestations {
+		data.LowestTargetEpoch = fmt.Sprintf("%d", report.LowestTargetEpoch)
+		data.HighestTargetEpoch = fmt.Sprintf("%d", report.HighestTargetEpoch)
+	}
+	if report.HasProposals {
+		data.LowestProposalSlot = fmt.Sprintf("%d", report.LowestProposalSlot)
+		data.HighestProposalSlot = fmt.Sprintf("%d", report.HighestProposalSlot)
+	}
+	if report.HasSpans {
+		data.LowestSpanEpoch = fmt.Sprintf("%d", report.LowestSpanEpoch)
+		data.HighestSpanEpoch = fmt.Sprintf("%d", report.HighestSpanEpoch)
+	}
+	for _, c := range report.Coverage {
+		missing := make([]string, len(c.MissingValidatorChunks))
+		for i, m := range c.MissingValidatorChunks {
+			missing[i] = strconv.FormatUint(m, 10)
+		}
+		data.ChunkCoverage = append(data.ChunkCoverage, &structs.SlasherChunkCoverage{
+			Kind:                    c.Kind.String(),
+			Chunks:                  strconv.FormatUint(c.Chunks, 10),
+			ValidatorChunks:         strconv.FormatUint(c.ValidatorChunks, 10),
+			CompleteValidatorChunks: strconv.FormatUint(c.CompleteValidatorChunks, 10),
+			MissingValidatorChunks:  missing,
+			HighestValidatorIndex:   fmt.Sprintf("%d", c.HighestValidatorIndex),
+		})
+	}
+	httputil.WriteJson(w, &structs.GetSlasherDatabaseResponse{Data: data})
+}
@@ -0,0 +1,73 @@
+package over
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	dbTest "github.com/prysmaticlabs/prysm/v5/beacon-chain/db/testing"
+	slashertypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/slasher/types"
+	"github.com/prysmaticlabs/prysm/v5/network/httputil"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestGetSlasherDatabase(t *testing.T) {
+	t.Run("slasher disabled", func(t *testing.T) {
+		s := &Server{}
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/over/v1/slasher/database", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetSlasherDatabase(writer, request)
+		assert.Equal(t, http.StatusNotFound, writer.Code)
+		e := &httputil.DefaultJsonError{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+		assert.StringContains(t, "Slasher is not enabled", e.Message)
+	})
+	t.Run("reports epoch ranges", func(t *testing.T) {
+		slasherDB := dbTest.SetupSlasherDB(t)
+		require.NoError(t, slasherDB.SaveAttestationRecordsForValidators(context.Background(), []*slashertypes.IndexedAttestationWrapper{
+			{
+				IndexedAttestation: &ethpb.IndexedAttestation{
+					AttestingIndices: []uint64{3},
+					Data: &ethpb.AttestationData{
+						BeaconBlockRoot: make([]byte, 32),
+						Source:          &ethpb.Checkpoint{Epoch: 4999, Root: make([]byte, 32)},
+						Target:          &ethpb.Checkpoint{Epoch: 5000, Root: make([]byte, 32)},
+					},
+					Signature: make([]byte, 96),
+				},
+				DataRoot: [32]byte{1},
+			},
+		}))
+		s := &Server{SlasherDB: slasherDB}
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/over/v1/slasher/database", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetSlasherDatabase(writer, request)
+		assert.Equal(t, http.StatusOK, writer.Code)
+		resp := &structs.GetSlasherDatabaseResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		assert.Equal(t, "5000", resp.Data.LowestTargetEpoch)
+		assert.Equal(t, "5000", resp.Data.HighestTargetEpoch)
+		// The default history is 4096 epochs.
+		assert.Equal(t, "905", resp.Data.LowestSpanEpoch)
+		assert.Equal(t, "5000", resp.Data.HighestSpanEpoch)
+		assert.Equal(t, "", resp.Data.LowestProposalSlot)
+		assert.NotEqual(t, 0, len(resp.Data.Buckets))
+		for _, b := range resp.Data.Buckets {
+			assert.NotEqual(t, "slasher-chunks", b.Name)
+		}
+		assert.Equal(t, "0", resp.Data.AttestedValidators)
+		require.Equal(t, 2, len(resp.Data.ChunkCoverage))
+		assert.Equal(t, "minspan", resp.Data.ChunkCoverage[0].Kind)
+		assert.Equal(t, "0", resp.Data.ChunkCoverage[0].Chunks)
+	})
+}
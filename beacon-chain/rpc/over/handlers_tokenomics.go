@@ -0,0 +1,320 @@
+package over
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/time"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/monitoring/tracing/trace"
+	"github.com/prysmaticlabs/prysm/v5/network/httputil"
+)
+
+const (
+	// maxProjectionYears is the maximum horizon that can be requested for a tokenomics projection.
+	maxProjectionYears = 20
+	// defaultProjectionPoints is the number of points returned when no interval is given.
+	defaultProjectionPoints = 100
+	// maxProjectionPoints is the maximum number of points that can be included in a response.
+	maxProjectionPoints = 10000
+	// maxHistoryEpochs is the maximum number of epochs that can be requested for the tokenomics history.
+	maxHistoryEpochs = 10000
+
+	// depositModelLinear changes the total deposit by deposit_growth every epoch.
+	depositModelLinear = "linear"
+	// depositModelRestake behaves like depositModelLinear, but also restakes every epoch reward.
+	depositModelRestake = "restake"
+	// depositModelTarget makes the total deposit follow the target deposit plan.
+	depositModelTarget = "target"
+)
+
+// tokenomicsProjectionConfig holds the assumptions used to project tokenomics forward.
+type tokenomicsProjectionConfig struct {
+	startEpoch    primitives.Epoch
+	epochs        uint64
+	interval      uint64
+	depositModel  string
+	depositGrowth int64
+}
+
+// tokenomicsProjectionStart holds the tokenomics values of the state the projection starts from.
+type tokenomicsProjectionStart struct {
+	rewardAdjustmentFactor uint64
+	reserves               uint64
+	deposit                uint64
+}
+
+// GetTokenomicsProjection simulates the reward adjustment factor, reserves and issuance forward
+// from the requested state. The projection assumes full participation, so every epoch reward is paid out.
+// The same helpers used by the state transition are used for each epoch, so the projection
+// follows the consensus rules exactly for the given deposit assumption.
+func (s *Server) GetTokenomicsProjection(w http.ResponseWriter, r *http.Request) {
+	ctx, span := trace.StartSpan(r.Context(), "over.GetTokenomicsProjection")
+	defer span.End()
+
+	query := r.URL.Query()
+
+	// Parse state_id from URL params and replay to the state
+	stateId := query.Get("state_id")
+	if stateId == "" {
+		stateId = "head"
+	}
+	st, err := s.Stater.State(ctx, []byte(stateId))
+	if err != nil {
+		httputil.WriteError(w, handleWrapError(err, "could not retrieve state", http.StatusNotFound))
+		return
+	}
+
+	cfg := &tokenomicsProjectionConfig{
+		startEpoch:   time.CurrentEpoch(st),
+		depositModel: depositModelLinear,
+	}
+
+	// Parse horizon from URL params
+	rawEpochs, rawYears := query.Get("epochs"), query.Get("years")
+	maxEpochs := maxProjectionYears * params.BeaconConfig().EpochsPerYear
+	switch {
+	case rawEpochs != "" && rawYears != "":
+		httputil.HandleError(w, "Only one of epochs and years can be specified", http.StatusBadRequest)
+		return
+	case rawEpochs != "":
+		cfg.epochs, err = strconv.ParseUint(rawEpochs, 10, 64)
+		if err != nil {
+			httputil.HandleError(w, "epochs must be a number", http.StatusBadRequest)
+			return
+		}
+	case rawYears != "":
+		years, err := strconv.ParseUint(rawYears, 10, 64)
+		if err != nil {
+			httputil.HandleError(w, "years must be a number", http.StatusBadRequest)
+			return
+		}
+		if years > maxProjectionYears {
+			httputil.HandleError(w, fmt.Sprintf("years must be at most %d", maxProjectionYears), http.StatusBadRequest)
+			return
+		}
+		cfg.epochs = years * params.BeaconConfig().EpochsPerYear
+	default:
+		httputil.HandleError(w, "epochs or years is required in query params", http.StatusBadRequest)
+		return
+	}
+	if cfg.epochs == 0 || cfg.epochs > maxEpochs {
+		httputil.HandleError(w, fmt.Sprintf("Projection horizon must be between 1 and %d epochs", maxEpochs), http.StatusBadRequest)
+		return
+	}
+
+	// Parse interval from URL params
+	cfg.interval = (cfg.epochs + defaultProjectionPoints - 1) / defaultProjectionPoints
+	if rawInterval := query.Get("interval"); rawInterval != "" {
+		cfg.interval, err = strconv.ParseUint(rawInterval, 10, 64)
+		if err != nil || cfg.interval == 0 {
+			httputil.HandleError(w, "interval must be a positive number", http.StatusBadRequest)
+			return
+		}
+		if cfg.epochs/cfg.interval > maxProjectionPoints {
+			httputil.HandleError(w, fmt.Sprintf("interval is too small, the projection can include at most %d points", maxProjectionPoints), http.StatusBadRequest)
+			return
+		}
+	}
+
+	// Parse deposit assumptions from URL params
+	if rawModel := query.Get("deposit_model"); rawModel != "" {
+		if rawModel != depositModelLinear && rawModel != depositModelRestake && rawModel != depositModelTarget {
+			httputil.HandleError(w, fmt.Sprintf("deposit_model must be one of %s, %s or %s", depositModelLinear, depositModelRestake, depositModelTarget), http.StatusBadRequest)
+			return
+		}
+		cfg.depositModel = rawModel
+	}
+	if rawGrowth := query.Get("deposit_growth"); rawGrowth != "" {
+		cfg.depositGrowth, err = strconv.ParseInt(rawGrowth, 10, 64)
+		if err != nil {
+			httputil.HandleError(w, "deposit_growth must be a number", http.StatusBadRequest)
+			return
+		}
+	}
+
+	// Get metadata for response
+	isOptimistic, err := s.OptimisticModeFetcher.IsOptimistic(r.Context())
+	if err != nil {
+		httputil.WriteError(w, handleWrapError(err, "could not get optimistic mode info", http.StatusInternalServerError))
+		return
+	}
+	blockRoot, err := st.LatestBlockHeader().HashTreeRoot()
+	if err != nil {
+		httputil.WriteError(w, handleWrapError(err, "could not calculate root of latest block header", http.StatusInternalServerError))
+		return
+	}
+	isFinalized := s.FinalizationFetcher.IsFinalized(ctx, blockRoot)
+
+	deposit, err := helpers.TotalBalanceWithQueue(st)
+	if err != nil {
+		httputil.WriteError(w, handleWrapError(err, "could not get total balance with queue", http.StatusInternalServerError))
+		return
+	}
+	start := &tokenomicsProjectionStart{
+		rewardAdjustmentFactor: st.RewardAdjustmentFactor(),
+		reserves:               st.Reserves(),
+		deposit:                deposit,
+	}
+
+	httputil.WriteJson(w, &structs.GetTokenomicsProjectionResponse{
+		ExecutionOptimistic: isOptimistic,
+		Finalized:           isFinalized,
+		Data:                projectTokenomics(start, cfg),
+	})
+}
+
+// projectTokenomics runs the tokenomics simulation epoch by epoch.
+// For every epoch, it follows the order of the epoch processing:
+// rewards are paid with the issuance and the feedback boost from the reserves,
+// then the reward adjustment factor is updated for the next epoch.
+func projectTokenomics(start *tokenomicsProjectionStart, cfg *tokenomicsProjectionConfig) *structs.TokenomicsProjection {
+	factor := start.rewardAdjustmentFactor
+	reserves := start.reserves
+	deposit := start.deposit
+	totalIssuance := uint64(0)
+	totalReserveUsage := uint64(0)
+
+	projection := &structs.TokenomicsProjection{
+		StartEpoch:    strconv.FormatUint(uint64(cfg.startEpoch), 10),
+		EndEpoch:      strconv.FormatUint(uint64(cfg.startEpoch)+cfg.epochs, 10),
+		DepositModel:  cfg.depositModel,
+		DepositGrowth: strconv.FormatInt(cfg.depositGrowth, 10),
+		Points:        make([]*structs.TokenomicsProjectionPoint, 0, cfg.epochs/cfg.interval+1),
+	}
+
+	for i := uint64(0); i < cfg.epochs; i++ {
+		epoch := cfg.startEpoch + primitives.Epoch(i)
+		factorAtEpoch, reservesAtEpoch, depositAtEpoch := factor, reserves, deposit
+
+		issuance := helpers.EpochIssuance(epoch)
+		boost := helpers.FeedbackBoost(factor, reserves)
+		reserves -= boost
+		totalIssuance += issuance
+		totalReserveUsage += boost
+		if reservesAtEpoch > 0 && reserves == 0 && projection.ReservesDepletionEpoch == "" {
+			projection.ReservesDepletionEpoch = strconv.FormatUint(uint64(epoch), 10)
+		}
+
+		nextTarget := helpers.TargetDepositPlan(epoch + 1)
+		deposit = nextProjectedDeposit(deposit, issuance+boost, nextTarget, cfg)
+		factor = helpers.NextRewardAdjustmentFactor(factor, deposit, nextTarget, epoch)
+
+		if i%cfg.interval == 0 || i == cfg.epochs-1 {
+			projection.Points = append(projection.Points, &structs.TokenomicsProjectionPoint{
+				Epoch:                  strconv.FormatUint(uint64(epoch), 10),
+				Year:                   strconv.Itoa(helpers.EpochToYear(epoch)),
+				RewardAdjustmentFactor: strconv.FormatUint(factorAtEpoch, 10),
+				Reserves:               strconv.FormatUint(reservesAtEpoch, 10),
+				Deposit:                strconv.FormatUint(depositAtEpoch, 10),
+				TargetDeposit:          strconv.FormatUint(helpers.TargetDepositPlan(epoch), 10),
+				EpochIssuance:          strconv.FormatUint(issuance, 10),
+				FeedbackBoost:          strconv.FormatUint(boost, 10),
+				TotalIssuance:          strconv.FormatUint(totalIssuance, 10),
+				TotalReserveUsage:      strconv.FormatUint(totalReserveUsage, 10),
+			})
+		}
+	}
+
+	projection.TotalIssuance = strconv.FormatUint(totalIssuance, 10)
+	projection.TotalReserveUsage = strconv.FormatUint(totalReserveUsage, 10)
+	projection.FinalReserves = strconv.FormatUint(reserves, 10)
+	projection.FinalRewardAdjustmentFactor = strconv.FormatUint(factor, 10)
+	return projection
+}
+
+// nextProjectedDeposit returns the total deposit for the next epoch under the configured deposit model.
+func nextProjectedDeposit(deposit, reward, target uint64, cfg *tokenomicsProjectionConfig) uint64 {
+	if cfg.depositModel == depositModelTarget {
+		return target
+	}
+	if cfg.depositModel == depositModelRestake {
+		deposit += reward
+	}
+	if cfg.depositGrowth >= 0 {
+		return deposit + uint64(cfg.depositGrowth)
+	}
+	// Like TotalBalanceWithQueue, the deposit never goes below `EffectiveBalanceIncrement`.
+	decrease := uint64(-cfg.depositGrowth)
+	minDeposit := params.BeaconConfig().EffectiveBalanceIncrement
+	if deposit < decrease+minDeposit {
+		return minDeposit
+	}
+	return deposit - decrease
+}
+
+// GetTokenomicsHistory returns the reserves, the reward adjustment factor and the related values
+// of the finalized epochs within [start_epoch, end_epoch], as stored by the tokenomics indexer.
+// When end_epoch is not given, the last indexed epoch is used.
+func (s *Server) GetTokenomicsHistory(w http.ResponseWriter, r *http.Request) {
+	ctx, span := trace.StartSpan(r.Context(), "over.GetTokenomicsHistory")
+	defer span.End()
+
+	query := r.URL.Query()
+
+	last, err := s.BeaconDB.LastTokenomicsSnapshot(ctx)
+	if err != nil {
+		httputil.WriteError(w, handleWrapError(err, "could not get last tokenomics snapshot", http.StatusInternalServerError))
+		return
+	}
+	if last == nil {
+		httputil.HandleError(w, "Tokenomics history is not indexed. Enable the indexer with --enable-tokenomics-indexer", http.StatusNotFound)
+		return
+	}
+
+	// Parse epoch range from URL params
+	rawStart := query.Get("start_epoch")
+	if rawStart == "" {
+		httputil.HandleError(w, "start_epoch is required in query params", http.StatusBadRequest)
+		return
+	}
+	start, err := strconv.ParseUint(rawStart, 10, 64)
+	if err != nil {
+		httputil.HandleError(w, "start_epoch must be a number", http.StatusBadRequest)
+		return
+	}
+	end := uint64(last.Epoch)
+	if rawEnd := query.Get("end_epoch"); rawEnd != "" {
+		end, err = strconv.ParseUint(rawEnd, 10, 64)
+		if err != nil {
+			httputil.HandleError(w, "end_epoch must be a number", http.StatusBadRequest)
+			return
+		}
+	}
+	if start > end {
+		httputil.HandleError(w, "start_epoch must not be greater than end_epoch", http.StatusBadRequest)
+		return
+	}
+	if end-start >= maxHistoryEpochs {
+		httputil.HandleError(w, fmt.Sprintf("At most %d epochs can be requested at once", maxHistoryEpochs), http.StatusBadRequest)
+		return
+	}
+
+	snapshots, err := s.BeaconDB.TokenomicsSnapshots(ctx, primitives.Epoch(start), primitives.Epoch(end))
+	if err != nil {
+		httputil.WriteError(w, handleWrapError(err, "could not get tokenomics snapshots", http.StatusInternalServerError))
+		return
+	}
+	data := make([]*structs.TokenomicsSnapshot, len(snapshots))
+	for i, snapshot := range snapshots {
+		data[i] = &structs.TokenomicsSnapshot{
+			Epoch:                  strconv.FormatUint(uint64(snapshot.Epoch), 10),
+			BlockRoot:              hexutil.Encode(snapshot.BlockRoot[:]),
+			Reserves:               strconv.FormatUint(snapshot.Reserves, 10),
+			RewardAdjustmentFactor: strconv.FormatUint(snapshot.RewardAdjustmentFactor, 10),
+			FeedbackBoost:          strconv.FormatUint(snapshot.FeedbackBoost, 10),
+			EpochIssuance:          strconv.FormatUint(snapshot.EpochIssuance, 10),
+			TotalActiveBalance:     strconv.FormatUint(snapshot.TotalActiveBalance, 10),
+		}
+	}
+
+	httputil.WriteJson(w, &structs.GetTokenomicsHistoryResponse{
+		Data:             data,
+		LastIndexedEpoch: strconv.FormatUint(uint64(last.Epoch), 10),
+	})
+}
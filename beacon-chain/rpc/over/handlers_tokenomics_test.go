@@ -0,0 +1,247 @@
+package over
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	chainMock "github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
+	dbTest "github.com/prysmaticlabs/prysm/v5/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/testutil"
+	tokenomicstypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/tokenomics/types"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/network/httputil"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+)
+
+func TestGetTokenomicsProjection(t *testing.T) {
+	st, _ := util.DeterministicGenesisStateElectra(t, 64)
+	currentEpoch := primitives.Epoch(100)
+	currentSlot, err := slots.EpochStart(currentEpoch)
+	require.NoError(t, err)
+	require.NoError(t, st.SetSlot(currentSlot))
+	require.NoError(t, st.SetRewardAdjustmentFactor(200000))
+	require.NoError(t, st.SetReserves(1000000000000))
+
+	chainService := &chainMock.ChainService{Optimistic: true}
+	s := &Server{
+		Stater:                &testutil.MockStater{BeaconState: st},
+		OptimisticModeFetcher: chainService,
+		FinalizationFetcher:   chainService,
+	}
+
+	t.Run("project epochs with real helpers", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/over/v1/tokenomics/projection?epochs=10", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetTokenomicsProjection(writer, request)
+		assert.Equal(t, http.StatusOK, writer.Code)
+		resp := &structs.GetTokenomicsProjectionResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		assert.Equal(t, true, resp.ExecutionOptimistic)
+		assert.Equal(t, "100", resp.Data.StartEpoch)
+		assert.Equal(t, "110", resp.Data.EndEpoch)
+		require.Equal(t, 10, len(resp.Data.Points))
+
+		// The deposit of the small state is far below the target, so the factor increases every epoch.
+		factor := st.RewardAdjustmentFactor()
+		reserves := st.Reserves()
+		totalIssuance := uint64(0)
+		for i, p := range resp.Data.Points {
+			epoch := currentEpoch + primitives.Epoch(i)
+			totalIssuance += helpers.EpochIssuance(epoch)
+			boost := helpers.FeedbackBoost(factor, reserves)
+			assert.Equal(t, strconv.FormatUint(uint64(epoch), 10), p.Epoch)
+			assert.Equal(t, strconv.FormatUint(factor, 10), p.RewardAdjustmentFactor)
+			assert.Equal(t, strconv.FormatUint(reserves, 10), p.Reserves)
+			assert.Equal(t, strconv.FormatUint(boost, 10), p.FeedbackBoost)
+			assert.Equal(t, strconv.FormatUint(totalIssuance, 10), p.TotalIssuance)
+			reserves -= boost
+			factor = helpers.IncreasedRewardAdjustmentFactor(factor, epoch)
+		}
+		assert.Equal(t, strconv.FormatUint(reserves, 10), resp.Data.FinalReserves)
+		assert.Equal(t, strconv.FormatUint(factor, 10), resp.Data.FinalRewardAdjustmentFactor)
+	})
+
+	t.Run("project years with interval", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/over/v1/tokenomics/projection?state_id=head&years=1&interval=8212", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetTokenomicsProjection(writer, request)
+		assert.Equal(t, http.StatusOK, writer.Code)
+		resp := &structs.GetTokenomicsProjectionResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		// 82125 epochs sampled every 8212 epochs, plus the last epoch.
+		assert.Equal(t, 12, len(resp.Data.Points))
+		assert.Equal(t, strconv.FormatUint(uint64(currentEpoch)+params.BeaconConfig().EpochsPerYear, 10), resp.Data.EndEpoch)
+	})
+
+	badRequests := []struct {
+		name    string
+		query   string
+		wantErr string
+	}{
+		{name: "no horizon", query: "", wantErr: "epochs or years is required"},
+		{name: "both horizons", query: "?epochs=1&years=1", wantErr: "Only one of epochs and years"},
+		{name: "too many years", query: "?years=21", wantErr: "years must be at most 20"},
+		{name: "zero epochs", query: "?epochs=0", wantErr: "Projection horizon must be between"},
+		{name: "too small interval", query: "?epochs=20000&interval=1", wantErr: "interval is too small"},
+		{name: "unknown deposit model", query: "?epochs=1&deposit_model=foo", wantErr: "deposit_model must be one of"},
+		{name: "invalid deposit growth", query: "?epochs=1&deposit_growth=foo", wantErr: "deposit_growth must be a number"},
+	}
+	for _, tt := range badRequests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodGet, "http://example.com/over/v1/tokenomics/projection"+tt.query, nil)
+			writer := httptest.NewRecorder()
+			writer.Body = &bytes.Buffer{}
+
+			s.GetTokenomicsProjection(writer, request)
+			assert.Equal(t, http.StatusBadRequest, writer.Code)
+			e := &httputil.DefaultJsonError{}
+			require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+			assert.StringContains(t, tt.wantErr, e.Message)
+		})
+	}
+}
+
+func TestProjectTokenomics(t *testing.T) {
+	t.Run("reserves depletion", func(t *testing.T) {
+		factor := uint64(1000000)
+		boost := helpers.FeedbackBoost(factor, ^uint64(0))
+		start := &tokenomicsProjectionStart{
+			rewardAdjustmentFactor: factor,
+			reserves:               boost*3 - 1,
+			deposit:                1,
+		}
+		cfg := &tokenomicsProjectionConfig{startEpoch: 10, epochs: 5, interval: 1, depositModel: depositModelLinear}
+
+		projection := projectTokenomics(start, cfg)
+		assert.Equal(t, "12", projection.ReservesDepletionEpoch)
+		assert.Equal(t, "0", projection.FinalReserves)
+		assert.Equal(t, strconv.FormatUint(boost*3-1, 10), projection.TotalReserveUsage)
+		assert.Equal(t, "0", projection.Points[4].FeedbackBoost)
+	})
+
+	t.Run("target deposit keeps the factor", func(t *testing.T) {
+		start := &tokenomicsProjectionStart{
+			rewardAdjustmentFactor: 300,
+			reserves:               1000000000000,
+			deposit:                helpers.TargetDepositPlan(10),
+		}
+		cfg := &tokenomicsProjectionConfig{startEpoch: 10, epochs: 5, interval: 2, depositModel: depositModelTarget}
+
+		projection := projectTokenomics(start, cfg)
+		require.Equal(t, 3, len(projection.Points))
+		for _, p := range projection.Points {
+			assert.Equal(t, "300", p.RewardAdjustmentFactor)
+		}
+		assert.Equal(t, "300", projection.FinalRewardAdjustmentFactor)
+	})
+
+	t.Run("negative deposit growth", func(t *testing.T) {
+		minDeposit := params.BeaconConfig().EffectiveBalanceIncrement
+		cfg := &tokenomicsProjectionConfig{depositModel: depositModelLinear, depositGrowth: -10}
+		assert.Equal(t, minDeposit+10, nextProjectedDeposit(minDeposit+20, 5, 0, cfg))
+		assert.Equal(t, minDeposit, nextProjectedDeposit(minDeposit+5, 5, 0, cfg))
+
+		cfg.depositModel = depositModelRestake
+		assert.Equal(t, minDeposit+15, nextProjectedDeposit(minDeposit+20, 5, 0, cfg))
+	})
+}
+
+func TestGetTokenomicsHistory(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := dbTest.SetupDB(t)
+	s := &Server{BeaconDB: beaconDB}
+
+	t.Run("not indexed", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/over/v1/tokenomics/history?start_epoch=0", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetTokenomicsHistory(writer, request)
+		assert.Equal(t, http.StatusNotFound, writer.Code)
+	})
+
+	snapshots := make([]*tokenomicstypes.EpochSnapshot, 10)
+	for i := range snapshots {
+		snapshots[i] = &tokenomicstypes.EpochSnapshot{
+			Epoch:                  primitives.Epoch(i),
+			BlockRoot:              [32]byte{byte(i)},
+			Reserves:               1000 - uint64(i),
+			RewardAdjustmentFactor: 100 + uint64(i),
+			FeedbackBoost:          1,
+			EpochIssuance:          2,
+			TotalActiveBalance:     3,
+		}
+	}
+	require.NoError(t, beaconDB.SaveTokenomicsSnapshots(ctx, snapshots))
+
+	t.Run("range", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/over/v1/tokenomics/history?start_epoch=3&end_epoch=5", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetTokenomicsHistory(writer, request)
+		assert.Equal(t, http.StatusOK, writer.Code)
+		resp := &structs.GetTokenomicsHistoryResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		assert.Equal(t, "9", resp.LastIndexedEpoch)
+		require.Equal(t, 3, len(resp.Data))
+		assert.Equal(t, "3", resp.Data[0].Epoch)
+		assert.Equal(t, "997", resp.Data[0].Reserves)
+		assert.Equal(t, "103", resp.Data[0].RewardAdjustmentFactor)
+		assert.Equal(t, hexutil.Encode(snapshots[3].BlockRoot[:]), resp.Data[0].BlockRoot)
+		assert.Equal(t, "5", resp.Data[2].Epoch)
+	})
+
+	t.Run("until last indexed epoch", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/over/v1/tokenomics/history?start_epoch=8", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetTokenomicsHistory(writer, request)
+		assert.Equal(t, http.StatusOK, writer.Code)
+		resp := &structs.GetTokenomicsHistoryResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.Equal(t, 2, len(resp.Data))
+		assert.Equal(t, "9", resp.Data[1].Epoch)
+	})
+
+	badRequests := []struct {
+		name    string
+		query   string
+		wantErr string
+	}{
+		{name: "no start epoch", query: "", wantErr: "start_epoch is required"},
+		{name: "invalid end epoch", query: "?start_epoch=1&end_epoch=foo", wantErr: "end_epoch must be a number"},
+		{name: "inverted range", query: "?start_epoch=5&end_epoch=1", wantErr: "must not be greater than end_epoch"},
+		{name: "too large range", query: "?start_epoch=0&end_epoch=10000", wantErr: "At most 10000 epochs"},
+	}
+	for _, tt := range badRequests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodGet, "http://example.com/over/v1/tokenomics/history"+tt.query, nil)
+			writer := httptest.NewRecorder()
+			writer.Body = &bytes.Buffer{}
+
+			s.GetTokenomicsHistory(writer, request)
+			assert.Equal(t, http.StatusBadRequest, writer.Code)
+			e := &httputil.DefaultJsonError{}
+			require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+			assert.StringContains(t, tt.wantErr, e.Message)
+		})
+	}
+}
@@ -1,12 +1,15 @@
 package over
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"strconv"
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/api/pagination"
 	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
 	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
@@ -22,6 +25,9 @@ import (
 const (
 	// pendingPartialWithdrawalResponseLimit is the maximum number of partial withdrawals that can be included in a response.
 	pendingPartialWithdrawalResponseLimit = 100
+
+	// maxEstimationPageSize is the maximum number of validators that can be estimated in a page of the bulk estimations.
+	maxEstimationPageSize = 1000
 )
 
 // GetWithdrawalEstimation returns the estimated processing epoch for a validator's pending partial withdrawals.
@@ -75,31 +81,29 @@ func (s *Server) GetWithdrawalEstimation(w http.ResponseWriter, r *http.Request)
 	isFinalized := s.FinalizationFetcher.IsFinalized(ctx, blockRoot)
 
 	// Parse search_limit from URL params
-	searchLimit := defaultSearchLimit
-	rawSearchLimit := r.URL.Query().Get("search_limit")
-	if rawSearchLimit != "" {
-		_limit, err := strconv.Atoi(rawSearchLimit)
-		if err != nil {
-			httputil.HandleError(w, "search_limit must be a number", http.StatusBadRequest)
-			return
-		}
-		if _limit < minSearchLimit {
-			searchLimit = minSearchLimit
-		} else if _limit > maxSearchLimit {
-			searchLimit = maxSearchLimit
-		} else {
-			searchLimit = _limit
-		}
+	searchLimit, ok := parseSearchLimit(w, r)
+	if !ok {
+		return
 	}
 
-	ppws, err := st.PendingPartialWithdrawals()
+	val, err := st.ValidatorAtIndex(valId)
 	if err != nil {
+		httputil.WriteError(w, handleWrapError(err, "could not get validator at index", http.StatusInternalServerError))
+		return
+	}
+
+	data := &structs.WithdrawalEstimationContainer{
+		Pubkey: hexutil.Encode(val.PublicKey),
+	}
+
+	filters := map[primitives.ValidatorIndex][]*structs.PendingPartialWithdrawalContainer{valId: nil}
+	if err := buildPendingPartialWithdrawalEstimations(st, filters, searchLimit); err != nil {
 		httputil.WriteError(w, handleWrapError(err, "could not get pending partial withdrawals from state", http.StatusInternalServerError))
 		return
 	}
+	estimatedPendingPartialWithdrawals := filters[valId]
 
-	// Return early if there is no pending partial withdrawal
-	if len(ppws) == 0 {
+	if len(estimatedPendingPartialWithdrawals) == 0 {
 		httputil.WriteError(w, &httputil.DefaultJsonError{
 			Message: errors.New("could not find pending partial withdrawals for requested validator").Error(),
 			Code:    http.StatusNotFound,
@@ -107,25 +111,128 @@ func (s *Server) GetWithdrawalEstimation(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	// Limit the number of partial withdrawals to search
-	ppws = ppws[:min(len(ppws), searchLimit)]
+	data.PendingPartialWithdrawals = estimatedPendingPartialWithdrawals
 
-	val, err := st.ValidatorAtIndex(valId)
+	httputil.WriteJson(w, &structs.GetWithdrawalEstimationResponse{
+		ExecutionOptimistic: isOptimistic,
+		Finalized:           isFinalized,
+		Data:                data,
+	})
+}
+
+// PostWithdrawalEstimations returns the estimated processing epochs of the pending partial withdrawals
+// for a list of validator IDs in the request body. Accepts validator IDs as either validator indices or public keys.
+// The estimations of all requested validators in a page are calculated in a single pass over the
+// pending partial withdrawal queue. Validators without pending partial withdrawals are included with an empty list.
+// The response is paginated by the page_size and page_token query params.
+func (s *Server) PostWithdrawalEstimations(w http.ResponseWriter, r *http.Request) {
+	ctx, span := trace.StartSpan(r.Context(), "over.PostWithdrawalEstimations")
+	defer span.End()
+
+	// Parse state_id and replay to the state
+	stateId := r.PathValue("state_id")
+	if stateId == "" {
+		httputil.HandleError(w, "state_id is required in URL params", http.StatusBadRequest)
+		return
+	}
+	st, err := s.Stater.State(ctx, []byte(stateId))
 	if err != nil {
-		httputil.WriteError(w, handleWrapError(err, "could not get validator at index", http.StatusInternalServerError))
+		httputil.WriteError(w, handleWrapError(err, "could not retrieve state", http.StatusNotFound))
 		return
 	}
 
-	data := &structs.WithdrawalEstimationContainer{
-		Pubkey: hexutil.Encode(val.PublicKey),
+	// Partial withdrawal estimation is only supported for Electra and later versions.
+	if st.Version() < version.Alpaca {
+		httputil.HandleError(w, "Withdrawal estimation is not supported for pre-Electra.", http.StatusBadRequest)
+		return
+	}
+
+	rawIds, ok := decodeEstimationIds(w, r)
+	if !ok {
+		return
+	}
+	searchLimit, ok := parseSearchLimit(w, r)
+	if !ok {
+		return
+	}
+	start, end, nextPageToken, ok := estimationPage(w, r, len(rawIds))
+	if !ok {
+		return
+	}
+
+	// Resolve the validator indices of the requested page
+	valIds := make([]primitives.ValidatorIndex, 0, end-start)
+	filters := make(map[primitives.ValidatorIndex][]*structs.PendingPartialWithdrawalContainer, end-start)
+	for _, rawId := range rawIds[start:end] {
+		valId, ok := decodeId(w, rawId, st)
+		if !ok {
+			return
+		}
+		valIds = append(valIds, valId)
+		filters[valId] = nil
+	}
+
+	// Get metadata for response
+	isOptimistic, err := s.OptimisticModeFetcher.IsOptimistic(ctx)
+	if err != nil {
+		httputil.WriteError(w, handleWrapError(err, "could not get optimistic mode info", http.StatusInternalServerError))
+		return
+	}
+	blockRoot, err := st.LatestBlockHeader().HashTreeRoot()
+	if err != nil {
+		httputil.WriteError(w, handleWrapError(err, "could not calculate root of latest block header", http.StatusInternalServerError))
+		return
+	}
+	isFinalized := s.FinalizationFetcher.IsFinalized(ctx, blockRoot)
+
+	if err := buildPendingPartialWithdrawalEstimations(st, filters, searchLimit); err != nil {
+		httputil.WriteError(w, handleWrapError(err, "could not get pending partial withdrawals from state", http.StatusInternalServerError))
+		return
+	}
+
+	data := make([]*structs.WithdrawalEstimationContainer, len(valIds))
+	for i, valId := range valIds {
+		pubkey := st.PubkeyAtIndex(valId)
+		ppws := filters[valId]
+		if ppws == nil {
+			ppws = make([]*structs.PendingPartialWithdrawalContainer, 0)
+		}
+		data[i] = &structs.WithdrawalEstimationContainer{
+			Pubkey:                    hexutil.Encode(pubkey[:]),
+			PendingPartialWithdrawals: ppws,
+		}
+	}
+
+	httputil.WriteJson(w, &structs.GetWithdrawalEstimationsResponse{
+		ExecutionOptimistic: isOptimistic,
+		Finalized:           isFinalized,
+		Data:                data,
+		NextPageToken:       nextPageToken,
+		TotalSize:           len(rawIds),
+	})
+}
+
+// buildPendingPartialWithdrawalEstimations iterates through the pending partial withdrawals and calculates the expected epoch.
+// The estimations of the partial withdrawals for the validator indices in filters are collected into the filters,
+// so any number of validators are estimated in a single pass over the queue.
+func buildPendingPartialWithdrawalEstimations(
+	st state.BeaconState,
+	filters map[primitives.ValidatorIndex][]*structs.PendingPartialWithdrawalContainer,
+	searchLimit int,
+) error {
+	ppws, err := st.PendingPartialWithdrawals()
+	if err != nil {
+		return errors.Wrap(err, "could not get pending partial withdrawals")
 	}
 
+	// Limit the number of partial withdrawals to search
+	ppws = ppws[:min(len(ppws), searchLimit)]
+
 	// Initialize variables
 	currentEpoch := slots.ToEpoch(st.Slot())
 	partialWithdrawalsCount := uint64(0)
-	estimatedPendingPartialWithdrawals := make([]*structs.PendingPartialWithdrawalContainer, 0)
 
-	// Iterate through pending partial withdrawals to estimate the expected epoch for requested validator
+	// Iterate through pending partial withdrawals to estimate the expected epoch for requested validators
 	for _, ppw := range ppws {
 		if currentEpoch < ppw.WithdrawableEpoch {
 			currentEpoch = ppw.WithdrawableEpoch
@@ -137,36 +244,59 @@ func (s *Server) GetWithdrawalEstimation(w http.ResponseWriter, r *http.Request)
 			partialWithdrawalsCount = 0
 		}
 
-		if ppw.Index == valId {
-			cont := &structs.PendingPartialWithdrawalContainer{
+		// Limit the number of partial withdrawals to return
+		if estimations, ok := filters[ppw.Index]; ok && len(estimations) < pendingPartialWithdrawalResponseLimit {
+			filters[ppw.Index] = append(estimations, &structs.PendingPartialWithdrawalContainer{
 				Amount:        ppw.Amount,
 				ExpectedEpoch: uint64(currentEpoch),
-			}
-			estimatedPendingPartialWithdrawals = append(estimatedPendingPartialWithdrawals, cont)
-			if len(estimatedPendingPartialWithdrawals) >= pendingPartialWithdrawalResponseLimit {
-				// Limit the number of partial withdrawals to return
-				break
-			}
+			})
 		}
 
 		partialWithdrawalsCount++
 	}
+	return nil
+}
 
-	if len(estimatedPendingPartialWithdrawals) == 0 {
-		httputil.WriteError(w, &httputil.DefaultJsonError{
-			Message: errors.New("could not find pending partial withdrawals for requested validator").Error(),
-			Code:    http.StatusNotFound,
-		})
-		return
+// decodeEstimationIds decodes the validator IDs of the bulk estimations from the request body.
+func decodeEstimationIds(w http.ResponseWriter, r *http.Request) ([]string, bool) {
+	var rawIds []string
+	err := json.NewDecoder(r.Body).Decode(&rawIds)
+	switch {
+	case errors.Is(err, io.EOF):
+		httputil.HandleError(w, "No data submitted", http.StatusBadRequest)
+		return nil, false
+	case err != nil:
+		httputil.HandleError(w, "Could not decode request body: "+err.Error(), http.StatusBadRequest)
+		return nil, false
+	case len(rawIds) == 0:
+		httputil.HandleError(w, "No validator IDs submitted", http.StatusBadRequest)
+		return nil, false
 	}
+	return rawIds, true
+}
 
-	data.PendingPartialWithdrawals = estimatedPendingPartialWithdrawals
-
-	httputil.WriteJson(w, &structs.GetWithdrawalEstimationResponse{
-		ExecutionOptimistic: isOptimistic,
-		Finalized:           isFinalized,
-		Data:                data,
-	})
+// estimationPage parses the page_size and page_token query params of the bulk estimations,
+// and returns the range of the requested IDs in the page along with the next page token.
+func estimationPage(w http.ResponseWriter, r *http.Request, totalSize int) (int, int, string, bool) {
+	pageSize := 0
+	if rawPageSize := r.URL.Query().Get("page_size"); rawPageSize != "" {
+		size, err := strconv.Atoi(rawPageSize)
+		if err != nil || size < 0 {
+			httputil.HandleError(w, "page_size must be a non-negative number", http.StatusBadRequest)
+			return 0, 0, "", false
+		}
+		if size > maxEstimationPageSize {
+			httputil.HandleError(w, fmt.Sprintf("Requested page size %d can not be greater than max size %d", size, maxEstimationPageSize), http.StatusBadRequest)
+			return 0, 0, "", false
+		}
+		pageSize = size
+	}
+	start, end, nextPageToken, err := pagination.StartAndEndPage(r.URL.Query().Get("page_token"), pageSize, totalSize)
+	if err != nil {
+		httputil.HandleError(w, "Could not paginate results: "+err.Error(), http.StatusBadRequest)
+		return 0, 0, "", false
+	}
+	return start, end, nextPageToken, true
 }
 
 // decodeId takes in a validator ID string (as either a pubkey or a validator index)
@@ -264,3 +264,84 @@ func TestGetWithdrawalEstimation(t *testing.T) {
 		}
 	}
 }
+
+func TestPostWithdrawalEstimations(t *testing.T) {
+	st, _ := util.DeterministicGenesisStateElectra(t, 10)
+	withdrawableEpoch := params.BeaconConfig().MinValidatorWithdrawabilityDelay
+	// Validator 1 fills the first sweep, so the withdrawal of validator 0 is processed an epoch later.
+	for i := uint64(0); i < params.BeaconConfig().MaxPendingPartialsPerWithdrawalsSweep; i++ {
+		require.NoError(t, st.AppendPendingPartialWithdrawal(&ethpb.PendingPartialWithdrawal{
+			Index:             1,
+			Amount:            1_000_000_000, // 1 OVER
+			WithdrawableEpoch: withdrawableEpoch,
+		}))
+	}
+	require.NoError(t, st.AppendPendingPartialWithdrawal(&ethpb.PendingPartialWithdrawal{
+		Index:             0,
+		Amount:            100_000_000_000, // 100 OVER
+		WithdrawableEpoch: withdrawableEpoch,
+	}))
+	val0, err := st.ValidatorAtIndex(0)
+	require.NoError(t, err)
+
+	chainService := &chainMock.ChainService{}
+	s := Server{
+		Stater: &testutil.MockStater{
+			BeaconState: st,
+		},
+		HeadFetcher:           chainService,
+		OptimisticModeFetcher: chainService,
+		FinalizationFetcher:   chainService,
+	}
+
+	postEstimations := func(t *testing.T, query string, body string) (*httptest.ResponseRecorder, *structs.GetWithdrawalEstimationsResponse) {
+		request := httptest.NewRequest(http.MethodPost,
+			"http://example.com/over/v1/beacon/states/{state_id}/withdrawal_estimations?"+query, bytes.NewBufferString(body))
+		request.SetPathValue("state_id", "head")
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.PostWithdrawalEstimations(writer, request)
+		resp := &structs.GetWithdrawalEstimationsResponse{}
+		if writer.Code == http.StatusOK {
+			require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		}
+		return writer, resp
+	}
+
+	t.Run("multiple validators", func(t *testing.T) {
+		writer, resp := postEstimations(t, "", `["`+hexutil.Encode(val0.PublicKey)+`","1","2"]`)
+		assert.Equal(t, http.StatusOK, writer.Code)
+		assert.Equal(t, 3, resp.TotalSize)
+		require.Equal(t, 3, len(resp.Data))
+
+		assert.Equal(t, hexutil.Encode(val0.PublicKey), resp.Data[0].Pubkey)
+		require.DeepEqual(t, []*structs.PendingPartialWithdrawalContainer{
+			{
+				Amount:        100_000_000_000, // 100 OVER
+				ExpectedEpoch: uint64(withdrawableEpoch) + 1,
+			},
+		}, resp.Data[0].PendingPartialWithdrawals)
+		require.Equal(t, int(params.BeaconConfig().MaxPendingPartialsPerWithdrawalsSweep), len(resp.Data[1].PendingPartialWithdrawals))
+		for _, ppw := range resp.Data[1].PendingPartialWithdrawals {
+			assert.Equal(t, uint64(withdrawableEpoch), ppw.ExpectedEpoch)
+		}
+		// A validator without pending partial withdrawals is included with an empty list.
+		assert.Equal(t, 0, len(resp.Data[2].PendingPartialWithdrawals))
+	})
+	t.Run("pagination", func(t *testing.T) {
+		writer, resp := postEstimations(t, "page_size=1&page_token=1", `["0","1","2"]`)
+		assert.Equal(t, http.StatusOK, writer.Code)
+		assert.Equal(t, "2", resp.NextPageToken)
+		require.Equal(t, 1, len(resp.Data))
+		pubkey := st.PubkeyAtIndex(1)
+		assert.Equal(t, hexutil.Encode(pubkey[:]), resp.Data[0].Pubkey)
+	})
+	t.Run("bad requests", func(t *testing.T) {
+		writer, _ := postEstimations(t, "", "")
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+		writer, _ = postEstimations(t, "", `["11"]`)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+		writer, _ = postEstimations(t, "page_size=-1", `["0"]`)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+	})
+}
@@ -2,19 +2,35 @@ package overnode
 
 import (
 	"net/http"
+	"strconv"
 
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	"github.com/prysmaticlabs/prysm/v5/network/httputil"
 	log "github.com/sirupsen/logrus"
 )
 
-// CloseClient closes beacon chain client gracefully.
+// CloseClient starts the graceful shutdown of the beacon node and returns its progress without
+// waiting for the node to stop. With the drain query parameter set, the node first waits for the
+// blocks being imported to be saved, then flushes its in-memory chain state to the database and syncs
+// it to disk. Requesting the shutdown again returns the progress of the shutdown which is already under way.
 func (s *Server) CloseClient(w http.ResponseWriter, r *http.Request) {
-	log.Infof("Got interrupt through HTTP request...")
+	drain := false
+	if raw := r.URL.Query().Get("drain"); raw != "" {
+		var err error
+		drain, err = strconv.ParseBool(raw)
+		if err != nil {
+			httputil.HandleError(w, "Invalid drain query parameter: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
 
-	closeFunc := s.CloseHandler.CloseFunc
-	closeCh := s.CloseHandler.CloseCh
-
-	go closeFunc()
+	if s.CloseHandler.Shutdown.Start(drain) {
+		log.WithField("drain", drain).Info("Got interrupt through HTTP request...")
+	}
+	httputil.WriteJson(w, structs.ShutdownResponseFromStatus(s.CloseHandler.Shutdown.Status()))
+}
 
-	// Wait for close channel to be closed.
-	<-closeCh
+// GetCloseStatus returns the progress of the shutdown of the beacon node.
+func (s *Server) GetCloseStatus(w http.ResponseWriter, _ *http.Request) {
+	httputil.WriteJson(w, structs.ShutdownResponseFromStatus(s.CloseHandler.Shutdown.Status()))
 }
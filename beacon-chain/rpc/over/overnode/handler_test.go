@@ -0,0 +1,74 @@
+package overnode
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	closehandler "github.com/prysmaticlabs/prysm/v5/beacon-chain/node/close-handler"
+	"github.com/prysmaticlabs/prysm/v5/runtime"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestCloseClient(t *testing.T) {
+	release := make(chan struct{})
+	closed := make(chan struct{})
+	shutdown := runtime.NewShutdown(func() { close(closed) }, func(ctx context.Context) error {
+		<-release
+		return nil
+	}, runtime.NewServiceRegistry())
+	s := &Server{CloseHandler: &closehandler.CloseHandler{Shutdown: shutdown}}
+
+	t.Run("invalid drain", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodPost, "http://example.com/over-node/close?drain=maybe", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.CloseClient(writer, request)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+		assert.Equal(t, runtime.ShutdownIdle, shutdown.Status().Phase)
+	})
+	t.Run("drain", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodPost, "http://example.com/over-node/close?drain=true", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.CloseClient(writer, request)
+		assert.Equal(t, http.StatusOK, writer.Code)
+		resp := &structs.ShutdownResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		assert.Equal(t, string(runtime.ShutdownDraining), resp.Phase)
+		assert.Equal(t, true, resp.Drain)
+		assert.NotEqual(t, "", resp.StartedAt)
+
+		// A second request reports the shutdown under way instead of starting another one.
+		request = httptest.NewRequest(http.MethodPost, "http://example.com/over-node/close", nil)
+		writer = httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.CloseClient(writer, request)
+		assert.Equal(t, http.StatusOK, writer.Code)
+		resp = &structs.ShutdownResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		assert.Equal(t, string(runtime.ShutdownDraining), resp.Phase)
+
+		close(release)
+		<-closed
+		<-shutdown.Done()
+	})
+	t.Run("status", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/over-node/close/status", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetCloseStatus(writer, request)
+		assert.Equal(t, http.StatusOK, writer.Code)
+		resp := &structs.ShutdownResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		assert.Equal(t, string(runtime.ShutdownComplete), resp.Phase)
+	})
+}
@@ -2,14 +2,22 @@ package over
 
 import (
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/execution"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/eth/rewards"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/lookup"
 )
 
 // Server defines a server implementation of custom APIs for OverProtocol.
 type Server struct {
-	Stater                lookup.Stater
-	GenesisTimeFetcher    blockchain.TimeFetcher
-	HeadFetcher           blockchain.HeadFetcher
-	OptimisticModeFetcher blockchain.OptimisticModeFetcher
-	FinalizationFetcher   blockchain.FinalizationFetcher
+	Blocker                lookup.Blocker
+	Stater                 lookup.Stater
+	GenesisTimeFetcher     blockchain.TimeFetcher
+	HeadFetcher            blockchain.HeadFetcher
+	OptimisticModeFetcher  blockchain.OptimisticModeFetcher
+	FinalizationFetcher    blockchain.FinalizationFetcher
+	BeaconDB               db.ReadOnlyDatabase
+	ExecutionReconstructor execution.Reconstructor
+	BlockRewardFetcher     rewards.BlockRewardsFetcher
+	SlasherDB              db.SlasherDatabase
 }
@@ -4,7 +4,10 @@ import (
 	"encoding/json"
 	"io"
 	"net/http"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	corenet "github.com/libp2p/go-libp2p/core/network"
 	"github.com/libp2p/go-libp2p/core/peer"
@@ -13,6 +16,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/peers"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/peers/peerdata"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/sync/backfill"
 	"github.com/prysmaticlabs/prysm/v5/monitoring/tracing/trace"
 	"github.com/prysmaticlabs/prysm/v5/network/httputil"
 	eth "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
@@ -186,3 +190,235 @@ func httpPeerInfo(peerStatus *peers.Status, id peer.ID) (*structs.Peer, error) {
 
 	return &p, nil
 }
+
+// GetPeer retrieves the details of a peer known by the node: the score given by each scorer,
+// its gossip topic scores, its use of the rate limits and the last goodbye message exchanged with it.
+func (s *Server) GetPeer(w http.ResponseWriter, r *http.Request) {
+	_, span := trace.StartSpan(r.Context(), "node.GetPeer")
+	defer span.End()
+
+	segments := strings.Split(r.URL.Path, "/")
+	peerId, err := peer.Decode(segments[len(segments)-1])
+	if err != nil {
+		httputil.HandleError(w, errors.Wrap(err, "Could not decode peer id").Error(), http.StatusBadRequest)
+		return
+	}
+	peerStatus := s.PeersFetcher.Peers()
+	p, err := httpPeerInfo(peerStatus, peerId)
+	if err != nil {
+		httputil.HandleError(w, errors.Wrap(err, "Could not get peer info").Error(), http.StatusInternalServerError)
+		return
+	}
+	if p == nil {
+		httputil.HandleError(w, "Peer not found", http.StatusNotFound)
+		return
+	}
+	badResponses, err := peerStatus.Scorers().BadResponsesScorer().Count(peerId)
+	if err != nil {
+		httputil.HandleError(w, errors.Wrap(err, "Could not get bad responses").Error(), http.StatusInternalServerError)
+		return
+	}
+	gossipScore, behaviourPenalty, topicScores, err := peerStatus.Scorers().GossipScorer().GossipData(peerId)
+	if err != nil {
+		httputil.HandleError(w, errors.Wrap(err, "Could not get gossip scores").Error(), http.StatusInternalServerError)
+		return
+	}
+	goodbye, err := peerStatus.LastGoodbye(peerId)
+	if err != nil {
+		httputil.HandleError(w, errors.Wrap(err, "Could not get last goodbye").Error(), http.StatusInternalServerError)
+		return
+	}
+
+	scorers := peerStatus.Scorers()
+	details := &structs.PeerDetails{
+		Peer:         p,
+		Banned:       peerStatus.IsBanned(peerId) != nil,
+		Trusted:      peerStatus.IsTrustedPeers(peerId),
+		BadResponses: strconv.Itoa(badResponses),
+		Scores: &structs.PeerScores{
+			Total:         formatScore(scorers.Score(peerId)),
+			BadResponses:  formatScore(scorers.BadResponsesScorer().Score(peerId)),
+			BlockProvider: formatScore(scorers.BlockProviderScorer().Score(peerId)),
+			PeerStatus:    formatScore(scorers.PeerStatusScorer().Score(peerId)),
+			Gossip:        formatScore(scorers.GossipScorer().Score(peerId)),
+		},
+		Gossip: &structs.PeerGossipScores{
+			Score:            formatScore(gossipScore),
+			BehaviourPenalty: formatScore(behaviourPenalty),
+			Topics:           make([]*structs.TopicScore, 0, len(topicScores)),
+		},
+		RateLimits: make([]*structs.RateLimitUsage, 0),
+	}
+	for topic, snapshot := range topicScores {
+		details.Gossip.Topics = append(details.Gossip.Topics, &structs.TopicScore{
+			Topic:                    topic,
+			TimeInMesh:               strconv.FormatUint(snapshot.TimeInMesh, 10),
+			FirstMessageDeliveries:   formatScore(float64(snapshot.FirstMessageDeliveries)),
+			MeshMessageDeliveries:    formatScore(float64(snapshot.MeshMessageDeliveries)),
+			InvalidMessageDeliveries: formatScore(float64(snapshot.InvalidMessageDeliveries)),
+		})
+	}
+	sort.Slice(details.Gossip.Topics, func(i, j int) bool {
+		return details.Gossip.Topics[i].Topic < details.Gossip.Topics[j].Topic
+	})
+	if s.RateLimitUsageFetcher != nil {
+		for _, usage := range s.RateLimitUsageFetcher.PeerRateLimitUsage(peerId) {
+			details.RateLimits = append(details.RateLimits, &structs.RateLimitUsage{
+				Topic:     usage.Topic,
+				Count:     strconv.FormatInt(usage.Count, 10),
+				Remaining: strconv.FormatInt(usage.Remaining, 10),
+				Capacity:  strconv.FormatInt(usage.Capacity, 10),
+			})
+		}
+	}
+	if goodbye != nil {
+		details.LastGoodbye = &structs.Goodbye{
+			Code:   strconv.FormatUint(goodbye.Code, 10),
+			Reason: goodbye.Reason,
+			Sent:   goodbye.Sent,
+			Time:   goodbye.Time.UTC().Format(time.RFC3339),
+		}
+	}
+	httputil.WriteJson(w, &structs.GetPeerDetailsResponse{Data: details})
+}
+
+// ListBannedPeers retrieves the IDs of the peers banned by the operator.
+func (s *Server) ListBannedPeers(w http.ResponseWriter, r *http.Request) {
+	_, span := trace.StartSpan(r.Context(), "node.ListBannedPeers")
+	defer span.End()
+
+	banned := s.PeersFetcher.Peers().BannedPeers()
+	ids := make([]string, len(banned))
+	for i, pid := range banned {
+		ids[i] = pid.String()
+	}
+	httputil.WriteJson(w, &structs.BannedPeersResponse{PeerIds: ids})
+}
+
+// BanPeer bans a peer by its ID, disconnecting it if it is connected.
+func (s *Server) BanPeer(w http.ResponseWriter, r *http.Request) {
+	_, span := trace.StartSpan(r.Context(), "node.BanPeer")
+	defer span.End()
+
+	var req structs.BanPeerRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputil.HandleError(w, errors.Wrap(err, "Could not decode request body").Error(), http.StatusBadRequest)
+		return
+	}
+	peerId, err := peer.Decode(req.PeerId)
+	if err != nil {
+		httputil.HandleError(w, errors.Wrap(err, "Could not decode peer id").Error(), http.StatusBadRequest)
+		return
+	}
+	if err := s.PeerBanner.BanPeer(peerId); err != nil {
+		httputil.HandleError(w, errors.Wrap(err, "Could not ban peer").Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// UnbanPeer lifts the ban of a peer.
+func (s *Server) UnbanPeer(w http.ResponseWriter, r *http.Request) {
+	_, span := trace.StartSpan(r.Context(), "node.UnbanPeer")
+	defer span.End()
+
+	segments := strings.Split(r.URL.Path, "/")
+	peerId, err := peer.Decode(segments[len(segments)-1])
+	if err != nil {
+		httputil.HandleError(w, errors.Wrap(err, "Could not decode peer id").Error(), http.StatusBadRequest)
+		return
+	}
+	if err := s.PeerBanner.UnbanPeer(peerId); err != nil {
+		httputil.HandleError(w, errors.Wrap(err, "Could not lift peer ban").Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// ListBannedIPs retrieves the ranges of IP addresses banned by the operator, in CIDR notation.
+func (s *Server) ListBannedIPs(w http.ResponseWriter, r *http.Request) {
+	_, span := trace.StartSpan(r.Context(), "node.ListBannedIPs")
+	defer span.End()
+
+	banned := s.PeersFetcher.Peers().BannedIPNets()
+	ips := make([]string, len(banned))
+	for i, ipNet := range banned {
+		ips[i] = ipNet.String()
+	}
+	httputil.WriteJson(w, &structs.BannedIPsResponse{IPs: ips})
+}
+
+// BanIP bans the peers with an IP address, or with an IP address in a CIDR range,
+// disconnecting those which are connected.
+func (s *Server) BanIP(w http.ResponseWriter, r *http.Request) {
+	_, span := trace.StartSpan(r.Context(), "node.BanIP")
+	defer span.End()
+
+	var req structs.BanIPRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputil.HandleError(w, errors.Wrap(err, "Could not decode request body").Error(), http.StatusBadRequest)
+		return
+	}
+	ipNet, err := p2p.ParseIPNet(req.IP)
+	if err != nil {
+		httputil.HandleError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := s.PeerBanner.BanIPNet(ipNet); err != nil {
+		httputil.HandleError(w, errors.Wrap(err, "Could not ban IP").Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// UnbanIP lifts the ban of an IP address or CIDR range, given by the ip query parameter.
+func (s *Server) UnbanIP(w http.ResponseWriter, r *http.Request) {
+	_, span := trace.StartSpan(r.Context(), "node.UnbanIP")
+	defer span.End()
+
+	ipNet, err := p2p.ParseIPNet(r.URL.Query().Get("ip"))
+	if err != nil {
+		httputil.HandleError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := s.PeerBanner.UnbanIPNet(ipNet); err != nil {
+		httputil.HandleError(w, errors.Wrap(err, "Could not lift IP ban").Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func formatScore(score float64) string {
+	return strconv.FormatFloat(score, 'f', -1, 64)
+}
+
+// GetBackfillRepair retrieves the progress of the backfill repair mode, which fills the gaps in the block
+// and blob sidecar history of the node.
+func (s *Server) GetBackfillRepair(w http.ResponseWriter, r *http.Request) {
+	_, span := trace.StartSpan(r.Context(), "node.GetBackfillRepair")
+	defer span.End()
+
+	status := &backfill.RepairStatus{State: backfill.RepairDisabled}
+	if s.BackfillRepairFetcher != nil {
+		status = s.BackfillRepairFetcher.RepairStatus()
+	}
+	data := &structs.BackfillRepairStatus{
+		State:    string(status.State),
+		Pass:     strconv.Itoa(status.Pass),
+		LowSlot:  strconv.FormatUint(uint64(status.LowSlot), 10),
+		HighSlot: strconv.FormatUint(uint64(status.HighSlot), 10),
+		Gaps:     make([]*structs.BackfillRepairGap, len(status.Gaps)),
+		Error:    status.Error,
+	}
+	for i, g := range status.Gaps {
+		data.Gaps[i] = &structs.BackfillRepairGap{
+			Kind:             string(g.Kind),
+			StartSlot:        strconv.FormatUint(uint64(g.Start), 10),
+			EndSlot:          strconv.FormatUint(uint64(g.End), 10),
+			Repaired:         g.Repaired,
+			BatchesImported:  strconv.Itoa(g.BatchesImported),
+			BatchesRemaining: strconv.Itoa(g.BatchesRemaining),
+		}
+	}
+	httputil.WriteJson(w, &structs.GetBackfillRepairResponse{Data: data})
+}
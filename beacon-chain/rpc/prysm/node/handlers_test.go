@@ -3,10 +3,12 @@ package node
 import (
 	"bytes"
 	"encoding/json"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/ethereum/go-ethereum/p2p/enode"
 	"github.com/ethereum/go-ethereum/p2p/enr"
@@ -17,8 +19,12 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/peers"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/peers/peerdata"
 	mockp2p "github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/testing"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/sync"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/sync/backfill"
 	"github.com/prysmaticlabs/prysm/v5/network/httputil"
+	pb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v5/testing/assert"
 	"github.com/prysmaticlabs/prysm/v5/testing/require"
 )
@@ -249,3 +255,230 @@ func TestRemoveTrustedPeer_EmptyParameter(t *testing.T) {
 	assert.Equal(t, http.StatusBadRequest, writer.Code)
 	assert.Equal(t, "Could not decode peer id: failed to parse peer ID: invalid cid: cid too short", e.Message)
 }
+
+// peerBanner bans peers in the peer status, as the p2p service does.
+type peerBanner struct {
+	peers *peers.Status
+}
+
+func (b *peerBanner) BanPeer(pid peer.ID) error {
+	b.peers.BanPeer(pid)
+	return nil
+}
+
+func (b *peerBanner) UnbanPeer(pid peer.ID) error {
+	b.peers.UnbanPeer(pid)
+	return nil
+}
+
+func (b *peerBanner) BanIPNet(ipNet *net.IPNet) error {
+	b.peers.BanIPNet(ipNet)
+	return nil
+}
+
+func (b *peerBanner) UnbanIPNet(ipNet *net.IPNet) error {
+	b.peers.UnbanIPNet(ipNet)
+	return nil
+}
+
+type rateLimitUsageFetcher []*sync.RateLimitUsage
+
+func (f rateLimitUsageFetcher) PeerRateLimitUsage(peer.ID) []*sync.RateLimitUsage {
+	return f
+}
+
+func TestGetPeer(t *testing.T) {
+	peerFetcher := &mockp2p.MockPeersProvider{}
+	peerFetcher.ClearPeers()
+	peerStatus := peerFetcher.Peers()
+	id := libp2ptest.GeneratePeerIDs(1)[0]
+	addr, err := ma.NewMultiaddr("/ip4/127.0.0.1/tcp/13000")
+	require.NoError(t, err)
+	peerStatus.Add(nil, id, addr, corenet.DirInbound)
+	peerStatus.SetConnectionState(id, peers.Connected)
+	peerStatus.Scorers().BadResponsesScorer().Increment(id)
+	peerStatus.Scorers().GossipScorer().SetGossipData(id, 2.5, -1, map[string]*pb.TopicScoreSnapshot{
+		"/eth2/beacon_block": {TimeInMesh: 12, FirstMessageDeliveries: 3},
+	})
+	goodbyeTime := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	peerStatus.SetLastGoodbye(id, &peerdata.Goodbye{Code: 3, Reason: "client has error", Sent: true, Time: goodbyeTime})
+	s := Server{
+		PeersFetcher:          peerFetcher,
+		RateLimitUsageFetcher: rateLimitUsageFetcher{{Topic: "/status", Count: 2, Remaining: 3, Capacity: 5}},
+	}
+
+	request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/node/peers/"+id.String(), nil)
+	writer := httptest.NewRecorder()
+	writer.Body = &bytes.Buffer{}
+	s.GetPeer(writer, request)
+	require.Equal(t, http.StatusOK, writer.Code)
+	resp := &structs.GetPeerDetailsResponse{}
+	require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+	details := resp.Data
+	assert.Equal(t, id.String(), details.Peer.PeerId)
+	assert.Equal(t, "CONNECTED", details.Peer.State)
+	assert.Equal(t, false, details.Banned)
+	assert.Equal(t, "1", details.BadResponses)
+	assert.Equal(t, "2.5", details.Scores.Gossip)
+	assert.Equal(t, "-1", details.Gossip.BehaviourPenalty)
+	require.Equal(t, 1, len(details.Gossip.Topics))
+	assert.Equal(t, "/eth2/beacon_block", details.Gossip.Topics[0].Topic)
+	assert.Equal(t, "12", details.Gossip.Topics[0].TimeInMesh)
+	assert.Equal(t, "3", details.Gossip.Topics[0].FirstMessageDeliveries)
+	require.Equal(t, 1, len(details.RateLimits))
+	assert.DeepEqual(t, &structs.RateLimitUsage{Topic: "/status", Count: "2", Remaining: "3", Capacity: "5"}, details.RateLimits[0])
+	assert.DeepEqual(t, &structs.Goodbye{Code: "3", Reason: "client has error", Sent: true, Time: "2024-01-02T03:04:05Z"}, details.LastGoodbye)
+
+	peerStatus.BanPeer(id)
+	writer = httptest.NewRecorder()
+	writer.Body = &bytes.Buffer{}
+	s.GetPeer(writer, request)
+	require.Equal(t, http.StatusOK, writer.Code)
+	require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+	assert.Equal(t, true, resp.Data.Banned)
+}
+
+func TestGetPeer_Unknown(t *testing.T) {
+	peerFetcher := &mockp2p.MockPeersProvider{}
+	peerFetcher.ClearPeers()
+	s := Server{PeersFetcher: peerFetcher}
+
+	id := libp2ptest.GeneratePeerIDs(1)[0]
+	request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/node/peers/"+id.String(), nil)
+	writer := httptest.NewRecorder()
+	writer.Body = &bytes.Buffer{}
+	s.GetPeer(writer, request)
+	assert.Equal(t, http.StatusNotFound, writer.Code)
+}
+
+func TestBanPeer(t *testing.T) {
+	peerFetcher := &mockp2p.MockPeersProvider{}
+	peerFetcher.ClearPeers()
+	s := Server{PeersFetcher: peerFetcher, PeerBanner: &peerBanner{peers: peerFetcher.Peers()}}
+	id := libp2ptest.GeneratePeerIDs(1)[0]
+
+	body, err := json.Marshal(&structs.BanPeerRequest{PeerId: id.String()})
+	require.NoError(t, err)
+	request := httptest.NewRequest(http.MethodPost, "http://example.com", bytes.NewReader(body))
+	writer := httptest.NewRecorder()
+	writer.Body = &bytes.Buffer{}
+	s.BanPeer(writer, request)
+	require.Equal(t, http.StatusOK, writer.Code)
+
+	request = httptest.NewRequest(http.MethodGet, "http://example.com", nil)
+	writer = httptest.NewRecorder()
+	writer.Body = &bytes.Buffer{}
+	s.ListBannedPeers(writer, request)
+	require.Equal(t, http.StatusOK, writer.Code)
+	resp := &structs.BannedPeersResponse{}
+	require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+	assert.DeepEqual(t, []string{id.String()}, resp.PeerIds)
+
+	request = httptest.NewRequest(http.MethodDelete, "http://example.com/prysm/v1/node/banned_peers/"+id.String(), nil)
+	writer = httptest.NewRecorder()
+	writer.Body = &bytes.Buffer{}
+	s.UnbanPeer(writer, request)
+	require.Equal(t, http.StatusOK, writer.Code)
+	assert.Equal(t, 0, len(peerFetcher.Peers().BannedPeers()))
+}
+
+func TestBanPeer_BadPeerId(t *testing.T) {
+	peerFetcher := &mockp2p.MockPeersProvider{}
+	peerFetcher.ClearPeers()
+	s := Server{PeersFetcher: peerFetcher, PeerBanner: &peerBanner{peers: peerFetcher.Peers()}}
+
+	body, err := json.Marshal(&structs.BanPeerRequest{PeerId: "foo"})
+	require.NoError(t, err)
+	request := httptest.NewRequest(http.MethodPost, "http://example.com", bytes.NewReader(body))
+	writer := httptest.NewRecorder()
+	writer.Body = &bytes.Buffer{}
+	s.BanPeer(writer, request)
+	e := &httputil.DefaultJsonError{}
+	require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+	assert.Equal(t, http.StatusBadRequest, writer.Code)
+	assert.StringContains(t, "Could not decode peer id", e.Message)
+}
+
+func TestBanIP(t *testing.T) {
+	peerFetcher := &mockp2p.MockPeersProvider{}
+	peerFetcher.ClearPeers()
+	s := Server{PeersFetcher: peerFetcher, PeerBanner: &peerBanner{peers: peerFetcher.Peers()}}
+
+	for _, ip := range []string{"10.0.0.0/8", "192.168.1.1"} {
+		body, err := json.Marshal(&structs.BanIPRequest{IP: ip})
+		require.NoError(t, err)
+		request := httptest.NewRequest(http.MethodPost, "http://example.com", bytes.NewReader(body))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.BanIP(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+	}
+
+	request := httptest.NewRequest(http.MethodGet, "http://example.com", nil)
+	writer := httptest.NewRecorder()
+	writer.Body = &bytes.Buffer{}
+	s.ListBannedIPs(writer, request)
+	require.Equal(t, http.StatusOK, writer.Code)
+	resp := &structs.BannedIPsResponse{}
+	require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+	assert.Equal(t, 2, len(resp.IPs))
+
+	request = httptest.NewRequest(http.MethodDelete, "http://example.com/prysm/v1/node/banned_ips?ip=10.0.0.0/8", nil)
+	writer = httptest.NewRecorder()
+	writer.Body = &bytes.Buffer{}
+	s.UnbanIP(writer, request)
+	require.Equal(t, http.StatusOK, writer.Code)
+	banned := peerFetcher.Peers().BannedIPNets()
+	require.Equal(t, 1, len(banned))
+	assert.Equal(t, "192.168.1.1/32", banned[0].String())
+
+	body, err := json.Marshal(&structs.BanIPRequest{IP: "not an ip"})
+	require.NoError(t, err)
+	request = httptest.NewRequest(http.MethodPost, "http://example.com", bytes.NewReader(body))
+	writer = httptest.NewRecorder()
+	writer.Body = &bytes.Buffer{}
+	s.BanIP(writer, request)
+	assert.Equal(t, http.StatusBadRequest, writer.Code)
+}
+
+type repairStatusFetcher backfill.RepairStatus
+
+func (f *repairStatusFetcher) RepairStatus() *backfill.RepairStatus {
+	return (*backfill.RepairStatus)(f)
+}
+
+func TestGetBackfillRepair(t *testing.T) {
+	s := Server{}
+	request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/node/backfill/repair", nil)
+	writer := httptest.NewRecorder()
+	writer.Body = &bytes.Buffer{}
+	s.GetBackfillRepair(writer, request)
+	require.Equal(t, http.StatusOK, writer.Code)
+	resp := &structs.GetBackfillRepairResponse{}
+	require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+	assert.Equal(t, "disabled", resp.Data.State)
+	assert.Equal(t, 0, len(resp.Data.Gaps))
+
+	s.BackfillRepairFetcher = &repairStatusFetcher{
+		State:    backfill.RepairRepairing,
+		Pass:     1,
+		LowSlot:  100,
+		HighSlot: 200,
+		Gaps: []backfill.RepairGap{
+			{Kind: backfill.RepairGapBlobs, Start: 150, End: 152, Repaired: true, BatchesImported: 1},
+			{Kind: backfill.RepairGapBlocks, Start: 110, End: 140, BatchesImported: 2, BatchesRemaining: 1},
+		},
+	}
+	writer = httptest.NewRecorder()
+	writer.Body = &bytes.Buffer{}
+	s.GetBackfillRepair(writer, request)
+	require.Equal(t, http.StatusOK, writer.Code)
+	require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+	assert.Equal(t, "repairing", resp.Data.State)
+	assert.Equal(t, "1", resp.Data.Pass)
+	assert.Equal(t, "100", resp.Data.LowSlot)
+	assert.Equal(t, "200", resp.Data.HighSlot)
+	require.Equal(t, 2, len(resp.Data.Gaps))
+	assert.DeepEqual(t, &structs.BackfillRepairGap{Kind: "blobs", StartSlot: "150", EndSlot: "152", Repaired: true, BatchesImported: "1", BatchesRemaining: "0"}, resp.Data.Gaps[0])
+	assert.DeepEqual(t, &structs.BackfillRepairGap{Kind: "blocks", StartSlot: "110", EndSlot: "140", BatchesImported: "2", BatchesRemaining: "1"}, resp.Data.Gaps[1])
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/execution"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/sync"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/sync/backfill"
 )
 
 type Server struct {
@@ -14,8 +15,11 @@ type Server struct {
 	BeaconDB                  db.ReadOnlyDatabase
 	PeersFetcher              p2p.PeersProvider
 	PeerManager               p2p.PeerManager
+	PeerBanner                p2p.PeerBanner
+	RateLimitUsageFetcher     sync.RateLimitUsageFetcher
 	MetadataProvider          p2p.MetadataProvider
 	GenesisTimeFetcher        blockchain.TimeFetcher
 	HeadFetcher               blockchain.HeadFetcher
 	ExecutionChainInfoFetcher execution.ChainInfoFetcher
+	BackfillRepairFetcher     backfill.RepairStatusFetcher
 }
@@ -39,6 +39,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/startup"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state/stategen"
 	chainSync "github.com/prysmaticlabs/prysm/v5/beacon-chain/sync"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/sync/backfill"
 	"github.com/prysmaticlabs/prysm/v5/config/features"
 	"github.com/prysmaticlabs/prysm/v5/config/params"
 	"github.com/prysmaticlabs/prysm/v5/io/logs"
@@ -78,6 +79,7 @@ type Config struct {
 	BeaconMonitoringHost       string
 	BeaconMonitoringPort       int
 	BeaconDB                   db.HeadAccessDatabase
+	SlasherDB                  db.SlasherDatabase
 	ChainInfoFetcher           blockchain.ChainInfoFetcher
 	HeadFetcher                blockchain.HeadFetcher
 	CanonicalFetcher           blockchain.CanonicalFetcher
@@ -102,7 +104,10 @@ type Config struct {
 	Broadcaster                p2p.Broadcaster
 	PeersFetcher               p2p.PeersProvider
 	PeerManager                p2p.PeerManager
+	PeerBanner                 p2p.PeerBanner
 	MetadataProvider           p2p.MetadataProvider
+	RateLimitUsageFetcher      chainSync.RateLimitUsageFetcher
+	BackfillRepairFetcher      backfill.RepairStatusFetcher
 	DepositFetcher             cache.DepositFetcher
 	PendingDepositFetcher      depositsnapshot.PendingDepositsFetcher
 	StateNotifier              statefeed.Notifier
@@ -117,6 +122,7 @@ type Config struct {
 	ClockWaiter                startup.ClockWaiter
 	BlobStorage                *filesystem.BlobStorage
 	TrackedValidatorsCache     *cache.TrackedValidatorsCache
+	EventSubscribers           *cache.EventSubscribersCache
 	PayloadIDCache             *cache.PayloadIDCache
 	CloseHandler               *closehandler.CloseHandler
 	AuthTokenPath              string
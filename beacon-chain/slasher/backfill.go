@@ -0,0 +1,160 @@
+package slasher
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/filters"
+	slashertypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/slasher/types"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1/attestation"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// DefaultBackfillEpochsPerSlot is the number of epochs of history queued by the backfill on each slot.
+	DefaultBackfillEpochsPerSlot = 1
+	// maxBackfillQueuedAttestations is the number of queued attestations above which the backfill
+	// waits for the queue to be processed, so that live attestations are not delayed by history.
+	maxBackfillQueuedAttestations = 100_000
+)
+
+// Backfill the slasher history from the blocks stored in the beacon database. On each slot tick,
+// the proposals and the attestations of the blocks of the next epochs of the history ending at the
+// given epoch are queued as if they were received live, so that slashing detection and the submission
+// of the slashings found to the slashing pool happen in the usual processing routines.
+func (s *Service) backfill(ctx context.Context, slotTicker <-chan primitives.Slot, endEpoch primitives.Epoch) {
+	defer s.wg.Done()
+
+	// The head state holds the shufflings of the whole history.
+	headState, err := s.serviceCfg.HeadStateFetcher.HeadState(ctx)
+	if err != nil {
+		log.WithError(err).Error("Could not get head state for slasher backfill")
+		return
+	}
+	epochsPerSlot := s.serviceCfg.BackfillEpochsPerSlot
+	if epochsPerSlot == 0 {
+		epochsPerSlot = DefaultBackfillEpochsPerSlot
+	}
+	var epoch primitives.Epoch
+	if endEpoch >= s.params.historyLength {
+		epoch = endEpoch + 1 - s.params.historyLength
+	}
+	log.WithFields(logrus.Fields{
+		"startEpoch": epoch,
+		"endEpoch":   endEpoch,
+	}).Info("Starting slasher historical backfill")
+
+	start := time.Now()
+	for epoch <= endEpoch {
+		select {
+		case slot := <-slotTicker:
+			if s.attsQueue.size() > maxBackfillQueuedAttestations {
+				log.WithField("attsQueueSize", s.attsQueue.size()).Debug("Waiting for queued attestations to be processed")
+				continue
+			}
+			// The history window moves on while the backfill runs, so the epochs which left it are skipped.
+			if windowStart := s.backfillWindowStart(slot); epoch < windowStart {
+				log.WithFields(logrus.Fields{
+					"fromEpoch": epoch,
+					"toEpoch":   windowStart,
+				}).Debug("Skipping epochs which left the slasher history window")
+				epoch = windowStart
+			}
+			for i := uint64(0); i < epochsPerSlot && epoch <= endEpoch; i++ {
+				numBlocks, numAtts, err := s.backfillEpoch(ctx, headState, epoch)
+				if err != nil {
+					log.WithError(err).WithField("epoch", epoch).Error("Could not backfill slasher history")
+					return
+				}
+				backfilledEpochsTotal.Inc()
+				log.WithFields(logrus.Fields{
+					"epoch":     epoch,
+					"numBlocks": numBlocks,
+					"numAtts":   numAtts,
+				}).Debug("Queued historical blocks and attestations")
+				epoch++
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+	log.WithField("elapsed", time.Since(start)).Info("Completed slasher historical backfill")
+}
+
+// backfillWindowStart returns the lowest epoch worth backfilling at the given slot. The queued blocks and
+// attestations are processed from the next slot on, possibly in the next epoch, whose history window starts
+// after the epochs the slasher would drop.
+func (s *Service) backfillWindowStart(slot primitives.Slot) primitives.Epoch {
+	next := slots.ToEpoch(slot) + 1
+	if next < s.params.historyLength {
+		return 0
+	}
+	return next + 1 - s.params.historyLength
+}
+
+// Queue the proposals and the attestations of the blocks of an epoch stored in the beacon database,
+// returning the number of blocks and of attestations queued.
+func (s *Service) backfillEpoch(ctx context.Context, st state.ReadOnlyBeaconState, epoch primitives.Epoch) (int, int, error) {
+	startSlot, err := slots.EpochStart(epoch)
+	if err != nil {
+		return 0, 0, err
+	}
+	endSlot, err := slots.EpochEnd(epoch)
+	if err != nil {
+		return 0, 0, err
+	}
+	blks, _, err := s.serviceCfg.BeaconDB.Blocks(ctx, filters.NewFilter().SetStartSlot(startSlot).SetEndSlot(endSlot))
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "could not get blocks")
+	}
+
+	numAtts := 0
+	for _, blk := range blks {
+		header, err := blk.Header()
+		if err != nil {
+			return 0, 0, errors.Wrap(err, "could not get block header")
+		}
+		if validateBlockHeaderIntegrity(header) {
+			headerRoot, err := header.Header.HashTreeRoot()
+			if err != nil {
+				return 0, 0, errors.Wrap(err, "could not get hash tree root of block header")
+			}
+			s.blksQueue.push(&slashertypes.SignedBlockHeaderWrapper{
+				SignedBeaconBlockHeader: header,
+				HeaderRoot:              headerRoot,
+			})
+		}
+
+		for _, att := range blk.Block().Body().Attestations() {
+			// Attestations of blocks which are not canonical may not match the canonical shufflings.
+			committees, err := helpers.AttestationCommittees(ctx, st, att)
+			if err != nil {
+				log.WithError(err).Debug("Could not get committees of historical attestation")
+				continue
+			}
+			indexed, err := attestation.ConvertToIndexed(ctx, att, committees...)
+			if err != nil {
+				log.WithError(err).Debug("Could not convert historical attestation to indexed form")
+				continue
+			}
+			if !validateAttestationIntegrity(indexed) {
+				continue
+			}
+			dataRoot, err := indexed.GetData().HashTreeRoot()
+			if err != nil {
+				return 0, 0, errors.Wrap(err, "could not get hash tree root of attestation")
+			}
+			s.attsQueue.push(&slashertypes.IndexedAttestationWrapper{
+				IndexedAttestation: indexed,
+				DataRoot:           dataRoot,
+			})
+			numAtts++
+		}
+	}
+	return len(blks), numAtts, nil
+}
@@ -0,0 +1,147 @@
+package slasher
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/go-bitfield"
+	mock "github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
+	dbtest "github.com/prysmaticlabs/prysm/v5/beacon-chain/db/testing"
+	slashertypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/slasher/types"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+func TestService_Backfill(t *testing.T) {
+	ctx := context.Background()
+	helpers.ClearCache()
+	beaconDB := dbtest.SetupDB(t)
+	slasherDB := dbtest.SetupSlasherDB(t)
+
+	st, _ := util.DeterministicGenesisState(t, 64)
+	slotsPerEpoch := params.BeaconConfig().SlotsPerEpoch
+	require.NoError(t, st.SetSlot(primitives.Slot(3)*slotsPerEpoch))
+
+	// Every block includes an attestation of the first committee of its epoch.
+	for _, target := range []primitives.Epoch{1, 2, 3} {
+		attSlot := primitives.Slot(target) * slotsPerEpoch
+		committee, err := helpers.BeaconCommitteeFromState(ctx, st, attSlot, 0)
+		require.NoError(t, err)
+		bits := bitfield.NewBitlist(uint64(len(committee)))
+		bits.SetBitAt(0, true)
+		b := util.NewBeaconBlock()
+		b.Block.Slot = attSlot + 1
+		b.Block.Body.Attestations = []*ethpb.Attestation{{
+			AggregationBits: bits,
+			Data: &ethpb.AttestationData{
+				Slot:            attSlot,
+				BeaconBlockRoot: make([]byte, 32),
+				Source:          &ethpb.Checkpoint{Epoch: target - 1, Root: make([]byte, 32)},
+				Target:          &ethpb.Checkpoint{Epoch: target, Root: make([]byte, 32)},
+			},
+			Signature: make([]byte, 96),
+		}}
+		b.Signature = bytes.Repeat([]byte{1}, 96)
+		util.SaveBlock(t, ctx, beaconDB, b)
+	}
+	// A second block of the proposer of the first one.
+	b := util.NewBeaconBlock()
+	b.Block.Slot = slotsPerEpoch + 1
+	b.Block.Body.Graffiti = bytes.Repeat([]byte{2}, 32)
+	b.Signature = bytes.Repeat([]byte{1}, 96)
+	util.SaveBlock(t, ctx, beaconDB, b)
+
+	s := &Service{
+		params: NewParams(2, 8, 16),
+		serviceCfg: &ServiceConfig{
+			Database:              slasherDB,
+			BeaconDB:              beaconDB,
+			HeadStateFetcher:      &mock.ChainService{State: st},
+			BackfillEpochsPerSlot: 2,
+		},
+		attsQueue: newAttestationsQueue(),
+		blksQueue: newBlocksQueue(),
+	}
+
+	// The backfill waits while the attestations queue is full.
+	s.attsQueue.extend(make([]*slashertypes.IndexedAttestationWrapper, maxBackfillQueuedAttestations+1))
+	ticker := make(chan primitives.Slot)
+	s.wg.Add(1)
+	go s.backfill(ctx, ticker, 3)
+	ticker <- 0
+	ticker <- 1
+	require.Equal(t, 0, s.blksQueue.size())
+	s.attsQueue.dequeue()
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+	ticks := 0
+	for finished := false; !finished; {
+		select {
+		case ticker <- primitives.Slot(2 + ticks):
+			ticks++
+		case <-done:
+			finished = true
+		}
+	}
+	// Two epochs are queued on each slot, from the slot following the full queue at the latest.
+	require.Equal(t, true, ticks <= 2)
+
+	atts := s.attsQueue.dequeue()
+	require.Equal(t, 3, len(atts))
+	for i, att := range atts {
+		require.Equal(t, primitives.Epoch(i+1), att.IndexedAttestation.GetData().Target.Epoch)
+		require.Equal(t, 1, len(att.IndexedAttestation.GetAttestingIndices()))
+	}
+	blocks := s.blksQueue.dequeue()
+	require.Equal(t, 4, len(blocks))
+	slashings, err := s.detectProposerSlashings(ctx, blocks)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(slashings))
+	require.Equal(t, slotsPerEpoch+1, slashings[0].Header_1.Header.Slot)
+}
+
+func TestService_BackfillSkipsEpochsOutOfWindow(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := dbtest.SetupDB(t)
+	slasherDB := dbtest.SetupSlasherDB(t)
+
+	st, _ := util.DeterministicGenesisState(t, 64)
+	slotsPerEpoch := params.BeaconConfig().SlotsPerEpoch
+	for epoch := primitives.Epoch(1); epoch <= 4; epoch++ {
+		b := util.NewBeaconBlock()
+		b.Block.Slot = primitives.Slot(epoch) * slotsPerEpoch
+		b.Signature = bytes.Repeat([]byte{1}, 96)
+		util.SaveBlock(t, ctx, beaconDB, b)
+	}
+
+	s := &Service{
+		params: NewParams(2, 8, 2),
+		serviceCfg: &ServiceConfig{
+			Database:              slasherDB,
+			BeaconDB:              beaconDB,
+			HeadStateFetcher:      &mock.ChainService{State: st},
+			BackfillEpochsPerSlot: 8,
+		},
+		attsQueue: newAttestationsQueue(),
+		blksQueue: newBlocksQueue(),
+	}
+	ticker := make(chan primitives.Slot, 1)
+	ticker <- primitives.Slot(3) * slotsPerEpoch
+	s.wg.Add(1)
+	go s.backfill(ctx, ticker, 4)
+	s.wg.Wait()
+
+	// When processed in epoch 4, the history window of 2 epochs starts at epoch 3.
+	blocks := s.blksQueue.dequeue()
+	require.Equal(t, 2, len(blocks))
+	require.Equal(t, primitives.Slot(3)*slotsPerEpoch, blocks[0].SignedBeaconBlockHeader.Header.Slot)
+}
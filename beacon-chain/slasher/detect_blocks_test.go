@@ -102,12 +102,13 @@ func Test_processQueuedBlocks_DetectsDoubleProposals(t *testing.T) {
 			mockChain := &mock.ChainService{
 				State: beaconState,
 			}
+			stateGen := stategen.New(beaconDB, doublylinkedtree.New())
 			s := &Service{
 				serviceCfg: &ServiceConfig{
 					Database:             slasherDB,
 					StateNotifier:        &mock.MockStateNotifier{},
 					HeadStateFetcher:     mockChain,
-					StateGen:             stategen.New(beaconDB, doublylinkedtree.New()),
+					StateGen:             stateGen,
 					SlashingPoolInserter: &slashingsmock.PoolMock{},
 					ClockWaiter:          startup.NewClockSynchronizer(),
 				},
@@ -116,7 +117,7 @@ func Test_processQueuedBlocks_DetectsDoubleProposals(t *testing.T) {
 			}
 
 			parentRoot := bytesutil.ToBytes32([]byte("parent"))
-			err = s.serviceCfg.StateGen.SaveState(ctx, parentRoot, beaconState)
+			err = stateGen.SaveState(ctx, parentRoot, beaconState)
 			require.NoError(t, err)
 
 			currentSlotChan := make(chan primitives.Slot)
@@ -53,4 +53,8 @@ var (
 		Name: "slasher_surrounded_votes_total",
 		Help: "Total slashable surrounded votes successfully detected by slasher",
 	})
+	backfilledEpochsTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "slasher_backfilled_epochs_total",
+		Help: "Total number of historical epochs queued by the slasher backfill",
+	})
 )
@@ -42,11 +42,12 @@ func TestService_processAttesterSlashings(t *testing.T) {
 	mockChain := &mock.ChainService{
 		State: beaconState,
 	}
+	stateGen := stategen.New(beaconDB, doublylinkedtree.New())
 	s := &Service{
 		serviceCfg: &ServiceConfig{
 			Database:                slasherDB,
 			AttestationStateFetcher: mockChain,
-			StateGen:                stategen.New(beaconDB, doublylinkedtree.New()),
+			StateGen:                stateGen,
 			SlashingPoolInserter:    &slashingsmock.PoolMock{},
 			HeadStateFetcher:        mockChain,
 		},
@@ -164,18 +165,19 @@ func TestService_processProposerSlashings(t *testing.T) {
 	mockChain := &mock.ChainService{
 		State: beaconState,
 	}
+	stateGen := stategen.New(beaconDB, doublylinkedtree.New())
 	s := &Service{
 		serviceCfg: &ServiceConfig{
 			Database:                slasherDB,
 			AttestationStateFetcher: mockChain,
-			StateGen:                stategen.New(beaconDB, doublylinkedtree.New()),
+			StateGen:                stateGen,
 			SlashingPoolInserter:    &slashingsmock.PoolMock{},
 			HeadStateFetcher:        mockChain,
 		},
 	}
 
 	parentRoot := bytesutil.ToBytes32([]byte("parent"))
-	err = s.serviceCfg.StateGen.SaveState(ctx, parentRoot, beaconState)
+	err = stateGen.SaveState(ctx, parentRoot, beaconState)
 	require.NoError(t, err)
 
 	firstBlockHeader := util.HydrateSignedBeaconHeader(&ethpb.SignedBeaconBlockHeader{
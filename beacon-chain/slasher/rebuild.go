@@ -0,0 +1,238 @@
+package slasher
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/filters"
+	slashertypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/slasher/types"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1/attestation"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+	"github.com/sirupsen/logrus"
+)
+
+// RebuildConfig defines the offline databases of a span rebuild, the parameters the spans are
+// stored with, and the window of epochs whose spans are rebuilt.
+type RebuildConfig struct {
+	SlasherDB  db.SlasherDatabase
+	BeaconDB   db.HeadAccessDatabase
+	Params     *Parameters
+	StartEpoch primitives.Epoch
+	EndEpoch   primitives.Epoch
+}
+
+// RebuildResult reports the epoch the spans were rebuilt up to, and what the rebuild read and wrote.
+type RebuildResult struct {
+	CurrentEpoch primitives.Epoch
+	Blocks       int
+	Attestations int
+	Chunks       int
+}
+
+// RebuildSpans rebuilds the min and max span chunks holding the epochs of a window from the
+// attestations included in the blocks of the beacon database, for every validator.
+//
+// Chunks hold whole ranges of epochs, so every epoch held by the chunks of the window is reset
+// and rebuilt. The span of an epoch depends on the attestations whose source or target lie after
+// it, so the attestations of every block from the window up to the current epoch are applied.
+// Committees are computed from the latest state saved at or below the head, which holds the
+// shufflings up to the epoch following its own: that epoch is the current epoch of the rebuild,
+// and the window must lie within the slasher history ending at it.
+func RebuildSpans(ctx context.Context, cfg *RebuildConfig) (*RebuildResult, error) {
+	if cfg.EndEpoch < cfg.StartEpoch {
+		return nil, fmt.Errorf("end epoch %d is before start epoch %d", cfg.EndEpoch, cfg.StartEpoch)
+	}
+	head, err := cfg.BeaconDB.HeadBlock(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get head block")
+	}
+	if head == nil || head.IsNil() {
+		return nil, errors.New("no head block in the beacon db")
+	}
+	states, err := cfg.BeaconDB.HighestSlotStatesBelow(ctx, head.Block().Slot()+1)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get latest saved state")
+	}
+	if len(states) == 0 {
+		return nil, errors.New("no state saved in the beacon db")
+	}
+	st := states[0]
+
+	s := &Service{params: cfg.Params, serviceCfg: &ServiceConfig{Database: cfg.SlasherDB}}
+	current := min(slots.ToEpoch(head.Block().Slot()), slots.ToEpoch(st.Slot())+1)
+	var lowest primitives.Epoch
+	if current >= s.params.historyLength {
+		lowest = current + 1 - s.params.historyLength
+	}
+	if cfg.StartEpoch < lowest || cfg.EndEpoch > current {
+		return nil, fmt.Errorf(
+			"epochs [%d, %d] are outside the slasher history [%d, %d] of the beacon db",
+			cfg.StartEpoch, cfg.EndEpoch, lowest, current,
+		)
+	}
+
+	chunkIndexes := make(map[uint64]bool)
+	for e := cfg.StartEpoch; e <= cfg.EndEpoch; e++ {
+		chunkIndexes[s.params.chunkIndex(e)] = true
+	}
+	// The epochs of the history held by the chunks of the window, in increasing order.
+	var epochs []primitives.Epoch
+	for e := lowest; e <= current; e++ {
+		if chunkIndexes[s.params.chunkIndex(e)] {
+			epochs = append(epochs, e)
+		}
+	}
+	indexes := make([]uint64, 0, len(chunkIndexes))
+	for chunkIndex := range chunkIndexes {
+		indexes = append(indexes, chunkIndex)
+	}
+
+	validatorChunks := (uint64(st.NumValidators()) + s.params.validatorChunkSize - 1) / s.params.validatorChunkSize
+	chunksByKind := make(map[slashertypes.ChunkKind]map[uint64]map[uint64]Chunker)
+	for _, kind := range []slashertypes.ChunkKind{slashertypes.MinSpan, slashertypes.MaxSpan} {
+		chunksByKind[kind] = make(map[uint64]map[uint64]Chunker, validatorChunks)
+		for validatorChunkIndex := uint64(0); validatorChunkIndex < validatorChunks; validatorChunkIndex++ {
+			chunks, err := s.resetChunks(ctx, kind, validatorChunkIndex, indexes, epochs)
+			if err != nil {
+				return nil, err
+			}
+			chunksByKind[kind][validatorChunkIndex] = chunks
+		}
+	}
+
+	result := &RebuildResult{CurrentEpoch: current}
+	// The spans of an epoch depend on the attestations with a later target, which are included
+	// in blocks from the start of the following epoch.
+	for epoch := epochs[0] + 1; epoch <= slots.ToEpoch(head.Block().Slot()); epoch++ {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		start, err := slots.EpochStart(epoch)
+		if err != nil {
+			return nil, err
+		}
+		end, err := slots.EpochEnd(epoch)
+		if err != nil {
+			return nil, err
+		}
+		blks, _, err := cfg.BeaconDB.Blocks(ctx, filters.NewFilter().SetStartSlot(start).SetEndSlot(end))
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not get blocks of epoch %d", epoch)
+		}
+		for _, blk := range blks {
+			for _, att := range blk.Block().Body().Attestations() {
+				// Committees are only known up to the current epoch.
+				if att.GetData().Target.Epoch > current {
+					continue
+				}
+				committees, err := helpers.AttestationCommittees(ctx, st, att)
+				if err != nil {
+					return nil, errors.Wrap(err, "could not get attestation committees")
+				}
+				indexed, err := attestation.ConvertToIndexed(ctx, att, committees...)
+				if err != nil {
+					return nil, errors.Wrap(err, "could not convert to indexed attestation")
+				}
+				if !validateAttestationIntegrity(indexed) {
+					continue
+				}
+				data := indexed.GetData()
+				if err := s.applyRebuiltAttestation(
+					chunksByKind, data.Source.Epoch, data.Target.Epoch, indexed.GetAttestingIndices(), epochs,
+				); err != nil {
+					return nil, err
+				}
+				result.Attestations++
+			}
+		}
+		result.Blocks += len(blks)
+	}
+
+	for kind, chunks := range chunksByKind {
+		if err := s.saveChunksToDisk(ctx, kind, chunks); err != nil {
+			return nil, errors.Wrapf(err, "could not save %s chunks", kind)
+		}
+		for _, c := range chunks {
+			result.Chunks += len(c)
+		}
+	}
+	log.WithFields(logrus.Fields{
+		"startEpoch":   epochs[0],
+		"endEpoch":     epochs[len(epochs)-1],
+		"currentEpoch": current,
+		"blocks":       result.Blocks,
+		"attestations": result.Attestations,
+		"chunks":       result.Chunks,
+	}).Info("Rebuilt slasher spans")
+	return result, nil
+}
+
+// resetChunks loads the chunks of a validator chunk at the given chunk indexes, and resets the
+// spans of the given epochs to the neutral element for every validator of the validator chunk.
+func (s *Service) resetChunks(
+	ctx context.Context,
+	kind slashertypes.ChunkKind,
+	validatorChunkIndex uint64,
+	chunkIndexes []uint64,
+	epochs []primitives.Epoch,
+) (map[uint64]Chunker, error) {
+	chunks, err := s.loadChunksFromDisk(ctx, validatorChunkIndex, kind, chunkIndexes)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not load chunks of validator chunk %d", validatorChunkIndex)
+	}
+	for _, validatorIndex := range s.params.ValidatorIndexesInChunk(validatorChunkIndex) {
+		for _, epoch := range epochs {
+			chunk := chunks[s.params.chunkIndex(epoch)]
+			if err := setChunkRawDistance(s.params, chunk.Chunk(), validatorIndex, epoch, chunk.NeutralElement()); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return chunks, nil
+}
+
+// applyRebuiltAttestation applies an attestation to the spans of the given epochs of its attesters,
+// following the definition of the spans: the min span of an epoch is the lowest target of the
+// attestations with a later source, and the max span is the highest target of the attestations
+// surrounding it.
+func (s *Service) applyRebuiltAttestation(
+	chunksByKind map[slashertypes.ChunkKind]map[uint64]map[uint64]Chunker,
+	source, target primitives.Epoch,
+	attestingIndices []uint64,
+	epochs []primitives.Epoch,
+) error {
+	for _, idx := range attestingIndices {
+		validatorIndex := primitives.ValidatorIndex(idx)
+		validatorChunkIndex := s.params.validatorChunkIndex(validatorIndex)
+		minChunks, ok := chunksByKind[slashertypes.MinSpan][validatorChunkIndex]
+		if !ok {
+			return fmt.Errorf("validator %d is not in the latest saved state", validatorIndex)
+		}
+		maxChunks := chunksByKind[slashertypes.MaxSpan][validatorChunkIndex]
+		for _, epoch := range epochs {
+			var chunk []uint16
+			switch {
+			case epoch < source:
+				chunk = minChunks[s.params.chunkIndex(epoch)].Chunk()
+			case epoch > source && epoch < target:
+				chunk = maxChunks[s.params.chunkIndex(epoch)].Chunk()
+			default:
+				continue
+			}
+			existing, err := chunkDataAtEpoch(s.params, chunk, validatorIndex, epoch)
+			if err != nil {
+				return err
+			}
+			if (epoch < source && target < existing) || (epoch > source && target > existing) {
+				if err := setChunkDataAtEpoch(s.params, chunk, validatorIndex, epoch, target); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
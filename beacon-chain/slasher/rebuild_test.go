@@ -0,0 +1,99 @@
+package slasher
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
+	dbtest "github.com/prysmaticlabs/prysm/v5/beacon-chain/db/testing"
+	slashertypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/slasher/types"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+func TestRebuildSpans(t *testing.T) {
+	ctx := context.Background()
+	helpers.ClearCache()
+	beaconDB := dbtest.SetupDB(t)
+	slasherDB := dbtest.SetupSlasherDB(t)
+	p := NewParams(2, 8, 16)
+	s := &Service{params: p, serviceCfg: &ServiceConfig{Database: slasherDB}}
+
+	st, _ := util.DeterministicGenesisState(t, 64)
+	slotsPerEpoch := params.BeaconConfig().SlotsPerEpoch
+	require.NoError(t, st.SetSlot(primitives.Slot(3)*slotsPerEpoch))
+
+	// Every block includes an attestation of the first committee of its epoch, from the genesis epoch.
+	committees := make(map[primitives.Epoch][]primitives.ValidatorIndex)
+	var headRoot [32]byte
+	for _, target := range []primitives.Epoch{1, 2, 3} {
+		attSlot := primitives.Slot(target) * slotsPerEpoch
+		committee, err := helpers.BeaconCommitteeFromState(ctx, st, attSlot, 0)
+		require.NoError(t, err)
+		committees[target] = committee
+		bits := bitfield.NewBitlist(uint64(len(committee)))
+		for i := range committee {
+			bits.SetBitAt(uint64(i), true)
+		}
+		b := util.NewBeaconBlock()
+		b.Block.Slot = attSlot + 1
+		b.Block.Body.Attestations = []*ethpb.Attestation{{
+			AggregationBits: bits,
+			Data: &ethpb.AttestationData{
+				Slot:            attSlot,
+				BeaconBlockRoot: make([]byte, 32),
+				Source:          &ethpb.Checkpoint{Epoch: 0, Root: make([]byte, 32)},
+				Target:          &ethpb.Checkpoint{Epoch: target, Root: make([]byte, 32)},
+			},
+			Signature: make([]byte, 96),
+		}}
+		headRoot, err = util.SaveBlock(t, ctx, beaconDB, b).Block().HashTreeRoot()
+		require.NoError(t, err)
+	}
+	require.NoError(t, beaconDB.SaveState(ctx, st, headRoot))
+	require.NoError(t, beaconDB.SaveHeadBlockRoot(ctx, headRoot))
+
+	// Corrupt the max spans of the first chunk, which holds epochs 0 and 1.
+	corrupt := make([]uint16, p.chunkSize*p.validatorChunkSize)
+	for i := range corrupt {
+		corrupt[i] = 7
+	}
+	require.NoError(t, slasherDB.SaveSlasherChunks(ctx, slashertypes.MaxSpan, [][]byte{p.flatSliceID(0, 0)}, [][]uint16{corrupt}))
+
+	_, err := RebuildSpans(ctx, &RebuildConfig{SlasherDB: slasherDB, BeaconDB: beaconDB, Params: p, StartEpoch: 4, EndEpoch: 4})
+	require.ErrorContains(t, "outside the slasher history", err)
+	result, err := RebuildSpans(ctx, &RebuildConfig{SlasherDB: slasherDB, BeaconDB: beaconDB, Params: p, StartEpoch: 1, EndEpoch: 1})
+	require.NoError(t, err)
+	require.Equal(t, primitives.Epoch(3), result.CurrentEpoch)
+	require.Equal(t, 3, result.Attestations)
+
+	maxChunk, err := s.getChunkFromDatabase(ctx, slashertypes.MaxSpan, 0, 0)
+	require.NoError(t, err)
+	minChunk, err := s.getChunkFromDatabase(ctx, slashertypes.MinSpan, 0, 0)
+	require.NoError(t, err)
+	want := make(map[primitives.ValidatorIndex]primitives.Epoch)
+	for _, target := range []primitives.Epoch{2, 3} {
+		for _, v := range committees[target] {
+			want[v] = target
+		}
+	}
+	for _, v := range p.ValidatorIndexesInChunk(0) {
+		// The max span of epoch 1 is the highest target of the attestations surrounding it.
+		target, err := chunkDataAtEpoch(p, maxChunk.Chunk(), v, 1)
+		require.NoError(t, err)
+		if w, ok := want[v]; ok {
+			require.Equal(t, w, target)
+		} else {
+			require.Equal(t, primitives.Epoch(1), target)
+		}
+		// No attestation has a source after the genesis epoch.
+		target, err = chunkDataAtEpoch(p, minChunk.Chunk(), v, 0)
+		require.NoError(t, err)
+		require.Equal(t, primitives.Epoch(math.MaxUint16), target)
+	}
+}
@@ -0,0 +1,151 @@
+package remote
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/api"
+	"github.com/prysmaticlabs/prysm/v5/api/client"
+	"github.com/prysmaticlabs/prysm/v5/api/client/beacon"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v5/encoding/ssz/detect"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+)
+
+const (
+	getGenesisPath                = "/eth/v1/beacon/genesis"
+	getSyncingPath                = "/eth/v1/node/syncing"
+	postAttesterSlashingPath      = "/eth/v1/beacon/pool/attester_slashings"
+	postAttesterSlashingPathV2    = "/eth/v2/beacon/pool/attester_slashings"
+	postProposerSlashingPath      = "/eth/v1/beacon/pool/proposer_slashings"
+	defaultBeaconNodeTimeout      = 30 * time.Second
+	defaultBeaconNodeStateTimeout = 5 * time.Minute
+)
+
+// beaconNode is a beacon node followed through its beacon API.
+type beaconNode struct {
+	*beacon.Client
+	// The state is fetched with its own client, as it takes much longer than the other requests.
+	stateClient *beacon.Client
+	// The event stream is long-lived, so it is read without a timeout.
+	eventClient *http.Client
+}
+
+func newBeaconNode(host string, timeout time.Duration) (*beaconNode, error) {
+	if timeout == 0 {
+		timeout = defaultBeaconNodeTimeout
+	}
+	c, err := beacon.NewClient(host, client.WithTimeout(timeout))
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid beacon node %s", host)
+	}
+	sc, err := beacon.NewClient(
+		host, client.WithTimeout(defaultBeaconNodeStateTimeout), client.WithMaxBodySize(client.MaxBodySizeState),
+	)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid beacon node %s", host)
+	}
+	return &beaconNode{Client: c, stateClient: sc, eventClient: &http.Client{}}, nil
+}
+
+func (n *beaconNode) genesis(ctx context.Context) (time.Time, [32]byte, error) {
+	b, err := n.Get(ctx, getGenesisPath)
+	if err != nil {
+		return time.Time{}, [32]byte{}, errors.Wrap(err, "could not get genesis")
+	}
+	resp := &structs.GetGenesisResponse{}
+	if err := json.Unmarshal(b, resp); err != nil {
+		return time.Time{}, [32]byte{}, errors.Wrap(err, "could not decode genesis")
+	}
+	if resp.Data == nil {
+		return time.Time{}, [32]byte{}, errors.New("empty genesis response")
+	}
+	genesisTime, err := strconv.ParseInt(resp.Data.GenesisTime, 10, 64)
+	if err != nil {
+		return time.Time{}, [32]byte{}, errors.Wrap(err, "invalid genesis time")
+	}
+	root, err := hexutil.Decode(resp.Data.GenesisValidatorsRoot)
+	if err != nil {
+		return time.Time{}, [32]byte{}, errors.Wrap(err, "invalid genesis validators root")
+	}
+	return time.Unix(genesisTime, 0), bytesutil.ToBytes32(root), nil
+}
+
+func (n *beaconNode) syncing(ctx context.Context) (bool, error) {
+	b, err := n.Get(ctx, getSyncingPath)
+	if err != nil {
+		return false, errors.Wrap(err, "could not get sync status")
+	}
+	resp := &structs.SyncStatusResponse{}
+	if err := json.Unmarshal(b, resp); err != nil {
+		return false, errors.Wrap(err, "could not decode sync status")
+	}
+	if resp.Data == nil {
+		return false, errors.New("empty sync status response")
+	}
+	return resp.Data.IsSyncing, nil
+}
+
+func (n *beaconNode) headState(ctx context.Context) (state.BeaconState, error) {
+	b, err := n.stateClient.GetState(ctx, beacon.IdHead)
+	if err != nil {
+		return nil, err
+	}
+	vu, err := detect.FromState(b)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not detect state version")
+	}
+	return vu.UnmarshalBeaconState(b)
+}
+
+func (n *beaconNode) block(ctx context.Context, root [32]byte) (interfaces.ReadOnlySignedBeaconBlock, error) {
+	b, err := n.GetBlock(ctx, beacon.IdFromRoot(root))
+	if err != nil {
+		return nil, err
+	}
+	vu, err := detect.FromBlock(b)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not detect block version")
+	}
+	return vu.UnmarshalBeaconBlock(b)
+}
+
+func (n *beaconNode) submitAttesterSlashing(ctx context.Context, slashing ethpb.AttSlashing) error {
+	var (
+		body []byte
+		err  error
+	)
+	switch s := slashing.(type) {
+	case *ethpb.AttesterSlashing:
+		if body, err = json.Marshal(structs.AttesterSlashingFromConsensus(s)); err != nil {
+			return err
+		}
+		_, err = n.Post(ctx, postAttesterSlashingPath, body)
+	case *ethpb.AttesterSlashingElectra:
+		if body, err = json.Marshal(structs.AttesterSlashingElectraFromConsensus(s)); err != nil {
+			return err
+		}
+		_, err = n.Post(ctx, postAttesterSlashingPathV2, body, client.WithHeader(api.VersionHeader, version.String(s.Version())))
+	default:
+		return errors.Errorf("unsupported attester slashing type %T", slashing)
+	}
+	return err
+}
+
+func (n *beaconNode) submitProposerSlashing(ctx context.Context, slashing *ethpb.ProposerSlashing) error {
+	body, err := json.Marshal(structs.ProposerSlashingFromConsensus(slashing))
+	if err != nil {
+		return err
+	}
+	_, err = n.Post(ctx, postProposerSlashingPath, body)
+	return err
+}
@@ -0,0 +1,406 @@
+// Package remote runs slasher detection outside of a beacon node. It follows beacon nodes through
+// the events of their beacon API, feeds the blocks and the attestations they process to the slasher,
+// and submits the slashings found to the operation pools of the beacon nodes.
+package remote
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/api/client/event"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	asyncevent "github.com/prysmaticlabs/prysm/v5/async/event"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/operations/slashings"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/slasher"
+	slashertypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/slasher/types"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/startup"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1/attestation"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+	"github.com/sirupsen/logrus"
+)
+
+// reconnectDelay is the time waited before subscribing again to the events of a beacon node.
+const reconnectDelay = 5 * time.Second
+
+var (
+	_ = slasher.HeadFetcher(&Service{})
+	_ = slasher.StateByRootFetcher(&Service{})
+	_ = slasher.SyncChecker(&Service{})
+	_ = startup.ClockWaiter(&Service{})
+	_ = slashings.PoolInserter(&Service{})
+)
+
+// Config of the service following beacon nodes for the slasher.
+type Config struct {
+	BeaconNodes             []string
+	Timeout                 time.Duration
+	IndexedAttestationsFeed *asyncevent.Feed
+	BeaconBlockHeadersFeed  *asyncevent.Feed
+}
+
+// Service follows beacon nodes through the events of their beacon API. The blocks and the
+// attestations they process are sent to the feeds of the slasher, and the service provides the
+// slasher with the head of the chain, the sync status and the genesis of the beacon nodes.
+// The slashings found are submitted to the operation pools of every beacon node.
+//
+// The committees of the attestations and the signatures of the slashings are computed from the
+// head state of the first beacon node which serves it, fetched again on every new epoch.
+type Service struct {
+	cfg        *Config
+	ctx        context.Context
+	cancel     context.CancelFunc
+	nodes      []*beaconNode
+	lock       sync.RWMutex
+	headSlot   primitives.Slot
+	seenBlocks map[[32]byte]primitives.Slot
+	stateLock  sync.Mutex
+	headState  state.BeaconState
+	wg         sync.WaitGroup
+}
+
+// NewService creates a service following the beacon nodes of the configuration.
+func NewService(ctx context.Context, cfg *Config) (*Service, error) {
+	if len(cfg.BeaconNodes) == 0 {
+		return nil, errors.New("no beacon node to follow")
+	}
+	nodes := make([]*beaconNode, len(cfg.BeaconNodes))
+	for i, host := range cfg.BeaconNodes {
+		n, err := newBeaconNode(host, cfg.Timeout)
+		if err != nil {
+			return nil, err
+		}
+		nodes[i] = n
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	return &Service{
+		cfg:        cfg,
+		ctx:        ctx,
+		cancel:     cancel,
+		nodes:      nodes,
+		seenBlocks: make(map[[32]byte]primitives.Slot),
+	}, nil
+}
+
+// Start following the events of the beacon nodes.
+func (s *Service) Start() {
+	for _, n := range s.nodes {
+		s.wg.Add(1)
+		go s.follow(n)
+	}
+}
+
+// Stop following the beacon nodes.
+func (s *Service) Stop() error {
+	s.cancel()
+	s.wg.Wait()
+	return nil
+}
+
+// Status of the service.
+func (*Service) Status() error {
+	return nil
+}
+
+// Subscribe to the block and attestation events of a beacon node, subscribing again whenever the
+// event stream ends.
+func (s *Service) follow(n *beaconNode) {
+	defer s.wg.Done()
+
+	topics := []string{event.EventBlock, event.EventAttestation}
+	for {
+		stream, err := event.NewEventStream(s.ctx, n.eventClient, n.NodeURL(), topics)
+		if err != nil {
+			log.WithError(err).WithField("beaconNode", n.NodeURL()).Error("Could not create event stream")
+			return
+		}
+		events := make(chan *event.Event, 64)
+		done := make(chan struct{})
+		go func() {
+			stream.Subscribe(events)
+			close(done)
+		}()
+		s.handleEvents(n, events, done)
+
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-time.After(reconnectDelay):
+		}
+	}
+}
+
+// Handle the events of a beacon node until its event stream ends.
+func (s *Service) handleEvents(n *beaconNode, events <-chan *event.Event, done <-chan struct{}) {
+	for {
+		select {
+		case ev, ok := <-events:
+			if !ok {
+				return
+			}
+			switch ev.EventType {
+			case event.EventConnectionError:
+				log.WithField("beaconNode", n.NodeURL()).WithError(errors.New(string(ev.Data))).Warn(
+					"Lost connection to beacon node events",
+				)
+				return
+			case event.EventBlock:
+				if err := s.handleBlock(s.ctx, n, ev.Data); err != nil {
+					log.WithError(err).WithField("beaconNode", n.NodeURL()).Error("Could not handle block event")
+				}
+			case event.EventAttestation:
+				if err := s.handleAttestation(s.ctx, ev.Data); err != nil {
+					log.WithError(err).WithField("beaconNode", n.NodeURL()).Debug("Could not handle attestation event")
+				}
+			}
+		case <-done:
+			return
+		case <-s.ctx.Done():
+			return
+		}
+	}
+}
+
+// Send the header and the attestations of a block processed by a beacon node to the slasher,
+// unless the block was already received from another beacon node.
+func (s *Service) handleBlock(ctx context.Context, n *beaconNode, data []byte) error {
+	e := &structs.BlockEvent{}
+	if err := json.Unmarshal(data, e); err != nil {
+		return errors.Wrap(err, "could not decode block event")
+	}
+	r, err := hexutil.Decode(e.Block)
+	if err != nil {
+		return errors.Wrap(err, "invalid block root")
+	}
+	root := bytesutil.ToBytes32(r)
+	slot, err := strconv.ParseUint(e.Slot, 10, 64)
+	if err != nil {
+		return errors.Wrap(err, "invalid block slot")
+	}
+	if !s.markBlockSeen(root, primitives.Slot(slot)) {
+		return nil
+	}
+
+	blk, err := n.block(ctx, root)
+	if err != nil {
+		s.unmarkBlockSeen(root)
+		return errors.Wrapf(err, "could not get block %#x", root)
+	}
+	s.updateHeadSlot(blk.Block().Slot())
+
+	header, err := blk.Header()
+	if err != nil {
+		return errors.Wrap(err, "could not get block header")
+	}
+	s.cfg.BeaconBlockHeadersFeed.Send(header)
+	for _, att := range blk.Block().Body().Attestations() {
+		if err := s.sendAttestation(ctx, att); err != nil {
+			log.WithError(err).Debug("Could not send block attestation to slasher")
+		}
+	}
+	return nil
+}
+
+// Send an attestation received by a beacon node to the slasher.
+func (s *Service) handleAttestation(ctx context.Context, data []byte) error {
+	// Attestations from Alpaca onwards carry committee bits.
+	var v struct {
+		CommitteeBits string `json:"committee_bits"`
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return errors.Wrap(err, "could not decode attestation event")
+	}
+	var (
+		att ethpb.Att
+		err error
+	)
+	if v.CommitteeBits != "" {
+		a := &structs.AttestationElectra{}
+		if err := json.Unmarshal(data, a); err != nil {
+			return errors.Wrap(err, "could not decode attestation event")
+		}
+		att, err = a.ToConsensus()
+	} else {
+		a := &structs.Attestation{}
+		if err := json.Unmarshal(data, a); err != nil {
+			return errors.Wrap(err, "could not decode attestation event")
+		}
+		att, err = a.ToConsensus()
+	}
+	if err != nil {
+		return errors.Wrap(err, "could not convert attestation")
+	}
+	return s.sendAttestation(ctx, att)
+}
+
+func (s *Service) sendAttestation(ctx context.Context, att ethpb.Att) error {
+	st, err := s.HeadState(ctx)
+	if err != nil {
+		return err
+	}
+	committees, err := helpers.AttestationCommittees(ctx, st, att)
+	if err != nil {
+		return errors.Wrap(err, "could not get attestation committees")
+	}
+	indexed, err := attestation.ConvertToIndexed(ctx, att, committees...)
+	if err != nil {
+		return errors.Wrap(err, "could not convert to indexed attestation")
+	}
+	s.cfg.IndexedAttestationsFeed.Send(&slashertypes.WrappedIndexedAtt{IndexedAtt: indexed})
+	return nil
+}
+
+// markBlockSeen records a block root, returning false if it was already recorded.
+// The roots of the blocks older than two epochs are forgotten.
+func (s *Service) markBlockSeen(root [32]byte, slot primitives.Slot) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if _, ok := s.seenBlocks[root]; ok {
+		return false
+	}
+	s.seenBlocks[root] = slot
+	for r, seen := range s.seenBlocks {
+		if seen+2*params.BeaconConfig().SlotsPerEpoch < s.headSlot {
+			delete(s.seenBlocks, r)
+		}
+	}
+	return true
+}
+
+func (s *Service) unmarkBlockSeen(root [32]byte) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	delete(s.seenBlocks, root)
+}
+
+func (s *Service) updateHeadSlot(slot primitives.Slot) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.headSlot = max(s.headSlot, slot)
+}
+
+// HeadSlot is the highest slot of the blocks received from the beacon nodes.
+func (s *Service) HeadSlot() primitives.Slot {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.headSlot
+}
+
+// HeadState returns the head state of the first beacon node which serves it. The state is cached
+// until a block of a later epoch is received. If no beacon node serves it, the cached state is returned.
+func (s *Service) HeadState(ctx context.Context) (state.BeaconState, error) {
+	s.stateLock.Lock()
+	defer s.stateLock.Unlock()
+	headEpoch := slots.ToEpoch(s.HeadSlot())
+	if s.headState != nil && slots.ToEpoch(s.headState.Slot()) >= headEpoch {
+		return s.headState, nil
+	}
+	var err error
+	for _, n := range s.nodes {
+		var st state.BeaconState
+		st, err = n.headState(ctx)
+		if err != nil {
+			log.WithError(err).WithField("beaconNode", n.NodeURL()).Warn("Could not get head state")
+			continue
+		}
+		s.headState = st
+		s.updateHeadSlot(st.Slot())
+		log.WithField("slot", st.Slot()).Debug("Fetched head state")
+		return st, nil
+	}
+	if s.headState != nil {
+		return s.headState, nil
+	}
+	return nil, errors.Wrap(err, "could not get head state from any beacon node")
+}
+
+// AttestationTargetState returns the head state, from which the signatures of the attestations
+// of recent epochs can be verified.
+func (s *Service) AttestationTargetState(ctx context.Context, _ *ethpb.Checkpoint) (state.ReadOnlyBeaconState, error) {
+	return s.HeadState(ctx)
+}
+
+// StateByRoot returns the head state, from which the signatures of the blocks of recent epochs
+// can be verified.
+func (s *Service) StateByRoot(ctx context.Context, _ [32]byte) (state.BeaconState, error) {
+	return s.HeadState(ctx)
+}
+
+// Syncing is false once any of the beacon nodes is synced.
+func (s *Service) Syncing() bool {
+	for _, n := range s.nodes {
+		syncing, err := n.syncing(s.ctx)
+		if err != nil {
+			log.WithError(err).WithField("beaconNode", n.NodeURL()).Debug("Could not get sync status")
+			continue
+		}
+		if !syncing {
+			return false
+		}
+	}
+	return true
+}
+
+// WaitForClock waits until the genesis of the chain is served by one of the beacon nodes.
+func (s *Service) WaitForClock(ctx context.Context) (*startup.Clock, error) {
+	for {
+		for _, n := range s.nodes {
+			genesisTime, root, err := n.genesis(ctx)
+			if err != nil {
+				log.WithError(err).WithField("beaconNode", n.NodeURL()).Warn("Could not get genesis")
+				continue
+			}
+			return startup.NewClock(genesisTime, root), nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(reconnectDelay):
+		}
+	}
+}
+
+// InsertAttesterSlashing submits an attester slashing to the pools of the beacon nodes,
+// failing if none of them accepts it.
+func (s *Service) InsertAttesterSlashing(ctx context.Context, _ state.ReadOnlyBeaconState, slashing ethpb.AttSlashing) error {
+	return s.submit(func(n *beaconNode) error {
+		return n.submitAttesterSlashing(ctx, slashing)
+	})
+}
+
+// InsertProposerSlashing submits a proposer slashing to the pools of the beacon nodes,
+// failing if none of them accepts it.
+func (s *Service) InsertProposerSlashing(ctx context.Context, _ state.ReadOnlyBeaconState, slashing *ethpb.ProposerSlashing) error {
+	return s.submit(func(n *beaconNode) error {
+		return n.submitProposerSlashing(ctx, slashing)
+	})
+}
+
+func (s *Service) submit(f func(n *beaconNode) error) error {
+	var err error
+	submitted := 0
+	for _, n := range s.nodes {
+		if err = f(n); err != nil {
+			log.WithError(err).WithField("beaconNode", n.NodeURL()).Error("Could not submit slashing")
+			continue
+		}
+		submitted++
+	}
+	if submitted == 0 {
+		return errors.Wrap(err, "no beacon node accepted the slashing")
+	}
+	log.WithFields(logrus.Fields{
+		"beaconNodes": submitted,
+	}).Info("Submitted slashing to beacon nodes")
+	return nil
+}
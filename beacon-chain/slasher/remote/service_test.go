@@ -0,0 +1,236 @@
+package remote
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/prysmaticlabs/prysm/v5/api"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	"github.com/prysmaticlabs/prysm/v5/async/event"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
+	slashertypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/slasher/types"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+// beaconNodeServer serves the beacon API endpoints used by the service.
+type beaconNodeServer struct {
+	t        *testing.T
+	lock     sync.Mutex
+	syncing  bool
+	state    []byte
+	blocks   map[string][]byte
+	events   []string
+	posted   map[string][]byte
+	versions map[string]string
+}
+
+func (b *beaconNodeServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	switch {
+	case r.URL.Path == getGenesisPath:
+		writeJSON(b.t, w, &structs.GetGenesisResponse{Data: &structs.Genesis{
+			GenesisTime:           "1606824023",
+			GenesisValidatorsRoot: hexutil.Encode(bytes.Repeat([]byte{1}, 32)),
+		}})
+	case r.URL.Path == getSyncingPath:
+		writeJSON(b.t, w, &structs.SyncStatusResponse{Data: &structs.SyncStatusResponseData{IsSyncing: b.syncing}})
+	case r.URL.Path == "/eth/v2/debug/beacon/states/head":
+		_, err := w.Write(b.state)
+		require.NoError(b.t, err)
+	case r.URL.Path == "/eth/v1/events":
+		w.Header().Set("Content-Type", api.EventStreamMediaType)
+		for _, e := range b.events {
+			_, err := w.Write([]byte(e))
+			require.NoError(b.t, err)
+		}
+		w.(http.Flusher).Flush()
+		b.lock.Unlock()
+		<-r.Context().Done()
+		b.lock.Lock()
+	case r.Method == http.MethodPost:
+		body, err := io.ReadAll(r.Body)
+		require.NoError(b.t, err)
+		b.posted[r.URL.Path] = body
+		b.versions[r.URL.Path] = r.Header.Get(api.VersionHeader)
+	default:
+		blk, ok := b.blocks[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, err := w.Write(blk)
+		require.NoError(b.t, err)
+	}
+}
+
+func writeJSON(t *testing.T, w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", api.JsonMediaType)
+	require.NoError(t, json.NewEncoder(w).Encode(v))
+}
+
+func newBeaconNodeServer(t *testing.T) (*beaconNodeServer, *httptest.Server) {
+	b := &beaconNodeServer{
+		t:        t,
+		blocks:   make(map[string][]byte),
+		posted:   make(map[string][]byte),
+		versions: make(map[string]string),
+	}
+	srv := httptest.NewServer(b)
+	t.Cleanup(srv.Close)
+	return b, srv
+}
+
+func TestService_WaitForClockAndSyncing(t *testing.T) {
+	ctx := context.Background()
+	syncing, srv := newBeaconNodeServer(t)
+	syncing.syncing = true
+	_, synced := newBeaconNodeServer(t)
+
+	s, err := NewService(ctx, &Config{BeaconNodes: []string{srv.URL}})
+	require.NoError(t, err)
+	clock, err := s.WaitForClock(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int64(1606824023), clock.GenesisTime().Unix())
+	require.Equal(t, [32]byte(bytes.Repeat([]byte{1}, 32)), clock.GenesisValidatorsRoot())
+	require.Equal(t, true, s.Syncing())
+
+	// The chain is synced once any beacon node is.
+	s, err = NewService(ctx, &Config{BeaconNodes: []string{srv.URL, synced.URL}})
+	require.NoError(t, err)
+	require.Equal(t, false, s.Syncing())
+
+	_, err = NewService(ctx, &Config{})
+	require.ErrorContains(t, "no beacon node", err)
+}
+
+func TestService_FollowBlocks(t *testing.T) {
+	ctx := context.Background()
+	helpers.ClearCache()
+	st, _ := util.DeterministicGenesisStateElectra(t, 64)
+	slotsPerEpoch := params.BeaconConfig().SlotsPerEpoch
+	require.NoError(t, st.SetSlot(slotsPerEpoch))
+	alpaca := params.BeaconConfig().AlpacaForkVersion
+	require.NoError(t, st.SetFork(&ethpb.Fork{PreviousVersion: alpaca, CurrentVersion: alpaca}))
+	stateSSZ, err := st.MarshalSSZ()
+	require.NoError(t, err)
+
+	committee, err := helpers.BeaconCommitteeFromState(ctx, st, slotsPerEpoch, 0)
+	require.NoError(t, err)
+	bits := bitfield.NewBitlist(uint64(len(committee)))
+	bits.SetBitAt(1, true)
+	committeeBits := bitfield.NewBitvector64()
+	committeeBits.SetBitAt(0, true)
+	b := util.NewBeaconBlockElectra()
+	b.Block.Slot = slotsPerEpoch + 1
+	b.Block.Body.Attestations = []*ethpb.AttestationElectra{{
+		AggregationBits: bits,
+		CommitteeBits:   committeeBits,
+		Data: &ethpb.AttestationData{
+			Slot:            slotsPerEpoch,
+			BeaconBlockRoot: make([]byte, 32),
+			Source:          &ethpb.Checkpoint{Root: make([]byte, 32)},
+			Target:          &ethpb.Checkpoint{Epoch: 1, Root: make([]byte, 32)},
+		},
+		Signature: make([]byte, 96),
+	}}
+	blockSSZ, err := b.MarshalSSZ()
+	require.NoError(t, err)
+	root, err := b.Block.HashTreeRoot()
+	require.NoError(t, err)
+	blockEvent := fmt.Sprintf("event: block\ndata: {\"slot\":\"%d\",\"block\":\"%#x\"}\n\n", b.Block.Slot, root)
+
+	// Both beacon nodes process the block, which is only sent to the slasher once.
+	var hosts []string
+	for i := 0; i < 2; i++ {
+		bn, srv := newBeaconNodeServer(t)
+		bn.state = stateSSZ
+		bn.blocks[fmt.Sprintf("/eth/v2/beacon/blocks/%#x", root)] = blockSSZ
+		bn.events = []string{blockEvent}
+		hosts = append(hosts, srv.URL)
+	}
+	attsFeed, headersFeed := new(event.Feed), new(event.Feed)
+	atts := make(chan *slashertypes.WrappedIndexedAtt, 4)
+	headers := make(chan *ethpb.SignedBeaconBlockHeader, 4)
+	attsSub, headersSub := attsFeed.Subscribe(atts), headersFeed.Subscribe(headers)
+	defer attsSub.Unsubscribe()
+	defer headersSub.Unsubscribe()
+
+	s, err := NewService(ctx, &Config{
+		BeaconNodes:             hosts,
+		IndexedAttestationsFeed: attsFeed,
+		BeaconBlockHeadersFeed:  headersFeed,
+	})
+	require.NoError(t, err)
+	s.Start()
+	defer func() {
+		require.NoError(t, s.Stop())
+	}()
+
+	select {
+	case header := <-headers:
+		require.Equal(t, b.Block.Slot, header.Header.Slot)
+	case <-time.After(10 * time.Second):
+		t.Fatal("Did not receive block header")
+	}
+	select {
+	case att := <-atts:
+		require.DeepEqual(t, []uint64{uint64(committee[1])}, att.GetAttestingIndices())
+	case <-time.After(10 * time.Second):
+		t.Fatal("Did not receive attestation")
+	}
+	require.Equal(t, b.Block.Slot, s.HeadSlot())
+	select {
+	case <-headers:
+		t.Fatal("Received the block of the second beacon node")
+	case <-time.After(500 * time.Millisecond):
+	}
+}
+
+func TestService_InsertSlashings(t *testing.T) {
+	ctx := context.Background()
+	var servers []*beaconNodeServer
+	var hosts []string
+	for i := 0; i < 2; i++ {
+		bn, srv := newBeaconNodeServer(t)
+		servers = append(servers, bn)
+		hosts = append(hosts, srv.URL)
+	}
+	s, err := NewService(ctx, &Config{BeaconNodes: hosts})
+	require.NoError(t, err)
+
+	att := util.HydrateIndexedAttestation(&ethpb.IndexedAttestation{AttestingIndices: []uint64{1}})
+	require.NoError(t, s.InsertAttesterSlashing(ctx, nil, &ethpb.AttesterSlashing{Attestation_1: att, Attestation_2: att}))
+	attElectra := &ethpb.IndexedAttestationElectra{AttestingIndices: []uint64{1}, Data: att.Data, Signature: att.Signature}
+	require.NoError(t, s.InsertAttesterSlashing(ctx, nil, &ethpb.AttesterSlashingElectra{Attestation_1: attElectra, Attestation_2: attElectra}))
+	header := util.HydrateSignedBeaconHeader(&ethpb.SignedBeaconBlockHeader{Header: &ethpb.BeaconBlockHeader{ProposerIndex: 3}})
+	require.NoError(t, s.InsertProposerSlashing(ctx, nil, &ethpb.ProposerSlashing{Header_1: header, Header_2: header}))
+
+	for _, bn := range servers {
+		slashing := &structs.AttesterSlashing{}
+		require.NoError(t, json.Unmarshal(bn.posted[postAttesterSlashingPath], slashing))
+		require.DeepEqual(t, []string{"1"}, slashing.Attestation1.AttestingIndices)
+		require.NotEqual(t, "", bn.versions[postAttesterSlashingPathV2])
+		proposerSlashing := &structs.ProposerSlashing{}
+		require.NoError(t, json.Unmarshal(bn.posted[postProposerSlashingPath], proposerSlashing))
+		require.Equal(t, "3", proposerSlashing.SignedHeader1.Message.ProposerIndex)
+	}
+
+	// The slashing fails when no beacon node accepts it.
+	s, err = NewService(ctx, &Config{BeaconNodes: []string{"http://127.0.0.1:1"}})
+	require.NoError(t, err)
+	require.ErrorContains(t, "no beacon node accepted", s.InsertProposerSlashing(ctx, nil, &ethpb.ProposerSlashing{Header_1: header, Header_2: header}))
+}
@@ -16,8 +16,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/operations/slashings"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/slasher/types"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/startup"
-	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state/stategen"
-	beaconChainSync "github.com/prysmaticlabs/prysm/v5/beacon-chain/sync"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
 	"github.com/prysmaticlabs/prysm/v5/config/params"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
 	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
@@ -28,7 +27,7 @@ const (
 	shutdownTimeout = time.Minute * 5
 )
 
-// ServiceConfig for the slasher service in the beacon node.
+// ServiceConfig for the slasher service in the beacon node, or in the standalone slasher.
 // This struct allows us to specify required dependencies and
 // parameters for slasher to function as needed.
 type ServiceConfig struct {
@@ -37,11 +36,30 @@ type ServiceConfig struct {
 	Database                db.SlasherDatabase
 	StateNotifier           statefeed.Notifier
 	AttestationStateFetcher blockchain.AttestationStateFetcher
-	StateGen                stategen.StateManager
+	StateGen                StateByRootFetcher
 	SlashingPoolInserter    slashings.PoolInserter
-	HeadStateFetcher        blockchain.HeadFetcher
-	SyncChecker             beaconChainSync.Checker
+	HeadStateFetcher        HeadFetcher
+	SyncChecker             SyncChecker
 	ClockWaiter             startup.ClockWaiter
+	BeaconDB                db.ReadOnlyDatabase
+	HistoricalBackfill      bool
+	BackfillEpochsPerSlot   uint64
+}
+
+// HeadFetcher retrieves the head of the chain on which slashable offenses are detected.
+type HeadFetcher interface {
+	HeadSlot() primitives.Slot
+	HeadState(ctx context.Context) (state.BeaconState, error)
+}
+
+// StateByRootFetcher retrieves the state of a block, used to verify the signatures of proposals.
+type StateByRootFetcher interface {
+	StateByRoot(ctx context.Context, blockRoot [32]byte) (state.BeaconState, error)
+}
+
+// SyncChecker tells whether the chain on which slashable offenses are detected is syncing.
+type SyncChecker interface {
+	Syncing() bool
 }
 
 // Service defining a slasher implementation as part of
@@ -59,6 +77,7 @@ type Service struct {
 	attsSlotTicker                 *slots.SlotTicker
 	blocksSlotTicker               *slots.SlotTicker
 	pruningSlotTicker              *slots.SlotTicker
+	backfillSlotTicker             *slots.SlotTicker
 	latestEpochUpdatedForValidator map[primitives.ValidatorIndex]primitives.Epoch
 	wg                             sync.WaitGroup
 }
@@ -160,6 +179,14 @@ func (s *Service) run() {
 
 	s.wg.Add(1)
 	go s.pruneSlasherData(s.ctx, s.pruningSlotTicker.C())
+
+	// Live detection covers the epochs from the head onwards, the history before it is read from the
+	// blocks of the beacon database.
+	if s.serviceCfg.HistoricalBackfill {
+		s.backfillSlotTicker = slots.NewSlotTicker(s.genesisTime, secondsPerSlot)
+		s.wg.Add(1)
+		go s.backfill(s.ctx, s.backfillSlotTicker.C(), headEpoch)
+	}
 }
 
 // Stop the slasher service.
@@ -176,6 +203,9 @@ func (s *Service) Stop() error {
 	if s.pruningSlotTicker != nil {
 		s.pruningSlotTicker.Done()
 	}
+	if s.backfillSlotTicker != nil {
+		s.backfillSlotTicker.Done()
+	}
 	// Flush the latest epoch written map to disk.
 	start := time.Now()
 	// New context as the service context has already been canceled.
@@ -0,0 +1,108 @@
+package slasher
+
+import (
+	"context"
+	"sort"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db"
+	slashertypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/slasher/types"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+)
+
+// ChunkCoverage reports which validator chunks hold span chunks of a kind. A validator chunk
+// is complete when it holds a chunk for every chunk index of the history, and missing when
+// it holds none while a higher validator chunk holds some.
+type ChunkCoverage struct {
+	Kind                    slashertypes.ChunkKind
+	Chunks                  uint64
+	ValidatorChunks         uint64
+	CompleteValidatorChunks uint64
+	MissingValidatorChunks  []uint64
+	HighestValidatorIndex   primitives.ValidatorIndex
+}
+
+// DatabaseReport reports the sizes and the coverage of a slasher database. The spans cover
+// the epochs of the history ending at the highest epoch written for a validator.
+type DatabaseReport struct {
+	*slashertypes.DatabaseStats
+	HasSpans         bool
+	LowestSpanEpoch  primitives.Epoch
+	HighestSpanEpoch primitives.Epoch
+	Coverage         []*ChunkCoverage
+}
+
+// ReportDatabase reports the sizes, the epoch coverage and the validator chunk coverage of
+// a slasher database whose spans are stored with the given parameters.
+func ReportDatabase(ctx context.Context, d db.SlasherDatabase, params *Parameters) (*DatabaseReport, error) {
+	stats, err := d.Stats(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get slasher database stats")
+	}
+	report := &DatabaseReport{DatabaseStats: stats}
+	if stats.AttestedValidators > 0 {
+		report.HasSpans = true
+		report.HighestSpanEpoch = stats.HighestEpochWritten
+		if stats.HighestEpochWritten >= params.historyLength {
+			report.LowestSpanEpoch = stats.HighestEpochWritten + 1 - params.historyLength
+		}
+	}
+	for _, kind := range []slashertypes.ChunkKind{slashertypes.MinSpan, slashertypes.MaxSpan} {
+		report.Coverage = append(report.Coverage, params.chunkCoverage(kind, stats.ChunkIDs[kind]))
+	}
+	return report, nil
+}
+
+// SummarizeDatabase reports the sizes, the epoch coverage and the validator chunk coverage of a slasher
+// database without reading its records, so that it can be called on the database of a running slasher.
+// The size of the slasher chunks bucket is left out, and the spans are assumed to cover the history
+// ending at the highest target epoch of the attestation records.
+func SummarizeDatabase(ctx context.Context, d db.SlasherDatabase, params *Parameters) (*DatabaseReport, error) {
+	stats, err := d.Summary(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get slasher database summary")
+	}
+	report := &DatabaseReport{DatabaseStats: stats}
+	if stats.HasAttestations {
+		report.HasSpans = true
+		report.HighestSpanEpoch = stats.HighestTargetEpoch
+		if stats.HighestTargetEpoch >= params.historyLength {
+			report.LowestSpanEpoch = stats.HighestTargetEpoch + 1 - params.historyLength
+		}
+	}
+	for _, kind := range []slashertypes.ChunkKind{slashertypes.MinSpan, slashertypes.MaxSpan} {
+		report.Coverage = append(report.Coverage, params.chunkCoverage(kind, stats.ChunkIDs[kind]))
+	}
+	return report, nil
+}
+
+// chunkCoverage computes the coverage of chunks of a kind from their flat slice ids.
+func (p *Parameters) chunkCoverage(kind slashertypes.ChunkKind, ids []uint64) *ChunkCoverage {
+	coverage := &ChunkCoverage{Kind: kind, Chunks: uint64(len(ids))}
+	if len(ids) == 0 {
+		return coverage
+	}
+	width := uint64(p.historyLength.Div(p.chunkSize))
+	chunksByValidatorChunk := make(map[uint64]uint64)
+	for _, id := range ids {
+		chunksByValidatorChunk[id/width]++
+	}
+	validatorChunks := make([]uint64, 0, len(chunksByValidatorChunk))
+	for validatorChunkIndex, count := range chunksByValidatorChunk {
+		validatorChunks = append(validatorChunks, validatorChunkIndex)
+		if count == width {
+			coverage.CompleteValidatorChunks++
+		}
+	}
+	sort.Slice(validatorChunks, func(i, j int) bool { return validatorChunks[i] < validatorChunks[j] })
+
+	highest := validatorChunks[len(validatorChunks)-1]
+	for i := uint64(0); i < highest; i++ {
+		if _, ok := chunksByValidatorChunk[i]; !ok {
+			coverage.MissingValidatorChunks = append(coverage.MissingValidatorChunks, i)
+		}
+	}
+	coverage.ValidatorChunks = uint64(len(validatorChunks))
+	coverage.HighestValidatorIndex = primitives.ValidatorIndex((highest+1)*p.validatorChunkSize - 1)
+	return coverage
+}
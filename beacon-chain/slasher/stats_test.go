@@ -0,0 +1,107 @@
+package slasher
+
+import (
+	"context"
+	"testing"
+
+	dbtest "github.com/prysmaticlabs/prysm/v5/beacon-chain/db/testing"
+	slashertypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/slasher/types"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestReportDatabase(t *testing.T) {
+	ctx := context.Background()
+	slasherDB := dbtest.SetupSlasherDB(t)
+	// 4 chunks of 2 epochs per validator chunk of 8 validators.
+	p := NewParams(2, 8, 8)
+
+	report, err := ReportDatabase(ctx, slasherDB, p)
+	require.NoError(t, err)
+	assert.Equal(t, false, report.HasSpans)
+	assert.Equal(t, 2, len(report.Coverage))
+	assert.Equal(t, uint64(0), report.Coverage[0].Chunks)
+
+	chunk := make([]uint16, p.chunkSize*p.validatorChunkSize)
+	var keys [][]byte
+	var chunks [][]uint16
+	// Validator chunk 0 is complete, 1 is missing and 2 only holds its first chunk.
+	for chunkIndex := uint64(0); chunkIndex < 4; chunkIndex++ {
+		keys = append(keys, p.flatSliceID(0, chunkIndex))
+		chunks = append(chunks, chunk)
+	}
+	keys = append(keys, p.flatSliceID(2, 0))
+	chunks = append(chunks, chunk)
+	require.NoError(t, slasherDB.SaveSlasherChunks(ctx, slashertypes.MinSpan, keys, chunks))
+	require.NoError(t, slasherDB.SaveLastEpochWrittenForValidators(ctx, map[primitives.ValidatorIndex]primitives.Epoch{
+		0: 10,
+		1: 12,
+	}))
+	require.NoError(t, slasherDB.SaveAttestationRecordsForValidators(ctx, []*slashertypes.IndexedAttestationWrapper{
+		createAttestationWrapperEmptySig(t, 3, 4, []uint64{0}, nil),
+		createAttestationWrapperEmptySig(t, 4, 9, []uint64{1}, nil),
+	}))
+
+	report, err = ReportDatabase(ctx, slasherDB, p)
+	require.NoError(t, err)
+	assert.Equal(t, true, report.HasSpans)
+	assert.Equal(t, primitives.Epoch(5), report.LowestSpanEpoch)
+	assert.Equal(t, primitives.Epoch(12), report.HighestSpanEpoch)
+	assert.Equal(t, true, report.HasAttestations)
+	assert.Equal(t, primitives.Epoch(4), report.LowestTargetEpoch)
+	assert.Equal(t, primitives.Epoch(9), report.HighestTargetEpoch)
+	assert.Equal(t, uint64(2), report.AttestedValidators)
+	assert.DeepEqual(t, &ChunkCoverage{
+		Kind:                    slashertypes.MinSpan,
+		Chunks:                  5,
+		ValidatorChunks:         2,
+		CompleteValidatorChunks: 1,
+		MissingValidatorChunks:  []uint64{1},
+		HighestValidatorIndex:   23,
+	}, report.Coverage[0])
+	assert.DeepEqual(t, &ChunkCoverage{Kind: slashertypes.MaxSpan}, report.Coverage[1])
+}
+
+func TestSummarizeDatabase(t *testing.T) {
+	ctx := context.Background()
+	slasherDB := dbtest.SetupSlasherDB(t)
+	p := &Parameters{chunkSize: 2, validatorChunkSize: 12, historyLength: 8}
+
+	report, err := SummarizeDatabase(ctx, slasherDB, p)
+	require.NoError(t, err)
+	assert.Equal(t, false, report.HasSpans)
+	assert.Equal(t, false, report.HasAttestations)
+
+	require.NoError(t, slasherDB.SaveAttestationRecordsForValidators(ctx, []*slashertypes.IndexedAttestationWrapper{
+		createAttestationWrapperEmptySig(t, 3, 4, []uint64{0}, nil),
+		createAttestationWrapperEmptySig(t, 4, 9, []uint64{1}, nil),
+	}))
+
+	chunk := make([]uint16, p.chunkSize*p.validatorChunkSize)
+	require.NoError(t, slasherDB.SaveSlasherChunks(ctx, slashertypes.MinSpan, [][]byte{p.flatSliceID(0, 0), p.flatSliceID(2, 1)}, [][]uint16{chunk, chunk}))
+	require.NoError(t, slasherDB.SaveLastEpochWrittenForValidators(ctx, map[primitives.ValidatorIndex]primitives.Epoch{
+		0: 4,
+		1: 9,
+	}))
+
+	report, err = SummarizeDatabase(ctx, slasherDB, p)
+	require.NoError(t, err)
+	assert.Equal(t, true, report.HasAttestations)
+	assert.Equal(t, primitives.Epoch(4), report.LowestTargetEpoch)
+	assert.Equal(t, primitives.Epoch(9), report.HighestTargetEpoch)
+	assert.Equal(t, true, report.HasSpans)
+	assert.Equal(t, primitives.Epoch(2), report.LowestSpanEpoch)
+	assert.Equal(t, primitives.Epoch(9), report.HighestSpanEpoch)
+	assert.NotEqual(t, 0, len(report.Buckets))
+	assert.Equal(t, uint64(2), report.AttestedValidators)
+	require.Equal(t, 2, len(report.Coverage))
+	assert.DeepEqual(t, &ChunkCoverage{
+		Kind:                   slashertypes.MinSpan,
+		Chunks:                 2,
+		ValidatorChunks:        2,
+		MissingValidatorChunks: []uint64{1},
+		HighestValidatorIndex:  35,
+	}, report.Coverage[0])
+	assert.DeepEqual(t, &ChunkCoverage{Kind: slashertypes.MaxSpan}, report.Coverage[1])
+}
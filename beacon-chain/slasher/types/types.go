@@ -70,3 +70,30 @@ type AttestedEpochForValidator struct {
 	ValidatorIndex primitives.ValidatorIndex
 	Epoch          primitives.Epoch
 }
+
+// BucketStats reports the number of entries of a slasher database bucket, along with
+// the bytes its pages use and the bytes allocated to them.
+type BucketStats struct {
+	Name           string
+	Entries        uint64
+	InUseBytes     uint64
+	AllocatedBytes uint64
+}
+
+// DatabaseStats reports the size of a slasher database and of each of its buckets,
+// the epochs covered by its attestation and proposal records, and the keys of the
+// min and max span chunks it holds.
+type DatabaseStats struct {
+	FileSize            int64
+	Buckets             []*BucketStats
+	HasAttestations     bool
+	LowestTargetEpoch   primitives.Epoch
+	HighestTargetEpoch  primitives.Epoch
+	HasProposals        bool
+	LowestProposalSlot  primitives.Slot
+	HighestProposalSlot primitives.Slot
+	AttestedValidators  uint64
+	LowestEpochWritten  primitives.Epoch
+	HighestEpochWritten primitives.Epoch
+	ChunkIDs            map[ChunkKind][]uint64
+}
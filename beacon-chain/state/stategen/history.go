@@ -34,6 +34,12 @@ func NewCanonicalHistory(h HistoryAccessor, cc CanonicalChecker, cs CurrentSlott
 	return ch
 }
 
+// hierarchicalStateAccessor is implemented by a HistoryAccessor which stores finalized states as
+// hierarchical state diffs, from which the state of a slot is rebuilt without replaying blocks.
+type hierarchicalStateAccessor interface {
+	HierarchicalState(ctx context.Context, slot primitives.Slot) (state.BeaconState, error)
+}
+
 type CanonicalHistory struct {
 	h     HistoryAccessor
 	cc    CanonicalChecker
@@ -103,9 +109,19 @@ func (c *CanonicalHistory) bestForSlot(ctx context.Context, roots [][32]byte) ([
 // and the stategen transition helper methods. This implementation uses the following algorithm:
 // - find the highest canonical block <= the target slot
 // - starting with this block, recursively search backwards for a stored state, and accumulate intervening blocks
+// When the state of the target slot is stored as a hierarchical state diff, it is returned without any block.
 func (c *CanonicalHistory) chainForSlot(ctx context.Context, target primitives.Slot) (state.BeaconState, []interfaces.ReadOnlySignedBeaconBlock, error) {
 	ctx, span := trace.StartSpan(ctx, "canonicalChainer.chainForSlot")
 	defer span.End()
+	if h, ok := c.h.(hierarchicalStateAccessor); ok {
+		s, err := h.HierarchicalState(ctx, target)
+		if err == nil {
+			return s, nil, nil
+		}
+		if !errors.Is(err, db.ErrNotFoundState) {
+			return nil, nil, errors.Wrapf(err, "could not rebuild hierarchical state at slot=%d", target)
+		}
+	}
 	r, err := c.BlockRootForSlot(ctx, target)
 	if err != nil {
 		return nil, nil, errors.Wrapf(err, "no canonical block root found below slot=%d", target)
@@ -6,6 +6,7 @@ import (
 	"fmt"
 
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
 	"github.com/prysmaticlabs/prysm/v5/monitoring/tracing/trace"
 	"github.com/sirupsen/logrus"
@@ -109,6 +110,22 @@ func (s *State) MigrateToCold(ctx context.Context, fRoot [32]byte) error {
 		}
 	}
 
+	// A failure to save the hierarchical states does not hold back the finalized point. The slots
+	// from the first one which could not be saved are retried by the next migration.
+	diffStart := oldFSlot
+	if s.hierarchicalStates.pending && s.hierarchicalStates.slot < diffStart {
+		diffStart = s.hierarchicalStates.slot
+	}
+	if err := s.migrateHierarchicalStates(ctx, diffStart, fSlot); err != nil {
+		log.WithError(err).WithFields(logrus.Fields{
+			"startSlot": diffStart,
+			"endSlot":   fSlot,
+		}).Error("Could not save hierarchical states, retrying at the next migration")
+		s.hierarchicalStates = hierarchicalStatesProgress{pending: true, slot: diffStart}
+	} else {
+		s.hierarchicalStates = hierarchicalStatesProgress{}
+	}
+
 	// Update finalized info in memory.
 	fInfo, ok, err := s.epochBoundaryStateCache.getByBlockRoot(fRoot)
 	if err != nil {
@@ -120,3 +137,54 @@ func (s *State) MigrateToCold(ctx context.Context, fRoot [32]byte) error {
 
 	return nil
 }
+
+// migrateHierarchicalStates saves the finalized states of the slots in [startSlot, endSlot) which belong to a
+// layer of the hierarchical state diffs. The first state is regenerated, and every following one is built by
+// advancing the previous one through the finalized blocks in between, so every block is only processed once.
+func (s *State) migrateHierarchicalStates(ctx context.Context, startSlot, endSlot primitives.Slot) error {
+	ctx, span := trace.StartSpan(ctx, "stateGen.migrateHierarchicalStates")
+	defer span.End()
+
+	var st state.BeaconState
+	for slot := startSlot; slot < endSlot; slot++ {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if !s.beaconDB.IsHierarchicalStateSlot(slot) || s.beaconDB.HasHierarchicalState(ctx, slot) {
+			continue
+		}
+		_, roots, err := s.beaconDB.HighestRootsBelowSlot(ctx, slot+1)
+		if err != nil {
+			return err
+		}
+		// Given the block has been finalized, the db should not have more than one block in a given slot.
+		if len(roots) != 1 {
+			return errUnknownBlock
+		}
+		if st == nil {
+			st, err = s.StateByRoot(ctx, roots[0])
+			if err != nil {
+				return err
+			}
+			// The state may be shared with the caches, and is advanced in place from here on.
+			st, err = ReplayProcessSlots(ctx, st.Copy(), slot)
+			if err != nil {
+				return err
+			}
+		} else {
+			blks, err := s.loadBlocks(ctx, st.Slot()+1, slot, roots[0])
+			if err != nil {
+				return err
+			}
+			st, err = s.replayBlocks(ctx, st, blks, slot)
+			if err != nil {
+				return err
+			}
+		}
+		if err := s.beaconDB.SaveHierarchicalState(ctx, st); err != nil {
+			return err
+		}
+		log.WithField("slot", slot).Debug("Saved hierarchical state in DB")
+	}
+	return nil
+}
@@ -2,12 +2,17 @@ package stategen
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/blocks"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/kv"
 	testDB "github.com/prysmaticlabs/prysm/v5/beacon-chain/db/testing"
 	doublylinkedtree "github.com/prysmaticlabs/prysm/v5/beacon-chain/forkchoice/doubly-linked-tree"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
 	consensusblocks "github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
 	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v5/testing/assert"
@@ -225,3 +230,128 @@ func TestMigrateToCold_ParallelCalls(t *testing.T) {
 	assert.DeepEqual(t, [][32]byte{r7}, service.saveHotStateDB.blockRootsOfSavedStates, "Did not remove all saved hot state roots")
 	require.LogsContain(t, hook, "Saved state in DB")
 }
+
+func TestMigrateToCold_HierarchicalStates(t *testing.T) {
+	ctx := context.Background()
+	beaconDB, err := kv.NewKVStore(ctx, t.TempDir(), kv.WithStateDiffExponents([]uint64{2, 0}))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, beaconDB.Close())
+	})
+
+	service := New(beaconDB, doublylinkedtree.New())
+	service.slotsPerArchivedPoint = 1024
+	beaconState, pks := util.DeterministicGenesisState(t, 32)
+	genesisStateRoot, err := beaconState.HashTreeRoot(ctx)
+	require.NoError(t, err)
+	genesis := blocks.NewGenesisBlock(genesisStateRoot[:])
+	util.SaveBlock(t, ctx, beaconDB, genesis)
+	gRoot, err := genesis.Block.HashTreeRoot()
+	require.NoError(t, err)
+	require.NoError(t, beaconDB.SaveState(ctx, beaconState, gRoot))
+	require.NoError(t, beaconDB.SaveGenesisBlockRoot(ctx, gRoot))
+
+	b1, err := util.GenerateFullBlock(beaconState, pks, util.DefaultBlockGenConfig(), 1)
+	require.NoError(t, err)
+	r1, err := b1.Block.HashTreeRoot()
+	require.NoError(t, err)
+	util.SaveBlock(t, ctx, beaconDB, b1)
+	require.NoError(t, beaconDB.SaveStateSummary(ctx, &ethpb.StateSummary{Slot: 1, Root: r1[:]}))
+	b4, err := util.GenerateFullBlock(beaconState, pks, util.DefaultBlockGenConfig(), 4)
+	require.NoError(t, err)
+	r4, err := b4.Block.HashTreeRoot()
+	require.NoError(t, err)
+	util.SaveBlock(t, ctx, beaconDB, b4)
+	require.NoError(t, beaconDB.SaveStateSummary(ctx, &ethpb.StateSummary{Slot: 4, Root: r4[:]}))
+	service.finalizedInfo = &finalizedInfo{
+		slot:  0,
+		root:  genesisStateRoot,
+		state: beaconState,
+	}
+
+	require.NoError(t, service.MigrateToCold(ctx, r4))
+
+	sb1, err := consensusblocks.NewSignedBeaconBlock(b1)
+	require.NoError(t, err)
+	// The migration stops before the finalized slot.
+	assert.Equal(t, false, beaconDB.HasHierarchicalState(ctx, 4))
+	h := NewCanonicalHistory(beaconDB, &mockCanonicalChecker{err: errors.New("no replay expected")}, &mockCurrentSlotter{Slot: 10})
+	for slot := primitives.Slot(0); slot < 4; slot++ {
+		require.Equal(t, true, beaconDB.HasHierarchicalState(ctx, slot))
+		want, err := service.replayBlocks(ctx, beaconState.Copy(), []interfaces.ReadOnlySignedBeaconBlock{sb1}, slot)
+		require.NoError(t, err)
+		wantRoot, err := want.HashTreeRoot(ctx)
+		require.NoError(t, err)
+
+		got, err := h.ReplayerForSlot(slot).ReplayBlocks(ctx)
+		require.NoError(t, err)
+		gotRoot, err := got.HashTreeRoot(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, wantRoot, gotRoot, "wrong state at slot %d", slot)
+	}
+}
+
+// failingDiffsDB fails to save the hierarchical states from the given slot.
+type failingDiffsDB struct {
+	db.NoHeadAccessDatabase
+	failFrom primitives.Slot
+	fail     bool
+}
+
+func (d *failingDiffsDB) SaveHierarchicalState(ctx context.Context, st state.ReadOnlyBeaconState) error {
+	if d.fail && st.Slot() >= d.failFrom {
+		return errors.New("could not save state diff")
+	}
+	return d.NoHeadAccessDatabase.SaveHierarchicalState(ctx, st)
+}
+
+func TestMigrateToCold_HierarchicalStatesRetried(t *testing.T) {
+	ctx := context.Background()
+	store, err := kv.NewKVStore(ctx, t.TempDir(), kv.WithStateDiffExponents([]uint64{2, 0}))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, store.Close())
+	})
+	beaconDB := &failingDiffsDB{NoHeadAccessDatabase: store, failFrom: 2, fail: true}
+
+	service := New(beaconDB, doublylinkedtree.New())
+	service.slotsPerArchivedPoint = 1024
+	beaconState, pks := util.DeterministicGenesisState(t, 32)
+	genesisStateRoot, err := beaconState.HashTreeRoot(ctx)
+	require.NoError(t, err)
+	genesis := blocks.NewGenesisBlock(genesisStateRoot[:])
+	util.SaveBlock(t, ctx, beaconDB, genesis)
+	gRoot, err := genesis.Block.HashTreeRoot()
+	require.NoError(t, err)
+	require.NoError(t, beaconDB.SaveState(ctx, beaconState, gRoot))
+	require.NoError(t, beaconDB.SaveGenesisBlockRoot(ctx, gRoot))
+
+	b1, err := util.GenerateFullBlock(beaconState, pks, util.DefaultBlockGenConfig(), 1)
+	require.NoError(t, err)
+	util.SaveBlock(t, ctx, beaconDB, b1)
+	b4, err := util.GenerateFullBlock(beaconState, pks, util.DefaultBlockGenConfig(), 4)
+	require.NoError(t, err)
+	r4, err := b4.Block.HashTreeRoot()
+	require.NoError(t, err)
+	util.SaveBlock(t, ctx, beaconDB, b4)
+	service.finalizedInfo = &finalizedInfo{
+		slot:  0,
+		root:  genesisStateRoot,
+		state: beaconState,
+	}
+	require.NoError(t, service.epochBoundaryStateCache.put(r4, beaconState))
+
+	// The finalized point advances, and the states which could not be saved are left for the next migration.
+	require.NoError(t, service.MigrateToCold(ctx, r4))
+	assert.Equal(t, primitives.Slot(4), service.finalizedInfo.slot)
+	assert.Equal(t, true, beaconDB.HasHierarchicalState(ctx, 1))
+	assert.Equal(t, false, beaconDB.HasHierarchicalState(ctx, 2))
+	assert.Equal(t, true, service.hierarchicalStates.pending)
+
+	beaconDB.fail = false
+	require.NoError(t, service.MigrateToCold(ctx, r4))
+	for slot := primitives.Slot(0); slot < 4; slot++ {
+		assert.Equal(t, true, beaconDB.HasHierarchicalState(ctx, slot), "missing hierarchical state at slot %d", slot)
+	}
+	assert.Equal(t, false, service.hierarchicalStates.pending)
+}
@@ -55,9 +55,18 @@ type State struct {
 	saveHotStateDB          *saveHotStateDbConfig
 	avb                     coverage.AvailableBlocker
 	migrationLock           *sync.Mutex
+	hierarchicalStates      hierarchicalStatesProgress
 	fc                      forkchoice.ForkChoicer
 }
 
+// This tracks the progress of the hierarchical state diffs separately from the finalized point,
+// so that the states a migration failed to save are retried by the next migration. It is
+// guarded by the migration lock.
+type hierarchicalStatesProgress struct {
+	pending bool
+	slot    primitives.Slot
+}
+
 // This tracks the config in the event of long non-finality,
 // how often does the node save hot states to db? what are
 // the saved hot states in db?... etc
@@ -38,6 +38,15 @@ func (c *batchSequencer) sequence() ([]batch, error) {
 			} else {
 				b = c.batcher.beforeBatch(c.seq[i-1])
 			}
+			// When the range is shorter than the sequence, the batcher reaches the lower bound before all the
+			// items are initialized. Those items keep signaling the end of the sequence rather than being scheduled.
+			if b.state == batchEndSequence {
+				c.seq[i] = b
+				if len(s) == 0 {
+					s = append(s, c.seq[i])
+				}
+				continue
+			}
 			c.seq[i] = b.withState(batchSequenced)
 			s = append(s, c.seq[i])
 		case batchEndSequence:
@@ -125,6 +134,17 @@ func (c *batchSequencer) moveMinimum(min primitives.Slot) error {
 	return nil
 }
 
+// finished returns true once every item in the sequence signals the end of the sequence, meaning that all
+// the batches between the minimum and maximum slots have been imported.
+func (c *batchSequencer) finished() bool {
+	for i := range c.seq {
+		if c.seq[i].state != batchEndSequence {
+			return false
+		}
+	}
+	return true
+}
+
 // countWithState provides a view into how many batches are in a particular state
 // to be used for logging or metrics purposes.
 func (c *batchSequencer) countWithState(s batchState) int {
@@ -235,3 +235,22 @@ func TestBatchSequencer(t *testing.T) {
 	//require.ErrorIs(t, err, errEndSequence)
 	require.Equal(t, batchEndSequence, end.state)
 }
+
+func TestBatchSequencerShortRange(t *testing.T) {
+	// The range only fits 2 batches, so the other items of the sequence signal its end right away.
+	seq := newBatchSequencer(4, 10, 15, 3)
+	got, err := seq.sequence()
+	require.NoError(t, err)
+	require.Equal(t, 2, len(got))
+	require.Equal(t, primitives.Slot(12), got[0].begin)
+	require.Equal(t, primitives.Slot(10), got[1].begin)
+	require.Equal(t, batchEndSequence, seq.seq[2].state)
+	require.Equal(t, batchEndSequence, seq.seq[3].state)
+	require.Equal(t, false, seq.finished())
+
+	seq.update(got[0].withState(batchImportComplete))
+	require.Equal(t, false, seq.finished())
+	seq.update(got[1].withState(batchImportComplete))
+	require.Equal(t, true, seq.finished())
+	require.Equal(t, 0, seq.numTodo())
+}
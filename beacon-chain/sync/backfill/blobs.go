@@ -96,6 +96,27 @@ func (bs *blobSync) validateNext(rb blocks.ROBlob) error {
 	return nil
 }
 
+// saveVerified writes the blob sidecars of the given blocks to the blob storage once they are all verified,
+// without consulting the storage summary as the availability check does.
+func (bs *blobSync) saveVerified(ctx context.Context, blks verifiedROBlocks, store *filesystem.BlobStorage) error {
+	for i := range blks {
+		// Blocks outside of the retention period, or without commitments, don't have verifiers.
+		if _, ok := bs.bbv.verifiers[blks[i].Root()]; !ok {
+			continue
+		}
+		vbs, err := bs.bbv.VerifiedROBlobs(ctx, blks[i], nil)
+		if err != nil {
+			return err
+		}
+		for j := range vbs {
+			if err := store.Save(vbs[j]); err != nil {
+				return errors.Wrapf(err, "failed to save BlobSidecar index %d for block %#x", vbs[j].Index, vbs[j].BlockRoot())
+			}
+		}
+	}
+	return nil
+}
+
 func newBlobBatchVerifier(nbv verification.NewBlobVerifier) *blobBatchVerifier {
 	return &blobBatchVerifier{newBlobVerifier: nbv, verifiers: make(blobVerifierMap)}
 }
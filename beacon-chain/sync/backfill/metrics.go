@@ -33,6 +33,12 @@ var (
 			Help: "Number of backfill batches downloaded and imported.",
 		},
 	)
+	backfillRepairBatchesImported = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "backfill_repair_batches_imported",
+			Help: "Number of batches downloaded and imported by backfill repair to fill gaps in the db.",
+		},
+	)
 	backfillBlocksApproximateBytes = promauto.NewCounter(
 		prometheus.CounterOpts{
 			Name: "backfill_blocks_bytes_downloaded",
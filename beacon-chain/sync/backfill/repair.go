@@ -0,0 +1,375 @@
+package backfill
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/filesystem"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/sync"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	errRepairUnsupportedDB = errors.New("backfill repair requires a db that can scan the block index")
+	errRepairStalled       = errors.New("backfill repair found the same gaps again after filling them")
+	errNoRepairState       = errors.New("no state found in the db to verify the signatures of repaired blocks")
+)
+
+// RepairDB describes the set of DB methods that the repair mode needs, in addition to those of BeaconDB,
+// to scan the finalized history for gaps.
+type RepairDB interface {
+	BeaconDB
+	FinalizedCheckpoint(ctx context.Context) (*ethpb.Checkpoint, error)
+	HasBlock(ctx context.Context, blockRoot [32]byte) bool
+	HighestRootsBelowSlot(ctx context.Context, slot primitives.Slot) (primitives.Slot, [][32]byte, error)
+	HighestSlotStatesBelow(ctx context.Context, slot primitives.Slot) ([]state.ReadOnlyBeaconState, error)
+	IsFinalizedBlock(ctx context.Context, blockRoot [32]byte) bool
+	LowestAvailableSlot(ctx context.Context) (primitives.Slot, error)
+}
+
+// repairGap is a range of slots where blocks or blob sidecars are missing from the db. Like batch, it is a
+// half-open interval, [begin, end).
+type repairGap struct {
+	kind  RepairGapKind
+	begin primitives.Slot
+	end   primitives.Slot
+	// next is the root that the highest block of the next batch imported into the gap must have. Like the
+	// LowParentRoot of the backfill status, it moves to the parent_root of the lowest block of each imported batch.
+	next [32]byte
+	// child is the root of the block that descends from the highest block of the next batch, which is
+	// needed to connect the imported blocks to the finalized index.
+	child [32]byte
+}
+
+func (g *repairGap) logFields() logrus.Fields {
+	return logrus.Fields{
+		"kind":  g.kind,
+		"begin": g.begin,
+		"end":   g.end,
+	}
+}
+
+// repairer finds the gaps in the db and imports the batches downloaded to fill them.
+type repairer struct {
+	db       RepairDB
+	blobs    *filesystem.BlobStorage
+	progress *repairProgress
+}
+
+func newRepairer(db RepairDB, bs *filesystem.BlobStorage) *repairer {
+	return &repairer{db: db, blobs: bs, progress: newRepairProgress()}
+}
+
+// scan walks the chain of finalized blocks backwards, following parent roots from the finalized checkpoint
+// down to the given low slot. A parent root that can't be found in the db is the upper bound of a gap of
+// missing blocks, which extends down to the highest slot below it where the block index has a block.
+// Blocks at or above blobStart with commitments for blob sidecars that are not all in the blob storage
+// are gathered in gaps of missing blobs. Gaps are returned in descending slot order.
+func (r *repairer) scan(ctx context.Context, low, blobStart primitives.Slot) ([]*repairGap, error) {
+	cp, err := r.db.FinalizedCheckpoint(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get finalized checkpoint")
+	}
+	root := bytesutil.ToBytes32(cp.Root)
+	if root == params.BeaconConfig().ZeroHash {
+		return nil, nil
+	}
+	blk, err := r.block(ctx, root)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get finalized block")
+	}
+	r.progress.startScan(low, blk.Block().Slot())
+
+	gaps := make([]*repairGap, 0)
+	var blobGap *repairGap
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		slot := blk.Block().Slot()
+		missing, err := r.missingBlobs(blk, blobStart)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case !missing:
+			blobGap = nil
+		case blobGap != nil:
+			// Extend the gap down to this block, as the blocks in between have all their blobs.
+			blobGap.begin = slot
+		default:
+			blobGap = &repairGap{kind: RepairGapBlobs, begin: slot, end: slot + 1, next: blk.Root()}
+			gaps = append(gaps, blobGap)
+		}
+		if slot <= low {
+			break
+		}
+
+		parent := blk.Block().ParentRoot()
+		if r.db.HasBlock(ctx, parent) {
+			if blk, err = r.block(ctx, parent); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		below, roots, err := r.db.HighestRootsBelowSlot(ctx, slot)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not find blocks below slot %d", slot)
+		}
+		begin := below + 1
+		if begin == slot {
+			// The block right below is not the missing parent, so it must be on a fork. Include its slot in
+			// the gap, the canonical block is found further down.
+			begin = below
+		}
+		if begin < low {
+			begin = low
+		}
+		gaps = append(gaps, &repairGap{kind: RepairGapBlocks, begin: begin, end: slot, next: parent, child: blk.Root()})
+		blobGap = nil
+		if below < low || len(roots) == 0 {
+			break
+		}
+		if blk, err = r.block(ctx, roots[0]); err != nil {
+			return nil, err
+		}
+	}
+	r.progress.setGaps(gaps)
+	return gaps, nil
+}
+
+// missingBlobs determines if the block is within the blob retention period and has commitments for blob
+// sidecars that are not in the blob storage.
+func (r *repairer) missingBlobs(b blocks.ROBlock, blobStart primitives.Slot) (bool, error) {
+	if b.Block().Slot() < blobStart || b.Block().Version() < version.Deneb {
+		return false, nil
+	}
+	c, err := b.Block().Body().BlobKzgCommitments()
+	if err != nil {
+		return false, errors.Wrapf(err, "unexpected error checking commitments for block root %#x", b.Root())
+	}
+	if len(c) == 0 {
+		return false, nil
+	}
+	onDisk, err := r.blobs.Indices(b.Root())
+	if err != nil {
+		return false, errors.Wrapf(err, "could not list blob sidecars for block root %#x", b.Root())
+	}
+	for i := range c {
+		if !onDisk[i] {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *repairer) block(ctx context.Context, root [32]byte) (blocks.ROBlock, error) {
+	b, err := r.db.Block(ctx, root)
+	if err != nil {
+		return blocks.ROBlock{}, errors.Wrapf(err, "could not get block with root %#x", root)
+	}
+	if err := blocks.BeaconBlockIsNil(b); err != nil {
+		return blocks.ROBlock{}, errors.Wrapf(err, "nil block found for root %#x", root)
+	}
+	return blocks.NewROBlockWithRoot(b, root)
+}
+
+// importBatch saves a batch downloaded for the gap, once its highest block is confirmed to be the expected
+// ancestor of the blocks above the gap. Blob sidecars are written straight to the blob storage, because
+// its summary cache still lists the sidecars that were removed from disk behind the node's back.
+func (r *repairer) importBatch(ctx context.Context, b batch, g *repairGap) error {
+	if len(b.results) == 0 {
+		// The batch only covers empty slots.
+		return nil
+	}
+	if err := b.ensureParent(g.next); err != nil {
+		return err
+	}
+	if b.bs != nil {
+		if err := b.bs.saveVerified(ctx, b.results, r.blobs); err != nil {
+			return err
+		}
+	}
+	if g.kind == RepairGapBlocks {
+		if err := r.db.SaveROBlocks(ctx, b.results, false); err != nil {
+			return errors.Wrap(err, "error saving repaired blocks")
+		}
+		// The finalized index can only be extended downwards from a block it already contains.
+		if r.db.IsFinalizedBlock(ctx, g.child) {
+			if err := r.db.BackfillFinalizedIndex(ctx, b.results, g.child); err != nil {
+				return errors.Wrapf(err, "failed to update finalized index for repaired batch, connecting to %#x", g.child)
+			}
+		}
+	}
+	lowest := b.results[0]
+	g.next = lowest.Block().ParentRoot()
+	g.child = lowest.Root()
+	return nil
+}
+
+// initVerifier uses the most recent finalized state in the db to verify the signatures of repaired blocks.
+// Unlike the origin state used by backfill, it includes the proposers of the blocks above the origin.
+func (r *repairer) initVerifier(ctx context.Context) (*verifier, sync.ContextByteVersions, error) {
+	cp, err := r.db.FinalizedCheckpoint(ctx)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "could not get finalized checkpoint")
+	}
+	fb, err := r.block(ctx, bytesutil.ToBytes32(cp.Root))
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "could not get finalized block")
+	}
+	sts, err := r.db.HighestSlotStatesBelow(ctx, fb.Block().Slot()+1)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(sts) == 0 || sts[0] == nil || sts[0].IsNil() {
+		return nil, nil, errNoRepairState
+	}
+	return newVerifierForState(sts[0])
+}
+
+// repairLowSlot is the lowest slot scanned by repair. The blocks below the backfill low slot are left to
+// backfill itself, and peers are not expected to serve blocks below the minimum backfill slot. The history
+// deleted by the pruner is not a gap either, so the scan also stops at the lowest available slot of the db.
+func (s *Service) repairLowSlot(ctx context.Context, current primitives.Slot) (primitives.Slot, error) {
+	low := s.ms(current)
+	if !s.store.isGenesisSync() {
+		if bl := primitives.Slot(s.store.status().LowSlot); bl > low {
+			low = bl
+		}
+	}
+	pruned, err := s.repair.db.LowestAvailableSlot(ctx)
+	if err != nil {
+		return 0, errors.Wrap(err, "could not get lowest available slot")
+	}
+	if pruned > low {
+		low = pruned
+	}
+	return low, nil
+}
+
+// runRepair scans the db for gaps and fills them, until a scan finds no gap. Filling a gap can uncover
+// another one below it, for instance if the lowest block of the gap was on a fork, so the scan is repeated
+// after the gaps are filled. Repair gives up if a scan finds exactly the gaps it just filled.
+func (s *Service) runRepair(ctx context.Context) error {
+	if s.initSyncWaiter != nil {
+		log.Info("Backfill repair waiting for initial-sync to reach head before starting")
+		if err := s.initSyncWaiter(); err != nil {
+			return errors.Wrap(err, "error waiting for init-sync to complete")
+		}
+	}
+	v, ctxMap, err := s.repair.initVerifier(ctx)
+	if err != nil {
+		return errors.Wrap(err, "unable to initialize backfill repair verifier")
+	}
+	s.repairPool.spawn(ctx, s.nWorkers, s.clock, s.pa, v, ctxMap, s.newBlobVerifier, s.blobStore)
+
+	var prev []*repairGap
+	for {
+		current := s.clock.CurrentSlot()
+		blobStart, err := sync.BlobRPCMinValidSlot(current)
+		if err != nil {
+			return errors.Wrap(err, "configuration issue, could not compute minimum blob retention slot")
+		}
+		low, err := s.repairLowSlot(ctx, current)
+		if err != nil {
+			return err
+		}
+		gaps, err := s.repair.scan(ctx, low, blobStart)
+		if err != nil {
+			return errors.Wrap(err, "could not scan db for gaps")
+		}
+		if len(gaps) == 0 {
+			s.repair.progress.setState(RepairComplete)
+			log.WithField("lowSlot", low).Info("Backfill repair complete, no gaps found")
+			return nil
+		}
+		if sameGaps(prev, gaps) {
+			return errRepairStalled
+		}
+		log.WithField("gaps", len(gaps)).WithField("lowSlot", low).Info("Backfill repair found gaps in the db")
+		for i := range gaps {
+			if err := s.repairGap(ctx, i, gaps[i]); err != nil {
+				return err
+			}
+		}
+		prev = gaps
+	}
+}
+
+// repairGap downloads the gap in batches with a dedicated batchSequencer, and imports them in descending
+// order so that each batch is confirmed to be the ancestor of the one imported before it.
+func (s *Service) repairGap(ctx context.Context, idx int, g *repairGap) error {
+	log.WithFields(g.logFields()).Info("Backfill repair filling gap")
+	seq := newBatchSequencer(s.nWorkers, g.begin, g.end, primitives.Slot(s.batchSize))
+	s.scheduleRepair(seq)
+	for !seq.finished() {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		b, err := s.repairPool.complete()
+		if err != nil {
+			return errors.Wrap(err, "backfill repair worker pool error")
+		}
+		seq.update(b)
+		imported := 0
+		for _, ib := range seq.importable() {
+			if err := s.repair.importBatch(ctx, ib, g); err != nil {
+				log.WithError(err).WithFields(ib.logFields()).Debug("Backfill repair batch failed to import")
+				s.downscore(ib)
+				seq.update(ib.withState(batchErrRetryable))
+				break
+			}
+			seq.update(ib.withState(batchImportComplete))
+			imported += 1
+		}
+		backfillRepairBatchesImported.Add(float64(imported))
+		s.repair.progress.updateGap(idx, imported, seq.numTodo(), seq.finished())
+		s.scheduleRepair(seq)
+	}
+	return nil
+}
+
+func (s *Service) scheduleRepair(seq *batchSequencer) {
+	batches, err := seq.sequence()
+	if err != nil {
+		// errMaxBatches, the outstanding batches need to complete first.
+		return
+	}
+	for _, b := range batches {
+		// repairGap detects the end of the sequence itself, so that the same pool can be used for every gap.
+		if b.state == batchEndSequence {
+			continue
+		}
+		s.repairPool.todo(b)
+	}
+}
+
+// RepairStatus returns the progress of the repair mode, with the RepairDisabled state if it is not enabled.
+func (s *Service) RepairStatus() *RepairStatus {
+	if s.repair == nil {
+		return &RepairStatus{State: RepairDisabled}
+	}
+	return s.repair.progress.get()
+}
+
+var _ RepairStatusFetcher = (*Service)(nil)
+
+func sameGaps(a, b []*repairGap) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].kind != b[i].kind || a[i].begin != b[i].begin || a[i].end != b[i].end {
+			return false
+		}
+	}
+	return true
+}
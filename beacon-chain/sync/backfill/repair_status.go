@@ -0,0 +1,129 @@
+package backfill
+
+import (
+	"sync"
+
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+)
+
+// RepairState describes the stage of the backfill repair mode.
+type RepairState string
+
+const (
+	// RepairDisabled means the node was not started in repair mode.
+	RepairDisabled RepairState = "disabled"
+	// RepairWaiting means repair is waiting for the node to be ready, ie for initial-sync to complete.
+	RepairWaiting RepairState = "waiting"
+	// RepairScanning means the block and blob indices are being scanned for gaps.
+	RepairScanning RepairState = "scanning"
+	// RepairRepairing means the gaps found by the last scan are being downloaded.
+	RepairRepairing RepairState = "repairing"
+	// RepairComplete means the last scan did not find any gap.
+	RepairComplete RepairState = "complete"
+	// RepairFailed means repair stopped before all the gaps were filled, see RepairStatus.Error.
+	RepairFailed RepairState = "failed"
+)
+
+// RepairGapKind describes the kind of data missing in a gap.
+type RepairGapKind string
+
+const (
+	// RepairGapBlocks is a range of slots where blocks are missing from the block index.
+	RepairGapBlocks RepairGapKind = "blocks"
+	// RepairGapBlobs is a range of slots where blocks are present, but some of their blob sidecars are missing.
+	RepairGapBlobs RepairGapKind = "blobs"
+)
+
+// RepairGap is a range of slots found by the repair scan, and the progress made filling it.
+type RepairGap struct {
+	Kind             RepairGapKind
+	Start            primitives.Slot // inclusive.
+	End              primitives.Slot // exclusive.
+	Repaired         bool
+	BatchesImported  int
+	BatchesRemaining int
+}
+
+// RepairStatus is a point in time view of the progress of the backfill repair mode.
+type RepairStatus struct {
+	State RepairState
+	// Pass counts the scans performed. Repair scans again after filling the gaps it found, to check that
+	// the repaired ranges connect to the rest of the chain.
+	Pass int
+	// LowSlot and HighSlot are the bounds of the last scan.
+	LowSlot  primitives.Slot
+	HighSlot primitives.Slot
+	Gaps     []RepairGap
+	Error    string
+}
+
+// RepairStatusFetcher provides the progress of the backfill repair mode.
+type RepairStatusFetcher interface {
+	RepairStatus() *RepairStatus
+}
+
+// repairProgress is the threadsafe holder of the RepairStatus, updated by the repair loop and read by the API.
+type repairProgress struct {
+	sync.RWMutex
+	status RepairStatus
+}
+
+func newRepairProgress() *repairProgress {
+	return &repairProgress{status: RepairStatus{State: RepairWaiting}}
+}
+
+func (p *repairProgress) get() *RepairStatus {
+	p.RLock()
+	defer p.RUnlock()
+	s := p.status
+	s.Gaps = make([]RepairGap, len(p.status.Gaps))
+	copy(s.Gaps, p.status.Gaps)
+	return &s
+}
+
+func (p *repairProgress) setState(s RepairState) {
+	p.Lock()
+	defer p.Unlock()
+	p.status.State = s
+}
+
+func (p *repairProgress) fail(err error) {
+	p.Lock()
+	defer p.Unlock()
+	p.status.State = RepairFailed
+	p.status.Error = err.Error()
+}
+
+func (p *repairProgress) startScan(low, high primitives.Slot) {
+	p.Lock()
+	defer p.Unlock()
+	p.status.State = RepairScanning
+	p.status.Pass += 1
+	p.status.LowSlot = low
+	p.status.HighSlot = high
+}
+
+func (p *repairProgress) setGaps(gaps []*repairGap) {
+	p.Lock()
+	defer p.Unlock()
+	p.status.Gaps = make([]RepairGap, len(gaps))
+	for i, g := range gaps {
+		p.status.Gaps[i] = RepairGap{Kind: g.kind, Start: g.begin, End: g.end}
+	}
+	if len(gaps) == 0 {
+		p.status.State = RepairComplete
+		return
+	}
+	p.status.State = RepairRepairing
+}
+
+func (p *repairProgress) updateGap(i int, imported, remaining int, repaired bool) {
+	p.Lock()
+	defer p.Unlock()
+	if i >= len(p.status.Gaps) {
+		return
+	}
+	p.status.Gaps[i].BatchesImported += imported
+	p.status.Gaps[i].BatchesRemaining = remaining
+	p.status.Gaps[i].Repaired = repaired
+}
@@ -0,0 +1,243 @@
+package backfill
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/filesystem"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/verification"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/proto/dbval"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+type mockRepairDB struct {
+	*mockBackfillDB
+	finalized      [32]byte
+	finalizedIndex map[[32]byte]bool
+	states         []state.ReadOnlyBeaconState
+	lowest         primitives.Slot
+}
+
+var _ RepairDB = &mockRepairDB{}
+
+func (d *mockRepairDB) FinalizedCheckpoint(_ context.Context) (*ethpb.Checkpoint, error) {
+	return &ethpb.Checkpoint{Root: d.finalized[:]}, nil
+}
+
+func (d *mockRepairDB) HasBlock(_ context.Context, blockRoot [32]byte) bool {
+	_, ok := d.blocks[blockRoot]
+	return ok
+}
+
+func (d *mockRepairDB) HighestRootsBelowSlot(_ context.Context, slot primitives.Slot) (primitives.Slot, [][32]byte, error) {
+	var highest primitives.Slot
+	var roots [][32]byte
+	for root, b := range d.blocks {
+		bs := b.Block().Slot()
+		if bs >= slot || bs < highest {
+			continue
+		}
+		if bs > highest {
+			highest, roots = bs, nil
+		}
+		roots = append(roots, root)
+	}
+	return highest, roots, nil
+}
+
+func (d *mockRepairDB) HighestSlotStatesBelow(_ context.Context, _ primitives.Slot) ([]state.ReadOnlyBeaconState, error) {
+	return d.states, nil
+}
+
+func (d *mockRepairDB) IsFinalizedBlock(_ context.Context, blockRoot [32]byte) bool {
+	return d.finalizedIndex[blockRoot]
+}
+
+func (d *mockRepairDB) LowestAvailableSlot(_ context.Context) (primitives.Slot, error) {
+	return d.lowest, nil
+}
+
+// testRepairChain generates a chain of blocks with 2 blob sidecars each, one block per slot from start to end.
+func testRepairChain(t *testing.T, start, end primitives.Slot) (map[primitives.Slot]blocks.ROBlock, map[primitives.Slot][]blocks.ROBlob) {
+	blks := make(map[primitives.Slot]blocks.ROBlock)
+	blobs := make(map[primitives.Slot][]blocks.ROBlob)
+	var parent [32]byte
+	for slot := start; slot <= end; slot++ {
+		b, bl := util.GenerateTestDenebBlockWithSidecar(t, parent, slot, 2)
+		blks[slot], blobs[slot] = b, bl
+		parent = b.Root()
+	}
+	return blks, blobs
+}
+
+func TestRepairScan(t *testing.T) {
+	ctx := context.Background()
+	blks, blobs := testRepairChain(t, 1, 30)
+	mdb := &mockRepairDB{mockBackfillDB: &mockBackfillDB{}}
+	bfs := filesystem.NewEphemeralBlobStorage(t)
+	for slot, b := range blks {
+		// Blocks 10 to 14 were deleted.
+		if slot >= 10 && slot < 15 {
+			continue
+		}
+		require.NoError(t, mdb.SaveROBlocks(ctx, []blocks.ROBlock{b}, false))
+		switch slot {
+		case 16, 20, 21:
+			// The blobs of 20 and 21 are missing, those of 16 are too but they are outside of retention.
+			continue
+		case 25:
+			// Only the second blob of 25 is missing.
+			require.NoError(t, bfs.Save(verification.FakeVerifyForTest(t, blobs[slot][0])))
+			continue
+		}
+		for _, bl := range blobs[slot] {
+			require.NoError(t, bfs.Save(verification.FakeVerifyForTest(t, bl)))
+		}
+	}
+	mdb.finalized = blks[30].Root()
+
+	r := newRepairer(mdb, bfs)
+	gaps, err := r.scan(ctx, 5, 18)
+	require.NoError(t, err)
+	require.Equal(t, 3, len(gaps))
+	expected := []*repairGap{
+		{kind: RepairGapBlobs, begin: 25, end: 26, next: blks[25].Root()},
+		{kind: RepairGapBlobs, begin: 20, end: 22, next: blks[21].Root()},
+		{kind: RepairGapBlocks, begin: 10, end: 15, next: blks[14].Root(), child: blks[15].Root()},
+	}
+	for i := range expected {
+		require.DeepEqual(t, expected[i], gaps[i])
+	}
+
+	status := r.progress.get()
+	require.Equal(t, RepairRepairing, status.State)
+	require.Equal(t, 1, status.Pass)
+	require.Equal(t, primitives.Slot(5), status.LowSlot)
+	require.Equal(t, primitives.Slot(30), status.HighSlot)
+	require.Equal(t, 3, len(status.Gaps))
+	require.Equal(t, RepairGapBlocks, status.Gaps[2].Kind)
+	require.Equal(t, primitives.Slot(10), status.Gaps[2].Start)
+	require.Equal(t, primitives.Slot(15), status.Gaps[2].End)
+
+	// The gap of blocks is not reported once the scan stops above it.
+	gaps, err = r.scan(ctx, 16, 18)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(gaps))
+}
+
+func TestRepairImportBatch(t *testing.T) {
+	ctx := context.Background()
+	blks, blobs := testRepairChain(t, 10, 15)
+	mdb := &mockRepairDB{mockBackfillDB: &mockBackfillDB{}, finalizedIndex: map[[32]byte]bool{blks[15].Root(): true}}
+	bfs := filesystem.NewEphemeralBlobStorage(t)
+	r := newRepairer(mdb, bfs)
+	g := &repairGap{kind: RepairGapBlocks, begin: 10, end: 15, next: blks[14].Root(), child: blks[15].Root()}
+
+	// A batch of empty slots leaves the gap untouched.
+	require.NoError(t, r.importBatch(ctx, batch{begin: 13, end: 15}, g))
+	require.Equal(t, blks[14].Root(), g.next)
+
+	// The highest block of the batch must be the parent of the block above the gap.
+	b := batch{begin: 11, end: 13, results: verifiedROBlocks{blks[11], blks[12]}}
+	require.ErrorIs(t, r.importBatch(ctx, b, g), ErrChainBroken)
+	require.Equal(t, false, mdb.HasBlock(ctx, blks[12].Root()))
+
+	nbv := func(b blocks.ROBlob, _ []verification.Requirement) verification.BlobVerifier {
+		return &verification.MockBlobVerifier{CbVerifiedROBlob: func() (blocks.VerifiedROBlob, error) {
+			return blocks.NewVerifiedROBlob(b), nil
+		}}
+	}
+	results := verifiedROBlocks{blks[13], blks[14]}
+	bs, err := newBlobSync(100, results, &blobSyncConfig{retentionStart: 0, nbv: nbv, store: bfs})
+	require.NoError(t, err)
+	for _, slot := range []primitives.Slot{13, 14} {
+		for _, bl := range blobs[slot] {
+			require.NoError(t, bs.validateNext(bl))
+		}
+	}
+	b = batch{begin: 13, end: 15, results: results, bs: bs}
+	require.NoError(t, r.importBatch(ctx, b, g))
+	require.Equal(t, true, mdb.HasBlock(ctx, blks[13].Root()))
+	require.Equal(t, true, mdb.HasBlock(ctx, blks[14].Root()))
+	onDisk, err := bfs.Indices(blks[13].Root())
+	require.NoError(t, err)
+	require.Equal(t, true, onDisk[0] && onDisk[1])
+	require.Equal(t, blks[12].Root(), g.next)
+	require.Equal(t, blks[13].Root(), g.child)
+}
+
+func TestRepairGap(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	blks, _ := testRepairChain(t, 9, 15)
+	mdb := &mockRepairDB{mockBackfillDB: &mockBackfillDB{}}
+	pool := &mockPool{todoChan: make(chan batch, 2), finishedChan: make(chan batch, 2)}
+	s := &Service{nWorkers: 2, batchSize: 2, repair: newRepairer(mdb, filesystem.NewEphemeralBlobStorage(t)), repairPool: pool}
+	g := &repairGap{kind: RepairGapBlocks, begin: 10, end: 15, next: blks[14].Root(), child: blks[15].Root()}
+	s.repair.progress.setGaps([]*repairGap{g})
+
+	errc := make(chan error, 1)
+	go func() {
+		errc <- s.repairGap(ctx, 0, g)
+	}()
+	// The gap is split in 3 batches, [13, 15), [11, 13) and [10, 11), the last one is scheduled once
+	// a worker is available.
+	for _, expected := range []batch{{begin: 13, end: 15}, {begin: 11, end: 13}, {begin: 10, end: 11}} {
+		var b batch
+		select {
+		case b = <-pool.todoChan:
+		case <-ctx.Done():
+			t.Fatal(ctx.Err())
+		}
+		require.Equal(t, expected.begin, b.begin)
+		require.Equal(t, expected.end, b.end)
+		for slot := b.begin; slot < b.end; slot++ {
+			b.results = append(b.results, blks[slot])
+		}
+		pool.finishedChan <- b.withState(batchImportable)
+	}
+	require.NoError(t, <-errc)
+	for slot := primitives.Slot(10); slot < 15; slot++ {
+		require.Equal(t, true, mdb.HasBlock(ctx, blks[slot].Root()))
+	}
+	require.Equal(t, blks[9].Root(), g.next)
+	status := s.RepairStatus()
+	require.Equal(t, true, status.Gaps[0].Repaired)
+	require.Equal(t, 3, status.Gaps[0].BatchesImported)
+	require.Equal(t, 0, status.Gaps[0].BatchesRemaining)
+}
+
+func TestRepairLowSlot(t *testing.T) {
+	ctx := context.Background()
+	mdb := &mockRepairDB{mockBackfillDB: &mockBackfillDB{}}
+	s := &Service{
+		ms:     mockMinimumSlotter{min: 100}.minimumSlot,
+		store:  &Store{bs: &dbval.BackfillStatus{LowSlot: 200}},
+		repair: newRepairer(mdb, filesystem.NewEphemeralBlobStorage(t)),
+	}
+	low, err := s.repairLowSlot(ctx, 1000)
+	require.NoError(t, err)
+	require.Equal(t, primitives.Slot(200), low)
+
+	s.store.genesisSync = true
+	low, err = s.repairLowSlot(ctx, 1000)
+	require.NoError(t, err)
+	require.Equal(t, primitives.Slot(100), low)
+
+	// The history deleted by the pruner is not scanned for gaps.
+	mdb.lowest = 300
+	low, err = s.repairLowSlot(ctx, 1000)
+	require.NoError(t, err)
+	require.Equal(t, primitives.Slot(300), low)
+}
+
+func TestRepairStatusDisabled(t *testing.T) {
+	s := &Service{}
+	require.Equal(t, RepairDisabled, s.RepairStatus().State)
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/filesystem"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/startup"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/sync"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/verification"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
@@ -39,6 +40,9 @@ type Service struct {
 	batchImporter   batchImporter
 	blobStore       *filesystem.BlobStorage
 	initSyncWaiter  func() error
+	repairEnabled   bool
+	repair          *repairer
+	repairPool      batchWorkerPool
 }
 
 var _ runtime.Service = (*Service)(nil)
@@ -102,6 +106,16 @@ func WithInitSyncWaiter(w func() error) ServiceOption {
 	}
 }
 
+// WithRepair enables the repair mode, which scans the finalized history in the db for ranges of missing blocks or
+// blob sidecars, such as those left by a manual deletion or a partial restore, and downloads them again. Repair runs
+// before backfill resumes.
+func WithRepair(enabled bool) ServiceOption {
+	return func(s *Service) error {
+		s.repairEnabled = enabled
+		return nil
+	}
+}
+
 // InitializerWaiter is an interface that is satisfied by verification.InitializerWaiter.
 // Using this interface enables node init to satisfy this requirement for the backfill service
 // while also allowing backfill to mock it in tests.
@@ -155,6 +169,14 @@ func NewService(ctx context.Context, su *Store, bStore *filesystem.BlobStorage,
 		}
 	}
 	s.pool = newP2PBatchWorkerPool(p, s.nWorkers)
+	if s.repairEnabled {
+		rdb, ok := su.store.(RepairDB)
+		if !ok {
+			return nil, errRepairUnsupportedDB
+		}
+		s.repair = newRepairer(rdb, bStore)
+		s.repairPool = newP2PBatchWorkerPool(p, s.nWorkers)
+	}
 
 	return s, nil
 }
@@ -164,6 +186,11 @@ func (s *Service) initVerifier(ctx context.Context) (*verifier, sync.ContextByte
 	if err != nil {
 		return nil, nil, err
 	}
+	return newVerifierForState(cps)
+}
+
+// newVerifierForState initializes a verifier with the public keys of all the validators in the given state.
+func newVerifierForState(cps state.ReadOnlyBeaconState) (*verifier, sync.ContextByteVersions, error) {
 	keys, err := cps.PublicKeys()
 	if err != nil {
 		return nil, nil, errors.Wrap(err, "unable to retrieve public keys for all validators in the origin state")
@@ -248,7 +275,7 @@ func (s *Service) scheduleTodos() {
 
 // Start begins the runloop of backfill.Service in the current goroutine.
 func (s *Service) Start() {
-	if !s.enabled {
+	if !s.enabled && !s.repairEnabled {
 		log.Info("Backfill service not enabled")
 		return
 	}
@@ -271,6 +298,16 @@ func (s *Service) Start() {
 		return
 	}
 
+	if s.repair != nil {
+		if err := s.runRepair(ctx); err != nil {
+			s.repair.progress.fail(err)
+			log.WithError(err).Error("Backfill repair failed")
+		}
+	}
+	if !s.enabled {
+		return
+	}
+
 	if s.store.isGenesisSync() {
 		log.Info("Backfill short-circuit; node synced from genesis")
 		return
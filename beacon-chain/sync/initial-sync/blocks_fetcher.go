@@ -78,6 +78,7 @@ type blocksFetcherConfig struct {
 	peerFilterCapacityWeight float64
 	mode                     syncMode
 	bs                       filesystem.BlobStorageSummarizer
+	rest                     *restBlocksSource
 }
 
 // blocksFetcher is a service to fetch chain data from peers.
@@ -94,6 +95,7 @@ type blocksFetcher struct {
 	p2p             p2p.P2P
 	db              db.ReadOnlyDatabase
 	bs              filesystem.BlobStorageSummarizer
+	rest            *restBlocksSource // when set, data is fetched from the beacon API instead of peers
 	blocksPerPeriod uint64
 	rateLimiter     *leakybucket.Collector
 	peerLocks       map[peer.ID]*peerLock
@@ -153,6 +155,7 @@ func newBlocksFetcher(ctx context.Context, cfg *blocksFetcherConfig) *blocksFetc
 		p2p:             cfg.p2p,
 		db:              cfg.db,
 		bs:              cfg.bs,
+		rest:            cfg.rest,
 		blocksPerPeriod: uint64(blocksPerPeriod),
 		rateLimiter:     rateLimiter,
 		peerLocks:       make(map[peer.ID]*peerLock),
@@ -241,8 +244,10 @@ func (f *blocksFetcher) loop() {
 	// Main loop.
 	for {
 		// Make sure there are available peers before processing requests.
-		if _, err := f.waitForMinimumPeers(f.ctx); err != nil {
-			log.Error(err)
+		if f.rest == nil {
+			if _, err := f.waitForMinimumPeers(f.ctx); err != nil {
+				log.Error(err)
+			}
 		}
 
 		select {
@@ -298,6 +303,10 @@ func (f *blocksFetcher) handleRequest(ctx context.Context, start primitives.Slot
 		return response
 	}
 
+	if f.rest != nil {
+		return f.handleRESTRequest(ctx, response)
+	}
+
 	_, targetEpoch, peers := f.calculateHeadAndTargetEpochs()
 	if len(peers) == 0 {
 		response.err = errNoPeersAvailable
@@ -0,0 +1,230 @@
+package initialsync
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync/atomic"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/api/client"
+	"github.com/prysmaticlabs/prysm/v5/api/client/beacon"
+	prysmsync "github.com/prysmaticlabs/prysm/v5/beacon-chain/sync"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/ssz/detect"
+	"github.com/prysmaticlabs/prysm/v5/monitoring/tracing/trace"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+)
+
+var (
+	errNoEndpointsAvailable = errors.New("no beacon API endpoint able to serve the request")
+	errNoEndpoints          = errors.New("no beacon API endpoint provided")
+)
+
+// restBlocksSource downloads blocks and blob sidecars from the Beacon API of trusted beacon nodes, as an alternative
+// to BeaconBlocksByRange and BlobSidecarsByRange requests when the node has no p2p connectivity. Blocks and blobs
+// obtained this way go through the same verification as those received from peers.
+type restBlocksSource struct {
+	clients []*beacon.Client
+	next    atomic.Uint64
+}
+
+func newRESTBlocksSource(endpoints []string, opts ...client.ClientOpt) (*restBlocksSource, error) {
+	if len(endpoints) == 0 {
+		return nil, errNoEndpoints
+	}
+	clients := make([]*beacon.Client, 0, len(endpoints))
+	for _, e := range endpoints {
+		c, err := beacon.NewClient(e, opts...)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid beacon API endpoint %s", e)
+		}
+		clients = append(clients, c)
+	}
+	return &restBlocksSource{clients: clients}, nil
+}
+
+// rotation returns all the clients, starting from a different one on every call to spread requests over the endpoints.
+func (r *restBlocksSource) rotation() []*beacon.Client {
+	start := int((r.next.Add(1) - 1) % uint64(len(r.clients)))
+	rotated := make([]*beacon.Client, 0, len(r.clients))
+	rotated = append(rotated, r.clients[start:]...)
+	return append(rotated, r.clients[:start]...)
+}
+
+// finalizedEpoch returns the highest finalized epoch of the reachable endpoints.
+func (r *restBlocksSource) finalizedEpoch(ctx context.Context) primitives.Epoch {
+	var highest primitives.Epoch
+	for _, c := range r.clients {
+		cp, err := c.GetFinalityCheckpoints(ctx, beacon.IdHead)
+		if err != nil {
+			log.WithField("endpoint", c.NodeURL()).WithError(err).Debug("Could not request finality checkpoints from beacon API")
+			continue
+		}
+		epoch, err := strconv.ParseUint(cp.Finalized.Epoch, 10, 64)
+		if err != nil {
+			log.WithField("endpoint", c.NodeURL()).WithError(err).Debug("Invalid finalized epoch in beacon API response")
+			continue
+		}
+		if primitives.Epoch(epoch) > highest {
+			highest = primitives.Epoch(epoch)
+		}
+	}
+	return highest
+}
+
+// headSlot returns the highest head slot of the reachable endpoints.
+func (r *restBlocksSource) headSlot(ctx context.Context) primitives.Slot {
+	var highest primitives.Slot
+	for _, c := range r.clients {
+		h, err := c.GetBlockHeader(ctx, beacon.IdHead)
+		if err != nil {
+			log.WithField("endpoint", c.NodeURL()).WithError(err).Debug("Could not request head block header from beacon API")
+			continue
+		}
+		slot, err := strconv.ParseUint(h.Header.Message.Slot, 10, 64)
+		if err != nil {
+			log.WithField("endpoint", c.NodeURL()).WithError(err).Debug("Invalid head slot in beacon API response")
+			continue
+		}
+		if primitives.Slot(slot) > highest {
+			highest = primitives.Slot(slot)
+		}
+	}
+	return highest
+}
+
+// blocks requests the canonical block of every slot in the range, a 404 response meaning the slot is skipped.
+func (r *restBlocksSource) blocks(ctx context.Context, c *beacon.Client, start primitives.Slot, count uint64) ([]interfaces.ReadOnlySignedBeaconBlock, error) {
+	blks := make([]interfaces.ReadOnlySignedBeaconBlock, 0, count)
+	for slot := start; slot < start.Add(count); slot++ {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		b, err := c.GetBlock(ctx, beacon.IdFromSlot(slot))
+		if errors.Is(err, client.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		vu, err := detect.FromBlock(b)
+		if err != nil {
+			return nil, errors.Wrapf(prysmsync.ErrInvalidFetchedData, "could not detect fork of block at slot %d: %v", slot, err)
+		}
+		blk, err := vu.UnmarshalBeaconBlock(b)
+		if err != nil {
+			return nil, errors.Wrapf(prysmsync.ErrInvalidFetchedData, "could not unmarshal block at slot %d: %v", slot, err)
+		}
+		if blk.Block().Slot() != slot {
+			return nil, errors.Wrapf(prysmsync.ErrInvalidFetchedData, "requested block at slot %d, received slot %d", slot, blk.Block().Slot())
+		}
+		blks = append(blks, blk)
+	}
+	return blks, nil
+}
+
+// blobSidecars requests all the blob sidecars of the block with the given root.
+func (r *restBlocksSource) blobSidecars(ctx context.Context, c *beacon.Client, root [32]byte) ([]blocks.ROBlob, error) {
+	b, err := c.GetBlobSidecars(ctx, beacon.IdFromRoot(root))
+	if err != nil {
+		return nil, err
+	}
+	if len(b)%fieldparams.BlobSidecarSize != 0 {
+		return nil, errors.Wrapf(prysmsync.ErrInvalidFetchedData, "blob sidecars response size %d is not a multiple of %d", len(b), fieldparams.BlobSidecarSize)
+	}
+	blobs := make([]blocks.ROBlob, 0, len(b)/fieldparams.BlobSidecarSize)
+	for i := 0; i < len(b); i += fieldparams.BlobSidecarSize {
+		sc := &ethpb.BlobSidecar{}
+		if err := sc.UnmarshalSSZ(b[i : i+fieldparams.BlobSidecarSize]); err != nil {
+			return nil, errors.Wrapf(prysmsync.ErrInvalidFetchedData, "could not unmarshal blob sidecar: %v", err)
+		}
+		// The sidecars of a block are served in index order, the position is checked so that blobs[i] has index i.
+		if sc.Index != uint64(len(blobs)) {
+			return nil, errors.Wrapf(prysmsync.ErrInvalidFetchedData, "unexpected blob sidecar index %d at position %d", sc.Index, len(blobs))
+		}
+		rob, err := blocks.NewROBlob(sc)
+		if err != nil {
+			return nil, errors.Wrapf(prysmsync.ErrInvalidFetchedData, "invalid blob sidecar: %v", err)
+		}
+		blobs = append(blobs, rob)
+	}
+	return blobs, nil
+}
+
+// handleRESTRequest is the counterpart of handleRequest, when the fetcher is configured with beacon API endpoints.
+func (f *blocksFetcher) handleRESTRequest(ctx context.Context, response *fetchRequestResponse) *fetchRequestResponse {
+	// Short circuit start far exceeding the highest finalized epoch in some infinite loop.
+	if f.mode == modeStopOnFinalizedEpoch {
+		highestFinalizedSlot := params.BeaconConfig().SlotsPerEpoch.Mul(uint64(f.rest.finalizedEpoch(ctx) + 1))
+		if response.start > highestFinalizedSlot {
+			response.err = fmt.Errorf("%w, slot: %d, highest finalized slot: %d",
+				errSlotIsTooHigh, response.start, highestFinalizedSlot)
+			return response
+		}
+	}
+	response.bwb, response.err = f.fetchFromREST(ctx, response.start, response.count)
+	return response
+}
+
+// fetchFromREST fetches the blocks of the requested range and their blob sidecars from a single endpoint,
+// failing over to the next configured endpoint when the data can't be obtained or is invalid.
+func (f *blocksFetcher) fetchFromREST(ctx context.Context, start primitives.Slot, count uint64) ([]blocks.BlockWithROBlobs, error) {
+	ctx, span := trace.StartSpan(ctx, "initialsync.fetchFromREST")
+	defer span.End()
+
+	for _, c := range f.rest.rotation() {
+		blks, err := f.rest.blocks(ctx, c, start, count)
+		if err != nil {
+			log.WithField("endpoint", c.NodeURL()).WithError(err).Debug("Could not request blocks by slot from beacon API")
+			continue
+		}
+		bwb, err := sortedBlockWithVerifiedBlobSlice(blks)
+		if err != nil {
+			log.WithField("endpoint", c.NodeURL()).WithError(err).Debug("Invalid blocks in beacon API response")
+			continue
+		}
+		bwb, err = f.fetchBlobsFromREST(ctx, c, bwb)
+		if err != nil {
+			log.WithField("endpoint", c.NodeURL()).WithError(err).Debug("Could not request blob sidecars from beacon API")
+			continue
+		}
+		return bwb, nil
+	}
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	return nil, errNoEndpointsAvailable
+}
+
+// fetchBlobsFromREST requests the blob sidecars of the blocks missing them within the retention period.
+func (f *blocksFetcher) fetchBlobsFromREST(ctx context.Context, c *beacon.Client, bwb []blocks.BlockWithROBlobs) ([]blocks.BlockWithROBlobs, error) {
+	if slots.ToEpoch(f.clock.CurrentSlot()) < params.BeaconConfig().DenebForkEpoch {
+		return bwb, nil
+	}
+	blobWindowStart, err := prysmsync.BlobRPCMinValidSlot(f.clock.CurrentSlot())
+	if err != nil {
+		return nil, err
+	}
+	req := countCommitments(bwb, blobWindowStart).blobRange(f.bs).Request()
+	if req == nil {
+		return bwb, nil
+	}
+	var blobs []blocks.ROBlob
+	for _, cc := range countCommitments(bwb, req.StartSlot) {
+		if f.bs != nil && f.bs.Summary(cc.root).AllAvailable(cc.count) {
+			continue
+		}
+		sidecars, err := f.rest.blobSidecars(ctx, c, cc.root)
+		if err != nil {
+			return nil, errors.Wrapf(err, "block root %#x at slot %d", cc.root, cc.slot)
+		}
+		blobs = append(blobs, sidecars...)
+	}
+	return verifyAndPopulateBlobs(bwb, blobs, req, f.bs)
+}
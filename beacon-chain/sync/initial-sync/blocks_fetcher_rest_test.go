@@ -0,0 +1,199 @@
+package initialsync
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/startup"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+// testBeaconAPI serves the blocks and blob sidecars of the given chain, along with its head and finalized epoch.
+type testBeaconAPI struct {
+	blocks    map[primitives.Slot]blocks.ROBlock
+	blobs     map[[32]byte][]blocks.ROBlob
+	head      primitives.Slot
+	finalized primitives.Epoch
+}
+
+func (a *testBeaconAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case strings.HasPrefix(r.URL.Path, "/eth/v2/beacon/blocks/"):
+		var slot primitives.Slot
+		if _, err := fmt.Sscanf(strings.TrimPrefix(r.URL.Path, "/eth/v2/beacon/blocks/"), "%d", &slot); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		b, ok := a.blocks[slot]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		ssz, err := b.MarshalSSZ()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		_, _ = w.Write(ssz)
+	case strings.HasPrefix(r.URL.Path, "/eth/v1/beacon/blob_sidecars/"):
+		root, err := hexutil.Decode(strings.TrimPrefix(r.URL.Path, "/eth/v1/beacon/blob_sidecars/"))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		for _, bl := range a.blobs[bytesutil.ToBytes32(root)] {
+			ssz, err := bl.MarshalSSZ()
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			_, _ = w.Write(ssz)
+		}
+	case r.URL.Path == "/eth/v1/beacon/states/head/finality_checkpoints":
+		_ = json.NewEncoder(w).Encode(&structs.GetFinalityCheckpointsResponse{Data: &structs.FinalityCheckpoints{
+			Finalized: &structs.Checkpoint{Epoch: fmt.Sprintf("%d", a.finalized)},
+		}})
+	case r.URL.Path == "/eth/v1/beacon/headers/head":
+		_ = json.NewEncoder(w).Encode(&structs.GetBlockHeaderResponse{Data: &structs.SignedBeaconBlockHeaderContainer{
+			Header: &structs.SignedBeaconBlockHeader{Message: &structs.BeaconBlockHeader{Slot: fmt.Sprintf("%d", a.head)}},
+		}})
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// testRESTChain generates a chain of blocks with 2 blob sidecars each, from slot 1 to end, skipping the given slots.
+func testRESTChain(t *testing.T, end primitives.Slot, skipped ...primitives.Slot) *testBeaconAPI {
+	// Blocks are generated with the deneb types, make it the latest fork of the schedule so that they can be decoded.
+	params.SetupTestConfigCleanup(t)
+	cfg := params.MainnetConfig().Copy()
+	cfg.AlpacaForkEpoch = math.MaxUint64
+	cfg.InitializeForkSchedule()
+	params.SetActiveTestCleanup(t, cfg)
+
+	api := &testBeaconAPI{
+		blocks: make(map[primitives.Slot]blocks.ROBlock),
+		blobs:  make(map[[32]byte][]blocks.ROBlob),
+		head:   end,
+	}
+	skip := make(map[primitives.Slot]bool)
+	for _, s := range skipped {
+		skip[s] = true
+	}
+	var parent [32]byte
+	for slot := primitives.Slot(1); slot <= end; slot++ {
+		if skip[slot] {
+			continue
+		}
+		b, bl := util.GenerateTestDenebBlockWithSidecar(t, parent, slot, 2)
+		api.blocks[slot], api.blobs[b.Root()] = b, bl
+		parent = b.Root()
+	}
+	return api
+}
+
+func TestRESTBlocksSource_ChainInfo(t *testing.T) {
+	ctx := context.Background()
+	behind := httptest.NewServer(&testBeaconAPI{head: 90, finalized: 2})
+	defer behind.Close()
+	ahead := httptest.NewServer(&testBeaconAPI{head: 100, finalized: 3})
+	defer ahead.Close()
+	down := httptest.NewServer(http.NotFoundHandler())
+	defer down.Close()
+
+	rest, err := newRESTBlocksSource([]string{behind.URL, down.URL, ahead.URL})
+	require.NoError(t, err)
+	require.Equal(t, primitives.Epoch(3), rest.finalizedEpoch(ctx))
+	require.Equal(t, primitives.Slot(100), rest.headSlot(ctx))
+
+	f := &blocksFetcher{ctx: ctx, rest: rest}
+	require.Equal(t, params.BeaconConfig().SlotsPerEpoch.Mul(3), f.bestFinalizedSlot())
+	require.Equal(t, primitives.Slot(100), f.bestNonFinalizedSlot())
+
+	_, err = newRESTBlocksSource(nil)
+	require.ErrorIs(t, err, errNoEndpoints)
+}
+
+func TestRESTBlocksSource_Rotation(t *testing.T) {
+	rest, err := newRESTBlocksSource([]string{"http://a:3500", "http://b:3500", "http://c:3500"})
+	require.NoError(t, err)
+	for _, first := range []string{"http://a:3500", "http://b:3500", "http://c:3500", "http://a:3500"} {
+		r := rest.rotation()
+		require.Equal(t, 3, len(r))
+		require.Equal(t, first, r[0].NodeURL())
+	}
+}
+
+func TestBlocksFetcher_fetchFromREST(t *testing.T) {
+	ctx := context.Background()
+	api := testRESTChain(t, 12, 4, 9)
+	clock := startup.NewClock(makeGenesisTime(14), [32]byte{})
+
+	good := httptest.NewServer(api)
+	defer good.Close()
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	}))
+	defer failing.Close()
+	// This endpoint serves the same blocks, but drops the second blob sidecar of the block at slot 6.
+	partialAPI := &testBeaconAPI{blocks: api.blocks, blobs: make(map[[32]byte][]blocks.ROBlob)}
+	for root, bl := range api.blobs {
+		partialAPI.blobs[root] = bl
+	}
+	partialAPI.blobs[api.blocks[6].Root()] = api.blobs[api.blocks[6].Root()][:1]
+	partial := httptest.NewServer(partialAPI)
+	defer partial.Close()
+
+	t.Run("fails over to the next endpoint", func(t *testing.T) {
+		rest, err := newRESTBlocksSource([]string{failing.URL, partial.URL, good.URL})
+		require.NoError(t, err)
+		f := &blocksFetcher{ctx: ctx, clock: clock, rest: rest}
+		for i := 0; i < 3; i++ {
+			bwb, err := f.fetchFromREST(ctx, 1, 12)
+			require.NoError(t, err)
+			require.Equal(t, 10, len(bwb))
+			for _, bw := range bwb {
+				require.NotEqual(t, primitives.Slot(4), bw.Block.Block().Slot())
+				require.NotEqual(t, primitives.Slot(9), bw.Block.Block().Slot())
+				require.Equal(t, 2, len(bw.Blobs))
+			}
+		}
+	})
+	t.Run("no endpoint able to serve the request", func(t *testing.T) {
+		rest, err := newRESTBlocksSource([]string{failing.URL, partial.URL})
+		require.NoError(t, err)
+		f := &blocksFetcher{ctx: ctx, clock: clock, rest: rest}
+		_, err = f.fetchFromREST(ctx, 1, 12)
+		require.ErrorIs(t, err, errNoEndpointsAvailable)
+		// The range below the incomplete block is served.
+		bwb, err := f.fetchFromREST(ctx, 1, 5)
+		require.NoError(t, err)
+		require.Equal(t, 4, len(bwb))
+	})
+	t.Run("handleRequest", func(t *testing.T) {
+		rest, err := newRESTBlocksSource([]string{good.URL})
+		require.NoError(t, err)
+		f := &blocksFetcher{ctx: ctx, clock: clock, rest: rest, mode: modeStopOnFinalizedEpoch}
+		res := f.handleRequest(ctx, 1, 12)
+		require.NoError(t, res.err)
+		require.Equal(t, 10, len(res.bwb))
+		require.Equal(t, primitives.Slot(1), res.start)
+
+		// The request is rejected when it starts above the finalized epoch of the endpoints.
+		res = f.handleRequest(ctx, params.BeaconConfig().SlotsPerEpoch+1, 12)
+		require.ErrorIs(t, res.err, errSlotIsTooHigh)
+	})
+}
@@ -325,16 +325,24 @@ func (f *blocksFetcher) findAncestor(ctx context.Context, pid peer.ID, b interfa
 	return nil, errors.New("no common ancestor found")
 }
 
-// bestFinalizedSlot returns the highest finalized slot of the majority of connected peers.
+// bestFinalizedSlot returns the highest finalized slot of the majority of connected peers,
+// or of the beacon API endpoints when the fetcher is configured with them.
 func (f *blocksFetcher) bestFinalizedSlot() primitives.Slot {
+	if f.rest != nil {
+		return params.BeaconConfig().SlotsPerEpoch.Mul(uint64(f.rest.finalizedEpoch(f.ctx)))
+	}
 	cp := f.chain.FinalizedCheckpt()
 	finalizedEpoch, _ := f.p2p.Peers().BestFinalized(
 		params.BeaconConfig().MaxPeersToSync, cp.Epoch)
 	return params.BeaconConfig().SlotsPerEpoch.Mul(uint64(finalizedEpoch))
 }
 
-// bestNonFinalizedSlot returns the highest non-finalized slot of enough number of connected peers.
+// bestNonFinalizedSlot returns the highest non-finalized slot of enough number of connected peers,
+// or the highest head slot of the beacon API endpoints when the fetcher is configured with them.
 func (f *blocksFetcher) bestNonFinalizedSlot() primitives.Slot {
+	if f.rest != nil {
+		return f.rest.headSlot(f.ctx)
+	}
 	headEpoch := slots.ToEpoch(f.chain.HeadSlot())
 	targetEpoch, _ := f.p2p.Peers().BestNonFinalized(flags.Get().MinimumSyncPeers*2, headEpoch)
 	return params.BeaconConfig().SlotsPerEpoch.Mul(uint64(targetEpoch))
@@ -87,6 +87,7 @@ func (s *Service) startBlocksQueue(ctx context.Context, highestSlot primitives.S
 		highestExpectedSlot: highestSlot,
 		mode:                mode,
 		bs:                  summarizer,
+		rest:                s.rest,
 	}
 	queue := newBlocksQueue(ctx, cfg)
 	if err := queue.start(); err != nil {
@@ -204,9 +205,13 @@ func syncFields(b blocks.ROBlock) logrus.Fields {
 	}
 }
 
-// highestFinalizedEpoch returns the absolute highest finalized epoch of all connected peers.
+// highestFinalizedEpoch returns the absolute highest finalized epoch of all connected peers, or of the
+// beacon API endpoints when syncing from them.
 // Note this can be lower than our finalized epoch if we have no peers or peers that are all behind us.
 func (s *Service) highestFinalizedEpoch() primitives.Epoch {
+	if s.rest != nil {
+		return s.rest.finalizedEpoch(s.ctx)
+	}
 	highest := primitives.Epoch(0)
 	for _, pid := range s.cfg.P2P.Peers().Connected() {
 		peerChainState, err := s.cfg.P2P.Peers().ChainState(pid)
@@ -11,6 +11,7 @@ import (
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/paulbellamy/ratecounter"
 	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/api/client"
 	"github.com/prysmaticlabs/prysm/v5/async/abool"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain"
 	blockfeed "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/feed/block"
@@ -68,6 +69,9 @@ type Service struct {
 	verifierWaiter  *verification.InitializerWaiter
 	newBlobVerifier verification.NewBlobVerifier
 	ctxMap          sync.ContextByteVersions
+	restEndpoints   []string
+	restTimeout     time.Duration
+	rest            *restBlocksSource
 }
 
 // Option is a functional option for the initial-sync Service.
@@ -81,6 +85,21 @@ func WithVerifierWaiter(viw *verification.InitializerWaiter) Option {
 	}
 }
 
+// WithRESTEndpoints configures the initial-sync Service to download blocks and blob sidecars
+// from the Beacon API of the given trusted beacon nodes, instead of requesting them from peers.
+func WithRESTEndpoints(endpoints ...string) Option {
+	return func(s *Service) {
+		s.restEndpoints = endpoints
+	}
+}
+
+// WithRESTTimeout sets the timeout of requests to the endpoints configured with WithRESTEndpoints.
+func WithRESTTimeout(timeout time.Duration) Option {
+	return func(s *Service) {
+		s.restTimeout = timeout
+	}
+}
+
 // WithSyncChecker registers the initial sync service
 // in the checker.
 func WithSyncChecker(checker *SyncChecker) Option {
@@ -149,13 +168,27 @@ func (s *Service) Start() {
 	}
 	s.newBlobVerifier = newBlobVerifierFromInitializer(v)
 
+	if len(s.restEndpoints) > 0 {
+		var opts []client.ClientOpt
+		if s.restTimeout > 0 {
+			opts = append(opts, client.WithTimeout(s.restTimeout))
+		}
+		rest, err := newRESTBlocksSource(s.restEndpoints, opts...)
+		if err != nil {
+			log.WithError(err).Error("Could not configure the beacon API endpoints to sync from")
+			return
+		}
+		s.rest = rest
+		log.WithField("endpoints", s.restEndpoints).Info("Syncing from beacon API endpoints instead of peers")
+	}
+
 	gt := clock.GenesisTime()
 	if gt.IsZero() {
 		log.Debug("Exiting Initial Sync Service")
 		return
 	}
 	// Exit entering round-robin sync if we require 0 peers to sync.
-	if flags.Get().MinimumSyncPeers == 0 {
+	if flags.Get().MinimumSyncPeers == 0 && s.rest == nil {
 		s.markSynced()
 		log.WithField("genesisTime", gt).Info("Due to number of peers required for sync being set at 0, entering regular sync immediately.")
 		return
@@ -179,10 +212,13 @@ func (s *Service) Start() {
 		s.markSynced()
 		return
 	}
-	peers, err := s.waitForMinimumPeers()
-	if err != nil {
-		log.WithError(err).Error("Error waiting for minimum number of peers")
-		return
+	var peers []peer.ID
+	if s.rest == nil {
+		peers, err = s.waitForMinimumPeers()
+		if err != nil {
+			log.WithError(err).Error("Error waiting for minimum number of peers")
+			return
+		}
 	}
 	if err := s.fetchOriginBlobs(peers); err != nil {
 		log.WithError(err).Error("Failed to fetch missing blobs for checkpoint origin")
@@ -240,9 +276,10 @@ func (s *Service) Resync() error {
 	defer func() { s.synced.Set() }()                       // Reset it at the end of the method.
 	genesis := time.Unix(int64(headState.GenesisTime()), 0) // lint:ignore uintcast -- Genesis time will not exceed int64 in your lifetime.
 
-	_, err = s.waitForMinimumPeers()
-	if err != nil {
-		return err
+	if s.rest == nil {
+		if _, err = s.waitForMinimumPeers(); err != nil {
+			return err
+		}
 	}
 	if err = s.roundRobinSync(genesis); err != nil {
 		log = log.WithError(err)
@@ -331,6 +368,9 @@ func (s *Service) fetchOriginBlobs(pids []peer.ID) error {
 		log.WithField("root", fmt.Sprintf("%#x", r)).Debug("All blobs for checkpoint block are present")
 		return nil
 	}
+	if s.rest != nil {
+		return s.fetchOriginBlobsFromREST(rob, req)
+	}
 	shufflePeers(pids)
 	for i := range pids {
 		sidecars, err := sync.SendBlobSidecarByRoot(s.ctx, s.clock, s.cfg.P2P, pids[i], s.ctxMap, &req)
@@ -340,20 +380,63 @@ func (s *Service) fetchOriginBlobs(pids []peer.ID) error {
 		if len(sidecars) != len(req) {
 			continue
 		}
-		bv := verification.NewBlobBatchVerifier(s.newBlobVerifier, verification.InitsyncBlobSidecarRequirements)
-		avs := das.NewLazilyPersistentStore(s.cfg.BlobStorage, bv)
-		current := s.clock.CurrentSlot()
-		if err := avs.Persist(current, sidecars...); err != nil {
+		if err := s.persistOriginBlobs(rob, sidecars); err != nil {
+			if errors.Is(err, errOriginBlobsUnusable) {
+				log.WithField("root", fmt.Sprintf("%#x", r)).WithField("peerID", pids[i]).Warn("Blobs from peer for origin block were unusable")
+				continue
+			}
 			return err
 		}
-		if err := avs.IsDataAvailable(s.ctx, current, rob); err != nil {
-			log.WithField("root", fmt.Sprintf("%#x", r)).WithField("peerID", pids[i]).Warn("Blobs from peer for origin block were unusable")
+		return nil
+	}
+	return fmt.Errorf("no connected peer able to provide blobs for checkpoint sync block %#x", r)
+}
+
+// fetchOriginBlobsFromREST is the counterpart of fetchOriginBlobs when syncing from beacon API endpoints.
+func (s *Service) fetchOriginBlobsFromREST(rob blocks.ROBlock, req p2ptypes.BlobSidecarsByRootReq) error {
+	r := rob.Root()
+	for _, c := range s.rest.rotation() {
+		all, err := s.rest.blobSidecars(s.ctx, c, r)
+		if err != nil {
+			log.WithField("endpoint", c.NodeURL()).WithError(err).Debug("Could not request blob sidecars from beacon API")
+			continue
+		}
+		sidecars := make([]blocks.ROBlob, 0, len(req))
+		for _, id := range req {
+			if id.Index < uint64(len(all)) {
+				sidecars = append(sidecars, all[id.Index])
+			}
+		}
+		if len(sidecars) != len(req) {
 			continue
 		}
-		log.WithField("nBlobs", len(sidecars)).WithField("root", fmt.Sprintf("%#x", r)).Info("Successfully downloaded blobs for checkpoint sync block")
+		if err := s.persistOriginBlobs(rob, sidecars); err != nil {
+			if errors.Is(err, errOriginBlobsUnusable) {
+				log.WithField("root", fmt.Sprintf("%#x", r)).WithField("endpoint", c.NodeURL()).Warn("Blobs from beacon API for origin block were unusable")
+				continue
+			}
+			return err
+		}
 		return nil
 	}
-	return fmt.Errorf("no connected peer able to provide blobs for checkpoint sync block %#x", r)
+	return fmt.Errorf("no beacon API endpoint able to provide blobs for checkpoint sync block %#x", r)
+}
+
+var errOriginBlobsUnusable = errors.New("blobs for origin block are unusable")
+
+// persistOriginBlobs verifies the given sidecars and saves them to the blob storage.
+func (s *Service) persistOriginBlobs(rob blocks.ROBlock, sidecars []blocks.ROBlob) error {
+	bv := verification.NewBlobBatchVerifier(s.newBlobVerifier, verification.InitsyncBlobSidecarRequirements)
+	avs := das.NewLazilyPersistentStore(s.cfg.BlobStorage, bv)
+	current := s.clock.CurrentSlot()
+	if err := avs.Persist(current, sidecars...); err != nil {
+		return err
+	}
+	if err := avs.IsDataAvailable(s.ctx, current, rob); err != nil {
+		return errors.Wrap(errOriginBlobsUnusable, err.Error())
+	}
+	log.WithField("nBlobs", len(sidecars)).WithField("root", fmt.Sprintf("%#x", rob.Root())).Info("Successfully downloaded blobs for checkpoint sync block")
+	return nil
 }
 
 func shufflePeers(pids []peer.ID) {
@@ -2,10 +2,12 @@ package sync
 
 import (
 	"reflect"
+	"sort"
 	"sync"
 	"time"
 
 	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	"github.com/trailofbits/go-mutexasserts"
@@ -26,6 +28,14 @@ const blockBucketPeriod = 30 * time.Second
 // Dummy topic to validate all incoming rpc requests.
 const rpcLimiterTopic = "rpc-limiter-topic"
 
+// RateLimitUsage is the use a peer made of the rate limit of a topic.
+type RateLimitUsage struct {
+	Topic     string
+	Count     int64
+	Remaining int64
+	Capacity  int64
+}
+
 type limiter struct {
 	limiterMap map[string]*leakybucket.Collector
 	p2p        p2p.P2P
@@ -176,6 +186,27 @@ func (l *limiter) addRawStream(stream network.Stream) {
 	collector.Add(key, 1)
 }
 
+// returns the use the peer made of the rate limit of each topic, sorted by topic.
+func (l *limiter) usage(pid peer.ID) []*RateLimitUsage {
+	l.RLock()
+	defer l.RUnlock()
+
+	key := pid.String()
+	usage := make([]*RateLimitUsage, 0, len(l.limiterMap))
+	for topic, collector := range l.limiterMap {
+		usage = append(usage, &RateLimitUsage{
+			Topic:     topic,
+			Count:     collector.Count(key),
+			Remaining: collector.Remaining(key),
+			Capacity:  collector.Capacity(),
+		})
+	}
+	sort.Slice(usage, func(i, j int) bool {
+		return usage[i].Topic < usage[j].Topic
+	})
+	return usage
+}
+
 // frees all the collectors and removes them.
 func (l *limiter) free() {
 	l.Lock()
@@ -27,6 +27,30 @@ func TestNewRateLimiter_FreeCorrectly(t *testing.T) {
 	assert.Equal(t, len(rlimiter.limiterMap), 0, "rate limiter not freed correctly")
 }
 
+func TestRateLimiter_Usage(t *testing.T) {
+	p1 := mockp2p.NewTestP2P(t)
+	p2 := mockp2p.NewTestP2P(t)
+	rlimiter := newRateLimiter(p1)
+
+	topic := p2p.RPCPingTopicV1 + p1.Encoding().ProtocolSuffix()
+	rlimiter.limiterMap[topic].Add(p2.PeerID().String(), 2)
+
+	usage := rlimiter.usage(p2.PeerID())
+	require.Equal(t, len(rlimiter.limiterMap), len(usage))
+	for i, u := range usage {
+		if i > 0 {
+			assert.Equal(t, true, usage[i-1].Topic < u.Topic, "usage is not sorted by topic")
+		}
+		if u.Topic != topic {
+			assert.Equal(t, int64(0), u.Count)
+			continue
+		}
+		assert.Equal(t, int64(2), u.Count)
+		assert.Equal(t, int64(defaultBurstLimit), u.Capacity)
+		assert.Equal(t, int64(defaultBurstLimit-2), u.Remaining)
+	}
+}
+
 func TestRateLimiter_ExceedCapacity(t *testing.T) {
 	p1 := mockp2p.NewTestP2P(t)
 	p2 := mockp2p.NewTestP2P(t)
@@ -47,7 +47,7 @@ func (s *Service) beaconBlocksByRangeRPCHandler(ctx context.Context, msg interfa
 		tracing.AnnotateError(span, err)
 		return err
 	}
-	available := s.validateRangeAvailability(rp)
+	available := s.validateRangeAvailability(ctx, rp)
 	if !available {
 		log.Debug("error in validating range availability")
 		s.writeErrorResponseToStream(responseCodeResourceUnavailable, p2ptypes.ErrResourceUnavailable.Error(), stream)
@@ -149,8 +149,16 @@ func validateRangeRequest(r *pb.BeaconBlocksByRangeRequest, current primitives.S
 	return rp, nil
 }
 
-func (s *Service) validateRangeAvailability(rp rangeParams) bool {
+func (s *Service) validateRangeAvailability(ctx context.Context, rp rangeParams) bool {
 	startBlock := rp.start
+	// Blocks before the lowest available slot were pruned from the database, so the range
+	// cannot be served in full.
+	lowest, err := s.cfg.beaconDB.LowestAvailableSlot(ctx)
+	if err != nil {
+		log.WithError(err).Error("Could not get the lowest available slot")
+	} else if startBlock < lowest {
+		return false
+	}
 	return s.availableBlocker.AvailableBlock(startBlock)
 }
 
@@ -1108,6 +1108,31 @@ func TestRPCBeaconBlocksByRange_FilterBlocks_PreviousRoot(t *testing.T) {
 	require.NotEqual(t, cf.prevRoot, [32]byte{})
 }
 
+func TestRPCBeaconBlocksByRange_validateRangeAvailability_Pruned(t *testing.T) {
+	ctx := context.Background()
+	d := db.SetupDB(t)
+	root, err := util.SaveBlock(t, ctx, d, util.NewBeaconBlock()).Block().HashTreeRoot()
+	require.NoError(t, err)
+	require.NoError(t, d.SaveGenesisBlockRoot(ctx, root))
+	for i := primitives.Slot(1); i <= 10; i++ {
+		blk := util.NewBeaconBlock()
+		blk.Block.Slot = i
+		blk.Block.ParentRoot = root[:]
+		root, err = util.SaveBlock(t, ctx, d, blk).Block().HashTreeRoot()
+		require.NoError(t, err)
+	}
+	require.NoError(t, d.SaveFinalizedCheckpoint(ctx, &ethpb.Checkpoint{Root: root[:]}))
+	_, err = d.PruneHistory(ctx, 5, 64)
+	require.NoError(t, err)
+
+	r := &Service{cfg: &config{beaconDB: d}, availableBlocker: mockBlocker{avail: true}}
+	assert.Equal(t, false, r.validateRangeAvailability(ctx, rangeParams{start: 0}))
+	assert.Equal(t, false, r.validateRangeAvailability(ctx, rangeParams{start: 4}))
+	assert.Equal(t, true, r.validateRangeAvailability(ctx, rangeParams{start: 5}))
+	r.availableBlocker = mockBlocker{avail: false}
+	assert.Equal(t, false, r.validateRangeAvailability(ctx, rangeParams{start: 5}))
+}
+
 type mockBlocker struct {
 	avail bool
 }
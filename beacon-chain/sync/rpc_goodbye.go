@@ -10,6 +10,7 @@ import (
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/prysmaticlabs/prysm/v5/async"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/peers/peerdata"
 	p2ptypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/types"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v5/time/slots"
@@ -48,6 +49,7 @@ func (s *Service) goodbyeRPCHandler(_ context.Context, msg interface{}, stream l
 	}
 	log := log.WithField("Reason", goodbyeMessage(*m))
 	log.WithField("peer", stream.Conn().RemotePeer()).Trace("Peer has sent a goodbye message")
+	s.setLastGoodbye(stream.Conn().RemotePeer(), *m, false /* sent */)
 	s.cfg.p2p.Peers().SetNextValidTime(stream.Conn().RemotePeer(), goodByeBackoff(*m))
 	// closes all streams with the peer
 	return s.cfg.p2p.Disconnect(stream.Conn().RemotePeer())
@@ -89,9 +91,21 @@ func (s *Service) sendGoodByeAndDisconnect(ctx context.Context, code p2ptypes.RP
 			"peer":  id,
 		}).Trace("Could not send goodbye message to peer")
 	}
+	s.setLastGoodbye(id, code, true /* sent */)
 	return s.cfg.p2p.Disconnect(id)
 }
 
+// setLastGoodbye records the last goodbye message exchanged with the peer, for it to be inspected
+// by the operator.
+func (s *Service) setLastGoodbye(id peer.ID, code p2ptypes.RPCGoodbyeCode, sent bool) {
+	s.cfg.p2p.Peers().SetLastGoodbye(id, &peerdata.Goodbye{
+		Code:   uint64(code),
+		Reason: goodbyeMessage(code),
+		Sent:   sent,
+		Time:   time.Now(),
+	})
+}
+
 func (s *Service) sendGoodByeMessage(ctx context.Context, code p2ptypes.RPCGoodbyeCode, id peer.ID) error {
 	ctx, cancel := context.WithTimeout(ctx, respTimeout)
 	defer cancel()
@@ -66,6 +66,12 @@ func TestGoodByeRPCHandler_Disconnects_With_Peer(t *testing.T) {
 	if len(conns) > 0 {
 		t.Error("Peer is still not disconnected despite sending a goodbye message")
 	}
+	goodbye, err := p1.Peers().LastGoodbye(p2.BHost.ID())
+	require.NoError(t, err)
+	require.NotNil(t, goodbye)
+	assert.Equal(t, uint64(failureCode), goodbye.Code)
+	assert.Equal(t, goodbyeMessage(failureCode), goodbye.Reason)
+	assert.Equal(t, false, goodbye.Sent)
 }
 
 func TestGoodByeRPCHandler_BackOffPeer(t *testing.T) {
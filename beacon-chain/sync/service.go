@@ -346,6 +346,16 @@ func (s *Service) chainIsStarted() bool {
 	return s.chainStarted.IsSet()
 }
 
+// RateLimitUsageFetcher returns the use peers made of the rate limits of the RPC topics.
+type RateLimitUsageFetcher interface {
+	PeerRateLimitUsage(pid peer.ID) []*RateLimitUsage
+}
+
+// PeerRateLimitUsage returns the use the peer made of the rate limit of each RPC topic.
+func (s *Service) PeerRateLimitUsage(pid peer.ID) []*RateLimitUsage {
+	return s.rateLimiter.usage(pid)
+}
+
 // Checker defines a struct which can verify whether a node is currently
 // synchronizing a chain with the rest of peers in the network.
 type Checker interface {
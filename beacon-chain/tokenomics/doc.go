@@ -0,0 +1,7 @@
+/*
+Package tokenomics defines a runtime service which indexes the tokenomics values
+of the canonical chain, such as reserves and the reward adjustment factor,
+at every epoch transition. The values are persisted in the beacon database,
+so that their history can be served without replaying states.
+*/
+package tokenomics
@@ -0,0 +1,5 @@
+package tokenomics
+
+import "github.com/sirupsen/logrus"
+
+var log = logrus.WithField("prefix", "tokenomics")
@@ -0,0 +1,21 @@
+package tokenomics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	lastIndexedEpoch = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "tokenomics_indexer_last_indexed_epoch",
+		Help: "The last epoch which tokenomics values are indexed for",
+	})
+	indexedReserves = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "tokenomics_indexer_reserves",
+		Help: "The reserves at the start of the last indexed epoch, in Gwei",
+	})
+	indexedRewardAdjustmentFactor = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "tokenomics_indexer_reward_adjustment_factor",
+		Help: "The reward adjustment factor of the last indexed epoch",
+	})
+)
@@ -0,0 +1,289 @@
+package tokenomics
+
+import (
+	"context"
+	"sort"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/feed"
+	statefeed "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/feed/state"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
+	coreTime "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/time"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/transition"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/filters"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state/stategen"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/tokenomics/types"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+	"github.com/sirupsen/logrus"
+)
+
+// Config contains the dependencies of the tokenomics indexer.
+type Config struct {
+	BeaconDB        db.NoHeadAccessDatabase
+	StateNotifier   statefeed.Notifier
+	ReplayerBuilder stategen.ReplayerBuilder
+	// Backfill makes the indexer start from genesis when nothing has been indexed yet.
+	// Otherwise, the indexer starts from the finalized epoch at the time it is started.
+	Backfill bool
+}
+
+// Service indexes the tokenomics values of every finalized epoch.
+// It keeps the state at the start of the last indexed epoch, and advances it
+// by applying the finalized blocks of each epoch. Therefore, each epoch only costs
+// one epoch worth of state transition, regardless of how states are stored in the DB.
+type Service struct {
+	cfg    *Config
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	// nextEpoch is the next epoch to index, and st is the state at the start of the last indexed epoch, if any.
+	nextEpoch primitives.Epoch
+	st        state.BeaconState
+}
+
+// NewService sets up a new tokenomics indexer service.
+func NewService(ctx context.Context, cfg *Config) *Service {
+	ctx, cancel := context.WithCancel(ctx)
+	return &Service{
+		cfg:    cfg,
+		ctx:    ctx,
+		cancel: cancel,
+	}
+}
+
+// Start the tokenomics indexer service.
+func (s *Service) Start() {
+	go s.run()
+}
+
+// Stop the tokenomics indexer service.
+func (s *Service) Stop() error {
+	s.cancel()
+	return nil
+}
+
+// Status of the tokenomics indexer service.
+func (*Service) Status() error {
+	return nil
+}
+
+func (s *Service) run() {
+	stateChannel := make(chan *feed.Event, 1)
+	stateSub := s.cfg.StateNotifier.StateFeed().Subscribe(stateChannel)
+	defer stateSub.Unsubscribe()
+
+	if err := s.initializeNextEpoch(s.ctx); err != nil {
+		log.WithError(err).Error("Could not initialize tokenomics indexer")
+		return
+	}
+	log.WithField("epoch", s.nextEpoch).Info("Starting tokenomics indexer")
+	s.indexFinalizedEpochs(s.ctx)
+
+	for {
+		select {
+		case ev := <-stateChannel:
+			if ev.Type == statefeed.FinalizedCheckpoint {
+				s.indexFinalizedEpochs(s.ctx)
+			}
+		case <-s.ctx.Done():
+			log.Debug("Context closed, exiting goroutine")
+			return
+		case err := <-stateSub.Err():
+			log.WithError(err).Error("Could not subscribe to state notifier")
+			return
+		}
+	}
+}
+
+// initializeNextEpoch sets the next epoch to index, continuing from the last indexed epoch.
+func (s *Service) initializeNextEpoch(ctx context.Context) error {
+	last, err := s.cfg.BeaconDB.LastTokenomicsSnapshot(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not get last tokenomics snapshot")
+	}
+	if last != nil {
+		s.nextEpoch = last.Epoch + 1
+		return nil
+	}
+	if s.cfg.Backfill {
+		s.nextEpoch = 0
+		return nil
+	}
+	cp, err := s.cfg.BeaconDB.FinalizedCheckpoint(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not get finalized checkpoint")
+	}
+	s.nextEpoch = cp.Epoch
+	return nil
+}
+
+// indexFinalizedEpochs indexes every epoch up to the finalized epoch.
+// The state at the start of the finalized epoch only depends on finalized blocks.
+func (s *Service) indexFinalizedEpochs(ctx context.Context) {
+	cp, err := s.cfg.BeaconDB.FinalizedCheckpoint(ctx)
+	if err != nil {
+		log.WithError(err).Error("Could not get finalized checkpoint")
+		return
+	}
+	for s.nextEpoch <= cp.Epoch {
+		if ctx.Err() != nil {
+			return
+		}
+		if err := s.indexNextEpoch(ctx); err != nil {
+			log.WithError(err).WithField("epoch", s.nextEpoch).Error("Could not index tokenomics")
+			// The cached state could be stale, rebuild it on the next attempt.
+			s.st = nil
+			return
+		}
+	}
+}
+
+// indexNextEpoch saves the snapshot of the next epoch.
+// The state of the last indexed epoch is kept, and only advanced when the next epoch is indexed,
+// so that only the blocks before the finalized checkpoint are applied to it.
+func (s *Service) indexNextEpoch(ctx context.Context) error {
+	var err error
+	switch {
+	case s.st != nil && coreTime.CurrentEpoch(s.st)+1 == s.nextEpoch:
+		s.st, err = s.advanceToNextEpoch(ctx, s.st)
+		if err != nil {
+			return errors.Wrap(err, "could not advance state to the next epoch")
+		}
+	case s.st == nil || coreTime.CurrentEpoch(s.st) != s.nextEpoch:
+		s.st, err = s.epochStartState(ctx, s.nextEpoch)
+		if err != nil {
+			return errors.Wrap(err, "could not get state at the start of the epoch")
+		}
+	}
+
+	snapshot, err := SnapshotFromState(ctx, s.st)
+	if err != nil {
+		return errors.Wrap(err, "could not build tokenomics snapshot")
+	}
+	if err := s.cfg.BeaconDB.SaveTokenomicsSnapshots(ctx, []*types.EpochSnapshot{snapshot}); err != nil {
+		return errors.Wrap(err, "could not save tokenomics snapshot")
+	}
+	lastIndexedEpoch.Set(float64(snapshot.Epoch))
+	indexedReserves.Set(float64(snapshot.Reserves))
+	indexedRewardAdjustmentFactor.Set(float64(snapshot.RewardAdjustmentFactor))
+	log.WithFields(logrus.Fields{
+		"epoch":                  snapshot.Epoch,
+		"reserves":               snapshot.Reserves,
+		"rewardAdjustmentFactor": snapshot.RewardAdjustmentFactor,
+	}).Debug("Indexed tokenomics")
+
+	s.nextEpoch++
+	return nil
+}
+
+// epochStartState returns the state at the start slot of the given epoch, after the epoch transition
+// and before the block at the start slot is applied.
+func (s *Service) epochStartState(ctx context.Context, epoch primitives.Epoch) (state.BeaconState, error) {
+	if epoch == 0 {
+		return s.cfg.BeaconDB.GenesisState(ctx)
+	}
+	start, err := slots.EpochStart(epoch)
+	if err != nil {
+		return nil, err
+	}
+	return s.cfg.ReplayerBuilder.ReplayerForSlot(start-1).ReplayToSlot(ctx, start)
+}
+
+// advanceToNextEpoch applies the canonical blocks of the state's epoch and processes slots
+// up to the start of the next epoch. The state must be at the start slot of its epoch.
+func (s *Service) advanceToNextEpoch(ctx context.Context, st state.BeaconState) (state.BeaconState, error) {
+	epoch := coreTime.CurrentEpoch(st)
+	start, err := slots.EpochStart(epoch)
+	if err != nil {
+		return nil, err
+	}
+	end, err := slots.EpochStart(epoch + 1)
+	if err != nil {
+		return nil, err
+	}
+	blks, err := s.canonicalBlocks(ctx, start, end-1)
+	if err != nil {
+		return nil, err
+	}
+
+	st = st.Copy()
+	for _, b := range blks {
+		// The block is already included in the state, e.g. the genesis block.
+		if b.Block().Slot() <= st.LatestBlockHeader().Slot {
+			continue
+		}
+		st, err = stategen.ReplayProcessSlots(ctx, st, b.Block().Slot())
+		if err != nil {
+			return nil, errors.Wrap(err, "could not process slots")
+		}
+		st, err = transition.ProcessBlockForStateRoot(ctx, st, b)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not process block")
+		}
+	}
+	return stategen.ReplayProcessSlots(ctx, st, end)
+}
+
+// canonicalBlocks returns the finalized blocks within [startSlot, endSlot], sorted by slot.
+func (s *Service) canonicalBlocks(ctx context.Context, startSlot, endSlot primitives.Slot) ([]interfaces.ReadOnlySignedBeaconBlock, error) {
+	blks, roots, err := s.cfg.BeaconDB.Blocks(ctx, filters.NewFilter().SetStartSlot(startSlot).SetEndSlot(endSlot))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get blocks")
+	}
+	canonical := make([]interfaces.ReadOnlySignedBeaconBlock, 0, len(blks))
+	for i, b := range blks {
+		if s.cfg.BeaconDB.IsFinalizedBlock(ctx, roots[i]) {
+			canonical = append(canonical, b)
+		}
+	}
+	sort.Slice(canonical, func(i, j int) bool {
+		return canonical[i].Block().Slot() < canonical[j].Block().Slot()
+	})
+	return canonical, nil
+}
+
+// SnapshotFromState returns the tokenomics snapshot of the given state.
+// The state is expected to be at the start slot of its epoch.
+func SnapshotFromState(ctx context.Context, st state.BeaconState) (*types.EpochSnapshot, error) {
+	header := st.LatestBlockHeader()
+	if header == nil {
+		return nil, errors.New("nil latest block header")
+	}
+	// The state root of the latest block header is only filled in the next slot.
+	// That is not the case for the genesis state, which is at the slot of its latest block.
+	if header.Slot == st.Slot() {
+		stateRoot, err := st.HashTreeRoot(ctx)
+		if err != nil {
+			return nil, err
+		}
+		header = &ethpb.BeaconBlockHeader{
+			Slot:          header.Slot,
+			ProposerIndex: header.ProposerIndex,
+			ParentRoot:    header.ParentRoot,
+			StateRoot:     stateRoot[:],
+			BodyRoot:      header.BodyRoot,
+		}
+	}
+	blockRoot, err := header.HashTreeRoot()
+	if err != nil {
+		return nil, err
+	}
+	totalActiveBalance, err := helpers.TotalActiveBalance(st)
+	if err != nil {
+		return nil, err
+	}
+	return &types.EpochSnapshot{
+		Epoch:                  coreTime.CurrentEpoch(st),
+		BlockRoot:              blockRoot,
+		Reserves:               st.Reserves(),
+		RewardAdjustmentFactor: st.RewardAdjustmentFactor(),
+		FeedbackBoost:          helpers.EpochFeedbackBoost(st),
+		EpochIssuance:          helpers.EpochIssuance(coreTime.CurrentEpoch(st)),
+		TotalActiveBalance:     totalActiveBalance,
+	}, nil
+}
@@ -0,0 +1,68 @@
+package tokenomics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/transition"
+	testDB "github.com/prysmaticlabs/prysm/v5/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+func TestService_IndexFinalizedEpochs(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := testDB.SetupDB(t)
+	genesis, _ := util.DeterministicGenesisStateElectra(t, 64)
+	require.NoError(t, genesis.SetReserves(1000000000000))
+	require.NoError(t, genesis.SetRewardAdjustmentFactor(100))
+	require.NoError(t, beaconDB.SaveGenesisData(ctx, genesis))
+	genesisRoot, err := beaconDB.GenesisBlockRoot(ctx)
+	require.NoError(t, err)
+	require.NoError(t, beaconDB.SaveFinalizedCheckpoint(ctx, &ethpb.Checkpoint{Epoch: 2, Root: genesisRoot[:]}))
+
+	s := NewService(ctx, &Config{BeaconDB: beaconDB, Backfill: true})
+	require.NoError(t, s.initializeNextEpoch(ctx))
+	require.Equal(t, 0, int(s.nextEpoch))
+	s.indexFinalizedEpochs(ctx)
+	require.Equal(t, 3, int(s.nextEpoch))
+
+	snapshots, err := beaconDB.TokenomicsSnapshots(ctx, 0, 10)
+	require.NoError(t, err)
+	require.Equal(t, 3, len(snapshots))
+
+	st, err := beaconDB.GenesisState(ctx)
+	require.NoError(t, err)
+	for i, snapshot := range snapshots {
+		if i > 0 {
+			st, err = transition.ProcessSlots(ctx, st, st.Slot()+params.BeaconConfig().SlotsPerEpoch)
+			require.NoError(t, err)
+		}
+		want, err := SnapshotFromState(ctx, st)
+		require.NoError(t, err)
+		require.DeepEqual(t, want, snapshot)
+		// No block is proposed after genesis.
+		require.Equal(t, snapshots[0].BlockRoot, snapshot.BlockRoot)
+	}
+
+	// Indexing continues from the last indexed epoch.
+	s = NewService(ctx, &Config{BeaconDB: beaconDB})
+	require.NoError(t, s.initializeNextEpoch(ctx))
+	require.Equal(t, 3, int(s.nextEpoch))
+}
+
+func TestService_InitializeNextEpoch_NoBackfill(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := testDB.SetupDB(t)
+	genesis, _ := util.DeterministicGenesisStateElectra(t, 64)
+	require.NoError(t, beaconDB.SaveGenesisData(ctx, genesis))
+	genesisRoot, err := beaconDB.GenesisBlockRoot(ctx)
+	require.NoError(t, err)
+	require.NoError(t, beaconDB.SaveFinalizedCheckpoint(ctx, &ethpb.Checkpoint{Epoch: 5, Root: genesisRoot[:]}))
+
+	s := NewService(ctx, &Config{BeaconDB: beaconDB})
+	require.NoError(t, s.initializeNextEpoch(ctx))
+	require.Equal(t, 5, int(s.nextEpoch))
+}
@@ -0,0 +1,53 @@
+package types
+
+import (
+	"encoding/binary"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+)
+
+// snapshotSize is the size of an encoded EpochSnapshot: 6 uint64 values and a 32 bytes root.
+const snapshotSize = 6*8 + 32
+
+// EpochSnapshot contains the tokenomics values of the canonical state
+// at the start of an epoch, right after the epoch transition.
+type EpochSnapshot struct {
+	Epoch primitives.Epoch
+	// BlockRoot is the root of the latest block included in the state, i.e. the epoch boundary block root.
+	BlockRoot              [32]byte
+	Reserves               uint64
+	RewardAdjustmentFactor uint64
+	// FeedbackBoost is the reward paid from the reserves for the epoch.
+	FeedbackBoost      uint64
+	EpochIssuance      uint64
+	TotalActiveBalance uint64
+}
+
+// MarshalBinary encodes the snapshot with a fixed size encoding.
+func (s *EpochSnapshot) MarshalBinary() ([]byte, error) {
+	enc := make([]byte, snapshotSize)
+	binary.LittleEndian.PutUint64(enc[0:8], uint64(s.Epoch))
+	copy(enc[8:40], s.BlockRoot[:])
+	binary.LittleEndian.PutUint64(enc[40:48], s.Reserves)
+	binary.LittleEndian.PutUint64(enc[48:56], s.RewardAdjustmentFactor)
+	binary.LittleEndian.PutUint64(enc[56:64], s.FeedbackBoost)
+	binary.LittleEndian.PutUint64(enc[64:72], s.EpochIssuance)
+	binary.LittleEndian.PutUint64(enc[72:80], s.TotalActiveBalance)
+	return enc, nil
+}
+
+// UnmarshalBinary decodes a snapshot encoded by MarshalBinary.
+func (s *EpochSnapshot) UnmarshalBinary(enc []byte) error {
+	if len(enc) != snapshotSize {
+		return errors.Errorf("wrong epoch snapshot size, want %d, got %d", snapshotSize, len(enc))
+	}
+	s.Epoch = primitives.Epoch(binary.LittleEndian.Uint64(enc[0:8]))
+	copy(s.BlockRoot[:], enc[8:40])
+	s.Reserves = binary.LittleEndian.Uint64(enc[40:48])
+	s.RewardAdjustmentFactor = binary.LittleEndian.Uint64(enc[48:56])
+	s.FeedbackBoost = binary.LittleEndian.Uint64(enc[56:64])
+	s.EpochIssuance = binary.LittleEndian.Uint64(enc[64:72])
+	s.TotalActiveBalance = binary.LittleEndian.Uint64(enc[72:80])
+	return nil
+}
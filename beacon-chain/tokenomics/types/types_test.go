@@ -0,0 +1,27 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestEpochSnapshot_MarshalUnmarshal(t *testing.T) {
+	s := &EpochSnapshot{
+		Epoch:                  12,
+		BlockRoot:              [32]byte{'a'},
+		Reserves:               1000,
+		RewardAdjustmentFactor: 150,
+		FeedbackBoost:          10,
+		EpochIssuance:          20,
+		TotalActiveBalance:     30,
+	}
+	enc, err := s.MarshalBinary()
+	require.NoError(t, err)
+
+	decoded := &EpochSnapshot{}
+	require.NoError(t, decoded.UnmarshalBinary(enc))
+	require.DeepEqual(t, s, decoded)
+
+	require.ErrorContains(t, "wrong epoch snapshot size", decoded.UnmarshalBinary(enc[1:]))
+}
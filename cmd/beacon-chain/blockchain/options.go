@@ -20,5 +20,8 @@ func FlagOptions(c *cli.Context) ([]blockchain.Option, error) {
 		blockchain.WithMaxGoroutines(maxRoutines),
 		blockchain.WithWeakSubjectivityCheckpoint(wsCheckpt),
 	}
+	if c.IsSet(flags.ReorgPolicyFile.Name) {
+		opts = append(opts, blockchain.WithReorgPolicyFile(c.String(flags.ReorgPolicyFile.Name)))
+	}
 	return opts, nil
 }
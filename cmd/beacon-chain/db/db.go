@@ -1,8 +1,19 @@
 package db
 
 import (
+	"fmt"
+	"path"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain/kzg"
 	beacondb "github.com/prysmaticlabs/prysm/v5/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/era"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/filesystem"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/kv"
 	"github.com/prysmaticlabs/prysm/v5/cmd"
+	"github.com/prysmaticlabs/prysm/v5/cmd/beacon-chain/storage"
+	"github.com/prysmaticlabs/prysm/v5/config/features"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
 	"github.com/prysmaticlabs/prysm/v5/runtime/tos"
 	"github.com/sirupsen/logrus"
 	"github.com/urfave/cli/v2"
@@ -18,9 +29,10 @@ var Commands = &cli.Command{
 	Subcommands: []*cli.Command{
 		{
 			Name:        "restore",
-			Description: `restores a database from a backup file`,
+			Description: `restores a database from a backup file, or from a backup archive and its incremental backup archives`,
 			Flags: cmd.WrapFlags([]cli.Flag{
 				cmd.RestoreSourceFileFlag,
+				cmd.RestoreIncrementalFilesFlag,
 				cmd.RestoreTargetDirFlag,
 			}),
 			Before: tos.VerifyTosAcceptedOrPrompt,
@@ -31,5 +43,97 @@ var Commands = &cli.Command{
 				return nil
 			},
 		},
+		{
+			Name:        "backup",
+			Description: `writes a backup archive of an offline database, optionally only with the blocks and states newer than the last backup`,
+			Flags: cmd.WrapFlags([]cli.Flag{
+				cmd.DataDirFlag,
+				cmd.BackupOutputFlag,
+				cmd.BackupIncrementalFlag,
+			}),
+			Before: tos.VerifyTosAcceptedOrPrompt,
+			Action: func(cliCtx *cli.Context) error {
+				if err := beacondb.Backup(cliCtx); err != nil {
+					log.WithError(err).Fatal("Could not back up database")
+				}
+				return nil
+			},
+		},
+		{
+			Name:        "import-era",
+			Description: `imports era files into an offline database, to seed a node with the finalized chain without syncing it from the network`,
+			Flags: cmd.WrapFlags([]cli.Flag{
+				cmd.DataDirFlag,
+				storage.BlobStoragePathFlag,
+				cmd.EraDirFlag,
+				cmd.ChainConfigFileFlag,
+				features.DolphinTestnet,
+			}),
+			Before: tos.VerifyTosAcceptedOrPrompt,
+			Action: func(cliCtx *cli.Context) error {
+				if err := importEras(cliCtx); err != nil {
+					log.WithError(err).Fatal("Could not import eras")
+				}
+				return nil
+			},
+		},
 	},
 }
+
+// importEras configures the network of the era files, which must be the network of the node, then verifies and
+// imports them into the offline beacon chain database of the data directory, which is created if it does not exist.
+// The blob sidecars of the eras are saved to the blob storage directory.
+func importEras(cliCtx *cli.Context) error {
+	if cliCtx.Bool(features.DolphinTestnet.Name) {
+		if err := params.SetActive(params.DolphinConfig().Copy()); err != nil {
+			return err
+		}
+	}
+	if cliCtx.IsSet(cmd.ChainConfigFileFlag.Name) {
+		if err := params.LoadChainConfigFile(cliCtx.String(cmd.ChainConfigFileFlag.Name), nil); err != nil {
+			return err
+		}
+	}
+	ctx := cliCtx.Context
+	eraDir := cliCtx.String(cmd.EraDirFlag.Name)
+	files, err := era.Files(eraDir)
+	if err != nil {
+		return errors.Wrapf(err, "could not list era files in %s", eraDir)
+	}
+	if len(files) == 0 {
+		return errors.Errorf("no era files found in %s", eraDir)
+	}
+	if err := kzg.Start(); err != nil {
+		return errors.Wrap(err, "could not load KZG trusted setup")
+	}
+	blobs, err := filesystem.NewBlobStorage(
+		filesystem.WithBasePath(storage.BlobStoragePath(cliCtx)),
+		filesystem.WithBlobRetentionEpochs(params.BeaconConfig().MinEpochsForBlobsSidecarsRequest),
+	)
+	if err != nil {
+		return err
+	}
+	d, err := kv.NewKVStore(ctx, path.Join(cliCtx.String(cmd.DataDirFlag.Name), kv.BeaconNodeDbDirName))
+	if err != nil {
+		return errors.Wrap(err, "could not open database")
+	}
+	defer func() {
+		if err := d.Close(); err != nil {
+			log.WithError(err).Error("Could not close database")
+		}
+	}()
+
+	if err := era.Import(ctx, &era.ImportConfig{BeaconDB: d, Blobs: blobs, Files: files}); err != nil {
+		return err
+	}
+	cp, err := d.FinalizedCheckpoint(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not get finalized checkpoint")
+	}
+	log.WithFields(logrus.Fields{
+		"eras":           len(files),
+		"finalizedEpoch": cp.Epoch,
+		"finalizedRoot":  fmt.Sprintf("%#x", cp.Root),
+	}).Info("Imported eras, the node resumes syncing from the last era")
+	return nil
+}
@@ -4,6 +4,7 @@ package flags
 
 import (
 	"strings"
+	"time"
 
 	"github.com/prysmaticlabs/prysm/v5/cmd"
 	"github.com/prysmaticlabs/prysm/v5/config/params"
@@ -180,6 +181,13 @@ var (
 		Usage: "The slot durations of when an archived state gets saved in the beaconDB.",
 		Value: 2048,
 	}
+	// StateDiffExponents specifies the layout of the hierarchical state diffs saved with --enable-state-diff.
+	StateDiffExponents = &cli.Uint64SliceFlag{
+		Name: "state-diff-exponents",
+		Usage: "The layers of the hierarchical state diffs, as strictly decreasing exponents of 2. The state of a slot is " +
+			"saved in the first layer whose interval divides the slot, as a snapshot in the first layer and as a diff " +
+			"against the closest coarser layer otherwise. Defaults to 21,17,13,9,5,0.",
+	}
 	// BlockBatchLimit specifies the requested block batch size.
 	BlockBatchLimit = &cli.IntFlag{
 		Name:  "block-batch-limit",
@@ -296,12 +304,64 @@ var (
 			"WARNING: This flag should be used only if you have a clear understanding that community has decided to override the terminal block hash activation epoch. " +
 			"Incorrect usage will result in your node experience consensus failure.",
 	}
+	// EnableTokenomicsIndexer enables the indexer persisting the reserves and the reward adjustment factor of every finalized epoch.
+	EnableTokenomicsIndexer = &cli.BoolFlag{
+		Name: "enable-tokenomics-indexer",
+		Usage: "Enables the background indexer which stores the reserves and the reward adjustment factor " +
+			"of every finalized epoch, served by the tokenomics history API.",
+	}
+	// TokenomicsIndexerBackfill makes the tokenomics indexer start from genesis.
+	TokenomicsIndexerBackfill = &cli.BoolFlag{
+		Name: "tokenomics-indexer-backfill",
+		Usage: "Makes the tokenomics indexer start from genesis when nothing has been indexed yet, " +
+			"instead of the current finalized epoch. Requires the blocks since genesis in the database.",
+	}
 	// SlasherDirFlag defines a path on disk where the slasher database is stored.
 	SlasherDirFlag = &cli.StringFlag{
 		Name:  "slasher-datadir",
 		Usage: "Directory for the slasher database",
 		Value: cmd.DefaultDataDir(),
 	}
+	// SlasherHistoricalBackfill makes the slasher backfill its history from the blocks of the beacon database.
+	SlasherHistoricalBackfill = &cli.BoolFlag{
+		Name: "slasher-historical-backfill",
+		Usage: "Makes the slasher detect slashable offenses in the blocks stored in the beacon database " +
+			"for its history length, in addition to the ones received once the node is synced.",
+	}
+	// SlasherBackfillEpochsPerSlot sets the number of epochs the slasher backfill processes per slot.
+	SlasherBackfillEpochsPerSlot = &cli.Uint64Flag{
+		Name: "slasher-backfill-epochs-per-slot",
+		Usage: "Number of epochs of history the slasher backfill queues for detection on each slot. " +
+			"Higher values complete the backfill faster at the cost of slower live detection.",
+		Value: 1,
+	}
+	// ForkChoiceRecordingEpochs sets the number of latest epochs whose fork choice inputs are recorded.
+	ForkChoiceRecordingEpochs = &cli.IntFlag{
+		Name: "forkchoice-recording-epochs",
+		Usage: "Number of latest epochs whose fork choice inputs are recorded, to be replayed with the debug " +
+			"Beacon API or prysmctl. Every recorded epoch holds a snapshot of fork choice, whose size grows with " +
+			"the number of validators. Disabled when 0.",
+	}
+	// ReorgPolicyFile sets the YAML file of the proposer boost and late block reorg policy of fork choice.
+	ReorgPolicyFile = &cli.StringFlag{
+		Name: "reorg-policy-file",
+		Usage: "YAML file overriding the proposer boost and late block reorg parameters of fork choice " +
+			"(DISABLE_LATE_BLOCK_REORGS, PROPOSER_SCORE_BOOST, REORG_HEAD_WEIGHT_THRESHOLD, " +
+			"REORG_PARENT_WEIGHT_THRESHOLD, REORG_MAX_EPOCHS_SINCE_FINALIZATION). The file is reloaded when it changes.",
+	}
+	// InitialSyncBeaconAPIURLs sets the trusted beacon nodes initial-sync downloads blocks and blob sidecars from.
+	InitialSyncBeaconAPIURLs = &cli.StringSliceFlag{
+		Name: "initial-sync-beacon-api-url",
+		Usage: "URL of the Beacon API of a trusted beacon node, which initial-sync downloads blocks and blob sidecars " +
+			"from instead of requesting them from peers. Can be set multiple times, requests are spread over the nodes. " +
+			"The downloaded data is fully verified.",
+	}
+	// InitialSyncBeaconAPITimeout sets the timeout of requests to the initial-sync beacon API endpoints.
+	InitialSyncBeaconAPITimeout = &cli.DurationFlag{
+		Name:  "initial-sync-beacon-api-timeout",
+		Usage: "Timeout for requests made to the --initial-sync-beacon-api-url endpoints (uses duration format, ex: 2m31s).",
+		Value: 2 * time.Minute,
+	}
 
 	// AuthTokenPathFlag defines the path to the auth token used to secure the validator api.
 	AuthTokenPathFlag = &cli.StringFlag{
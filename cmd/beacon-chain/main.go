@@ -62,6 +62,7 @@ var appFlags = []cli.Flag{
 	flags.BlobBatchLimitBurstFactor,
 	flags.InteropMockEth1DataVotesFlag,
 	flags.SlotsPerArchivedPoint,
+	flags.StateDiffExponents,
 	flags.EnableOverNodeRPCEndpoints,
 	flags.DisableDebugRPCEndpoints,
 	flags.SubscribeToAllSubnets,
@@ -139,6 +140,7 @@ var appFlags = []cli.Flag{
 	cmd.RestoreSourceFileFlag,
 	cmd.RestoreTargetDirFlag,
 	cmd.ValidatorMonitorIndicesFlag,
+	cmd.ValidatorMonitorBailoutWarningEpochsFlag,
 	cmd.P2PColocationWhitelistFlag,
 	cmd.P2PColocationLimitFlag,
 	cmd.P2PIpTrackerBanTimeFlag,
@@ -148,14 +150,24 @@ var appFlags = []cli.Flag{
 	checkpoint.RemoteURL,
 	genesis.StatePath,
 	genesis.BeaconAPIURL,
+	flags.EnableTokenomicsIndexer,
+	flags.TokenomicsIndexerBackfill,
 	flags.SlasherDirFlag,
+	flags.SlasherHistoricalBackfill,
+	flags.SlasherBackfillEpochsPerSlot,
+	flags.ForkChoiceRecordingEpochs,
+	flags.ReorgPolicyFile,
+	flags.InitialSyncBeaconAPIURLs,
+	flags.InitialSyncBeaconAPITimeout,
 	flags.JwtId,
 	storage.BlobStoragePathFlag,
 	storage.BlobRetentionEpochFlag,
+	storage.HistoryRetentionEpochFlag,
 	bflags.EnableExperimentalBackfill,
 	bflags.BackfillBatchSize,
 	bflags.BackfillWorkerCount,
 	bflags.BackfillOldestSlot,
+	bflags.BackfillRepair,
 	flags.AuthTokenPathFlag,
 }
 
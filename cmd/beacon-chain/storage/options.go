@@ -24,6 +24,13 @@ var (
 		Value:   uint64(params.BeaconConfig().MinEpochsForBlobsSidecarsRequest),
 		Aliases: []string{"extend-blob-retention-epoch"},
 	}
+	// HistoryRetentionEpochFlag enables pruning of the finalized blocks and states older than the given number of epochs.
+	HistoryRetentionEpochFlag = &cli.Uint64Flag{
+		Name: "history-retention-epochs",
+		Usage: "Delete finalized blocks, state summaries and archived states older than the given number of epochs " +
+			"in the background. History is kept forever when not set. The node will exit with an error at startup " +
+			"if the value is less than MIN_EPOCHS_FOR_BLOCK_REQUESTS.",
+	}
 )
 
 // BeaconNodeOptions sets configuration values on the node.BeaconNode value at node startup.
@@ -36,12 +43,20 @@ func BeaconNodeOptions(c *cli.Context) ([]node.Option, error) {
 		return nil, err
 	}
 	opts := []node.Option{node.WithBlobStorageOptions(
-		filesystem.WithBlobRetentionEpochs(e), filesystem.WithBasePath(blobStoragePath(c)),
+		filesystem.WithBlobRetentionEpochs(e), filesystem.WithBasePath(BlobStoragePath(c)),
 	)}
+	he, err := historyRetentionEpoch(c)
+	if err != nil {
+		return nil, err
+	}
+	if he > 0 {
+		opts = append(opts, node.WithHistoryRetentionEpochs(he))
+	}
 	return opts, nil
 }
 
-func blobStoragePath(c *cli.Context) string {
+// BlobStoragePath returns the blob storage directory, which defaults to a 'blobs' directory in the data directory.
+func BlobStoragePath(c *cli.Context) string {
 	blobsPath := c.Path(BlobStoragePathFlag.Name)
 	if blobsPath == "" {
 		// append a "blobs" subdir to the end of the data dir path
@@ -69,3 +84,20 @@ func blobRetentionEpoch(cliCtx *cli.Context) (primitives.Epoch, error) {
 
 	return re, nil
 }
+
+var errInvalidHistoryRetentionEpochs = errors.New("value is smaller than the minimum epochs for block requests")
+
+// historyRetentionEpoch returns the user-specified history retention period, or zero if history is kept forever.
+// If the period is smaller than MIN_EPOCHS_FOR_BLOCK_REQUESTS, an error will be returned, as the node must be
+// able to serve blocks to its peers for at least that long.
+func historyRetentionEpoch(cliCtx *cli.Context) (primitives.Epoch, error) {
+	if !cliCtx.IsSet(HistoryRetentionEpochFlag.Name) {
+		return 0, nil
+	}
+	re := primitives.Epoch(cliCtx.Uint64(HistoryRetentionEpochFlag.Name))
+	spec := primitives.Epoch(params.BeaconConfig().MinEpochsForBlockRequests)
+	if re < spec {
+		return 0, errors.Wrapf(errInvalidHistoryRetentionEpochs, "%s=%d, spec=%d", HistoryRetentionEpochFlag.Name, re, spec)
+	}
+	return re, nil
+}
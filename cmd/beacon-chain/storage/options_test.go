@@ -18,7 +18,7 @@ func TestBlobStoragePath_NoFlagSpecified(t *testing.T) {
 	set := flag.NewFlagSet("test", 0)
 	set.String(cmd.DataDirFlag.Name, cmd.DataDirFlag.Value, cmd.DataDirFlag.Usage)
 	cliCtx := cli.NewContext(&app, set, nil)
-	storagePath := blobStoragePath(cliCtx)
+	storagePath := BlobStoragePath(cliCtx)
 
 	assert.Equal(t, cmd.DefaultDataDir()+"/blobs", storagePath)
 }
@@ -28,7 +28,7 @@ func TestBlobStoragePath_FlagSpecified(t *testing.T) {
 	set := flag.NewFlagSet("test", 0)
 	set.String(BlobStoragePathFlag.Name, "/blah/blah", BlobStoragePathFlag.Usage)
 	cliCtx := cli.NewContext(&app, set, nil)
-	storagePath := blobStoragePath(cliCtx)
+	storagePath := BlobStoragePath(cliCtx)
 
 	assert.Equal(t, "/blah/blah", storagePath)
 }
@@ -61,3 +61,29 @@ func TestConfigureBlobRetentionEpoch(t *testing.T) {
 	_, err = blobRetentionEpoch(cliCtx)
 	require.ErrorIs(t, err, errInvalidBlobRetentionEpochs)
 }
+
+func TestConfigureHistoryRetentionEpoch(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	specMinEpochs := params.BeaconConfig().MinEpochsForBlockRequests
+	app := cli.App{}
+	set := flag.NewFlagSet("test", 0)
+	cliCtx := cli.NewContext(&app, set, nil)
+
+	// Test case: History is kept forever by default.
+	epochs, err := historyRetentionEpoch(cliCtx)
+	require.NoError(t, err)
+	require.Equal(t, primitives.Epoch(0), epochs)
+
+	set.Uint64(HistoryRetentionEpochFlag.Name, 0, "")
+
+	// Test case: Input epoch is greater than or equal to spec value.
+	require.NoError(t, set.Set(HistoryRetentionEpochFlag.Name, fmt.Sprintf("%d", specMinEpochs)))
+	epochs, err = historyRetentionEpoch(cliCtx)
+	require.NoError(t, err)
+	require.Equal(t, primitives.Epoch(specMinEpochs), epochs)
+
+	// Test case: Input epoch is less than spec value.
+	require.NoError(t, set.Set(HistoryRetentionEpochFlag.Name, fmt.Sprintf("%d", specMinEpochs-1)))
+	_, err = historyRetentionEpoch(cliCtx)
+	require.ErrorIs(t, err, errInvalidHistoryRetentionEpochs)
+}
@@ -35,6 +35,13 @@ var (
 			"This has a multiplicative effect with " + backfillBatchSizeName + ".",
 		Value: 2,
 	}
+	// BackfillRepair scans the db for gaps in the block and blob sidecar history, and downloads the missing data.
+	BackfillRepair = &cli.BoolFlag{
+		Name: "backfill-repair",
+		Usage: "Scans the finalized blocks and blob sidecars in the db for gaps, such as those left by a manual deletion " +
+			"or a partial restore, and downloads the missing data from peers before backfill resumes. " +
+			"Progress is reported by the /prysm/v1/node/backfill/repair endpoint.",
+	}
 	BackfillOldestSlot = &cli.Uint64Flag{
 		Name: "backfill-oldest-slot",
 		Usage: "Specifies the oldest slot that backfill should download. " +
@@ -16,6 +16,7 @@ func BeaconNodeOptions(c *cli.Context) ([]node.Option, error) {
 			backfill.WithBatchSize(c.Uint64(flags.BackfillBatchSize.Name)),
 			backfill.WithWorkerCount(c.Int(flags.BackfillWorkerCount.Name)),
 			backfill.WithEnableBackfill(c.Bool(flags.EnableExperimentalBackfill.Name)),
+			backfill.WithRepair(c.Bool(flags.BackfillRepair.Name)),
 		}
 		// The zero value of this uint flag would be genesis, so we use IsSet to differentiate nil from zero case.
 		if c.IsSet(flags.BackfillOldestSlot.Name) {
@@ -76,6 +76,7 @@ var appHelpFlagGroups = []flagGroup{
 			cmd.RestoreSourceFileFlag,
 			cmd.RestoreTargetDirFlag,
 			cmd.ValidatorMonitorIndicesFlag,
+			cmd.ValidatorMonitorBailoutWarningEpochsFlag,
 			cmd.ApiTimeoutFlag,
 		},
 	},
@@ -111,6 +112,7 @@ var appHelpFlagGroups = []flagGroup{
 			flags.ExecutionJWTSecretFlag,
 			flags.SetGCPercent,
 			flags.SlotsPerArchivedPoint,
+			flags.StateDiffExponents,
 			flags.BlockBatchLimit,
 			flags.BlockBatchLimitBurstFactor,
 			flags.BlobBatchLimit,
@@ -129,7 +131,15 @@ var appHelpFlagGroups = []flagGroup{
 			flags.MaxBuilderEpochMissedSlots,
 			flags.MaxBuilderConsecutiveMissedSlots,
 			flags.EngineEndpointTimeoutSeconds,
+			flags.EnableTokenomicsIndexer,
+			flags.TokenomicsIndexerBackfill,
 			flags.SlasherDirFlag,
+			flags.SlasherHistoricalBackfill,
+			flags.SlasherBackfillEpochsPerSlot,
+			flags.ForkChoiceRecordingEpochs,
+			flags.ReorgPolicyFile,
+			flags.InitialSyncBeaconAPIURLs,
+			flags.InitialSyncBeaconAPITimeout,
 			flags.LocalBlockValueBoost,
 			flags.MinBuilderBid,
 			flags.MinBuilderDiff,
@@ -141,10 +151,12 @@ var appHelpFlagGroups = []flagGroup{
 			genesis.BeaconAPIURL,
 			storage.BlobStoragePathFlag,
 			storage.BlobRetentionEpochFlag,
+			storage.HistoryRetentionEpochFlag,
 			backfill.EnableExperimentalBackfill,
 			backfill.BackfillWorkerCount,
 			backfill.BackfillBatchSize,
 			backfill.BackfillOldestSlot,
+			backfill.BackfillRepair,
 			flags.AuthTokenPathFlag,
 		},
 	},
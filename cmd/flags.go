@@ -294,6 +294,13 @@ var (
 		Name:  "monitor-indices",
 		Usage: "List of validator indices to track performance",
 	}
+	// ValidatorMonitorBailoutWarningEpochsFlag specifies how many epochs before the projected bailout
+	// the monitor starts to warn about a tracked validator.
+	ValidatorMonitorBailoutWarningEpochsFlag = &cli.Uint64Flag{
+		Name:  "monitor-bailout-warning-epochs",
+		Usage: "Warns when a tracked validator is projected to be bailed out within this number of epochs if it stays offline",
+		Value: 225,
+	}
 
 	// RestoreSourceFileFlag specifies the filepath to the backed-up database file
 	// which will be used to restore the database.
@@ -301,12 +308,35 @@ var (
 		Name:  "restore-source-file",
 		Usage: "Filepath to the backed-up database file which will be used to restore the database",
 	}
+	// RestoreIncrementalFilesFlag specifies the incremental backup archives applied, in order, on top of the
+	// backup archive of the restore source file.
+	RestoreIncrementalFilesFlag = &cli.StringSliceFlag{
+		Name:  "restore-incremental-files",
+		Usage: "Filepaths to incremental backup archives which are applied, in order, on top of the backup archive of the restore source file",
+	}
 	// RestoreTargetDirFlag specifies the target directory of the restored database.
 	RestoreTargetDirFlag = &cli.StringFlag{
 		Name:  "restore-target-dir",
 		Usage: "Target directory of the restored database",
 		Value: DefaultDataDir(),
 	}
+	// BackupOutputFlag specifies the file the database backup archive is written to.
+	BackupOutputFlag = &cli.StringFlag{
+		Name:  "backup-output",
+		Usage: "Filepath the database backup archive is written to in the tar format, or - to write it to the standard output",
+		Value: "-",
+	}
+	// BackupIncrementalFlag enables incremental database backups.
+	BackupIncrementalFlag = &cli.BoolFlag{
+		Name:  "backup-incremental",
+		Usage: "Only back up the blocks and states which are newer than the last backup of the database",
+	}
+	// EraDirFlag specifies the directory of the era files imported into the database.
+	EraDirFlag = &cli.StringFlag{
+		Name:     "era-dir",
+		Usage:    "Directory of the era files, exported with 'prysmctl db export-era', which are imported into the database",
+		Required: true,
+	}
 	// ApiTimeoutFlag specifies the timeout value for API requests in seconds. A timeout of zero means no timeout.
 	ApiTimeoutFlag = &cli.DurationFlag{
 		Name:  "api-timeout",
@@ -10,6 +10,13 @@ var Commands = []*cli.Command{
 			queryCmd,
 			bucketsCmd,
 			spanCmd,
+			slasherStatsCmd,
+			slasherRebuildCmd,
+			auditTokenomicsCmd,
+			verifyCmd,
+			compactCmd,
+			exportEraCmd,
+			verifyEraCmd,
 		},
 	},
 }
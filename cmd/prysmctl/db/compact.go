@@ -0,0 +1,44 @@
+package db
+
+import (
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/kv"
+	log "github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+)
+
+var compactFlags = struct {
+	Path string
+}{}
+
+var compactCmd = &cli.Command{
+	Name: "compact",
+	Usage: "rewrites an offline beacon db into a new file to reclaim the free pages left behind by deleted data, " +
+		"such as pruned history",
+	Action: func(cliCtx *cli.Context) error {
+		if err := compactAction(cliCtx); err != nil {
+			log.WithError(err).Fatal("Could not compact db")
+		}
+		return nil
+	},
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:        "path",
+			Usage:       "path to directory containing beaconchain.db",
+			Destination: &compactFlags.Path,
+			Required:    true,
+		},
+	},
+}
+
+func compactAction(_ *cli.Context) error {
+	before, after, err := kv.Compact(compactFlags.Path)
+	if err != nil {
+		return err
+	}
+	log.WithFields(log.Fields{
+		"sizeBefore": before,
+		"sizeAfter":  after,
+		"reclaimed":  before - after,
+	}).Info("Compacted db")
+	return nil
+}
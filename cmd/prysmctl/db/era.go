@@ -0,0 +1,189 @@
+package db
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain/kzg"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/era"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/filesystem"
+	"github.com/prysmaticlabs/prysm/v5/cmd"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	log "github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+)
+
+var exportEraFlags = struct {
+	Path     string
+	BlobPath string
+	Out      string
+	StartEra uint64
+	EndEra   uint64
+}{}
+
+var exportEraCmd = &cli.Command{
+	Name: "export-era",
+	Usage: "exports the finalized blocks, blob sidecars and era states of an offline beacon db into era files, " +
+		"which can be imported by a beacon node with 'beacon-chain db import-era'",
+	Action: func(cliCtx *cli.Context) error {
+		if err := exportEraAction(cliCtx); err != nil {
+			log.WithError(err).Fatal("Could not export eras")
+		}
+		return nil
+	},
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:        "path",
+			Usage:       "path to directory containing beaconchain.db",
+			Destination: &exportEraFlags.Path,
+			Required:    true,
+		},
+		&cli.StringFlag{
+			Name:        "blob-path",
+			Usage:       "path to the blob storage directory, blob sidecars are not exported if it is not set",
+			Destination: &exportEraFlags.BlobPath,
+		},
+		&cli.StringFlag{
+			Name:        "out",
+			Usage:       "directory the era files are written to",
+			Destination: &exportEraFlags.Out,
+			Required:    true,
+		},
+		&cli.Uint64Flag{
+			Name:        "start-era",
+			Usage:       "first era to export, era 0 only holds the genesis state",
+			Destination: &exportEraFlags.StartEra,
+		},
+		&cli.Uint64Flag{
+			Name:        "end-era",
+			Usage:       "last era to export, defaults to the latest finalized era",
+			Destination: &exportEraFlags.EndEra,
+		},
+		cmd.ChainConfigFileFlag,
+	},
+}
+
+var verifyEraFlags = struct {
+	Dir string
+}{}
+
+var verifyEraCmd = &cli.Command{
+	Name: "verify-era",
+	Usage: "verifies the era files of a directory, their slot indices, blocks, blob sidecars and historical " +
+		"summaries, and checks every era against the historical accumulator of the following eras",
+	Action: func(cliCtx *cli.Context) error {
+		if err := verifyEraAction(cliCtx); err != nil {
+			log.WithError(err).Fatal("Could not verify eras")
+		}
+		return nil
+	},
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:        "dir",
+			Usage:       "directory containing the era files",
+			Destination: &verifyEraFlags.Dir,
+			Required:    true,
+		},
+		cmd.ChainConfigFileFlag,
+	},
+}
+
+func loadChainConfig(cliCtx *cli.Context) error {
+	if !cliCtx.IsSet(cmd.ChainConfigFileFlag.Name) {
+		return nil
+	}
+	return params.LoadChainConfigFile(cliCtx.String(cmd.ChainConfigFileFlag.Name), nil)
+}
+
+func exportEraAction(cliCtx *cli.Context) error {
+	ctx := cliCtx.Context
+	f := exportEraFlags
+	if err := loadChainConfig(cliCtx); err != nil {
+		return err
+	}
+	d, err := openStore(cliCtx, f.Path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := d.Close(); err != nil {
+			log.WithError(err).Error("Could not close db")
+		}
+	}()
+	var blobs *filesystem.BlobStorage
+	if f.BlobPath != "" {
+		if _, err := os.Stat(f.BlobPath); err != nil {
+			return errors.Wrap(err, "could not find blob storage")
+		}
+		blobs, err = filesystem.NewBlobStorage(
+			filesystem.WithBasePath(f.BlobPath),
+			filesystem.WithBlobRetentionEpochs(params.BeaconConfig().MinEpochsForBlobsSidecarsRequest),
+		)
+		if err != nil {
+			return err
+		}
+	}
+	end := f.EndEra
+	if !cliCtx.IsSet("end-era") {
+		if end, err = era.LatestFinalizedEra(ctx, d); err != nil {
+			return err
+		}
+	}
+	if err := os.MkdirAll(f.Out, params.BeaconIoConfig().ReadWriteExecutePermissions); err != nil {
+		return errors.Wrap(err, "could not create output directory")
+	}
+	files, err := era.Export(ctx, &era.ExportConfig{
+		BeaconDB: d,
+		Blobs:    blobs,
+		Dir:      f.Out,
+		StartEra: f.StartEra,
+		EndEra:   end,
+	})
+	if err != nil {
+		return err
+	}
+	log.WithFields(log.Fields{
+		"startEra": f.StartEra,
+		"endEra":   end,
+		"files":    len(files),
+	}).Info("Exported eras")
+	return nil
+}
+
+func verifyEraAction(cliCtx *cli.Context) error {
+	if err := loadChainConfig(cliCtx); err != nil {
+		return err
+	}
+	files, err := era.Files(verifyEraFlags.Dir)
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		return fmt.Errorf("no era files found in %s", verifyEraFlags.Dir)
+	}
+	if err := kzg.Start(); err != nil {
+		return errors.Wrap(err, "could not load KZG trusted setup")
+	}
+
+	// Eras are decoded one at a time, only their historical summaries are kept to check them against the following eras.
+	var verified []*era.Era
+	for _, f := range files {
+		e, err := era.ReadFile(f)
+		if err != nil {
+			return err
+		}
+		if err := e.Verify(); err != nil {
+			return errors.Wrapf(err, "could not verify %s", f)
+		}
+		for _, v := range verified {
+			if err := v.VerifyAccumulator(e.State); err != nil {
+				return errors.Wrapf(err, "could not verify %s", f)
+			}
+		}
+		log.WithFields(log.Fields{"era": e.Number, "blocks": len(e.Blocks), "file": f}).Info("Verified era")
+		verified = append(verified, &era.Era{Number: e.Number, Summary: e.Summary})
+	}
+	log.WithField("eras", len(verified)).Info("Verified eras")
+	return nil
+}
@@ -0,0 +1,200 @@
+package db
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/slasherkv"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/slasher"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	log "github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+)
+
+var slasherFlags = struct {
+	BeaconPath string
+	StartEpoch uint64
+	EndEpoch   uint64
+}{}
+
+// slasherParamsFlags are the flags locating a slasher db and the parameters its spans are stored with.
+func slasherParamsFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:        "db-path-directory",
+			Usage:       "path to directory containing slasher.db",
+			Destination: &f.Path,
+			Required:    true,
+		},
+		&cli.Uint64Flag{
+			Name:        "chunk-size",
+			Usage:       "chunk size the spans are stored with",
+			Destination: &f.ChunkSize,
+			DefaultText: fmt.Sprintf("%d", slasherDefaultParams.ChunkSize()),
+		},
+		&cli.Uint64Flag{
+			Name:        "validator-chunk-size",
+			Usage:       "validator chunk size the spans are stored with",
+			Destination: &f.ValidatorChunkSize,
+			DefaultText: fmt.Sprintf("%d", slasherDefaultParams.ValidatorChunkSize()),
+		},
+		&cli.Uint64Flag{
+			Name:        "history-length",
+			Usage:       "history length the spans are stored with",
+			Destination: &f.HistoryLength,
+			DefaultText: fmt.Sprintf("%d", slasherDefaultParams.HistoryLength()),
+		},
+	}
+}
+
+var slasherStatsCmd = &cli.Command{
+	Name:  "slasher-stats",
+	Usage: "reports the bucket sizes, the epoch coverage and the validator chunk coverage of an offline slasher db",
+	Action: func(cliCtx *cli.Context) error {
+		if err := slasherStatsAction(cliCtx); err != nil {
+			log.WithError(err).Fatal("Could not report slasher db")
+		}
+		return nil
+	},
+	Flags: slasherParamsFlags(),
+}
+
+var slasherRebuildCmd = &cli.Command{
+	Name: "slasher-rebuild",
+	Usage: "rebuilds the min and max spans of an epoch window of an offline slasher db from the attestations " +
+		"of the blocks of an offline beacon db",
+	Action: func(cliCtx *cli.Context) error {
+		if err := slasherRebuildAction(cliCtx); err != nil {
+			log.WithError(err).Fatal("Could not rebuild slasher spans")
+		}
+		return nil
+	},
+	Flags: append(slasherParamsFlags(),
+		&cli.StringFlag{
+			Name:        "path",
+			Usage:       "path to directory containing beaconchain.db",
+			Destination: &slasherFlags.BeaconPath,
+			Required:    true,
+		},
+		&cli.Uint64Flag{
+			Name:        "start-epoch",
+			Usage:       "first epoch of the window to rebuild",
+			Destination: &slasherFlags.StartEpoch,
+			Required:    true,
+		},
+		&cli.Uint64Flag{
+			Name:        "end-epoch",
+			Usage:       "last epoch of the window to rebuild",
+			Destination: &slasherFlags.EndEpoch,
+			Required:    true,
+		},
+	),
+}
+
+func slasherStatsAction(cliCtx *cli.Context) error {
+	ctx := cliCtx.Context
+	d, err := openSlasherStore(cliCtx, f.Path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := d.Close(); err != nil {
+			log.WithError(err).Error("Could not close slasher db")
+		}
+	}()
+
+	params := getSlasherParams()
+	r, err := slasher.ReportDatabase(ctx, d, params)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("# DB: %s (%d bytes)\n", f.Path, r.FileSize)
+	fmt.Printf("# Chunk Size: %d, Validator Chunk Size: %d, History Length: %d\n\n",
+		params.ChunkSize(), params.ValidatorChunkSize(), params.HistoryLength())
+
+	tw := table.NewWriter()
+	tw.AppendHeader(table.Row{"Bucket", "Entries", "In use bytes", "Allocated bytes"})
+	for _, b := range r.Buckets {
+		tw.AppendRow(table.Row{b.Name, b.Entries, b.InUseBytes, b.AllocatedBytes})
+	}
+	displayTable(tw)
+
+	tw = table.NewWriter()
+	tw.AppendHeader(table.Row{"Records", "Lowest", "Highest"})
+	tw.AppendRow(epochRangeRow("Attestation target epochs", r.HasAttestations, uint64(r.LowestTargetEpoch), uint64(r.HighestTargetEpoch)))
+	tw.AppendRow(epochRangeRow("Proposal slots", r.HasProposals, uint64(r.LowestProposalSlot), uint64(r.HighestProposalSlot)))
+	tw.AppendRow(epochRangeRow("Epochs written by validators", r.AttestedValidators > 0, uint64(r.LowestEpochWritten), uint64(r.HighestEpochWritten)))
+	tw.AppendRow(epochRangeRow("Span epochs", r.HasSpans, uint64(r.LowestSpanEpoch), uint64(r.HighestSpanEpoch)))
+	displayTable(tw)
+
+	tw = table.NewWriter()
+	tw.AppendHeader(table.Row{"Chunk kind", "Chunks", "Validator chunks", "Complete", "Highest validator", "Missing validator chunks"})
+	for _, c := range r.Coverage {
+		missing := make([]string, len(c.MissingValidatorChunks))
+		for i, m := range c.MissingValidatorChunks {
+			missing[i] = fmt.Sprintf("%d", m)
+		}
+		var highest interface{} = "N/A"
+		if c.Chunks > 0 {
+			highest = c.HighestValidatorIndex
+		}
+		tw.AppendRow(table.Row{c.Kind, c.Chunks, c.ValidatorChunks, c.CompleteValidatorChunks, highest, strings.Join(missing, ",")})
+	}
+	displayTable(tw)
+	return nil
+}
+
+func epochRangeRow(name string, ok bool, lowest, highest uint64) table.Row {
+	if !ok {
+		return table.Row{name, "N/A", "N/A"}
+	}
+	return table.Row{name, lowest, highest}
+}
+
+func slasherRebuildAction(cliCtx *cli.Context) error {
+	ctx := cliCtx.Context
+	sd, err := openSlasherStore(cliCtx, f.Path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := sd.Close(); err != nil {
+			log.WithError(err).Error("Could not close slasher db")
+		}
+	}()
+	bd, err := openStore(cliCtx, slasherFlags.BeaconPath)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := bd.Close(); err != nil {
+			log.WithError(err).Error("Could not close db")
+		}
+	}()
+
+	_, err = slasher.RebuildSpans(ctx, &slasher.RebuildConfig{
+		SlasherDB:  sd,
+		BeaconDB:   bd,
+		Params:     getSlasherParams(),
+		StartEpoch: primitives.Epoch(slasherFlags.StartEpoch),
+		EndEpoch:   primitives.Epoch(slasherFlags.EndEpoch),
+	})
+	return err
+}
+
+// openSlasherStore opens the slasher db in the given directory, which must already exist.
+func openSlasherStore(cliCtx *cli.Context, path string) (*slasherkv.Store, error) {
+	if _, err := os.Stat(filepath.Join(path, slasherkv.DatabaseFileName)); err != nil {
+		return nil, errors.Wrap(err, "could not find slasher db")
+	}
+	d, err := slasherkv.NewKVStore(cliCtx.Context, path)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not open slasher db")
+	}
+	return d, nil
+}
@@ -0,0 +1,162 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/tokenomics"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/transition"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/kv"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+	log "github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+)
+
+var auditTokenomicsFlags = struct {
+	Path      string
+	StartSlot uint64
+	EndSlot   uint64
+}{}
+
+var auditTokenomicsCmd = &cli.Command{
+	Name: "audit-tokenomics",
+	Usage: "replays the canonical chain stored in the beacon db and checks every epoch transition for violations " +
+		"of the tokenomics invariants, i.e. the max token supply, the issuance schedule, the reserve usage and " +
+		"the reward adjustment factor bounds",
+	Action: func(cliCtx *cli.Context) error {
+		if err := auditTokenomicsAction(cliCtx); err != nil {
+			log.WithError(err).Fatal("Could not audit tokenomics")
+		}
+		return nil
+	},
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:        "path",
+			Usage:       "path to directory containing beaconchain.db",
+			Destination: &auditTokenomicsFlags.Path,
+			Required:    true,
+		},
+		&cli.Uint64Flag{
+			Name:        "start-slot",
+			Usage:       "slot to start the replay from, the replay starts from the closest state saved at or before it",
+			Destination: &auditTokenomicsFlags.StartSlot,
+		},
+		&cli.Uint64Flag{
+			Name:        "end-slot",
+			Usage:       "slot to end the replay at, defaults to the slot of the head block",
+			Destination: &auditTokenomicsFlags.EndSlot,
+		},
+	},
+}
+
+func auditTokenomicsAction(cliCtx *cli.Context) error {
+	ctx := cliCtx.Context
+	f := auditTokenomicsFlags
+
+	d, err := kv.NewKVStore(ctx, f.Path)
+	if err != nil {
+		return errors.Wrap(err, "could not open db")
+	}
+	defer func() {
+		if err := d.Close(); err != nil {
+			log.WithError(err).Error("Could not close db")
+		}
+	}()
+	head, err := d.HeadBlock(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not get head block")
+	}
+	start := primitives.Slot(f.StartSlot)
+	end := head.Block().Slot()
+	if f.EndSlot != 0 {
+		end = primitives.Slot(f.EndSlot)
+	}
+	if start > end {
+		return fmt.Errorf("start slot %d is after end slot %d", start, end)
+	}
+
+	st, chain, err := auditChain(ctx, d, head, start, end)
+	if err != nil {
+		return err
+	}
+	log.WithFields(log.Fields{
+		"startSlot": st.Slot(),
+		"endSlot":   end,
+		"blocks":    len(chain),
+	}).Info("Replaying chain")
+
+	// Every epoch transition must be processed, so none may be skipped by a cached state.
+	transition.SkipSlotCache.Disable()
+	c := &tokenomics.Collector{}
+	ctx = tokenomics.WithCollector(ctx, c)
+	firstEpoch := slots.ToEpoch(st.Slot())
+	for _, b := range chain {
+		_, st, err = transition.ExecuteStateTransitionNoVerifyAnySig(ctx, st, b)
+		if err != nil {
+			return errors.Wrapf(err, "could not replay block at slot %d", b.Block().Slot())
+		}
+	}
+	if st.Slot() < end {
+		if st, err = transition.ProcessSlots(ctx, st, end); err != nil {
+			return errors.Wrap(err, "could not process slots")
+		}
+	}
+
+	violations := c.Violations()
+	log.WithFields(log.Fields{
+		"epochs":     slots.ToEpoch(st.Slot()) - firstEpoch,
+		"violations": len(violations),
+	}).Info("Audited epoch transitions")
+	if len(violations) > 0 {
+		return fmt.Errorf("found %d tokenomics invariant violations", len(violations))
+	}
+	return nil
+}
+
+// auditChain returns the state the replay starts from and the canonical blocks to apply to it up to the end slot.
+// The chain is walked back from the head block until a block at or before the start slot with a saved state.
+func auditChain(
+	ctx context.Context,
+	d *kv.Store,
+	head interfaces.ReadOnlySignedBeaconBlock,
+	start, end primitives.Slot,
+) (state.BeaconState, []interfaces.ReadOnlySignedBeaconBlock, error) {
+	var chain []interfaces.ReadOnlySignedBeaconBlock
+	b := head
+	for {
+		if ctx.Err() != nil {
+			return nil, nil, ctx.Err()
+		}
+		root, err := b.Block().HashTreeRoot()
+		if err != nil {
+			return nil, nil, err
+		}
+		if b.Block().Slot() <= start && d.HasState(ctx, root) {
+			st, err := d.State(ctx, root)
+			if err != nil {
+				return nil, nil, errors.Wrapf(err, "could not get state of block %#x", root)
+			}
+			slices.Reverse(chain)
+			return st, chain, nil
+		}
+		if b.Block().Slot() <= end {
+			chain = append(chain, b)
+		}
+		parentRoot := b.Block().ParentRoot()
+		if parentRoot == [32]byte{} {
+			return nil, nil, fmt.Errorf("no state saved at or before slot %d", start)
+		}
+		b, err = d.Block(ctx, parentRoot)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "could not get block %#x", parentRoot)
+		}
+		if b == nil || b.IsNil() {
+			return nil, nil, fmt.Errorf("block %#x is missing from the db", parentRoot)
+		}
+	}
+}
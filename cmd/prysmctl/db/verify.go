@@ -0,0 +1,92 @@
+package db
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/kv"
+	log "github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+)
+
+var verifyFlags = struct {
+	Path string
+}{}
+
+var verifyCmd = &cli.Command{
+	Name: "verify",
+	Usage: "checks that the blocks, block slot indices, state summaries, finalized block roots index and states " +
+		"of an offline beacon db agree with each other, and that blocks and states decode",
+	Action: func(cliCtx *cli.Context) error {
+		if err := verifyAction(cliCtx); err != nil {
+			log.WithError(err).Fatal("Could not verify db")
+		}
+		return nil
+	},
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:        "path",
+			Usage:       "path to directory containing beaconchain.db",
+			Destination: &verifyFlags.Path,
+			Required:    true,
+		},
+	},
+}
+
+func verifyAction(cliCtx *cli.Context) error {
+	ctx := cliCtx.Context
+	d, err := openReadOnlyStore(cliCtx, verifyFlags.Path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := d.Close(); err != nil {
+			log.WithError(err).Error("Could not close db")
+		}
+	}()
+
+	r, err := d.VerifyIntegrity(ctx)
+	if err != nil {
+		return err
+	}
+	for _, issue := range r.Issues {
+		log.Error(issue.String())
+	}
+	log.WithFields(log.Fields{
+		"blocks":         r.Blocks,
+		"slotIndices":    r.SlotIndices,
+		"stateSummaries": r.StateSummaries,
+		"finalizedRoots": r.FinalizedRoots,
+		"states":         r.States,
+		"issues":         len(r.Issues),
+	}).Info("Verified db")
+	if !r.OK() {
+		return fmt.Errorf("found %d integrity issues", len(r.Issues))
+	}
+	return nil
+}
+
+// openStore opens the beacon db in the given directory, which must already exist.
+func openStore(cliCtx *cli.Context, path string) (*kv.Store, error) {
+	if _, err := os.Stat(kv.StoreDatafilePath(path)); err != nil {
+		return nil, errors.Wrap(err, "could not find db")
+	}
+	d, err := kv.NewKVStore(cliCtx.Context, path)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not open db")
+	}
+	return d, nil
+}
+
+// openReadOnlyStore opens the beacon db in the given directory in read-only mode.
+func openReadOnlyStore(cliCtx *cli.Context, path string) (*kv.Store, error) {
+	if _, err := os.Stat(kv.StoreDatafilePath(path)); err != nil {
+		return nil, errors.Wrap(err, "could not find db")
+	}
+	d, err := kv.NewReadOnlyKVStore(cliCtx.Context, path)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not open db")
+	}
+	return d, nil
+}
@@ -0,0 +1,14 @@
+package forkchoice
+
+import "github.com/urfave/cli/v2"
+
+var Commands = []*cli.Command{
+	{
+		Name:  "forkchoice",
+		Usage: "commands for debugging fork choice",
+		Subcommands: []*cli.Command{
+			downloadCmd,
+			replayCmd,
+		},
+	},
+}
@@ -0,0 +1,150 @@
+package forkchoice
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/api/client"
+	"github.com/prysmaticlabs/prysm/v5/api/client/beacon"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	doublylinkedtree "github.com/prysmaticlabs/prysm/v5/beacon-chain/forkchoice/doubly-linked-tree"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	log "github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+)
+
+var downloadFlags = struct {
+	BeaconNodeHost string
+	Timeout        time.Duration
+	Output         string
+}{}
+
+var downloadCmd = &cli.Command{
+	Name:    "download",
+	Aliases: []string{"dl"},
+	Usage:   "Download the fork choice inputs recorded by a beacon node started with --forkchoice-recording-epochs.",
+	Action: func(cliCtx *cli.Context) error {
+		if err := cliActionDownload(cliCtx); err != nil {
+			log.WithError(err).Fatal("Could not download fork choice recording")
+		}
+		return nil
+	},
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:        "beacon-node-host",
+			Usage:       "host:port for beacon node connection",
+			Destination: &downloadFlags.BeaconNodeHost,
+			Value:       "localhost:3500",
+		},
+		&cli.DurationFlag{
+			Name:        "http-timeout",
+			Usage:       "timeout for http requests made to beacon-node-url (uses duration format, ex: 2m31s). default: 2m",
+			Destination: &downloadFlags.Timeout,
+			Value:       time.Minute * 2,
+		},
+		&cli.StringFlag{
+			Name:        "output",
+			Usage:       "path of the file the recording is written to",
+			Destination: &downloadFlags.Output,
+			Value:       "forkchoice_recording.bin",
+		},
+	},
+}
+
+var replayFlags = struct {
+	BeaconNodeHost string
+	Timeout        time.Duration
+	Recording      string
+	Slot           uint64
+}{}
+
+var replayCmd = &cli.Command{
+	Name:  "replay",
+	Usage: "Replay recorded fork choice inputs offline, and print the head, the node weights and the proposer boost decisions at the end of a slot.",
+	Action: func(cliCtx *cli.Context) error {
+		if err := cliActionReplay(cliCtx); err != nil {
+			log.WithError(err).Fatal("Could not replay fork choice recording")
+		}
+		return nil
+	},
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:        "recording",
+			Usage:       "path of a recording saved with the download command. The recording is downloaded from --beacon-node-host when unset",
+			Destination: &replayFlags.Recording,
+		},
+		&cli.StringFlag{
+			Name:        "beacon-node-host",
+			Usage:       "host:port for beacon node connection",
+			Destination: &replayFlags.BeaconNodeHost,
+			Value:       "localhost:3500",
+		},
+		&cli.DurationFlag{
+			Name:        "http-timeout",
+			Usage:       "timeout for http requests made to beacon-node-url (uses duration format, ex: 2m31s). default: 2m",
+			Destination: &replayFlags.Timeout,
+			Value:       time.Minute * 2,
+		},
+		&cli.Uint64Flag{
+			Name:        "slot",
+			Usage:       "slot at the end of which fork choice is inspected",
+			Destination: &replayFlags.Slot,
+			Required:    true,
+		},
+	},
+}
+
+func cliActionDownload(_ *cli.Context) error {
+	ctx := context.Background()
+	f := downloadFlags
+
+	recording, err := downloadRecording(ctx, f.BeaconNodeHost, f.Timeout)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(f.Output, recording, 0600); err != nil {
+		return errors.Wrap(err, "could not save recording")
+	}
+	log.Printf("saved fork choice recording to %s", f.Output)
+	return nil
+}
+
+func cliActionReplay(_ *cli.Context) error {
+	ctx := context.Background()
+	f := replayFlags
+
+	var recording []byte
+	var err error
+	if f.Recording != "" {
+		recording, err = os.ReadFile(f.Recording)
+	} else {
+		recording, err = downloadRecording(ctx, f.BeaconNodeHost, f.Timeout)
+	}
+	if err != nil {
+		return err
+	}
+
+	result, err := doublylinkedtree.Replay(ctx, recording, primitives.Slot(f.Slot))
+	if err != nil {
+		return err
+	}
+	out, err := json.MarshalIndent(structs.ForkChoiceReplayFromConsensus(result), "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(out))
+	return nil
+}
+
+func downloadRecording(ctx context.Context, host string, timeout time.Duration) ([]byte, error) {
+	opts := []client.ClientOpt{client.WithTimeout(timeout), client.WithMaxBodySize(client.MaxBodySizeState)}
+	c, err := beacon.NewClient(host, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return c.GetForkChoiceRecording(ctx)
+}
@@ -0,0 +1,23 @@
+// Package flags contains all configuration runtime flags for
+// the standalone slasher.
+package flags
+
+import (
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	// BeaconRESTApiProviderFlag defines the beacon nodes followed by the slasher.
+	BeaconRESTApiProviderFlag = &cli.StringSliceFlag{
+		Name: "beacon-rest-api-provider",
+		Usage: "Beacon node REST API provider endpoint whose blocks and attestations are checked for slashable offenses, " +
+			"and to which the slashings found are submitted. Can be set several times to follow several beacon nodes.",
+		Value: cli.NewStringSlice("http://127.0.0.1:3500"),
+	}
+	// MonitoringPortFlag defines the http port used to serve prometheus metrics.
+	MonitoringPortFlag = &cli.IntFlag{
+		Name:  "monitoring-port",
+		Usage: "Port used to listening and respond metrics for prometheus.",
+		Value: 8082,
+	}
+)
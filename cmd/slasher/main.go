@@ -0,0 +1,128 @@
+// Package main defines a standalone slasher, which detects slashable offenses in the blocks and the
+// attestations processed by the beacon nodes it follows through their beacon API.
+package main
+
+import (
+	"fmt"
+	"os"
+	runtimeDebug "runtime/debug"
+
+	joonix "github.com/joonix/log"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/cmd"
+	"github.com/prysmaticlabs/prysm/v5/cmd/slasher/flags"
+	"github.com/prysmaticlabs/prysm/v5/config/features"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/io/logs"
+	"github.com/prysmaticlabs/prysm/v5/monitoring/journald"
+	prefixed "github.com/prysmaticlabs/prysm/v5/runtime/logging/logrus-prefixed-formatter"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+)
+
+var appFlags = []cli.Flag{
+	cmd.VerbosityFlag,
+	cmd.LogFormat,
+	cmd.LogFileName,
+	cmd.ConfigFileFlag,
+	cmd.DataDirFlag,
+	cmd.ChainConfigFileFlag,
+	cmd.ApiTimeoutFlag,
+	cmd.MonitoringHostFlag,
+	cmd.DisableMonitoringFlag,
+	flags.BeaconRESTApiProviderFlag,
+	flags.MonitoringPortFlag,
+	features.Mainnet,
+	features.DolphinTestnet,
+}
+
+func init() {
+	appFlags = cmd.WrapFlags(appFlags)
+}
+
+func main() {
+	app := cli.App{}
+	app.Name = "slasher"
+	app.Usage = "standalone slasher following beacon nodes through their beacon API"
+	app.Action = run
+	app.Version = version.Version()
+
+	app.Flags = appFlags
+
+	app.Before = func(ctx *cli.Context) error {
+		// Load flags from config file, if specified.
+		if err := cmd.LoadFlagsFromConfig(ctx, app.Flags); err != nil {
+			return err
+		}
+
+		verbosity := ctx.String(cmd.VerbosityFlag.Name)
+		level, err := logrus.ParseLevel(verbosity)
+		if err != nil {
+			return err
+		}
+		logrus.SetLevel(level)
+
+		format := ctx.String(cmd.LogFormat.Name)
+		switch format {
+		case "text":
+			formatter := new(prefixed.TextFormatter)
+			formatter.TimestampFormat = "2006-01-02 15:04:05"
+			formatter.FullTimestamp = true
+			// If persistent log files are written - we disable the log messages coloring because
+			// the colors are ANSI codes and seen as gibberish in the log files.
+			formatter.DisableColors = ctx.String(cmd.LogFileName.Name) != ""
+			logrus.SetFormatter(formatter)
+		case "fluentd":
+			f := joonix.NewFormatter()
+			if err := joonix.DisableTimestampFormat(f); err != nil {
+				panic(err)
+			}
+			logrus.SetFormatter(f)
+		case "json":
+			logrus.SetFormatter(&logrus.JSONFormatter{})
+		case "journald":
+			if err := journald.Enable(); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("unknown log format %s", format)
+		}
+
+		logFileName := ctx.String(cmd.LogFileName.Name)
+		if logFileName != "" {
+			if err := logs.ConfigurePersistentLogging(logFileName); err != nil {
+				log.WithError(err).Error("Failed to configuring logging to disk.")
+			}
+		}
+		return cmd.ValidateNoArgs(ctx)
+	}
+
+	defer func() {
+		if x := recover(); x != nil {
+			log.Errorf("Runtime panic: %v\n%v", x, string(runtimeDebug.Stack()))
+			panic(x)
+		}
+	}()
+
+	if err := app.Run(os.Args); err != nil {
+		log.Error(err.Error())
+	}
+}
+
+func run(cliCtx *cli.Context) error {
+	if err := features.ConfigureSlasher(cliCtx); err != nil {
+		return errors.Wrap(err, "could not configure slasher")
+	}
+	if cliCtx.IsSet(cmd.ChainConfigFileFlag.Name) {
+		if err := params.LoadChainConfigFile(cliCtx.String(cmd.ChainConfigFileFlag.Name), nil); err != nil {
+			return errors.Wrap(err, "could not load chain config file")
+		}
+	}
+	n, err := newNode(cliCtx)
+	if err != nil {
+		return errors.Wrap(err, "could not create slasher")
+	}
+	n.start()
+	return nil
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/async/event"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/slasherkv"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/slasher"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/slasher/remote"
+	"github.com/prysmaticlabs/prysm/v5/cmd"
+	"github.com/prysmaticlabs/prysm/v5/cmd/slasher/flags"
+	"github.com/prysmaticlabs/prysm/v5/monitoring/prometheus"
+	"github.com/prysmaticlabs/prysm/v5/runtime"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+)
+
+// node runs the slasher and the service following the beacon nodes which feeds it.
+type node struct {
+	ctx      context.Context
+	cancel   context.CancelFunc
+	db       *slasherkv.Store
+	services *runtime.ServiceRegistry
+}
+
+func newNode(cliCtx *cli.Context) (*node, error) {
+	ctx, cancel := context.WithCancel(cliCtx.Context)
+	n := &node{
+		ctx:      ctx,
+		cancel:   cancel,
+		services: runtime.NewServiceRegistry(),
+	}
+
+	dbPath := filepath.Join(cliCtx.String(cmd.DataDirFlag.Name), "slasherdata")
+	log.WithField("databasePath", dbPath).Info("Checking DB")
+	d, err := slasherkv.NewKVStore(ctx, dbPath)
+	if err != nil {
+		cancel()
+		return nil, errors.Wrap(err, "could not open slasher database")
+	}
+	n.db = d
+
+	if err := n.registerServices(cliCtx); err != nil {
+		cancel()
+		if err := d.Close(); err != nil {
+			log.WithError(err).Error("Failed to close database")
+		}
+		return nil, err
+	}
+	return n, nil
+}
+
+func (n *node) registerServices(cliCtx *cli.Context) error {
+	attsFeed, headersFeed := new(event.Feed), new(event.Feed)
+	beaconNodes, err := remote.NewService(n.ctx, &remote.Config{
+		BeaconNodes:             cliCtx.StringSlice(flags.BeaconRESTApiProviderFlag.Name),
+		Timeout:                 cliCtx.Duration(cmd.ApiTimeoutFlag.Name),
+		IndexedAttestationsFeed: attsFeed,
+		BeaconBlockHeadersFeed:  headersFeed,
+	})
+	if err != nil {
+		return errors.Wrap(err, "could not create beacon node service")
+	}
+	if err := n.services.RegisterService(beaconNodes); err != nil {
+		return err
+	}
+
+	slasherSrv, err := slasher.New(n.ctx, &slasher.ServiceConfig{
+		IndexedAttestationsFeed: attsFeed,
+		BeaconBlockHeadersFeed:  headersFeed,
+		Database:                n.db,
+		AttestationStateFetcher: beaconNodes,
+		StateGen:                beaconNodes,
+		SlashingPoolInserter:    beaconNodes,
+		HeadStateFetcher:        beaconNodes,
+		SyncChecker:             beaconNodes,
+		ClockWaiter:             beaconNodes,
+	})
+	if err != nil {
+		return errors.Wrap(err, "could not create slasher service")
+	}
+	if err := n.services.RegisterService(slasherSrv); err != nil {
+		return err
+	}
+
+	if !cliCtx.Bool(cmd.DisableMonitoringFlag.Name) {
+		service := prometheus.NewService(
+			fmt.Sprintf("%s:%d", cliCtx.String(cmd.MonitoringHostFlag.Name), cliCtx.Int(flags.MonitoringPortFlag.Name)),
+			n.services,
+		)
+		logrus.AddHook(prometheus.NewLogrusCollector())
+		if err := n.services.RegisterService(service); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// start the services and block until the process is interrupted.
+func (n *node) start() {
+	log.WithField("version", version.Version()).Info("Starting slasher")
+	n.services.StartAll()
+
+	sigc := make(chan os.Signal, 1)
+	signal.Notify(sigc, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(sigc)
+	select {
+	case <-sigc:
+		log.Info("Got interrupt, shutting down...")
+	case <-n.ctx.Done():
+	}
+	n.close()
+}
+
+func (n *node) close() {
+	log.Info("Stopping slasher")
+	n.services.StopAll()
+	if err := n.db.Close(); err != nil {
+		log.WithError(err).Error("Failed to close database")
+	}
+	n.cancel()
+}
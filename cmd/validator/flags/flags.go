@@ -81,6 +81,13 @@ var (
 		Name:  "disable-rewards-penalties-logging",
 		Usage: "Disables reward/penalty logging during cluster deployment.",
 	}
+	// BailoutWarningEpochsFlag defines how many epochs ahead of a projected bailout the validator client warns.
+	BailoutWarningEpochsFlag = &cli.Uint64Flag{
+		Name: "bailout-warning-epochs",
+		Usage: "Logs a warning when a validator is projected to be bailed out within this many epochs if it stays offline. " +
+			"Set to 0 to disable the warning.",
+		Value: 225,
+	}
 	// GraffitiFlag defines the graffiti value included in proposed blocks
 	GraffitiFlag = &cli.StringFlag{
 		Name:  "graffiti",
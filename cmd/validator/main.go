@@ -55,6 +55,7 @@ var appFlags = []cli.Flag{
 	flags.CertFlag,
 	flags.GraffitiFlag,
 	flags.DisablePenaltyRewardLogFlag,
+	flags.BailoutWarningEpochsFlag,
 	flags.InteropStartIndex,
 	flags.InteropNumValidators,
 	flags.EnableRPCFlag,
@@ -145,6 +145,7 @@ var appHelpFlagGroups = []flagGroup{
 		Name: "misc",
 		Flags: []cli.Flag{
 			flags.DisablePenaltyRewardLogFlag,
+			flags.BailoutWarningEpochsFlag,
 			flags.DisableAccountMetricsFlag,
 			flags.EnableDistributed,
 			flags.AuthTokenPathFlag,
@@ -79,6 +79,9 @@ type Flags struct {
 
 	EnableDiscoveryReboot bool // EnableDiscoveryReboot allows the node to have its local listener to be rebooted in the event of discovery issues.
 
+	EnableTokenomicsAudit bool // EnableTokenomicsAudit audits every epoch transition for violations of the tokenomics invariants.
+	EnableStateDiff       bool // EnableStateDiff stores the finalized states as hierarchical state diffs.
+
 	// KeystoreImportDebounceInterval specifies the time duration the validator waits to reload new keys if they have
 	// changed on disk. This feature is for advanced use cases only.
 	KeystoreImportDebounceInterval time.Duration
@@ -251,6 +254,14 @@ func ConfigureBeaconChain(ctx *cli.Context) error {
 		logEnabled(EnableDiscoveryReboot)
 		cfg.EnableDiscoveryReboot = true
 	}
+	if ctx.IsSet(enableTokenomicsAudit.Name) {
+		logEnabled(enableTokenomicsAudit)
+		cfg.EnableTokenomicsAudit = true
+	}
+	if ctx.IsSet(EnableStateDiff.Name) {
+		logEnabled(EnableStateDiff)
+		cfg.EnableStateDiff = true
+	}
 
 	cfg.AggregateIntervals = [3]time.Duration{aggregateFirstInterval.Value, aggregateSecondInterval.Value, aggregateThirdInterval.Value}
 	Init(cfg)
@@ -294,6 +305,17 @@ func ConfigureValidator(ctx *cli.Context) error {
 	return nil
 }
 
+// ConfigureSlasher sets the global config based
+// on what flags are enabled for the standalone slasher.
+func ConfigureSlasher(ctx *cli.Context) error {
+	complainOnDeprecatedFlags(ctx)
+	if err := configureTestnet(ctx); err != nil {
+		return err
+	}
+	Init(&Flags{EnableSlasher: true})
+	return nil
+}
+
 // enableDevModeFlags switches development mode features on.
 func enableDevModeFlags(ctx *cli.Context) {
 	log.Warn("Enabling development mode flags")
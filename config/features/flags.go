@@ -166,6 +166,17 @@ var (
 		Name:  "enable-discovery-reboot",
 		Usage: "Experimental: Enables the discovery listener to rebooted in the event of connectivity issues.",
 	}
+	enableTokenomicsAudit = &cli.BoolFlag{
+		Name: "enable-tokenomics-audit",
+		Usage: "Audits every epoch transition for violations of the tokenomics invariants, i.e. the max token supply, " +
+			"the issuance schedule, the reserve usage and the reward adjustment factor bounds. " +
+			"Violations are logged and counted in metrics. This slows down the epoch processing.",
+	}
+	EnableStateDiff = &cli.BoolFlag{
+		Name: "enable-state-diff",
+		Usage: "(Experimental): Stores the finalized states as layers of snapshots and diffs, as set by --state-diff-exponents, " +
+			"so historical states are rebuilt without replaying blocks. The layout cannot be changed once the database holds diffs.",
+	}
 )
 
 // devModeFlags holds list of flags that are set when development mode is on.
@@ -220,6 +231,8 @@ var BeaconChainFlags = append(deprecatedBeaconFlags, append(deprecatedFlags, []c
 	DisableQUIC,
 	DisableCommitteeAwarePacking,
 	EnableDiscoveryReboot,
+	enableTokenomicsAudit,
+	EnableStateDiff,
 }...)...)
 
 // E2EBeaconChainFlags contains a list of the beacon chain feature flags to be tested in E2E.
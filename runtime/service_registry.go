@@ -5,6 +5,7 @@ package runtime
 import (
 	"fmt"
 	"reflect"
+	"sync"
 
 	"github.com/sirupsen/logrus"
 )
@@ -29,6 +30,29 @@ type Service interface {
 type ServiceRegistry struct {
 	services     map[reflect.Type]Service // map of types to services.
 	serviceTypes []reflect.Type           // keep an ordered slice of registered service types.
+	stopLock     sync.RWMutex
+	stopStates   map[reflect.Type]*ServiceStopStatus // shutdown progress of the services being stopped.
+}
+
+// ServiceStopState is the state of a service during the shutdown of the registry.
+type ServiceStopState string
+
+const (
+	// ServiceRunning is the state of a service which has not been asked to stop yet.
+	ServiceRunning ServiceStopState = "running"
+	// ServiceStopping is the state of a service whose Stop method has not returned yet.
+	ServiceStopping ServiceStopState = "stopping"
+	// ServiceStopped is the state of a service which stopped cleanly.
+	ServiceStopped ServiceStopState = "stopped"
+	// ServiceStopFailed is the state of a service whose Stop method returned an error.
+	ServiceStopFailed ServiceStopState = "failed"
+)
+
+// ServiceStopStatus reports the shutdown progress of a single service.
+type ServiceStopStatus struct {
+	Service string
+	State   ServiceStopState
+	Err     error
 }
 
 // NewServiceRegistry starts a registry instance for convenience.
@@ -48,15 +72,46 @@ func (s *ServiceRegistry) StartAll() {
 }
 
 // StopAll ends every service in reverse order of registration, logging a
-// panic if any of them fail to stop.
+// panic if any of them fail to stop. The progress of the shutdown is
+// reported by StopProgress.
 func (s *ServiceRegistry) StopAll() {
 	for i := len(s.serviceTypes) - 1; i >= 0; i-- {
 		kind := s.serviceTypes[i]
 		service := s.services[kind]
+		s.setStopStatus(kind, ServiceStopping, nil)
 		if err := service.Stop(); err != nil {
 			log.WithError(err).Errorf("Could not stop the following service: %v", kind)
+			s.setStopStatus(kind, ServiceStopFailed, err)
+			continue
+		}
+		s.setStopStatus(kind, ServiceStopped, nil)
+	}
+}
+
+// StopProgress returns the shutdown progress of every service, in the order
+// in which StopAll stops them.
+func (s *ServiceRegistry) StopProgress() []ServiceStopStatus {
+	s.stopLock.RLock()
+	defer s.stopLock.RUnlock()
+	progress := make([]ServiceStopStatus, 0, len(s.serviceTypes))
+	for i := len(s.serviceTypes) - 1; i >= 0; i-- {
+		kind := s.serviceTypes[i]
+		if st, ok := s.stopStates[kind]; ok {
+			progress = append(progress, *st)
+			continue
 		}
+		progress = append(progress, ServiceStopStatus{Service: kind.String(), State: ServiceRunning})
+	}
+	return progress
+}
+
+func (s *ServiceRegistry) setStopStatus(kind reflect.Type, state ServiceStopState, err error) {
+	s.stopLock.Lock()
+	defer s.stopLock.Unlock()
+	if s.stopStates == nil {
+		s.stopStates = make(map[reflect.Type]*ServiceStopStatus, len(s.serviceTypes))
 	}
+	s.stopStates[kind] = &ServiceStopStatus{Service: kind.String(), State: state, Err: err}
 }
 
 // Statuses returns a map of Service type -> error. The map will be populated
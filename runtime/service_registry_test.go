@@ -13,7 +13,8 @@ type mockService struct {
 	status error
 }
 type secondMockService struct {
-	status error
+	status  error
+	stopErr error
 }
 
 func (_ *mockService) Start() {
@@ -30,8 +31,8 @@ func (m *mockService) Status() error {
 func (_ *secondMockService) Start() {
 }
 
-func (_ *secondMockService) Stop() error {
-	return nil
+func (s *secondMockService) Stop() error {
+	return s.stopErr
 }
 
 func (s *secondMockService) Status() error {
@@ -107,3 +108,26 @@ func TestServiceStatus_OK(t *testing.T) {
 	assert.ErrorContains(t, "something bad has happened", statuses[reflect.TypeOf(m)])
 	assert.ErrorContains(t, "woah, horsee", statuses[reflect.TypeOf(s)])
 }
+
+func TestStopAll_Progress(t *testing.T) {
+	registry := NewServiceRegistry()
+	m := &mockService{}
+	require.NoError(t, registry.RegisterService(m))
+	s := &secondMockService{stopErr: errors.New("could not stop")}
+	require.NoError(t, registry.RegisterService(s))
+
+	progress := registry.StopProgress()
+	require.Equal(t, 2, len(progress))
+	assert.Equal(t, reflect.TypeOf(s).String(), progress[0].Service)
+	assert.Equal(t, ServiceRunning, progress[0].State)
+	assert.Equal(t, reflect.TypeOf(m).String(), progress[1].Service)
+	assert.Equal(t, ServiceRunning, progress[1].State)
+
+	registry.StopAll()
+	progress = registry.StopProgress()
+	require.Equal(t, 2, len(progress))
+	assert.Equal(t, ServiceStopFailed, progress[0].State)
+	assert.ErrorContains(t, "could not stop", progress[0].Err)
+	assert.Equal(t, ServiceStopped, progress[1].State)
+	assert.NoError(t, progress[1].Err)
+}
@@ -0,0 +1,125 @@
+package runtime
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// DefaultDrainTimeout bounds how long a graceful shutdown waits for in-flight work
+// to be drained before the services are stopped regardless.
+const DefaultDrainTimeout = 2 * time.Minute
+
+// ShutdownPhase is the phase of a node shutdown.
+type ShutdownPhase string
+
+const (
+	// ShutdownIdle is the phase of a node which has not been asked to shut down.
+	ShutdownIdle ShutdownPhase = "idle"
+	// ShutdownDraining is the phase in which the node waits for its in-flight work.
+	ShutdownDraining ShutdownPhase = "draining"
+	// ShutdownStopping is the phase in which the services of the node are being stopped.
+	ShutdownStopping ShutdownPhase = "stopping"
+	// ShutdownComplete is the phase of a node whose services have all been stopped.
+	ShutdownComplete ShutdownPhase = "complete"
+)
+
+// ShutdownStatus reports the progress of a node shutdown.
+type ShutdownStatus struct {
+	Phase      ShutdownPhase
+	Drain      bool
+	DrainError error
+	StartedAt  time.Time
+	Services   []ServiceStopStatus
+}
+
+// Shutdown drives the shutdown of a node, which can be requested at most once. The node
+// is shut down in the background, so the caller can report the progress of the shutdown
+// instead of waiting for it to complete.
+type Shutdown struct {
+	closeFunc  func()
+	drainFunc  func(ctx context.Context) error
+	registry   *ServiceRegistry
+	lock       sync.RWMutex
+	phase      ShutdownPhase
+	drain      bool
+	drainErr   error
+	startedAt  time.Time
+	done       chan struct{}
+	drainLimit time.Duration
+}
+
+// NewShutdown returns a shutdown which stops the node with closeFunc. The drainFunc, if any,
+// waits for the in-flight work of the node when a drain is requested. The progress of every
+// service is read from the given registry.
+func NewShutdown(closeFunc func(), drainFunc func(ctx context.Context) error, registry *ServiceRegistry) *Shutdown {
+	return &Shutdown{
+		closeFunc:  closeFunc,
+		drainFunc:  drainFunc,
+		registry:   registry,
+		phase:      ShutdownIdle,
+		done:       make(chan struct{}),
+		drainLimit: DefaultDrainTimeout,
+	}
+}
+
+// Start begins the shutdown in the background and returns true, or returns false if the
+// shutdown was already started. When drain is set, the services are only stopped once the
+// in-flight work was drained or the drain timed out.
+func (s *Shutdown) Start(drain bool) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.phase != ShutdownIdle {
+		return false
+	}
+	s.drain = drain && s.drainFunc != nil
+	s.startedAt = time.Now()
+	s.phase = ShutdownStopping
+	if s.drain {
+		s.phase = ShutdownDraining
+	}
+	go s.run()
+	return true
+}
+
+func (s *Shutdown) run() {
+	defer close(s.done)
+	if s.drain {
+		log.Info("Draining in-flight work before shutting down")
+		ctx, cancel := context.WithTimeout(context.Background(), s.drainLimit)
+		err := s.drainFunc(ctx)
+		cancel()
+		if err != nil {
+			log.WithError(err).Warn("Could not drain in-flight work, shutting down regardless")
+		}
+		s.lock.Lock()
+		s.drainErr = err
+		s.phase = ShutdownStopping
+		s.lock.Unlock()
+	}
+	s.closeFunc()
+	s.lock.Lock()
+	s.phase = ShutdownComplete
+	s.lock.Unlock()
+}
+
+// Done returns a channel which is closed once the shutdown is complete.
+func (s *Shutdown) Done() <-chan struct{} {
+	return s.done
+}
+
+// Status returns the current progress of the shutdown.
+func (s *Shutdown) Status() *ShutdownStatus {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	st := &ShutdownStatus{
+		Phase:      s.phase,
+		Drain:      s.drain,
+		DrainError: s.drainErr,
+		StartedAt:  s.startedAt,
+	}
+	if s.registry != nil {
+		st.Services = s.registry.StopProgress()
+	}
+	return st
+}
@@ -0,0 +1,61 @@
+package runtime
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestShutdown(t *testing.T) {
+	t.Run("drain", func(t *testing.T) {
+		registry := NewServiceRegistry()
+		require.NoError(t, registry.RegisterService(&mockService{}))
+		release := make(chan struct{})
+		s := NewShutdown(registry.StopAll, func(ctx context.Context) error {
+			<-release
+			return nil
+		}, registry)
+		assert.Equal(t, ShutdownIdle, s.Status().Phase)
+
+		require.Equal(t, true, s.Start(true))
+		require.Equal(t, false, s.Start(false))
+		st := s.Status()
+		assert.Equal(t, ShutdownDraining, st.Phase)
+		assert.Equal(t, true, st.Drain)
+		require.Equal(t, 1, len(st.Services))
+		assert.Equal(t, ServiceRunning, st.Services[0].State)
+
+		close(release)
+		<-s.Done()
+		st = s.Status()
+		assert.Equal(t, ShutdownComplete, st.Phase)
+		assert.NoError(t, st.DrainError)
+		assert.Equal(t, ServiceStopped, st.Services[0].State)
+	})
+	t.Run("drain timeout", func(t *testing.T) {
+		closed := false
+		s := NewShutdown(func() { closed = true }, func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		}, nil)
+		s.drainLimit = 10 * time.Millisecond
+		require.Equal(t, true, s.Start(true))
+		<-s.Done()
+		st := s.Status()
+		assert.Equal(t, ShutdownComplete, st.Phase)
+		assert.Equal(t, true, errors.Is(st.DrainError, context.DeadlineExceeded))
+		assert.Equal(t, true, closed)
+	})
+	t.Run("no drain func", func(t *testing.T) {
+		s := NewShutdown(func() {}, nil, nil)
+		require.Equal(t, true, s.Start(true))
+		<-s.Done()
+		st := s.Status()
+		assert.Equal(t, false, st.Drain)
+		assert.Equal(t, ShutdownComplete, st.Phase)
+	})
+}
@@ -140,6 +140,10 @@ func (_ *Validator) LogValidatorGainsAndLosses(_ context.Context, _ primitives.S
 	panic("implement me")
 }
 
+func (_ *Validator) LogBailoutRisk(_ context.Context, _ primitives.Slot) error {
+	panic("implement me")
+}
+
 func (_ *Validator) UpdateDuties(_ context.Context, _ primitives.Slot) error {
 	panic("implement me")
 }
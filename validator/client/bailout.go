@@ -0,0 +1,126 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// LogBailoutRisk warns when a validator of this client is projected to be bailed out within
+// the configured number of epochs if it stays offline, and updates the bailout metrics.
+// Both conditions of the bailout are covered: the balance dropping below the bailout buffer
+// of the principal balance, and the inactivity score passing INACTIVITY_LEAK_BAILOUT_SCORE_THRESHOLD
+// during an inactivity leak.
+func (v *validator) LogBailoutRisk(ctx context.Context, slot primitives.Slot) error {
+	if !slots.IsEpochEnd(slot) || slot <= params.BeaconConfig().SlotsPerEpoch {
+		// Do nothing unless we are at the end of the epoch, and not in the first epoch.
+		return nil
+	}
+	if v.bailoutWarningEpochs == 0 {
+		return nil
+	}
+
+	pks, err := v.km.FetchValidatingPublicKeys(ctx)
+	if err != nil {
+		return err
+	}
+	if len(pks) == 0 {
+		return nil
+	}
+	pubKeys := bytesutil.FromBytes48Array(pks)
+
+	head, err := v.chainClient.ChainHead(ctx, &emptypb.Empty{})
+	if err != nil {
+		return errors.Wrap(err, "could not get chain head")
+	}
+	validators, err := v.activeValidatorRecords(ctx, pubKeys)
+	if err != nil {
+		return errors.Wrap(err, "could not get validators")
+	}
+	// The performance response holds the balances and the inactivity scores after the last epoch transition.
+	perf, err := v.chainClient.ValidatorPerformance(ctx, &ethpb.ValidatorPerformanceRequest{PublicKeys: pubKeys})
+	if err != nil {
+		return errors.Wrap(err, "could not get validator performance")
+	}
+
+	currentEpoch := slots.ToEpoch(slot)
+	leak := helpers.IsInInactivityLeak(currentEpoch-1, head.FinalizedEpoch)
+	for i, pubKey := range perf.PublicKeys {
+		val, ok := validators[bytesutil.ToBytes48(pubKey)]
+		if !ok || i >= len(perf.BalancesAfterEpochTransition) || i >= len(perf.InactivityScores) {
+			continue
+		}
+		v.logBailoutRiskForValidator(pubKey, val, perf.BalancesAfterEpochTransition[i], perf.InactivityScores[i], currentEpoch, leak)
+	}
+	return nil
+}
+
+// activeValidatorRecords returns the validator records of the given public keys
+// which are active and have not initiated their exit, as the bailout does not apply to the others.
+func (v *validator) activeValidatorRecords(ctx context.Context, pubKeys [][]byte) (map[[fieldparams.BLSPubkeyLength]byte]*ethpb.Validators_ValidatorContainer, error) {
+	validators := make(map[[fieldparams.BLSPubkeyLength]byte]*ethpb.Validators_ValidatorContainer, len(pubKeys))
+	req := &ethpb.ListValidatorsRequest{PublicKeys: pubKeys, Active: true}
+	for {
+		resp, err := v.chainClient.Validators(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		for _, val := range resp.ValidatorList {
+			if val.Validator == nil || val.Validator.ExitEpoch != params.BeaconConfig().FarFutureEpoch {
+				continue
+			}
+			validators[bytesutil.ToBytes48(val.Validator.PublicKey)] = val
+		}
+		if resp.NextPageToken == "" || len(resp.ValidatorList) == 0 {
+			return validators, nil
+		}
+		req.PageToken = resp.NextPageToken
+	}
+}
+
+func (v *validator) logBailoutRiskForValidator(
+	pubKey []byte,
+	val *ethpb.Validators_ValidatorContainer,
+	balance, inactivityScore uint64,
+	currentEpoch primitives.Epoch,
+	leak bool,
+) {
+	principal := val.Validator.PrincipalBalance
+	bufferRemaining := helpers.BailOutBufferRemaining(balance, principal)
+	epochs, ok := helpers.EpochsToBailOut(balance, principal, inactivityScore, leak)
+
+	fmtKey := fmt.Sprintf("%#x", pubKey)
+	if v.emitAccountMetrics {
+		ValidatorBailoutBufferRemainingGaugeVec.WithLabelValues(fmtKey).Set(float64(bufferRemaining))
+		if ok {
+			ValidatorEpochsToBailoutGaugeVec.WithLabelValues(fmtKey).Set(float64(epochs))
+		} else {
+			ValidatorEpochsToBailoutGaugeVec.WithLabelValues(fmtKey).Set(-1)
+		}
+	}
+	if !ok || epochs > v.bailoutWarningEpochs {
+		return
+	}
+	log.WithFields(logrus.Fields{
+		"pubkey":                   fmt.Sprintf("%#x", bytesutil.Trunc(pubKey)),
+		"validatorIndex":           val.Index,
+		"epoch":                    currentEpoch,
+		"balance":                  balance,
+		"principalBalance":         principal,
+		"bailoutBufferRemaining":   bufferRemaining,
+		"inactivityScore":          inactivityScore,
+		"inactivityScoreThreshold": params.BeaconConfig().InactivityLeakBailoutScoreThreshold,
+		"inactivityLeak":           leak,
+		"projectedEpochsToBailout": epochs,
+	}).Warn("Validator is close to being bailed out, make sure it is online and attesting")
+}
@@ -0,0 +1,62 @@
+package client
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	validatormock "github.com/prysmaticlabs/prysm/v5/testing/validator-mock"
+	logTest "github.com/sirupsen/logrus/hooks/test"
+	"go.uber.org/mock/gomock"
+)
+
+func TestLogBailoutRisk(t *testing.T) {
+	hook := logTest.NewGlobal()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	chainClient := validatormock.NewMockChainClient(ctrl)
+
+	atRisk, healthy := randKeypair(t), randKeypair(t)
+	v := &validator{
+		km:                   newMockKeymanager(t, atRisk, healthy),
+		chainClient:          chainClient,
+		bailoutWarningEpochs: 225,
+	}
+
+	cfg := params.BeaconConfig()
+	principal := cfg.MinActivationBalance
+	buffer := principal * cfg.InactivityPenaltyRate / cfg.InactivityPenaltyRatePrecision
+	chainClient.EXPECT().ChainHead(gomock.Any(), gomock.Any()).Return(&ethpb.ChainHead{FinalizedEpoch: 1}, nil)
+	chainClient.EXPECT().Validators(gomock.Any(), gomock.Any()).Return(&ethpb.Validators{
+		ValidatorList: []*ethpb.Validators_ValidatorContainer{
+			{Index: 1, Validator: &ethpb.Validator{PublicKey: atRisk.pub[:], PrincipalBalance: principal, ExitEpoch: cfg.FarFutureEpoch}},
+			{Index: 2, Validator: &ethpb.Validator{PublicKey: healthy.pub[:], PrincipalBalance: principal, ExitEpoch: cfg.FarFutureEpoch}},
+		},
+	}, nil)
+	chainClient.EXPECT().ValidatorPerformance(gomock.Any(), gomock.Any()).Return(&ethpb.ValidatorPerformanceResponse{
+		PublicKeys:                   [][]byte{atRisk.pub[:], healthy.pub[:]},
+		BalancesAfterEpochTransition: []uint64{principal - buffer + 1, principal},
+		InactivityScores:             []uint64{cfg.InactivityScorePenaltyThreshold + 100, 0},
+	}, nil)
+
+	slot := 3*cfg.SlotsPerEpoch - 1
+	require.NoError(t, v.LogBailoutRisk(context.Background(), slot))
+	require.Equal(t, 1, len(hook.AllEntries()))
+	assert.LogsContain(t, hook, "Validator is close to being bailed out")
+	assert.LogsContain(t, hook, "validatorIndex=1")
+}
+
+func TestLogBailoutRisk_NotEpochEnd(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	// The chain client must not be called.
+	v := &validator{
+		km:                   newMockKeymanager(t, randKeypair(t)),
+		chainClient:          validatormock.NewMockChainClient(ctrl),
+		bailoutWarningEpochs: 225,
+	}
+	require.NoError(t, v.LogBailoutRisk(context.Background(), 3*params.BeaconConfig().SlotsPerEpoch))
+}
@@ -181,6 +181,28 @@ var (
 			"pubkey",
 		},
 	)
+	// ValidatorBailoutBufferRemainingGaugeVec used to track how much the validator balance can drop before being bailed out.
+	ValidatorBailoutBufferRemainingGaugeVec = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "validator",
+			Name:      "bailout_buffer_remaining_gwei",
+			Help:      "Balance in Gwei the validator can lose before falling below the bailout buffer.",
+		},
+		[]string{
+			"pubkey",
+		},
+	)
+	// ValidatorEpochsToBailoutGaugeVec used to track the projected number of epochs until the validator is bailed out.
+	ValidatorEpochsToBailoutGaugeVec = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "validator",
+			Name:      "epochs_to_bailout",
+			Help:      "Projected number of epochs until the validator is bailed out if it stays offline, -1 if it cannot be bailed out.",
+		},
+		[]string{
+			"pubkey",
+		},
+	)
 )
 
 // LogValidatorGainsAndLosses logs important metrics related to this validator client's
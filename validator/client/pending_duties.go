@@ -0,0 +1,54 @@
+package client
+
+import (
+	"context"
+	"sync"
+)
+
+// pendingDuties keeps count of the duties being performed, so a graceful shutdown
+// can wait for them to complete instead of interrupting them.
+type pendingDuties struct {
+	lock  sync.Mutex
+	count int
+	idle  chan struct{}
+}
+
+func newPendingDuties() *pendingDuties {
+	idle := make(chan struct{})
+	close(idle)
+	return &pendingDuties{idle: idle}
+}
+
+func (p *pendingDuties) add(n int) {
+	if n <= 0 {
+		return
+	}
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if p.count == 0 {
+		p.idle = make(chan struct{})
+	}
+	p.count += n
+}
+
+func (p *pendingDuties) done() {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.count--
+	if p.count == 0 {
+		close(p.idle)
+	}
+}
+
+// wait blocks until no duty is being performed or the context is done.
+func (p *pendingDuties) wait(ctx context.Context) error {
+	p.lock.Lock()
+	idle := p.idle
+	p.lock.Unlock()
+	select {
+	case <-idle:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
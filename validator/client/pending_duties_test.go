@@ -0,0 +1,31 @@
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestPendingDuties(t *testing.T) {
+	duties := newPendingDuties()
+	require.NoError(t, duties.wait(context.Background()))
+
+	duties.add(2)
+	duties.done()
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	require.ErrorIs(t, duties.wait(ctx), context.DeadlineExceeded)
+
+	go duties.done()
+	require.NoError(t, duties.wait(context.Background()))
+
+	// The duties of the next slot are tracked again.
+	duties.add(1)
+	ctx, cancel = context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	require.ErrorIs(t, duties.wait(ctx), context.DeadlineExceeded)
+	duties.done()
+	require.NoError(t, duties.wait(context.Background()))
+}
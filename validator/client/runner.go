@@ -35,7 +35,9 @@ var backOffPeriod = 10 * time.Second
 // 4 - Update assignments
 // 5 - Determine role at current slot
 // 6 - Perform assigned role, if any
-func run(ctx context.Context, v iface.Validator) {
+//
+// The duties being performed are tracked in the given pending duties.
+func run(ctx context.Context, v iface.Validator, duties *pendingDuties) {
 	cleanup := v.Done
 	defer cleanup()
 
@@ -115,7 +117,7 @@ func run(ctx context.Context, v iface.Validator) {
 				span.End()
 				continue
 			}
-			performRoles(slotCtx, allRoles, v, slot, &wg, span)
+			performRoles(slotCtx, allRoles, v, slot, &wg, duties, span)
 		case isHealthyAgain := <-healthTracker.HealthUpdates():
 			if isHealthyAgain {
 				headSlot, err = initializeValidatorAndGetHeadSlot(ctx, v)
@@ -226,12 +228,14 @@ func initializeValidatorAndGetHeadSlot(ctx context.Context, v iface.Validator) (
 	return headSlot, nil
 }
 
-func performRoles(slotCtx context.Context, allRoles map[[48]byte][]iface.ValidatorRole, v iface.Validator, slot primitives.Slot, wg *sync.WaitGroup, span trace.Span) {
+func performRoles(slotCtx context.Context, allRoles map[[48]byte][]iface.ValidatorRole, v iface.Validator, slot primitives.Slot, wg *sync.WaitGroup, duties *pendingDuties, span trace.Span) {
 	for pubKey, roles := range allRoles {
 		wg.Add(len(roles))
+		duties.add(len(roles))
 		for _, role := range roles {
 			go func(role iface.ValidatorRole, pubKey [fieldparams.BLSPubkeyLength]byte) {
 				defer wg.Done()
+				defer duties.done()
 				switch role {
 				case iface.RoleAttester:
 					v.SubmitAttestation(slotCtx, slot, pubKey)
@@ -264,6 +268,9 @@ func performRoles(slotCtx context.Context, allRoles map[[48]byte][]iface.Validat
 		if err := v.LogValidatorGainsAndLosses(slotCtx, slot); err != nil {
 			log.WithError(err).Error("Could not report validator's rewards/penalties")
 		}
+		if err := v.LogBailoutRisk(slotCtx, slot); err != nil {
+			log.WithError(err).Error("Could not report validator's bailout risk")
+		}
 	}()
 }
 
@@ -56,7 +56,9 @@ type ValidatorService struct {
 	useOverNode             bool
 	emitAccountMetrics      bool
 	logValidatorPerformance bool
+	bailoutWarningEpochs    primitives.Epoch
 	distributed             bool
+	duties                  *pendingDuties
 }
 
 // Config for the validator service.
@@ -81,6 +83,7 @@ type Config struct {
 	ValidatorsRegBatchSize  int
 	UseOverNode             bool
 	LogValidatorPerformance bool
+	BailoutWarningEpochs    primitives.Epoch
 	EmitAccountMetrics      bool
 	Distributed             bool
 }
@@ -105,7 +108,9 @@ func NewValidatorService(ctx context.Context, cfg *Config) (*ValidatorService, e
 		useOverNode:             cfg.UseOverNode,
 		emitAccountMetrics:      cfg.EmitAccountMetrics,
 		logValidatorPerformance: cfg.LogValidatorPerformance,
+		bailoutWarningEpochs:    cfg.BailoutWarningEpochs,
 		distributed:             cfg.Distributed,
+		duties:                  newPendingDuties(),
 	}
 
 	dialOpts := ConstructDialOptions(
@@ -211,13 +216,14 @@ func (v *ValidatorService) Start() {
 		submittedAtts:                  make(map[submittedAttKey]*submittedAtt),
 		submittedAggregates:            make(map[submittedAttKey]*submittedAtt),
 		logValidatorPerformance:        v.logValidatorPerformance,
+		bailoutWarningEpochs:           v.bailoutWarningEpochs,
 		emitAccountMetrics:             v.emitAccountMetrics,
 		useOverNode:                    v.useOverNode,
 		distributed:                    v.distributed,
 	}
 
 	v.validator = valStruct
-	go run(v.ctx, v.validator)
+	go run(v.ctx, v.validator, v.duties)
 }
 
 // Stop the validator service.
@@ -230,6 +236,14 @@ func (v *ValidatorService) Stop() error {
 	return nil
 }
 
+// WaitForDuties waits until the duties being performed by the validator are complete.
+func (v *ValidatorService) WaitForDuties(ctx context.Context) error {
+	if err := v.duties.wait(ctx); err != nil {
+		return errors.Wrap(err, "duties are still being performed")
+	}
+	return nil
+}
+
 // Status of the validator service.
 func (v *ValidatorService) Status() error {
 	if v.conn == nil {
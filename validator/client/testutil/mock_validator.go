@@ -33,6 +33,7 @@ type FakeValidator struct {
 	AttestToBlockHeadCalled           bool
 	ProposeBlockCalled                bool
 	LogValidatorGainsAndLossesCalled  bool
+	LogBailoutRiskCalled              bool
 	SaveProtectionsCalled             bool
 	DeleteProtectionCalled            bool
 	SlotDeadlineCalled                bool
@@ -152,6 +153,12 @@ func (fv *FakeValidator) LogValidatorGainsAndLosses(_ context.Context, _ primiti
 	return nil
 }
 
+// LogBailoutRisk for mocking.
+func (fv *FakeValidator) LogBailoutRisk(_ context.Context, _ primitives.Slot) error {
+	fv.LogBailoutRiskCalled = true
+	return nil
+}
+
 // ResetAttesterProtectionData for mocking.
 func (fv *FakeValidator) ResetAttesterProtectionData() {
 	fv.DeleteProtectionCalled = true
@@ -99,6 +99,7 @@ type validator struct {
 	submittedAtts                        map[submittedAttKey]*submittedAtt
 	submittedAggregates                  map[submittedAttKey]*submittedAtt
 	logValidatorPerformance              bool
+	bailoutWarningEpochs                 primitives.Epoch
 	emitAccountMetrics                   bool
 	useOverNode                          bool
 	isWaitingForKeymanagerInitialization bool
@@ -266,6 +266,12 @@ func (s *Store) SaveAttestationsForPubKey(
 	return nil
 }
 
+// WaitForAttestationsFlush returns immediately, since the attestations are saved
+// to the disk as soon as they are signed.
+func (*Store) WaitForAttestationsFlush(_ context.Context) error {
+	return nil
+}
+
 // AttestationHistoryForPubKey returns the attestation history for a public key.
 func (s *Store) AttestationHistoryForPubKey(
 	_ context.Context,
@@ -69,6 +69,7 @@ type ValidatorDB interface {
 	AttestationHistoryForPubKey(
 		ctx context.Context, pubKey [fieldparams.BLSPubkeyLength]byte,
 	) ([]*common.AttestationRecord, error)
+	WaitForAttestationsFlush(ctx context.Context) error
 
 	// Graffiti ordered index related methods
 	SaveGraffitiOrderedIndex(ctx context.Context, index uint64) error
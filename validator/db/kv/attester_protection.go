@@ -404,6 +404,25 @@ func (s *Store) SaveAttestationForPubKey(
 	return res.err
 }
 
+// WaitForAttestationsFlush waits until the attestation records batched in memory are flushed
+// to the database, so none of them is lost when the validator client shuts down.
+func (s *Store) WaitForAttestationsFlush(ctx context.Context) error {
+	responseChan := make(chan saveAttestationsResponse, 1)
+	sub := s.batchAttestationsFlushedFeed.Subscribe(responseChan)
+	defer sub.Unsubscribe()
+	for s.batchedAttestations.Len() > 0 || len(s.batchedAttestationsChan) > 0 || s.batchedAttestationsFlushInProgress.IsSet() {
+		select {
+		case res := <-responseChan:
+			if res.err != nil {
+				return errors.Wrap(res.err, "could not flush attestation records")
+			}
+		case <-ctx.Done():
+			return errors.Wrap(ctx.Err(), "attestation records are still being flushed")
+		}
+	}
+	return nil
+}
+
 // Meant to run as a background routine, this function checks whether:
 // (a) we have reached a max capacity of batched attestations in the Store or
 // (b) attestationBatchWriteInterval has passed
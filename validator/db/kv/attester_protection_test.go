@@ -6,7 +6,9 @@ import (
 	"path/filepath"
 	"sync"
 	"testing"
+	"time"
 
+	"github.com/prysmaticlabs/prysm/v5/async/event"
 	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v5/crypto/bls"
@@ -566,6 +568,31 @@ func TestStore_flushAttestationRecords_InProgress(t *testing.T) {
 	assert.LogsContain(t, hook, "Attempted to flush attestation records when already in progress")
 }
 
+func TestStore_WaitForAttestationsFlush(t *testing.T) {
+	pubKeys := [][fieldparams.BLSPubkeyLength]byte{{1}}
+	validatorDB := setupDB(t, pubKeys)
+	require.NoError(t, validatorDB.WaitForAttestationsFlush(context.Background()))
+
+	// A record batched in memory is only saved by the next flush.
+	record := &common.AttestationRecord{PubKey: pubKeys[0], Source: 1, Target: 2, SigningRoot: make([]byte, 32)}
+	validatorDB.batchedAttestations.Append(record)
+	require.NoError(t, validatorDB.WaitForAttestationsFlush(context.Background()))
+	require.Equal(t, 0, validatorDB.batchedAttestations.Len())
+	_, exists, err := validatorDB.LowestSignedTargetEpoch(context.Background(), pubKeys[0])
+	require.NoError(t, err)
+	require.Equal(t, true, exists)
+
+	// The flush cannot happen once the background routine is stopped.
+	s := &Store{
+		batchedAttestations:          NewQueuedAttestationRecords(),
+		batchAttestationsFlushedFeed: new(event.Feed),
+	}
+	s.batchedAttestations.Append(record)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	require.ErrorContains(t, "attestation records are still being flushed", s.WaitForAttestationsFlush(ctx))
+}
+
 func BenchmarkStore_SaveAttestationForPubKey(b *testing.B) {
 	var wg sync.WaitGroup
 	ctx := context.Background()
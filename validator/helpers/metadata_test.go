@@ -131,6 +131,10 @@ func (db *ValidatorDBMock) AttestationHistoryForPubKey(
 	panic("not implemented")
 }
 
+func (db *ValidatorDBMock) WaitForAttestationsFlush(ctx context.Context) error {
+	panic("not implemented")
+}
+
 // Graffiti ordered index related methods
 func (db *ValidatorDBMock) SaveGraffitiOrderedIndex(ctx context.Context, index uint64) error {
 	panic("not implemented")
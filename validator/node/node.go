@@ -28,6 +28,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/config/params"
 	"github.com/prysmaticlabs/prysm/v5/config/proposer"
 	"github.com/prysmaticlabs/prysm/v5/config/proposer/loader"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v5/io/file"
 	"github.com/prysmaticlabs/prysm/v5/monitoring/backup"
 	"github.com/prysmaticlabs/prysm/v5/monitoring/prometheus"
@@ -490,6 +491,7 @@ func (c *ValidatorClient) registerValidatorService(cliCtx *cli.Context) error {
 		ValidatorsRegBatchSize:  c.cliCtx.Int(flags.ValidatorsRegistrationBatchSizeFlag.Name),
 		UseOverNode:             c.cliCtx.Bool(flags.EnableOverNodeFlag.Name),
 		LogValidatorPerformance: !c.cliCtx.Bool(flags.DisablePenaltyRewardLogFlag.Name),
+		BailoutWarningEpochs:    primitives.Epoch(c.cliCtx.Uint64(flags.BailoutWarningEpochsFlag.Name)),
 		EmitAccountMetrics:      !c.cliCtx.Bool(flags.DisableAccountMetricsFlag.Name),
 		Distributed:             c.cliCtx.Bool(flags.EnableDistributed.Name),
 	})
@@ -568,8 +570,12 @@ func (c *ValidatorClient) registerRPCService(router *http.ServeMux) error {
 	}
 
 	closeHandler := &closehandler.CloseHandler{
-		CloseFunc: c.Close,
-		CloseCh:   c.stop,
+		Shutdown: runtime.NewShutdown(c.Close, func(ctx context.Context) error {
+			if err := vs.WaitForDuties(ctx); err != nil {
+				return err
+			}
+			return c.db.WaitForAttestationsFlush(ctx)
+		}, c.services),
 	}
 
 	host := c.cliCtx.String(flags.HTTPServerHost.Name)
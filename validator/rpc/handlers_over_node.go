@@ -2,17 +2,34 @@ package rpc
 
 import (
 	"net/http"
+	"strconv"
+
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	"github.com/prysmaticlabs/prysm/v5/network/httputil"
 )
 
-// CloseClient closes validator client gracefully.
+// CloseClient starts the graceful shutdown of the validator client and returns its progress without
+// waiting for the client to stop. With the drain query parameter set, the client first waits for the
+// duties being performed and for the next flush of the slashing protection records. Requesting the
+// shutdown again returns the progress of the shutdown which is already under way.
 func (s *Server) CloseClient(w http.ResponseWriter, r *http.Request) {
-	log.Infof("Got interrupt through HTTP request...")
-
-	closeFunc := s.closeHandler.CloseFunc
-	closeCh := s.closeHandler.CloseCh
+	drain := false
+	if raw := r.URL.Query().Get("drain"); raw != "" {
+		var err error
+		drain, err = strconv.ParseBool(raw)
+		if err != nil {
+			httputil.HandleError(w, "Invalid drain query parameter: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
 
-	go closeFunc()
+	if s.closeHandler.Shutdown.Start(drain) {
+		log.WithField("drain", drain).Info("Got interrupt through HTTP request...")
+	}
+	httputil.WriteJson(w, structs.ShutdownResponseFromStatus(s.closeHandler.Shutdown.Status()))
+}
 
-	// Wait for close channel to be closed.
-	<-closeCh
+// GetCloseStatus returns the progress of the shutdown of the validator client.
+func (s *Server) GetCloseStatus(w http.ResponseWriter, _ *http.Request) {
+	httputil.WriteJson(w, structs.ShutdownResponseFromStatus(s.closeHandler.Shutdown.Status()))
 }
@@ -0,0 +1,52 @@
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	"github.com/prysmaticlabs/prysm/v5/runtime"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	closehandler "github.com/prysmaticlabs/prysm/v5/validator/node/close-handler"
+)
+
+func TestServer_CloseClient(t *testing.T) {
+	drained := false
+	shutdown := runtime.NewShutdown(func() {}, func(ctx context.Context) error {
+		drained = true
+		return nil
+	}, runtime.NewServiceRegistry())
+	s := &Server{closeHandler: &closehandler.CloseHandler{Shutdown: shutdown}}
+
+	request := httptest.NewRequest(http.MethodPost, "/over-node/close?drain=invalid", nil)
+	writer := httptest.NewRecorder()
+	writer.Body = &bytes.Buffer{}
+	s.CloseClient(writer, request)
+	assert.Equal(t, http.StatusBadRequest, writer.Code)
+
+	request = httptest.NewRequest(http.MethodPost, "/over-node/close?drain=true", nil)
+	writer = httptest.NewRecorder()
+	writer.Body = &bytes.Buffer{}
+	s.CloseClient(writer, request)
+	assert.Equal(t, http.StatusOK, writer.Code)
+	resp := &structs.ShutdownResponse{}
+	require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+	assert.Equal(t, true, resp.Drain)
+	assert.NotEqual(t, string(runtime.ShutdownIdle), resp.Phase)
+
+	<-shutdown.Done()
+	assert.Equal(t, true, drained)
+	request = httptest.NewRequest(http.MethodGet, "/over-node/close/status", nil)
+	writer = httptest.NewRecorder()
+	writer.Body = &bytes.Buffer{}
+	s.GetCloseStatus(writer, request)
+	assert.Equal(t, http.StatusOK, writer.Code)
+	resp = &structs.ShutdownResponse{}
+	require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+	assert.Equal(t, string(runtime.ShutdownComplete), resp.Phase)
+}
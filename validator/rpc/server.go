@@ -245,6 +245,7 @@ func (s *Server) InitializeOverNodeRoutes() error {
 
 	// OverNode API endpoints
 	s.router.HandleFunc("POST "+api.OverNodeApiPrefix+"close", s.CloseClient)
+	s.router.HandleFunc("GET "+api.OverNodeApiPrefix+"close/status", s.GetCloseStatus)
 
 	// health check
 	s.router.HandleFunc("POST "+api.OverNodeValidatorApiPrefix+"health/status", s.GetStatus)
@@ -70,6 +70,7 @@ func TestServer_InitializeOverNodeRoutes(t *testing.T) {
 
 	wantRouteList := map[string][]string{
 		"/over-node/close":                                {http.MethodPost},
+		"/over-node/close/status":                         {http.MethodGet},
 		"/v2/validator/health/status":                     {http.MethodPost},
 		"/v2/validator/wallet/initialize-wallet":          {http.MethodPost},
 		"/v2/validator/wallet/change-password":            {http.MethodPost},